@@ -1,14 +1,33 @@
 package main
 
 import (
+	"database/sql"
 	"flag"
+	"fmt"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/grpc"
 	"log"
+	"time"
 
 	"github.com/BurntSushi/toml"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/http"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/session"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/postgres"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/webhook"
 )
 
 type config struct {
@@ -16,12 +35,62 @@ type config struct {
 	Server   *http.Config
 	Session  *session.Config
 	GRPC     *grpc.Config
+	Events   *eventsConfig
+	Webhooks *webhooksConfig
+	ETA      *eta.Config
+	Pricing  *pricing.Config
+	Blobs    *blob.Config
+	Lockers  *lockersConfig
+	Payments *payment.Config
 }
 
+// eventsConfig configures how new tracking events are distributed to
+// clients watching parcels.
+type eventsConfig struct {
+	// Notifier is either "local", which only notifies clients connected to
+	// this instance, or "postgres", which uses PostgreSQL's LISTEN and
+	// NOTIFY commands to notify the clients of all ipps instances.
+	Notifier string
+	// MaxDeliveryAttempts is the number of failed delivery attempts, after
+	// which parcels are returned to their senders.
+	MaxDeliveryAttempts int `toml:"max_delivery_attempts"`
+}
+
+// webhooksConfig configures the delivery of webhooks.
+type webhooksConfig struct {
+	// Workers is the number of concurrent delivery workers.
+	Workers   int
+	BatchSize int `toml:"batch_size"`
+	Interval  http.Duration
+	// Timeout is the time after which a webhook request is aborted.
+	Timeout     http.Duration
+	MaxAttempts int `toml:"max_attempts"`
+	// Backoff is the delay before the first retry, which is doubled for
+	// every further retry up to MaxBackoff.
+	Backoff    http.Duration
+	MaxBackoff http.Duration `toml:"max_backoff"`
+}
+
+// lockersConfig configures how long parcels are kept at pickup points.
+type lockersConfig struct {
+	// HoldingPeriod is the time after which parcels, which have not been
+	// collected, are returned to their senders.
+	HoldingPeriod http.Duration `toml:"holding_period"`
+	// Interval is the time between two checks for expired parcels.
+	Interval http.Duration
+}
+
+// defaultExpiryInterval is the time between two checks for parcels, which
+// have not been collected from pickup points in time, unless configured
+// otherwise.
+const defaultExpiryInterval = time.Hour
+
 func main() {
-	var configPath string
+	var configPath, operator string
 	flag.StringVar(&configPath, "c", "./config.toml",
 		"use another configuration file")
+	flag.StringVar(&operator, "grant-operator", "",
+		"grant operator privileges to the user with the given username and exit")
 	flag.Parse()
 
 	conf := &config{}
@@ -62,25 +131,239 @@ func main() {
 	if err != nil {
 		log.Fatal(err)
 	}
+	qs, err := postgres.NewQuoteStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer qs.Close()
+	rs, err := postgres.NewRocketStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rs.Close()
+	ls, err := postgres.NewLaunchStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer ls.Close()
+	pks, err := postgres.NewPickupStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pks.Close()
+	lks, err := postgres.NewLockerStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer lks.Close()
+	cns, err := postgres.NewCenterStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cns.Close()
+	rts, err := postgres.NewRouteStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer rts.Close()
+	cos, err := postgres.NewConsignmentStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cos.Close()
+	cls, err := postgres.NewClaimStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cls.Close()
+	is, err := postgres.NewInvoiceStorage(db, ps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer is.Close()
 	us, err := postgres.NewUserStorage(db)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer us.Close()
+	ws, err := postgres.NewWebhookStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer ws.Close()
+	wds, err := postgres.NewWebhookDeliveryStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer wds.Close()
+	if operator != "" {
+		err = grantOperator(us, operator)
+		if err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
+
+	est, err := eta.New(conf.ETA)
+	if err != nil {
+		log.Fatal(err)
+	}
+	pr, err := pricing.New(conf.Pricing, qs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	bs, err := blob.Open(conf.Blobs)
+	if err != nil {
+		log.Fatal(err)
+	}
+	gw, err := payment.OpenGateway(conf.Payments)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	hub := parcel.NewHub()
+	n, err := newPublisher(conf, db, hub)
+	if err != nil {
+		log.Fatal(err)
+	}
+	fl := &fleet.Service{Launches: ls, Parcels: ps}
+	ar := &parcel.AutoReturner{}
+	if conf.Events != nil {
+		ar.MaxAttempts = conf.Events.MaxDeliveryAttempts
+	}
+	pub := parcel.Publishers{n, &webhook.Enqueuer{Deliveries: wds}, &fleet.AutoAssigner{Service: fl}, ar,
+		&locker.Releaser{Points: lks}}
+	// The events recorded on departure or by the auto returner are
+	// published like all others.
+	fl.Events = &parcel.NotifyingEventStorage{EventStorage: es, Publisher: pub}
+	ar.Events = fl.Events
+	if conf.Webhooks != nil {
+		startWebhookWorkers(conf.Webhooks, wds)
+	}
+	lk := newLockerService(conf.Lockers, lks, as, ps, fl.Events)
+	go startLockerExpirer(conf.Lockers, lk)
 
-	go runGRPCServer(conf)
+	co := &consignment.Service{Consignments: cos, Addresses: as, Parcels: ps, Events: fl.Events,
+		Estimator: est}
+	cl := &claim.Service{Claims: cls, Addresses: as, Parcels: ps, Events: fl.Events, Cards: cs, Blobs: bs}
+
+	go runGRPCServer(conf, bs, est, gw, pr, pub, hub)
 	s := http.Server{
-		AddressStorage:  as,
-		CreditStorage:   cs,
-		EventStorage:    es,
-		FeedbackStorage: fs,
-		ParcelStorage:   ps,
-		UserStorage:     us,
+		AddressStorage:      as,
+		BlobStore:           bs,
+		CenterService:       &center.Service{Centers: cns, Events: fl.Events},
+		ClaimService:        cl,
+		ConsignmentService:  co,
+		CreditStorage:       cs,
+		EventStorage:        &parcel.NotifyingEventStorage{EventStorage: es, Publisher: pub},
+		Estimator:           est,
+		FeedbackStorage:     fs,
+		FleetService:        fl,
+		LockerService:       lk,
+		MaxDeliveryAttempts: ar.MaxAttempts,
+		ParcelStorage:       &parcel.NotifyingStorage{Storage: ps, Publisher: pub},
+		PaymentService:      newPaymentService(conf.Payments, gw, is, cs, pr, pub),
+		PickupService:       &pickup.Service{Pickups: pks, Addresses: as, Parcels: ps, Events: fl.Events},
+		Pricing:             pr,
+		RocketStorage:       rs,
+		RouteService: &route.Service{Routes: rts, Centers: cns, Parcels: ps, Events: fl.Events,
+			Deliveries: &parcel.NotifyingStorage{Storage: ps, Publisher: pub}, Blobs: bs, Lockers: lk,
+			Publisher: pub},
+		Subscriber:  hub,
+		UserStorage: us,
+
+		WebhookStorage:         ws,
+		WebhookDeliveryStorage: wds,
 	}
 	log.Fatal(s.ListenAndServe(conf.Server, conf.Session))
 }
 
-func runGRPCServer(c *config) {
+// grantOperator allows the user identified by username to record tracking
+// events for parcels.
+func grantOperator(us user.Storage, username string) error {
+	u, err := us.ByUsername(username)
+	if err != nil {
+		return err
+	}
+	u.Operator = true
+
+	return us.Update(u)
+}
+
+// newPublisher returns the publisher for new tracking events, as
+// configured in c. Events are eventually published to hub.
+func newPublisher(c *config, db *sql.DB, hub *parcel.Hub) (parcel.Publisher, error) {
+	if c.Events == nil || c.Events.Notifier == "" || c.Events.Notifier == "local" {
+		return hub, nil
+	} else if c.Events.Notifier != "postgres" {
+		return nil, fmt.Errorf("unknown event notifier %q", c.Events.Notifier)
+	}
+
+	n, err := postgres.NewEventNotifier(db, c.Database, hub)
+	if err != nil {
+		return nil, err
+	}
+	go n.Listen()
+
+	return n, nil
+}
+
+// newLockerService returns the service managing pickup points as
+// configured in c.
+func newLockerService(c *lockersConfig, s locker.Storage, as address.Storage, ps parcel.Storage,
+	es parcel.EventStorage) *locker.Service {
+	lk := &locker.Service{Points: s, Addresses: as, Parcels: ps, Events: es}
+	if c != nil {
+		lk.HoldingPeriod = c.HoldingPeriod.Duration()
+	}
+
+	return lk
+}
+
+// newPaymentService returns the service charging credit cards through gw
+// and storing the invoices with their parcels in s, which adds the tax
+// configured in c and publishes the paid parcels' events to pub.
+func newPaymentService(c *payment.Config, gw payment.Gateway, s payment.Storage, cs credit.Accesser,
+	pr *pricing.Service, pub parcel.Publisher) *payment.Service {
+	pm := &payment.Service{Gateway: gw, Invoices: s, Cards: cs, Pricing: pr, Tax: payment.DefaultTax,
+		Publisher: pub}
+	if c != nil {
+		pm.Tax = c.Tax
+	}
+
+	return pm
+}
+
+// startLockerExpirer periodically returns the parcels, which have not been
+// collected from pickup points in time, as configured in c.
+func startLockerExpirer(c *lockersConfig, lk *locker.Service) {
+	e := &locker.Expirer{Service: lk, Interval: defaultExpiryInterval}
+	if c != nil && c.Interval.Duration() > 0 {
+		e.Interval = c.Interval.Duration()
+	}
+	e.Run()
+}
+
+// startWebhookWorkers starts the workers delivering webhooks as configured
+// in c.
+func startWebhookWorkers(c *webhooksConfig, ds webhook.DeliveryStorage) {
+	client := webhook.NewClient(c.Timeout.Duration())
+	for i := 0; i < c.Workers; i++ {
+		w := &webhook.Worker{
+			Deliveries:  ds,
+			Client:      client,
+			Interval:    c.Interval.Duration(),
+			BatchSize:   c.BatchSize,
+			MaxAttempts: c.MaxAttempts,
+			Backoff:     c.Backoff.Duration(),
+			MaxBackoff:  c.MaxBackoff.Duration(),
+		}
+		go w.Run()
+	}
+}
+
+func runGRPCServer(c *config, bs blob.Store, est *eta.Estimator, gw payment.Gateway, pr *pricing.Service,
+	pub parcel.Publisher, sub parcel.Subscriber) {
 	db, err := postgres.Connect(c.Database)
 	if err != nil {
 		log.Fatal(err)
@@ -95,13 +378,72 @@ func runGRPCServer(c *config) {
 		log.Fatal(err)
 	}
 	defer cs.Close()
+	es, err := postgres.NewEventStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer es.Close()
+	ps, err := postgres.NewParcelStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer ps.Close()
+	lks, err := postgres.NewLockerStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer lks.Close()
+	cns, err := postgres.NewCenterStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cns.Close()
+	pks, err := postgres.NewPickupStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer pks.Close()
+	cos, err := postgres.NewConsignmentStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cos.Close()
+	cls, err := postgres.NewClaimStorage(db)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer cls.Close()
+	is, err := postgres.NewInvoiceStorage(db, ps)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer is.Close()
 	us, err := postgres.NewUserStorage(db)
 	if err != nil {
 		log.Fatal(err)
 	}
 	defer us.Close()
 
-	s, err := grpc.NewServer(c.GRPC, as, cs, us)
+	nes := &parcel.NotifyingEventStorage{EventStorage: es, Publisher: pub}
+	co := &consignment.Service{Consignments: cos, Addresses: as, Parcels: ps, Events: nes, Estimator: est}
+	cl := &claim.Service{Claims: cls, Addresses: as, Parcels: ps, Events: nes, Cards: cs, Blobs: bs}
+	s, err := grpc.NewServer(c.GRPC, &grpc.Dependencies{
+		AddressStorage:     as,
+		BlobStore:          bs,
+		CenterService:      &center.Service{Centers: cns, Events: nes},
+		ClaimService:       cl,
+		ConsignmentService: co,
+		CreditStorage:      cs,
+		EventStorage:       nes,
+		Estimator:          est,
+		LockerService:      newLockerService(c.Lockers, lks, as, ps, nes),
+		ParcelStorage:      &parcel.NotifyingStorage{Storage: ps, Publisher: pub},
+		PaymentService:     newPaymentService(c.Payments, gw, is, cs, pr, pub),
+		PickupService:      &pickup.Service{Pickups: pks, Addresses: as, Parcels: ps, Events: nes},
+		Pricing:            pr,
+		Subscriber:         sub,
+		UserStorage:        us,
+	})
 	if err != nil {
 		log.Fatal(err)
 	}
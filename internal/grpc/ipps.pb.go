@@ -8,6 +8,7 @@ import (
 	"fmt"
 	"github.com/golang/protobuf/proto"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
@@ -25,6 +26,363 @@ var _ = math.Inf
 // proto package needs to be updated.
 const _ = proto.ProtoPackageIsVersion3 // please upgrade the proto package
 
+// Hazard mirrors the hazard flags of the parcel package, using the same
+// numeric values.
+type Hazard int32
+
+const (
+	Hazard_NO_HAZARD         Hazard = 0
+	Hazard_FLAMMABLE         Hazard = 1
+	Hazard_CORROSIVE         Hazard = 2
+	Hazard_TOXIC             Hazard = 4
+	Hazard_RADIOACTIVE       Hazard = 8
+	Hazard_LITHIUM_BATTERIES Hazard = 16
+	Hazard_EXPLOSIVE         Hazard = 32
+)
+
+var Hazard_name = map[int32]string{
+	0:  "NO_HAZARD",
+	1:  "FLAMMABLE",
+	2:  "CORROSIVE",
+	4:  "TOXIC",
+	8:  "RADIOACTIVE",
+	16: "LITHIUM_BATTERIES",
+	32: "EXPLOSIVE",
+}
+
+var Hazard_value = map[string]int32{
+	"NO_HAZARD":         0,
+	"FLAMMABLE":         1,
+	"CORROSIVE":         2,
+	"TOXIC":             4,
+	"RADIOACTIVE":       8,
+	"LITHIUM_BATTERIES": 16,
+	"EXPLOSIVE":         32,
+}
+
+func (x Hazard) String() string {
+	return proto.EnumName(Hazard_name, int32(x))
+}
+
+func (Hazard) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{0}
+}
+
+// CustomsCategory mirrors the customs categories of the parcel package.
+type CustomsCategory int32
+
+const (
+	CustomsCategory_SALE_OF_GOODS     CustomsCategory = 0
+	CustomsCategory_GIFT              CustomsCategory = 1
+	CustomsCategory_DOCUMENTS         CustomsCategory = 2
+	CustomsCategory_COMMERCIAL_SAMPLE CustomsCategory = 3
+	CustomsCategory_RETURNED_GOODS    CustomsCategory = 4
+	CustomsCategory_OTHER             CustomsCategory = 5
+)
+
+var CustomsCategory_name = map[int32]string{
+	0: "SALE_OF_GOODS",
+	1: "GIFT",
+	2: "DOCUMENTS",
+	3: "COMMERCIAL_SAMPLE",
+	4: "RETURNED_GOODS",
+	5: "OTHER",
+}
+
+var CustomsCategory_value = map[string]int32{
+	"SALE_OF_GOODS":     0,
+	"GIFT":              1,
+	"DOCUMENTS":         2,
+	"COMMERCIAL_SAMPLE": 3,
+	"RETURNED_GOODS":    4,
+	"OTHER":             5,
+}
+
+func (x CustomsCategory) String() string {
+	return proto.EnumName(CustomsCategory_name, int32(x))
+}
+
+func (CustomsCategory) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{1}
+}
+
+// EventType mirrors the event types of the parcel package, using the same
+// numeric values.
+type EventType int32
+
+const (
+	EventType_DATA_RECEIVED               EventType = 0
+	EventType_DELIVERED_TO_IPPS           EventType = 1
+	EventType_DELIVERED_TO_PROCESSING     EventType = 2
+	EventType_LOADED_INTO_ROCKET          EventType = 3
+	EventType_LOADED_INTO_VEHICLE         EventType = 4
+	EventType_DELIVERED_TO_DESTINATION    EventType = 5
+	EventType_HELD_AT_CUSTOMS             EventType = 6
+	EventType_RELEASED_FROM_CUSTOMS       EventType = 7
+	EventType_DELIVERY_FAILED             EventType = 8
+	EventType_RETURN_INITIATED            EventType = 9
+	EventType_RETURNED_TO_SENDER          EventType = 10
+	EventType_REDIRECTED                  EventType = 11
+	EventType_HOLD_REQUESTED              EventType = 12
+	EventType_COLLECTED_BY_RECIPIENT      EventType = 13
+	EventType_PICKED_UP_BY_COURIER        EventType = 14
+	EventType_DELIVERED_TO_PICKUP_POINT   EventType = 15
+	EventType_COLLECTED_FROM_PICKUP_POINT EventType = 16
+	EventType_DELIVERY_RESCHEDULED        EventType = 17
+)
+
+var EventType_name = map[int32]string{
+	0:  "DATA_RECEIVED",
+	1:  "DELIVERED_TO_IPPS",
+	2:  "DELIVERED_TO_PROCESSING",
+	3:  "LOADED_INTO_ROCKET",
+	4:  "LOADED_INTO_VEHICLE",
+	5:  "DELIVERED_TO_DESTINATION",
+	6:  "HELD_AT_CUSTOMS",
+	7:  "RELEASED_FROM_CUSTOMS",
+	8:  "DELIVERY_FAILED",
+	9:  "RETURN_INITIATED",
+	10: "RETURNED_TO_SENDER",
+	11: "REDIRECTED",
+	12: "HOLD_REQUESTED",
+	13: "COLLECTED_BY_RECIPIENT",
+	14: "PICKED_UP_BY_COURIER",
+	15: "DELIVERED_TO_PICKUP_POINT",
+	16: "COLLECTED_FROM_PICKUP_POINT",
+	17: "DELIVERY_RESCHEDULED",
+}
+
+var EventType_value = map[string]int32{
+	"DATA_RECEIVED":               0,
+	"DELIVERED_TO_IPPS":           1,
+	"DELIVERED_TO_PROCESSING":     2,
+	"LOADED_INTO_ROCKET":          3,
+	"LOADED_INTO_VEHICLE":         4,
+	"DELIVERED_TO_DESTINATION":    5,
+	"HELD_AT_CUSTOMS":             6,
+	"RELEASED_FROM_CUSTOMS":       7,
+	"DELIVERY_FAILED":             8,
+	"RETURN_INITIATED":            9,
+	"RETURNED_TO_SENDER":          10,
+	"REDIRECTED":                  11,
+	"HOLD_REQUESTED":              12,
+	"COLLECTED_BY_RECIPIENT":      13,
+	"PICKED_UP_BY_COURIER":        14,
+	"DELIVERED_TO_PICKUP_POINT":   15,
+	"COLLECTED_FROM_PICKUP_POINT": 16,
+	"DELIVERY_RESCHEDULED":        17,
+}
+
+func (x EventType) String() string {
+	return proto.EnumName(EventType_name, int32(x))
+}
+
+func (EventType) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{2}
+}
+
+// FailureReason mirrors the reasons of failed delivery attempts of the
+// parcel package, using the same numeric values.
+type FailureReason int32
+
+const (
+	FailureReason_NO_REASON         FailureReason = 0
+	FailureReason_RECIPIENT_ABSENT  FailureReason = 1
+	FailureReason_ADDRESS_NOT_FOUND FailureReason = 2
+	FailureReason_REFUSED           FailureReason = 3
+)
+
+var FailureReason_name = map[int32]string{
+	0: "NO_REASON",
+	1: "RECIPIENT_ABSENT",
+	2: "ADDRESS_NOT_FOUND",
+	3: "REFUSED",
+}
+
+var FailureReason_value = map[string]int32{
+	"NO_REASON":         0,
+	"RECIPIENT_ABSENT":  1,
+	"ADDRESS_NOT_FOUND": 2,
+	"REFUSED":           3,
+}
+
+func (x FailureReason) String() string {
+	return proto.EnumName(FailureReason_name, int32(x))
+}
+
+func (FailureReason) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{3}
+}
+
+// ServiceLevel mirrors the service levels of the parcel package, using the
+// same numeric values.
+type ServiceLevel int32
+
+const (
+	ServiceLevel_STANDARD ServiceLevel = 0
+	ServiceLevel_EXPRESS  ServiceLevel = 1
+)
+
+var ServiceLevel_name = map[int32]string{
+	0: "STANDARD",
+	1: "EXPRESS",
+}
+
+var ServiceLevel_value = map[string]int32{
+	"STANDARD": 0,
+	"EXPRESS":  1,
+}
+
+func (x ServiceLevel) String() string {
+	return proto.EnumName(ServiceLevel_name, int32(x))
+}
+
+func (ServiceLevel) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{4}
+}
+
+type LabelFormat int32
+
+const (
+	LabelFormat_PDF  LabelFormat = 0
+	LabelFormat_ZPL  LabelFormat = 1
+	LabelFormat_CN23 LabelFormat = 2
+)
+
+var LabelFormat_name = map[int32]string{
+	0: "PDF",
+	1: "ZPL",
+	2: "CN23",
+}
+
+var LabelFormat_value = map[string]int32{
+	"PDF":  0,
+	"ZPL":  1,
+	"CN23": 2,
+}
+
+func (x LabelFormat) String() string {
+	return proto.EnumName(LabelFormat_name, int32(x))
+}
+
+func (LabelFormat) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{5}
+}
+
+type PickupPointKind int32
+
+const (
+	PickupPointKind_SHOP   PickupPointKind = 0
+	PickupPointKind_LOCKER PickupPointKind = 1
+)
+
+var PickupPointKind_name = map[int32]string{
+	0: "SHOP",
+	1: "LOCKER",
+}
+
+var PickupPointKind_value = map[string]int32{
+	"SHOP":   0,
+	"LOCKER": 1,
+}
+
+func (x PickupPointKind) String() string {
+	return proto.EnumName(PickupPointKind_name, int32(x))
+}
+
+func (PickupPointKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{6}
+}
+
+// ConsignmentStatus is the aggregated delivery status of a consignment or
+// the status of one of its parcels.
+type ConsignmentStatus int32
+
+const (
+	ConsignmentStatus_REGISTERED          ConsignmentStatus = 0
+	ConsignmentStatus_IN_TRANSIT          ConsignmentStatus = 1
+	ConsignmentStatus_PARTIALLY_DELIVERED ConsignmentStatus = 2
+	ConsignmentStatus_DELIVERED           ConsignmentStatus = 3
+	ConsignmentStatus_RETURNED            ConsignmentStatus = 4
+)
+
+var ConsignmentStatus_name = map[int32]string{
+	0: "REGISTERED",
+	1: "IN_TRANSIT",
+	2: "PARTIALLY_DELIVERED",
+	3: "DELIVERED",
+	4: "RETURNED",
+}
+
+var ConsignmentStatus_value = map[string]int32{
+	"REGISTERED":          0,
+	"IN_TRANSIT":          1,
+	"PARTIALLY_DELIVERED": 2,
+	"DELIVERED":           3,
+	"RETURNED":            4,
+}
+
+func (x ConsignmentStatus) String() string {
+	return proto.EnumName(ConsignmentStatus_name, int32(x))
+}
+
+func (ConsignmentStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{7}
+}
+
+type ClaimKind int32
+
+const (
+	ClaimKind_DAMAGE ClaimKind = 0
+	ClaimKind_LOSS   ClaimKind = 1
+)
+
+var ClaimKind_name = map[int32]string{
+	0: "DAMAGE",
+	1: "LOSS",
+}
+
+var ClaimKind_value = map[string]int32{
+	"DAMAGE": 0,
+	"LOSS":   1,
+}
+
+func (x ClaimKind) String() string {
+	return proto.EnumName(ClaimKind_name, int32(x))
+}
+
+func (ClaimKind) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{8}
+}
+
+type ClaimStatus int32
+
+const (
+	ClaimStatus_SUBMITTED ClaimStatus = 0
+	ClaimStatus_APPROVED  ClaimStatus = 1
+	ClaimStatus_REJECTED  ClaimStatus = 2
+)
+
+var ClaimStatus_name = map[int32]string{
+	0: "SUBMITTED",
+	1: "APPROVED",
+	2: "REJECTED",
+}
+
+var ClaimStatus_value = map[string]int32{
+	"SUBMITTED": 0,
+	"APPROVED":  1,
+	"REJECTED":  2,
+}
+
+func (x ClaimStatus) String() string {
+	return proto.EnumName(ClaimStatus_name, int32(x))
+}
+
+func (ClaimStatus) EnumDescriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{9}
+}
+
 type LoginRequest struct {
 	Username             string   `protobuf:"bytes,1,opt,name=username,proto3" json:"username,omitempty"`
 	Password             []byte   `protobuf:"bytes,2,opt,name=password,proto3" json:"password,omitempty"`
@@ -234,6 +592,7 @@ type Address struct {
 	City                 string   `protobuf:"bytes,3,opt,name=city,proto3" json:"city,omitempty"`
 	Country              string   `protobuf:"bytes,4,opt,name=country,proto3" json:"country,omitempty"`
 	Planet               string   `protobuf:"bytes,5,opt,name=planet,proto3" json:"planet,omitempty"`
+	Id                   string   `protobuf:"bytes,6,opt,name=id,proto3" json:"id,omitempty"`
 	XXX_NoUnkeyedLiteral struct{} `json:"-"`
 	XXX_unrecognized     []byte   `json:"-"`
 	XXX_sizecache        int32    `json:"-"`
@@ -299,6 +658,13 @@ func (m *Address) GetPlanet() string {
 	return ""
 }
 
+func (m *Address) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
 type Addresses struct {
 	Addresses            []*Address `protobuf:"bytes,1,rep,name=addresses,proto3" json:"addresses,omitempty"`
 	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
@@ -338,277 +704,5264 @@ func (m *Addresses) GetAddresses() []*Address {
 	return nil
 }
 
-func init() {
-	proto.RegisterType((*LoginRequest)(nil), "grpc.LoginRequest")
-	proto.RegisterType((*LoginResponse)(nil), "grpc.LoginResponse")
-	proto.RegisterType((*PublicKey)(nil), "grpc.PublicKey")
-	proto.RegisterType((*CreditCard)(nil), "grpc.CreditCard")
-	proto.RegisterType((*CreditCards)(nil), "grpc.CreditCards")
-	proto.RegisterType((*Address)(nil), "grpc.Address")
-	proto.RegisterType((*Addresses)(nil), "grpc.Addresses")
+type SendParcelRequest struct {
+	ReturnAddressId string `protobuf:"bytes,1,opt,name=returnAddressId,proto3" json:"returnAddressId,omitempty"`
+	// destinationAddressId identifies one of the user's addresses. If it is
+	// empty, newDestination is used as the parcel's destination instead.
+	DestinationAddressId string   `protobuf:"bytes,2,opt,name=destinationAddressId,proto3" json:"destinationAddressId,omitempty"`
+	NewDestination       *Address `protobuf:"bytes,3,opt,name=newDestination,proto3" json:"newDestination,omitempty"`
+	// quoteId identifies the quote accepted for the parcel. It may be empty.
+	QuoteId    string            `protobuf:"bytes,4,opt,name=quoteId,proto3" json:"quoteId,omitempty"`
+	Attributes *ParcelAttributes `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// customs is required for parcels sent to other planets.
+	Customs *CustomsDeclaration `protobuf:"bytes,6,opt,name=customs,proto3" json:"customs,omitempty"`
+	// pickupPointId identifies the shop or parcel locker, at which the
+	// recipient collects the parcel. If it is set, the destination is
+	// ignored.
+	PickupPointId string `protobuf:"bytes,7,opt,name=pickupPointId,proto3" json:"pickupPointId,omitempty"`
+	// cardId identifies the user's credit card, which is charged for the
+	// parcel. If it is empty, the parcel is paid at the shop.
+	CardId string `protobuf:"bytes,8,opt,name=cardId,proto3" json:"cardId,omitempty"`
+	// recipient is the username of the registered user receiving the
+	// parcel, who may redirect it or have it held. It may be empty.
+	Recipient            string   `protobuf:"bytes,9,opt,name=recipient,proto3" json:"recipient,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-func init() {
-	proto.RegisterFile("ipps.proto", fileDescriptor_e433d43e56f7944c)
+func (m *SendParcelRequest) Reset()         { *m = SendParcelRequest{} }
+func (m *SendParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*SendParcelRequest) ProtoMessage()    {}
+func (*SendParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{7}
 }
 
-var fileDescriptor_e433d43e56f7944c = []byte{
-	// 467 bytes of a gzipped FileDescriptorProto
-	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0x84, 0x53, 0x5d, 0x6b, 0xd4, 0x4c,
-	0x14, 0xde, 0xed, 0xee, 0xb6, 0x6f, 0x4e, 0x77, 0x5f, 0xeb, 0x08, 0x25, 0x44, 0x85, 0x65, 0x10,
-	0x59, 0x90, 0x26, 0x25, 0x52, 0xb4, 0x88, 0x17, 0x6b, 0xd1, 0x22, 0x7a, 0xb1, 0x44, 0xaf, 0xbc,
-	0x4b, 0x32, 0x67, 0x63, 0x68, 0x36, 0x19, 0xe7, 0x03, 0x89, 0x7f, 0xd7, 0x3f, 0x22, 0x93, 0xc9,
-	0xc7, 0x56, 0xa9, 0xde, 0x84, 0xf3, 0x9c, 0xf3, 0xe4, 0x39, 0x9f, 0x03, 0x90, 0x73, 0x2e, 0x7d,
-	0x2e, 0x2a, 0x55, 0x91, 0x69, 0x26, 0x78, 0xea, 0x3d, 0xcc, 0xaa, 0x2a, 0x2b, 0x30, 0x68, 0x7c,
-	0x89, 0xde, 0x06, 0xb8, 0xe3, 0xaa, 0xb6, 0x14, 0xfa, 0x0e, 0xe6, 0x1f, 0xab, 0x2c, 0x2f, 0x23,
-	0xfc, 0xa6, 0x51, 0x2a, 0xe2, 0xc1, 0x7f, 0x5a, 0xa2, 0x28, 0xe3, 0x1d, 0xba, 0xe3, 0xe5, 0x78,
-	0xe5, 0x44, 0x3d, 0x36, 0x31, 0x1e, 0x4b, 0xf9, 0xbd, 0x12, 0xcc, 0x3d, 0x58, 0x8e, 0x57, 0xf3,
-	0xa8, 0xc7, 0xf4, 0x0c, 0x16, 0xad, 0x8e, 0xe4, 0x55, 0x29, 0x91, 0x3c, 0x02, 0x27, 0xd6, 0xea,
-	0xeb, 0xe7, 0xea, 0x06, 0xcb, 0x56, 0x69, 0x70, 0xd0, 0xc7, 0xe0, 0x6c, 0x74, 0x52, 0xe4, 0xe9,
-	0x07, 0xac, 0xc9, 0x09, 0x4c, 0x6e, 0xb0, 0x6e, 0x49, 0xc6, 0xa4, 0x4f, 0x00, 0xae, 0x04, 0xb2,
-	0x5c, 0x5d, 0xc5, 0x82, 0x91, 0x53, 0x38, 0x2c, 0xf5, 0x2e, 0x41, 0xd1, 0x52, 0x5a, 0x44, 0x2f,
-	0xe0, 0x78, 0x60, 0x49, 0xf2, 0x14, 0x66, 0xa9, 0x31, 0xdc, 0xf1, 0x72, 0xb2, 0x3a, 0x0e, 0x4f,
-	0x7c, 0xd3, 0xbd, 0x3f, 0x30, 0x22, 0x1b, 0xa6, 0x35, 0x1c, 0xad, 0x19, 0x13, 0x28, 0xa5, 0x51,
-	0x96, 0x4a, 0x20, 0xaa, 0x4e, 0xd9, 0x22, 0x53, 0xd1, 0x8f, 0x9c, 0x37, 0x4d, 0x3a, 0x91, 0x31,
-	0x09, 0x81, 0x69, 0x9a, 0xab, 0xda, 0x9d, 0x34, 0xae, 0xc6, 0x26, 0x2e, 0x1c, 0xa5, 0x95, 0x2e,
-	0x95, 0xa8, 0xdd, 0x69, 0xe3, 0xee, 0xa0, 0xd1, 0xe5, 0x45, 0x5c, 0xa2, 0x72, 0x67, 0x56, 0xd7,
-	0x22, 0xfa, 0x12, 0x9c, 0x36, 0x35, 0x4a, 0xf2, 0x0c, 0x9c, 0xb8, 0x03, 0x6d, 0xcd, 0x0b, 0x5b,
-	0x73, 0xcb, 0x89, 0x86, 0x78, 0xf8, 0xf3, 0x00, 0xa6, 0xef, 0x37, 0x9b, 0x4f, 0x24, 0x84, 0x59,
-	0x33, 0x68, 0x42, 0x2c, 0x77, 0x7f, 0x7b, 0xde, 0x83, 0x5b, 0x3e, 0xbb, 0x09, 0x3a, 0x22, 0x97,
-	0x30, 0xbf, 0x46, 0x35, 0x0c, 0xfc, 0xd4, 0xb7, 0x27, 0xe1, 0x77, 0x27, 0xe1, 0xbf, 0x35, 0x27,
-	0xe1, 0xdd, 0xb3, 0xbf, 0xf7, 0x44, 0x3a, 0x22, 0x17, 0x00, 0x6b, 0xc6, 0xba, 0x79, 0xdd, 0xae,
-	0xcf, 0xbb, 0x43, 0xa7, 0xcf, 0x38, 0xf4, 0xfa, 0x8f, 0x8c, 0x3d, 0x91, 0x8e, 0xc8, 0x2b, 0x58,
-	0xac, 0x19, 0xdb, 0x5b, 0xff, 0x1f, 0x8b, 0xfc, 0x4b, 0xde, 0xd7, 0xf0, 0xff, 0x35, 0xaa, 0xfd,
-	0xab, 0xb8, 0x2b, 0xf3, 0xfd, 0xdf, 0x55, 0x25, 0x1d, 0xbd, 0xb9, 0xfc, 0xf2, 0x22, 0xcb, 0x55,
-	0x11, 0x27, 0x7e, 0x2a, 0xfd, 0x6d, 0xac, 0x7d, 0x86, 0xc1, 0x36, 0xd6, 0x52, 0xd9, 0x6f, 0xaa,
-	0xb6, 0x67, 0xe1, 0x79, 0x78, 0x1e, 0x98, 0x37, 0x16, 0xe4, 0xa5, 0x32, 0x0f, 0xa3, 0x08, 0x8c,
-	0x50, 0x72, 0xd8, 0xe8, 0x3f, 0xff, 0x15, 0x00, 0x00, 0xff, 0xff, 0x97, 0xc8, 0x47, 0xb8, 0x80,
-	0x03, 0x00, 0x00,
+func (m *SendParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SendParcelRequest.Unmarshal(m, b)
+}
+func (m *SendParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SendParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *SendParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SendParcelRequest.Merge(m, src)
+}
+func (m *SendParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_SendParcelRequest.Size(m)
+}
+func (m *SendParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_SendParcelRequest.DiscardUnknown(m)
 }
 
-// Reference imports to suppress errors if they are not otherwise used.
-var _ context.Context
-var _ grpc.ClientConnInterface
-
-// This is a compile-time assertion to ensure that this generated file
-// is compatible with the grpc package it is being compiled against.
-const _ = grpc.SupportPackageIsVersion6
+var xxx_messageInfo_SendParcelRequest proto.InternalMessageInfo
 
-// IPPSClient is the client API for IPPS service.
-//
-// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
-type IPPSClient interface {
-	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
-	GetPublicKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PublicKey, error)
-	AddAddress(ctx context.Context, in *Address, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetAddresses(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Addresses, error)
-	AddCreditCard(ctx context.Context, in *CreditCard, opts ...grpc.CallOption) (*empty.Empty, error)
-	GetCreditCards(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CreditCards, error)
+func (m *SendParcelRequest) GetReturnAddressId() string {
+	if m != nil {
+		return m.ReturnAddressId
+	}
+	return ""
 }
 
-type iPPSClient struct {
-	cc grpc.ClientConnInterface
+func (m *SendParcelRequest) GetDestinationAddressId() string {
+	if m != nil {
+		return m.DestinationAddressId
+	}
+	return ""
 }
 
-func NewIPPSClient(cc grpc.ClientConnInterface) IPPSClient {
-	return &iPPSClient{cc}
+func (m *SendParcelRequest) GetNewDestination() *Address {
+	if m != nil {
+		return m.NewDestination
+	}
+	return nil
 }
 
-func (c *iPPSClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
-	out := new(LoginResponse)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/Login", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetQuoteId() string {
+	if m != nil {
+		return m.QuoteId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *iPPSClient) GetPublicKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PublicKey, error) {
-	out := new(PublicKey)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPublicKey", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetAttributes() *ParcelAttributes {
+	if m != nil {
+		return m.Attributes
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iPPSClient) AddAddress(ctx context.Context, in *Address, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddAddress", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetCustoms() *CustomsDeclaration {
+	if m != nil {
+		return m.Customs
 	}
-	return out, nil
+	return nil
 }
 
-func (c *iPPSClient) GetAddresses(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Addresses, error) {
-	out := new(Addresses)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetAddresses", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetPickupPointId() string {
+	if m != nil {
+		return m.PickupPointId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *iPPSClient) AddCreditCard(ctx context.Context, in *CreditCard, opts ...grpc.CallOption) (*empty.Empty, error) {
-	out := new(empty.Empty)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddCreditCard", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetCardId() string {
+	if m != nil {
+		return m.CardId
 	}
-	return out, nil
+	return ""
 }
 
-func (c *iPPSClient) GetCreditCards(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CreditCards, error) {
-	out := new(CreditCards)
-	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetCreditCards", in, out, opts...)
-	if err != nil {
-		return nil, err
+func (m *SendParcelRequest) GetRecipient() string {
+	if m != nil {
+		return m.Recipient
 	}
-	return out, nil
+	return ""
 }
 
-// IPPSServer is the server API for IPPS service.
-type IPPSServer interface {
-	Login(context.Context, *LoginRequest) (*LoginResponse, error)
-	GetPublicKey(context.Context, *empty.Empty) (*PublicKey, error)
-	AddAddress(context.Context, *Address) (*empty.Empty, error)
-	GetAddresses(context.Context, *empty.Empty) (*Addresses, error)
-	AddCreditCard(context.Context, *CreditCard) (*empty.Empty, error)
-	GetCreditCards(context.Context, *empty.Empty) (*CreditCards, error)
+type Parcel struct {
+	Id                 string            `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ReturnAddress      *Address          `protobuf:"bytes,2,opt,name=returnAddress,proto3" json:"returnAddress,omitempty"`
+	DestinationAddress *Address          `protobuf:"bytes,3,opt,name=destinationAddress,proto3" json:"destinationAddress,omitempty"`
+	QuoteId            string            `protobuf:"bytes,4,opt,name=quoteId,proto3" json:"quoteId,omitempty"`
+	Attributes         *ParcelAttributes `protobuf:"bytes,5,opt,name=attributes,proto3" json:"attributes,omitempty"`
+	// customs is required for parcels sent to other planets.
+	Customs *CustomsDeclaration `protobuf:"bytes,6,opt,name=customs,proto3" json:"customs,omitempty"`
+	// pickupPointId is empty, unless the parcel is sent to a pickup point.
+	PickupPointId string `protobuf:"bytes,7,opt,name=pickupPointId,proto3" json:"pickupPointId,omitempty"`
+	// trackingCode is the human-friendly form of the id, which is accepted
+	// wherever a tracking id is.
+	TrackingCode string `protobuf:"bytes,8,opt,name=trackingCode,proto3" json:"trackingCode,omitempty"`
+	// invoice is only set in the response to SendParcel, if the parcel has
+	// been paid by credit card.
+	Invoice              *Invoice `protobuf:"bytes,9,opt,name=invoice,proto3" json:"invoice,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
 }
 
-// UnimplementedIPPSServer can be embedded to have forward compatible implementations.
-type UnimplementedIPPSServer struct {
+func (m *Parcel) Reset()         { *m = Parcel{} }
+func (m *Parcel) String() string { return proto.CompactTextString(m) }
+func (*Parcel) ProtoMessage()    {}
+func (*Parcel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{8}
 }
 
-func (*UnimplementedIPPSServer) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
-}
-func (*UnimplementedIPPSServer) GetPublicKey(ctx context.Context, req *empty.Empty) (*PublicKey, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+func (m *Parcel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Parcel.Unmarshal(m, b)
 }
-func (*UnimplementedIPPSServer) AddAddress(ctx context.Context, req *Address) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddAddress not implemented")
+func (m *Parcel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Parcel.Marshal(b, m, deterministic)
 }
-func (*UnimplementedIPPSServer) GetAddresses(ctx context.Context, req *empty.Empty) (*Addresses, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetAddresses not implemented")
+func (m *Parcel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Parcel.Merge(m, src)
 }
-func (*UnimplementedIPPSServer) AddCreditCard(ctx context.Context, req *CreditCard) (*empty.Empty, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method AddCreditCard not implemented")
+func (m *Parcel) XXX_Size() int {
+	return xxx_messageInfo_Parcel.Size(m)
 }
-func (*UnimplementedIPPSServer) GetCreditCards(ctx context.Context, req *empty.Empty) (*CreditCards, error) {
-	return nil, status.Errorf(codes.Unimplemented, "method GetCreditCards not implemented")
+func (m *Parcel) XXX_DiscardUnknown() {
+	xxx_messageInfo_Parcel.DiscardUnknown(m)
 }
 
-func RegisterIPPSServer(s *grpc.Server, srv IPPSServer) {
-	s.RegisterService(&_IPPS_serviceDesc, srv)
+var xxx_messageInfo_Parcel proto.InternalMessageInfo
+
+func (m *Parcel) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
 }
 
-func _IPPS_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(LoginRequest)
-	if err := dec(in); err != nil {
-		return nil, err
+func (m *Parcel) GetReturnAddress() *Address {
+	if m != nil {
+		return m.ReturnAddress
 	}
-	if interceptor == nil {
+	return nil
+}
+
+func (m *Parcel) GetDestinationAddress() *Address {
+	if m != nil {
+		return m.DestinationAddress
+	}
+	return nil
+}
+
+func (m *Parcel) GetQuoteId() string {
+	if m != nil {
+		return m.QuoteId
+	}
+	return ""
+}
+
+func (m *Parcel) GetAttributes() *ParcelAttributes {
+	if m != nil {
+		return m.Attributes
+	}
+	return nil
+}
+
+func (m *Parcel) GetCustoms() *CustomsDeclaration {
+	if m != nil {
+		return m.Customs
+	}
+	return nil
+}
+
+func (m *Parcel) GetPickupPointId() string {
+	if m != nil {
+		return m.PickupPointId
+	}
+	return ""
+}
+
+func (m *Parcel) GetTrackingCode() string {
+	if m != nil {
+		return m.TrackingCode
+	}
+	return ""
+}
+
+func (m *Parcel) GetInvoice() *Invoice {
+	if m != nil {
+		return m.Invoice
+	}
+	return nil
+}
+
+type ParcelAttributes struct {
+	// weight is given in kilograms.
+	Weight float64 `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// length, width and height are given in centimeters.
+	Length float64 `protobuf:"fixed64,2,opt,name=length,proto3" json:"length,omitempty"`
+	Width  float64 `protobuf:"fixed64,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height float64 `protobuf:"fixed64,4,opt,name=height,proto3" json:"height,omitempty"`
+	// declaredValue is given in cents.
+	DeclaredValue int64        `protobuf:"varint,5,opt,name=declaredValue,proto3" json:"declaredValue,omitempty"`
+	Contents      string       `protobuf:"bytes,6,opt,name=contents,proto3" json:"contents,omitempty"`
+	ServiceLevel  ServiceLevel `protobuf:"varint,7,opt,name=serviceLevel,proto3,enum=grpc.ServiceLevel" json:"serviceLevel,omitempty"`
+	Hazards       []Hazard     `protobuf:"varint,8,rep,packed,name=hazards,proto3,enum=grpc.Hazard" json:"hazards,omitempty"`
+	// insured parcels are insured for their declared value.
+	Insured              bool     `protobuf:"varint,9,opt,name=insured,proto3" json:"insured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParcelAttributes) Reset()         { *m = ParcelAttributes{} }
+func (m *ParcelAttributes) String() string { return proto.CompactTextString(m) }
+func (*ParcelAttributes) ProtoMessage()    {}
+func (*ParcelAttributes) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{9}
+}
+
+func (m *ParcelAttributes) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParcelAttributes.Unmarshal(m, b)
+}
+func (m *ParcelAttributes) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParcelAttributes.Marshal(b, m, deterministic)
+}
+func (m *ParcelAttributes) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParcelAttributes.Merge(m, src)
+}
+func (m *ParcelAttributes) XXX_Size() int {
+	return xxx_messageInfo_ParcelAttributes.Size(m)
+}
+func (m *ParcelAttributes) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParcelAttributes.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParcelAttributes proto.InternalMessageInfo
+
+func (m *ParcelAttributes) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *ParcelAttributes) GetLength() float64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *ParcelAttributes) GetWidth() float64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *ParcelAttributes) GetHeight() float64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *ParcelAttributes) GetDeclaredValue() int64 {
+	if m != nil {
+		return m.DeclaredValue
+	}
+	return 0
+}
+
+func (m *ParcelAttributes) GetContents() string {
+	if m != nil {
+		return m.Contents
+	}
+	return ""
+}
+
+func (m *ParcelAttributes) GetServiceLevel() ServiceLevel {
+	if m != nil {
+		return m.ServiceLevel
+	}
+	return ServiceLevel_STANDARD
+}
+
+func (m *ParcelAttributes) GetHazards() []Hazard {
+	if m != nil {
+		return m.Hazards
+	}
+	return nil
+}
+
+func (m *ParcelAttributes) GetInsured() bool {
+	if m != nil {
+		return m.Insured
+	}
+	return false
+}
+
+type CustomsItem struct {
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	HsCode      string `protobuf:"bytes,2,opt,name=hsCode,proto3" json:"hsCode,omitempty"`
+	Quantity    int32  `protobuf:"varint,3,opt,name=quantity,proto3" json:"quantity,omitempty"`
+	// weight is given in kilograms.
+	Weight float64 `protobuf:"fixed64,4,opt,name=weight,proto3" json:"weight,omitempty"`
+	// value is given in cents.
+	Value                int64    `protobuf:"varint,5,opt,name=value,proto3" json:"value,omitempty"`
+	Origin               string   `protobuf:"bytes,6,opt,name=origin,proto3" json:"origin,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CustomsItem) Reset()         { *m = CustomsItem{} }
+func (m *CustomsItem) String() string { return proto.CompactTextString(m) }
+func (*CustomsItem) ProtoMessage()    {}
+func (*CustomsItem) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{10}
+}
+
+func (m *CustomsItem) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomsItem.Unmarshal(m, b)
+}
+func (m *CustomsItem) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomsItem.Marshal(b, m, deterministic)
+}
+func (m *CustomsItem) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomsItem.Merge(m, src)
+}
+func (m *CustomsItem) XXX_Size() int {
+	return xxx_messageInfo_CustomsItem.Size(m)
+}
+func (m *CustomsItem) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomsItem.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomsItem proto.InternalMessageInfo
+
+func (m *CustomsItem) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *CustomsItem) GetHsCode() string {
+	if m != nil {
+		return m.HsCode
+	}
+	return ""
+}
+
+func (m *CustomsItem) GetQuantity() int32 {
+	if m != nil {
+		return m.Quantity
+	}
+	return 0
+}
+
+func (m *CustomsItem) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *CustomsItem) GetValue() int64 {
+	if m != nil {
+		return m.Value
+	}
+	return 0
+}
+
+func (m *CustomsItem) GetOrigin() string {
+	if m != nil {
+		return m.Origin
+	}
+	return ""
+}
+
+type CustomsDeclaration struct {
+	Category             CustomsCategory `protobuf:"varint,1,opt,name=category,proto3,enum=grpc.CustomsCategory" json:"category,omitempty"`
+	Items                []*CustomsItem  `protobuf:"bytes,2,rep,name=items,proto3" json:"items,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *CustomsDeclaration) Reset()         { *m = CustomsDeclaration{} }
+func (m *CustomsDeclaration) String() string { return proto.CompactTextString(m) }
+func (*CustomsDeclaration) ProtoMessage()    {}
+func (*CustomsDeclaration) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{11}
+}
+
+func (m *CustomsDeclaration) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CustomsDeclaration.Unmarshal(m, b)
+}
+func (m *CustomsDeclaration) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CustomsDeclaration.Marshal(b, m, deterministic)
+}
+func (m *CustomsDeclaration) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CustomsDeclaration.Merge(m, src)
+}
+func (m *CustomsDeclaration) XXX_Size() int {
+	return xxx_messageInfo_CustomsDeclaration.Size(m)
+}
+func (m *CustomsDeclaration) XXX_DiscardUnknown() {
+	xxx_messageInfo_CustomsDeclaration.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CustomsDeclaration proto.InternalMessageInfo
+
+func (m *CustomsDeclaration) GetCategory() CustomsCategory {
+	if m != nil {
+		return m.Category
+	}
+	return CustomsCategory_SALE_OF_GOODS
+}
+
+func (m *CustomsDeclaration) GetItems() []*CustomsItem {
+	if m != nil {
+		return m.Items
+	}
+	return nil
+}
+
+type TrackParcelRequest struct {
+	// id is the parcel's tracking id or its tracking code.
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *TrackParcelRequest) Reset()         { *m = TrackParcelRequest{} }
+func (m *TrackParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*TrackParcelRequest) ProtoMessage()    {}
+func (*TrackParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{12}
+}
+
+func (m *TrackParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrackParcelRequest.Unmarshal(m, b)
+}
+func (m *TrackParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrackParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *TrackParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrackParcelRequest.Merge(m, src)
+}
+func (m *TrackParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_TrackParcelRequest.Size(m)
+}
+func (m *TrackParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrackParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrackParcelRequest proto.InternalMessageInfo
+
+func (m *TrackParcelRequest) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+type Event struct {
+	Id          string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Type        EventType            `protobuf:"varint,2,opt,name=type,proto3,enum=grpc.EventType" json:"type,omitempty"`
+	Description string               `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	Time        *timestamp.Timestamp `protobuf:"bytes,4,opt,name=time,proto3" json:"time,omitempty"`
+	// reason is only set for DELIVERY_FAILED events.
+	Reason FailureReason `protobuf:"varint,5,opt,name=reason,proto3,enum=grpc.FailureReason" json:"reason,omitempty"`
+	// deliveryDate is the day chosen by the recipient in the format
+	// YYYY-MM-DD. It is only set for DELIVERY_RESCHEDULED events.
+	DeliveryDate         string   `protobuf:"bytes,6,opt,name=deliveryDate,proto3" json:"deliveryDate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Event) Reset()         { *m = Event{} }
+func (m *Event) String() string { return proto.CompactTextString(m) }
+func (*Event) ProtoMessage()    {}
+func (*Event) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{13}
+}
+
+func (m *Event) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Event.Unmarshal(m, b)
+}
+func (m *Event) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Event.Marshal(b, m, deterministic)
+}
+func (m *Event) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Event.Merge(m, src)
+}
+func (m *Event) XXX_Size() int {
+	return xxx_messageInfo_Event.Size(m)
+}
+func (m *Event) XXX_DiscardUnknown() {
+	xxx_messageInfo_Event.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Event proto.InternalMessageInfo
+
+func (m *Event) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Event) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_DATA_RECEIVED
+}
+
+func (m *Event) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Event) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Event) GetReason() FailureReason {
+	if m != nil {
+		return m.Reason
+	}
+	return FailureReason_NO_REASON
+}
+
+func (m *Event) GetDeliveryDate() string {
+	if m != nil {
+		return m.DeliveryDate
+	}
+	return ""
+}
+
+type TrackingInfo struct {
+	Parcel               *Parcel           `protobuf:"bytes,1,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	Events               []*Event          `protobuf:"bytes,2,rep,name=events,proto3" json:"events,omitempty"`
+	Estimate             *DeliveryEstimate `protobuf:"bytes,3,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *TrackingInfo) Reset()         { *m = TrackingInfo{} }
+func (m *TrackingInfo) String() string { return proto.CompactTextString(m) }
+func (*TrackingInfo) ProtoMessage()    {}
+func (*TrackingInfo) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{14}
+}
+
+func (m *TrackingInfo) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_TrackingInfo.Unmarshal(m, b)
+}
+func (m *TrackingInfo) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_TrackingInfo.Marshal(b, m, deterministic)
+}
+func (m *TrackingInfo) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_TrackingInfo.Merge(m, src)
+}
+func (m *TrackingInfo) XXX_Size() int {
+	return xxx_messageInfo_TrackingInfo.Size(m)
+}
+func (m *TrackingInfo) XXX_DiscardUnknown() {
+	xxx_messageInfo_TrackingInfo.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_TrackingInfo proto.InternalMessageInfo
+
+func (m *TrackingInfo) GetParcel() *Parcel {
+	if m != nil {
+		return m.Parcel
+	}
+	return nil
+}
+
+func (m *TrackingInfo) GetEvents() []*Event {
+	if m != nil {
+		return m.Events
+	}
+	return nil
+}
+
+func (m *TrackingInfo) GetEstimate() *DeliveryEstimate {
+	if m != nil {
+		return m.Estimate
+	}
+	return nil
+}
+
+type DeliveryEstimate struct {
+	Earliest *timestamp.Timestamp `protobuf:"bytes,1,opt,name=earliest,proto3" json:"earliest,omitempty"`
+	Expected *timestamp.Timestamp `protobuf:"bytes,2,opt,name=expected,proto3" json:"expected,omitempty"`
+	Latest   *timestamp.Timestamp `protobuf:"bytes,3,opt,name=latest,proto3" json:"latest,omitempty"`
+	// delivered is true, if the parcel has already been delivered. All
+	// times are the time of delivery then.
+	Delivered bool `protobuf:"varint,4,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	// returning is true, if the parcel is being returned to its sender. The
+	// estimate refers to the delivery to the sender then.
+	Returning            bool     `protobuf:"varint,5,opt,name=returning,proto3" json:"returning,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *DeliveryEstimate) Reset()         { *m = DeliveryEstimate{} }
+func (m *DeliveryEstimate) String() string { return proto.CompactTextString(m) }
+func (*DeliveryEstimate) ProtoMessage()    {}
+func (*DeliveryEstimate) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{15}
+}
+
+func (m *DeliveryEstimate) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliveryEstimate.Unmarshal(m, b)
+}
+func (m *DeliveryEstimate) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliveryEstimate.Marshal(b, m, deterministic)
+}
+func (m *DeliveryEstimate) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliveryEstimate.Merge(m, src)
+}
+func (m *DeliveryEstimate) XXX_Size() int {
+	return xxx_messageInfo_DeliveryEstimate.Size(m)
+}
+func (m *DeliveryEstimate) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliveryEstimate.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliveryEstimate proto.InternalMessageInfo
+
+func (m *DeliveryEstimate) GetEarliest() *timestamp.Timestamp {
+	if m != nil {
+		return m.Earliest
+	}
+	return nil
+}
+
+func (m *DeliveryEstimate) GetExpected() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expected
+	}
+	return nil
+}
+
+func (m *DeliveryEstimate) GetLatest() *timestamp.Timestamp {
+	if m != nil {
+		return m.Latest
+	}
+	return nil
+}
+
+func (m *DeliveryEstimate) GetDelivered() bool {
+	if m != nil {
+		return m.Delivered
+	}
+	return false
+}
+
+func (m *DeliveryEstimate) GetReturning() bool {
+	if m != nil {
+		return m.Returning
+	}
+	return false
+}
+
+type AddParcelEventRequest struct {
+	ParcelId string    `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Type     EventType `protobuf:"varint,2,opt,name=type,proto3,enum=grpc.EventType" json:"type,omitempty"`
+	// time is the time at which the event occurred. If it is not set, the
+	// time at which the request is handled is used.
+	Time *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	// reason may only be set for DELIVERY_FAILED events.
+	Reason               FailureReason `protobuf:"varint,4,opt,name=reason,proto3,enum=grpc.FailureReason" json:"reason,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *AddParcelEventRequest) Reset()         { *m = AddParcelEventRequest{} }
+func (m *AddParcelEventRequest) String() string { return proto.CompactTextString(m) }
+func (*AddParcelEventRequest) ProtoMessage()    {}
+func (*AddParcelEventRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{16}
+}
+
+func (m *AddParcelEventRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_AddParcelEventRequest.Unmarshal(m, b)
+}
+func (m *AddParcelEventRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_AddParcelEventRequest.Marshal(b, m, deterministic)
+}
+func (m *AddParcelEventRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_AddParcelEventRequest.Merge(m, src)
+}
+func (m *AddParcelEventRequest) XXX_Size() int {
+	return xxx_messageInfo_AddParcelEventRequest.Size(m)
+}
+func (m *AddParcelEventRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_AddParcelEventRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_AddParcelEventRequest proto.InternalMessageInfo
+
+func (m *AddParcelEventRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *AddParcelEventRequest) GetType() EventType {
+	if m != nil {
+		return m.Type
+	}
+	return EventType_DATA_RECEIVED
+}
+
+func (m *AddParcelEventRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *AddParcelEventRequest) GetReason() FailureReason {
+	if m != nil {
+		return m.Reason
+	}
+	return FailureReason_NO_REASON
+}
+
+type QuoteRequest struct {
+	// weight is given in kilograms.
+	Weight float64 `protobuf:"fixed64,1,opt,name=weight,proto3" json:"weight,omitempty"`
+	// length, width and height are given in centimeters.
+	Length       float64      `protobuf:"fixed64,2,opt,name=length,proto3" json:"length,omitempty"`
+	Width        float64      `protobuf:"fixed64,3,opt,name=width,proto3" json:"width,omitempty"`
+	Height       float64      `protobuf:"fixed64,4,opt,name=height,proto3" json:"height,omitempty"`
+	From         string       `protobuf:"bytes,5,opt,name=from,proto3" json:"from,omitempty"`
+	To           string       `protobuf:"bytes,6,opt,name=to,proto3" json:"to,omitempty"`
+	ServiceLevel ServiceLevel `protobuf:"varint,7,opt,name=serviceLevel,proto3,enum=grpc.ServiceLevel" json:"serviceLevel,omitempty"`
+	// declaredValue is given in cents.
+	DeclaredValue        int64    `protobuf:"varint,8,opt,name=declaredValue,proto3" json:"declaredValue,omitempty"`
+	Insured              bool     `protobuf:"varint,9,opt,name=insured,proto3" json:"insured,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteRequest) Reset()         { *m = QuoteRequest{} }
+func (m *QuoteRequest) String() string { return proto.CompactTextString(m) }
+func (*QuoteRequest) ProtoMessage()    {}
+func (*QuoteRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{17}
+}
+
+func (m *QuoteRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteRequest.Unmarshal(m, b)
+}
+func (m *QuoteRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteRequest.Marshal(b, m, deterministic)
+}
+func (m *QuoteRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteRequest.Merge(m, src)
+}
+func (m *QuoteRequest) XXX_Size() int {
+	return xxx_messageInfo_QuoteRequest.Size(m)
+}
+func (m *QuoteRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteRequest proto.InternalMessageInfo
+
+func (m *QuoteRequest) GetWeight() float64 {
+	if m != nil {
+		return m.Weight
+	}
+	return 0
+}
+
+func (m *QuoteRequest) GetLength() float64 {
+	if m != nil {
+		return m.Length
+	}
+	return 0
+}
+
+func (m *QuoteRequest) GetWidth() float64 {
+	if m != nil {
+		return m.Width
+	}
+	return 0
+}
+
+func (m *QuoteRequest) GetHeight() float64 {
+	if m != nil {
+		return m.Height
+	}
+	return 0
+}
+
+func (m *QuoteRequest) GetFrom() string {
+	if m != nil {
+		return m.From
+	}
+	return ""
+}
+
+func (m *QuoteRequest) GetTo() string {
+	if m != nil {
+		return m.To
+	}
+	return ""
+}
+
+func (m *QuoteRequest) GetServiceLevel() ServiceLevel {
+	if m != nil {
+		return m.ServiceLevel
+	}
+	return ServiceLevel_STANDARD
+}
+
+func (m *QuoteRequest) GetDeclaredValue() int64 {
+	if m != nil {
+		return m.DeclaredValue
+	}
+	return 0
+}
+
+func (m *QuoteRequest) GetInsured() bool {
+	if m != nil {
+		return m.Insured
+	}
+	return false
+}
+
+type QuoteLine struct {
+	Description string `protobuf:"bytes,1,opt,name=description,proto3" json:"description,omitempty"`
+	// amount is given in cents.
+	Amount               int64    `protobuf:"varint,2,opt,name=amount,proto3" json:"amount,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *QuoteLine) Reset()         { *m = QuoteLine{} }
+func (m *QuoteLine) String() string { return proto.CompactTextString(m) }
+func (*QuoteLine) ProtoMessage()    {}
+func (*QuoteLine) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{18}
+}
+
+func (m *QuoteLine) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_QuoteLine.Unmarshal(m, b)
+}
+func (m *QuoteLine) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_QuoteLine.Marshal(b, m, deterministic)
+}
+func (m *QuoteLine) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_QuoteLine.Merge(m, src)
+}
+func (m *QuoteLine) XXX_Size() int {
+	return xxx_messageInfo_QuoteLine.Size(m)
+}
+func (m *QuoteLine) XXX_DiscardUnknown() {
+	xxx_messageInfo_QuoteLine.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_QuoteLine proto.InternalMessageInfo
+
+func (m *QuoteLine) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *QuoteLine) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+type Quote struct {
+	Id      string        `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Request *QuoteRequest `protobuf:"bytes,2,opt,name=request,proto3" json:"request,omitempty"`
+	Lines   []*QuoteLine  `protobuf:"bytes,3,rep,name=lines,proto3" json:"lines,omitempty"`
+	// total is given in cents.
+	Total                int64                `protobuf:"varint,4,opt,name=total,proto3" json:"total,omitempty"`
+	Currency             string               `protobuf:"bytes,5,opt,name=currency,proto3" json:"currency,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,6,opt,name=created,proto3" json:"created,omitempty"`
+	Expires              *timestamp.Timestamp `protobuf:"bytes,7,opt,name=expires,proto3" json:"expires,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Quote) Reset()         { *m = Quote{} }
+func (m *Quote) String() string { return proto.CompactTextString(m) }
+func (*Quote) ProtoMessage()    {}
+func (*Quote) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{19}
+}
+
+func (m *Quote) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Quote.Unmarshal(m, b)
+}
+func (m *Quote) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Quote.Marshal(b, m, deterministic)
+}
+func (m *Quote) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Quote.Merge(m, src)
+}
+func (m *Quote) XXX_Size() int {
+	return xxx_messageInfo_Quote.Size(m)
+}
+func (m *Quote) XXX_DiscardUnknown() {
+	xxx_messageInfo_Quote.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Quote proto.InternalMessageInfo
+
+func (m *Quote) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Quote) GetRequest() *QuoteRequest {
+	if m != nil {
+		return m.Request
+	}
+	return nil
+}
+
+func (m *Quote) GetLines() []*QuoteLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *Quote) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Quote) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Quote) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Quote) GetExpires() *timestamp.Timestamp {
+	if m != nil {
+		return m.Expires
+	}
+	return nil
+}
+
+type GetLabelRequest struct {
+	ParcelId             string      `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Format               LabelFormat `protobuf:"varint,2,opt,name=format,proto3,enum=grpc.LabelFormat" json:"format,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *GetLabelRequest) Reset()         { *m = GetLabelRequest{} }
+func (m *GetLabelRequest) String() string { return proto.CompactTextString(m) }
+func (*GetLabelRequest) ProtoMessage()    {}
+func (*GetLabelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{20}
+}
+
+func (m *GetLabelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetLabelRequest.Unmarshal(m, b)
+}
+func (m *GetLabelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetLabelRequest.Marshal(b, m, deterministic)
+}
+func (m *GetLabelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetLabelRequest.Merge(m, src)
+}
+func (m *GetLabelRequest) XXX_Size() int {
+	return xxx_messageInfo_GetLabelRequest.Size(m)
+}
+func (m *GetLabelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetLabelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetLabelRequest proto.InternalMessageInfo
+
+func (m *GetLabelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *GetLabelRequest) GetFormat() LabelFormat {
+	if m != nil {
+		return m.Format
+	}
+	return LabelFormat_PDF
+}
+
+type Label struct {
+	Data                 []byte   `protobuf:"bytes,1,opt,name=data,proto3" json:"data,omitempty"`
+	ContentType          string   `protobuf:"bytes,2,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Label) Reset()         { *m = Label{} }
+func (m *Label) String() string { return proto.CompactTextString(m) }
+func (*Label) ProtoMessage()    {}
+func (*Label) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{21}
+}
+
+func (m *Label) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Label.Unmarshal(m, b)
+}
+func (m *Label) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Label.Marshal(b, m, deterministic)
+}
+func (m *Label) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Label.Merge(m, src)
+}
+func (m *Label) XXX_Size() int {
+	return xxx_messageInfo_Label.Size(m)
+}
+func (m *Label) XXX_DiscardUnknown() {
+	xxx_messageInfo_Label.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Label proto.InternalMessageInfo
+
+func (m *Label) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+func (m *Label) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+type ReturnParcelRequest struct {
+	ParcelId             string   `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReturnParcelRequest) Reset()         { *m = ReturnParcelRequest{} }
+func (m *ReturnParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*ReturnParcelRequest) ProtoMessage()    {}
+func (*ReturnParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{22}
+}
+
+func (m *ReturnParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReturnParcelRequest.Unmarshal(m, b)
+}
+func (m *ReturnParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReturnParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *ReturnParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReturnParcelRequest.Merge(m, src)
+}
+func (m *ReturnParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_ReturnParcelRequest.Size(m)
+}
+func (m *ReturnParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReturnParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReturnParcelRequest proto.InternalMessageInfo
+
+func (m *ReturnParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+type RedirectParcelRequest struct {
+	ParcelId string `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// addressId identifies one of the recipient's addresses on the planet of
+	// the parcel's current destination.
+	AddressId string `protobuf:"bytes,2,opt,name=addressId,proto3" json:"addressId,omitempty"`
+	// pickupPointId identifies a pickup point on the same planet, to which
+	// the parcel is redirected instead, if it is set.
+	PickupPointId        string   `protobuf:"bytes,3,opt,name=pickupPointId,proto3" json:"pickupPointId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RedirectParcelRequest) Reset()         { *m = RedirectParcelRequest{} }
+func (m *RedirectParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*RedirectParcelRequest) ProtoMessage()    {}
+func (*RedirectParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{23}
+}
+
+func (m *RedirectParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RedirectParcelRequest.Unmarshal(m, b)
+}
+func (m *RedirectParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RedirectParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *RedirectParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RedirectParcelRequest.Merge(m, src)
+}
+func (m *RedirectParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_RedirectParcelRequest.Size(m)
+}
+func (m *RedirectParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RedirectParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RedirectParcelRequest proto.InternalMessageInfo
+
+func (m *RedirectParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *RedirectParcelRequest) GetAddressId() string {
+	if m != nil {
+		return m.AddressId
+	}
+	return ""
+}
+
+func (m *RedirectParcelRequest) GetPickupPointId() string {
+	if m != nil {
+		return m.PickupPointId
+	}
+	return ""
+}
+
+type RescheduleDeliveryRequest struct {
+	ParcelId string `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// date is the day of the next delivery attempt in the format YYYY-MM-DD.
+	Date                 string   `protobuf:"bytes,2,opt,name=date,proto3" json:"date,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *RescheduleDeliveryRequest) Reset()         { *m = RescheduleDeliveryRequest{} }
+func (m *RescheduleDeliveryRequest) String() string { return proto.CompactTextString(m) }
+func (*RescheduleDeliveryRequest) ProtoMessage()    {}
+func (*RescheduleDeliveryRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{24}
+}
+
+func (m *RescheduleDeliveryRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_RescheduleDeliveryRequest.Unmarshal(m, b)
+}
+func (m *RescheduleDeliveryRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_RescheduleDeliveryRequest.Marshal(b, m, deterministic)
+}
+func (m *RescheduleDeliveryRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_RescheduleDeliveryRequest.Merge(m, src)
+}
+func (m *RescheduleDeliveryRequest) XXX_Size() int {
+	return xxx_messageInfo_RescheduleDeliveryRequest.Size(m)
+}
+func (m *RescheduleDeliveryRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_RescheduleDeliveryRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_RescheduleDeliveryRequest proto.InternalMessageInfo
+
+func (m *RescheduleDeliveryRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *RescheduleDeliveryRequest) GetDate() string {
+	if m != nil {
+		return m.Date
+	}
+	return ""
+}
+
+type HoldParcelRequest struct {
+	ParcelId             string   `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *HoldParcelRequest) Reset()         { *m = HoldParcelRequest{} }
+func (m *HoldParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*HoldParcelRequest) ProtoMessage()    {}
+func (*HoldParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{25}
+}
+
+func (m *HoldParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_HoldParcelRequest.Unmarshal(m, b)
+}
+func (m *HoldParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_HoldParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *HoldParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_HoldParcelRequest.Merge(m, src)
+}
+func (m *HoldParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_HoldParcelRequest.Size(m)
+}
+func (m *HoldParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_HoldParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_HoldParcelRequest proto.InternalMessageInfo
+
+func (m *HoldParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+type ParcelSummary struct {
+	Parcel *Parcel `protobuf:"bytes,1,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	// latest is the parcel's most recent tracking event.
+	Latest               *Event   `protobuf:"bytes,2,opt,name=latest,proto3" json:"latest,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ParcelSummary) Reset()         { *m = ParcelSummary{} }
+func (m *ParcelSummary) String() string { return proto.CompactTextString(m) }
+func (*ParcelSummary) ProtoMessage()    {}
+func (*ParcelSummary) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{26}
+}
+
+func (m *ParcelSummary) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParcelSummary.Unmarshal(m, b)
+}
+func (m *ParcelSummary) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParcelSummary.Marshal(b, m, deterministic)
+}
+func (m *ParcelSummary) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParcelSummary.Merge(m, src)
+}
+func (m *ParcelSummary) XXX_Size() int {
+	return xxx_messageInfo_ParcelSummary.Size(m)
+}
+func (m *ParcelSummary) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParcelSummary.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParcelSummary proto.InternalMessageInfo
+
+func (m *ParcelSummary) GetParcel() *Parcel {
+	if m != nil {
+		return m.Parcel
+	}
+	return nil
+}
+
+func (m *ParcelSummary) GetLatest() *Event {
+	if m != nil {
+		return m.Latest
+	}
+	return nil
+}
+
+type ParcelSummaries struct {
+	Parcels              []*ParcelSummary `protobuf:"bytes,1,rep,name=parcels,proto3" json:"parcels,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}         `json:"-"`
+	XXX_unrecognized     []byte           `json:"-"`
+	XXX_sizecache        int32            `json:"-"`
+}
+
+func (m *ParcelSummaries) Reset()         { *m = ParcelSummaries{} }
+func (m *ParcelSummaries) String() string { return proto.CompactTextString(m) }
+func (*ParcelSummaries) ProtoMessage()    {}
+func (*ParcelSummaries) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{27}
+}
+
+func (m *ParcelSummaries) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ParcelSummaries.Unmarshal(m, b)
+}
+func (m *ParcelSummaries) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ParcelSummaries.Marshal(b, m, deterministic)
+}
+func (m *ParcelSummaries) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ParcelSummaries.Merge(m, src)
+}
+func (m *ParcelSummaries) XXX_Size() int {
+	return xxx_messageInfo_ParcelSummaries.Size(m)
+}
+func (m *ParcelSummaries) XXX_DiscardUnknown() {
+	xxx_messageInfo_ParcelSummaries.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ParcelSummaries proto.InternalMessageInfo
+
+func (m *ParcelSummaries) GetParcels() []*ParcelSummary {
+	if m != nil {
+		return m.Parcels
+	}
+	return nil
+}
+
+type Location struct {
+	Latitude             float64  `protobuf:"fixed64,1,opt,name=latitude,proto3" json:"latitude,omitempty"`
+	Longitude            float64  `protobuf:"fixed64,2,opt,name=longitude,proto3" json:"longitude,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Location) Reset()         { *m = Location{} }
+func (m *Location) String() string { return proto.CompactTextString(m) }
+func (*Location) ProtoMessage()    {}
+func (*Location) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{28}
+}
+
+func (m *Location) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Location.Unmarshal(m, b)
+}
+func (m *Location) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Location.Marshal(b, m, deterministic)
+}
+func (m *Location) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Location.Merge(m, src)
+}
+func (m *Location) XXX_Size() int {
+	return xxx_messageInfo_Location.Size(m)
+}
+func (m *Location) XXX_DiscardUnknown() {
+	xxx_messageInfo_Location.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Location proto.InternalMessageInfo
+
+func (m *Location) GetLatitude() float64 {
+	if m != nil {
+		return m.Latitude
+	}
+	return 0
+}
+
+func (m *Location) GetLongitude() float64 {
+	if m != nil {
+		return m.Longitude
+	}
+	return 0
+}
+
+type DeliverParcelRequest struct {
+	ParcelId string `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// recipientName is the name of the person accepting the parcel.
+	RecipientName string `protobuf:"bytes,2,opt,name=recipientName,proto3" json:"recipientName,omitempty"`
+	// signature and photo are PNG or JPEG images of at most 2 MiB. At least
+	// one of them is required.
+	Signature []byte    `protobuf:"bytes,3,opt,name=signature,proto3" json:"signature,omitempty"`
+	Photo     []byte    `protobuf:"bytes,4,opt,name=photo,proto3" json:"photo,omitempty"`
+	Location  *Location `protobuf:"bytes,5,opt,name=location,proto3" json:"location,omitempty"`
+	// time is the time of delivery. If it is not set, the time at which the
+	// request is handled is used.
+	Time                 *timestamp.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DeliverParcelRequest) Reset()         { *m = DeliverParcelRequest{} }
+func (m *DeliverParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*DeliverParcelRequest) ProtoMessage()    {}
+func (*DeliverParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{29}
+}
+
+func (m *DeliverParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DeliverParcelRequest.Unmarshal(m, b)
+}
+func (m *DeliverParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DeliverParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *DeliverParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DeliverParcelRequest.Merge(m, src)
+}
+func (m *DeliverParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_DeliverParcelRequest.Size(m)
+}
+func (m *DeliverParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DeliverParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DeliverParcelRequest proto.InternalMessageInfo
+
+func (m *DeliverParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *DeliverParcelRequest) GetRecipientName() string {
+	if m != nil {
+		return m.RecipientName
+	}
+	return ""
+}
+
+func (m *DeliverParcelRequest) GetSignature() []byte {
+	if m != nil {
+		return m.Signature
+	}
+	return nil
+}
+
+func (m *DeliverParcelRequest) GetPhoto() []byte {
+	if m != nil {
+		return m.Photo
+	}
+	return nil
+}
+
+func (m *DeliverParcelRequest) GetLocation() *Location {
+	if m != nil {
+		return m.Location
+	}
+	return nil
+}
+
+func (m *DeliverParcelRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type PickupSlot struct {
+	// id is ignored when adding a time slot.
+	Id       string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	City     string               `protobuf:"bytes,2,opt,name=city,proto3" json:"city,omitempty"`
+	Planet   string               `protobuf:"bytes,3,opt,name=planet,proto3" json:"planet,omitempty"`
+	Start    *timestamp.Timestamp `protobuf:"bytes,4,opt,name=start,proto3" json:"start,omitempty"`
+	End      *timestamp.Timestamp `protobuf:"bytes,5,opt,name=end,proto3" json:"end,omitempty"`
+	Capacity int32                `protobuf:"varint,6,opt,name=capacity,proto3" json:"capacity,omitempty"`
+	// booked is the number of pickups booked in the time slot. It is ignored
+	// when adding a time slot.
+	Booked               int32    `protobuf:"varint,7,opt,name=booked,proto3" json:"booked,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PickupSlot) Reset()         { *m = PickupSlot{} }
+func (m *PickupSlot) String() string { return proto.CompactTextString(m) }
+func (*PickupSlot) ProtoMessage()    {}
+func (*PickupSlot) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{30}
+}
+
+func (m *PickupSlot) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupSlot.Unmarshal(m, b)
+}
+func (m *PickupSlot) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupSlot.Marshal(b, m, deterministic)
+}
+func (m *PickupSlot) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupSlot.Merge(m, src)
+}
+func (m *PickupSlot) XXX_Size() int {
+	return xxx_messageInfo_PickupSlot.Size(m)
+}
+func (m *PickupSlot) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupSlot.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupSlot proto.InternalMessageInfo
+
+func (m *PickupSlot) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PickupSlot) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *PickupSlot) GetPlanet() string {
+	if m != nil {
+		return m.Planet
+	}
+	return ""
+}
+
+func (m *PickupSlot) GetStart() *timestamp.Timestamp {
+	if m != nil {
+		return m.Start
+	}
+	return nil
+}
+
+func (m *PickupSlot) GetEnd() *timestamp.Timestamp {
+	if m != nil {
+		return m.End
+	}
+	return nil
+}
+
+func (m *PickupSlot) GetCapacity() int32 {
+	if m != nil {
+		return m.Capacity
+	}
+	return 0
+}
+
+func (m *PickupSlot) GetBooked() int32 {
+	if m != nil {
+		return m.Booked
+	}
+	return 0
+}
+
+type PickupSlots struct {
+	Slots                []*PickupSlot `protobuf:"bytes,1,rep,name=slots,proto3" json:"slots,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}      `json:"-"`
+	XXX_unrecognized     []byte        `json:"-"`
+	XXX_sizecache        int32         `json:"-"`
+}
+
+func (m *PickupSlots) Reset()         { *m = PickupSlots{} }
+func (m *PickupSlots) String() string { return proto.CompactTextString(m) }
+func (*PickupSlots) ProtoMessage()    {}
+func (*PickupSlots) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{31}
+}
+
+func (m *PickupSlots) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupSlots.Unmarshal(m, b)
+}
+func (m *PickupSlots) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupSlots.Marshal(b, m, deterministic)
+}
+func (m *PickupSlots) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupSlots.Merge(m, src)
+}
+func (m *PickupSlots) XXX_Size() int {
+	return xxx_messageInfo_PickupSlots.Size(m)
+}
+func (m *PickupSlots) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupSlots.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupSlots proto.InternalMessageInfo
+
+func (m *PickupSlots) GetSlots() []*PickupSlot {
+	if m != nil {
+		return m.Slots
+	}
+	return nil
+}
+
+type GetPickupSlotsRequest struct {
+	City                 string   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Planet               string   `protobuf:"bytes,2,opt,name=planet,proto3" json:"planet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPickupSlotsRequest) Reset()         { *m = GetPickupSlotsRequest{} }
+func (m *GetPickupSlotsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPickupSlotsRequest) ProtoMessage()    {}
+func (*GetPickupSlotsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{32}
+}
+
+func (m *GetPickupSlotsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPickupSlotsRequest.Unmarshal(m, b)
+}
+func (m *GetPickupSlotsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPickupSlotsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPickupSlotsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPickupSlotsRequest.Merge(m, src)
+}
+func (m *GetPickupSlotsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPickupSlotsRequest.Size(m)
+}
+func (m *GetPickupSlotsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPickupSlotsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPickupSlotsRequest proto.InternalMessageInfo
+
+func (m *GetPickupSlotsRequest) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *GetPickupSlotsRequest) GetPlanet() string {
+	if m != nil {
+		return m.Planet
+	}
+	return ""
+}
+
+type Pickup struct {
+	Id       string      `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParcelId string      `protobuf:"bytes,2,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Slot     *PickupSlot `protobuf:"bytes,3,opt,name=slot,proto3" json:"slot,omitempty"`
+	// status is "booked", "cancelled" or "collected".
+	Status               string               `protobuf:"bytes,4,opt,name=status,proto3" json:"status,omitempty"`
+	BookedAt             *timestamp.Timestamp `protobuf:"bytes,5,opt,name=bookedAt,proto3" json:"bookedAt,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Pickup) Reset()         { *m = Pickup{} }
+func (m *Pickup) String() string { return proto.CompactTextString(m) }
+func (*Pickup) ProtoMessage()    {}
+func (*Pickup) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{33}
+}
+
+func (m *Pickup) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Pickup.Unmarshal(m, b)
+}
+func (m *Pickup) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Pickup.Marshal(b, m, deterministic)
+}
+func (m *Pickup) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Pickup.Merge(m, src)
+}
+func (m *Pickup) XXX_Size() int {
+	return xxx_messageInfo_Pickup.Size(m)
+}
+func (m *Pickup) XXX_DiscardUnknown() {
+	xxx_messageInfo_Pickup.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Pickup proto.InternalMessageInfo
+
+func (m *Pickup) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Pickup) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *Pickup) GetSlot() *PickupSlot {
+	if m != nil {
+		return m.Slot
+	}
+	return nil
+}
+
+func (m *Pickup) GetStatus() string {
+	if m != nil {
+		return m.Status
+	}
+	return ""
+}
+
+func (m *Pickup) GetBookedAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.BookedAt
+	}
+	return nil
+}
+
+type BookPickupRequest struct {
+	ParcelId             string   `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	SlotId               string   `protobuf:"bytes,2,opt,name=slotId,proto3" json:"slotId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *BookPickupRequest) Reset()         { *m = BookPickupRequest{} }
+func (m *BookPickupRequest) String() string { return proto.CompactTextString(m) }
+func (*BookPickupRequest) ProtoMessage()    {}
+func (*BookPickupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{34}
+}
+
+func (m *BookPickupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_BookPickupRequest.Unmarshal(m, b)
+}
+func (m *BookPickupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_BookPickupRequest.Marshal(b, m, deterministic)
+}
+func (m *BookPickupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_BookPickupRequest.Merge(m, src)
+}
+func (m *BookPickupRequest) XXX_Size() int {
+	return xxx_messageInfo_BookPickupRequest.Size(m)
+}
+func (m *BookPickupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_BookPickupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_BookPickupRequest proto.InternalMessageInfo
+
+func (m *BookPickupRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *BookPickupRequest) GetSlotId() string {
+	if m != nil {
+		return m.SlotId
+	}
+	return ""
+}
+
+type CancelPickupRequest struct {
+	ParcelId             string   `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CancelPickupRequest) Reset()         { *m = CancelPickupRequest{} }
+func (m *CancelPickupRequest) String() string { return proto.CompactTextString(m) }
+func (*CancelPickupRequest) ProtoMessage()    {}
+func (*CancelPickupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{35}
+}
+
+func (m *CancelPickupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CancelPickupRequest.Unmarshal(m, b)
+}
+func (m *CancelPickupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CancelPickupRequest.Marshal(b, m, deterministic)
+}
+func (m *CancelPickupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CancelPickupRequest.Merge(m, src)
+}
+func (m *CancelPickupRequest) XXX_Size() int {
+	return xxx_messageInfo_CancelPickupRequest.Size(m)
+}
+func (m *CancelPickupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CancelPickupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CancelPickupRequest proto.InternalMessageInfo
+
+func (m *CancelPickupRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+type ConfirmPickupRequest struct {
+	PickupId string `protobuf:"bytes,1,opt,name=pickupId,proto3" json:"pickupId,omitempty"`
+	// time is the time of collection. If it is not set, the time at which
+	// the request is handled is used.
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ConfirmPickupRequest) Reset()         { *m = ConfirmPickupRequest{} }
+func (m *ConfirmPickupRequest) String() string { return proto.CompactTextString(m) }
+func (*ConfirmPickupRequest) ProtoMessage()    {}
+func (*ConfirmPickupRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{36}
+}
+
+func (m *ConfirmPickupRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConfirmPickupRequest.Unmarshal(m, b)
+}
+func (m *ConfirmPickupRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConfirmPickupRequest.Marshal(b, m, deterministic)
+}
+func (m *ConfirmPickupRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConfirmPickupRequest.Merge(m, src)
+}
+func (m *ConfirmPickupRequest) XXX_Size() int {
+	return xxx_messageInfo_ConfirmPickupRequest.Size(m)
+}
+func (m *ConfirmPickupRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConfirmPickupRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConfirmPickupRequest proto.InternalMessageInfo
+
+func (m *ConfirmPickupRequest) GetPickupId() string {
+	if m != nil {
+		return m.PickupId
+	}
+	return ""
+}
+
+func (m *ConfirmPickupRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type PickupPoint struct {
+	// id is ignored when adding a pickup point.
+	Id           string          `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Kind         PickupPointKind `protobuf:"varint,2,opt,name=kind,proto3,enum=grpc.PickupPointKind" json:"kind,omitempty"`
+	Name         string          `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Street       string          `protobuf:"bytes,4,opt,name=street,proto3" json:"street,omitempty"`
+	Zip          string          `protobuf:"bytes,5,opt,name=zip,proto3" json:"zip,omitempty"`
+	City         string          `protobuf:"bytes,6,opt,name=city,proto3" json:"city,omitempty"`
+	Country      string          `protobuf:"bytes,7,opt,name=country,proto3" json:"country,omitempty"`
+	Planet       string          `protobuf:"bytes,8,opt,name=planet,proto3" json:"planet,omitempty"`
+	OpeningHours string          `protobuf:"bytes,9,opt,name=openingHours,proto3" json:"openingHours,omitempty"`
+	Compartments int32           `protobuf:"varint,10,opt,name=compartments,proto3" json:"compartments,omitempty"`
+	// occupied is the number of allocated compartments. It is ignored when
+	// adding a pickup point.
+	Occupied             int32    `protobuf:"varint,11,opt,name=occupied,proto3" json:"occupied,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *PickupPoint) Reset()         { *m = PickupPoint{} }
+func (m *PickupPoint) String() string { return proto.CompactTextString(m) }
+func (*PickupPoint) ProtoMessage()    {}
+func (*PickupPoint) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{37}
+}
+
+func (m *PickupPoint) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupPoint.Unmarshal(m, b)
+}
+func (m *PickupPoint) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupPoint.Marshal(b, m, deterministic)
+}
+func (m *PickupPoint) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupPoint.Merge(m, src)
+}
+func (m *PickupPoint) XXX_Size() int {
+	return xxx_messageInfo_PickupPoint.Size(m)
+}
+func (m *PickupPoint) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupPoint.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupPoint proto.InternalMessageInfo
+
+func (m *PickupPoint) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetKind() PickupPointKind {
+	if m != nil {
+		return m.Kind
+	}
+	return PickupPointKind_SHOP
+}
+
+func (m *PickupPoint) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetStreet() string {
+	if m != nil {
+		return m.Street
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetZip() string {
+	if m != nil {
+		return m.Zip
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetCountry() string {
+	if m != nil {
+		return m.Country
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetPlanet() string {
+	if m != nil {
+		return m.Planet
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetOpeningHours() string {
+	if m != nil {
+		return m.OpeningHours
+	}
+	return ""
+}
+
+func (m *PickupPoint) GetCompartments() int32 {
+	if m != nil {
+		return m.Compartments
+	}
+	return 0
+}
+
+func (m *PickupPoint) GetOccupied() int32 {
+	if m != nil {
+		return m.Occupied
+	}
+	return 0
+}
+
+type PickupPoints struct {
+	Points               []*PickupPoint `protobuf:"bytes,1,rep,name=points,proto3" json:"points,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *PickupPoints) Reset()         { *m = PickupPoints{} }
+func (m *PickupPoints) String() string { return proto.CompactTextString(m) }
+func (*PickupPoints) ProtoMessage()    {}
+func (*PickupPoints) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{38}
+}
+
+func (m *PickupPoints) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_PickupPoints.Unmarshal(m, b)
+}
+func (m *PickupPoints) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_PickupPoints.Marshal(b, m, deterministic)
+}
+func (m *PickupPoints) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_PickupPoints.Merge(m, src)
+}
+func (m *PickupPoints) XXX_Size() int {
+	return xxx_messageInfo_PickupPoints.Size(m)
+}
+func (m *PickupPoints) XXX_DiscardUnknown() {
+	xxx_messageInfo_PickupPoints.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_PickupPoints proto.InternalMessageInfo
+
+func (m *PickupPoints) GetPoints() []*PickupPoint {
+	if m != nil {
+		return m.Points
+	}
+	return nil
+}
+
+type GetPickupPointsRequest struct {
+	City                 string   `protobuf:"bytes,1,opt,name=city,proto3" json:"city,omitempty"`
+	Planet               string   `protobuf:"bytes,2,opt,name=planet,proto3" json:"planet,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPickupPointsRequest) Reset()         { *m = GetPickupPointsRequest{} }
+func (m *GetPickupPointsRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPickupPointsRequest) ProtoMessage()    {}
+func (*GetPickupPointsRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{39}
+}
+
+func (m *GetPickupPointsRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPickupPointsRequest.Unmarshal(m, b)
+}
+func (m *GetPickupPointsRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPickupPointsRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPickupPointsRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPickupPointsRequest.Merge(m, src)
+}
+func (m *GetPickupPointsRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPickupPointsRequest.Size(m)
+}
+func (m *GetPickupPointsRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPickupPointsRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPickupPointsRequest proto.InternalMessageInfo
+
+func (m *GetPickupPointsRequest) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+func (m *GetPickupPointsRequest) GetPlanet() string {
+	if m != nil {
+		return m.Planet
+	}
+	return ""
+}
+
+type DepositParcelRequest struct {
+	ParcelId string `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// time is the time of the deposit. If it is not set, the time at which
+	// the request is handled is used.
+	Time                 *timestamp.Timestamp `protobuf:"bytes,2,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *DepositParcelRequest) Reset()         { *m = DepositParcelRequest{} }
+func (m *DepositParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*DepositParcelRequest) ProtoMessage()    {}
+func (*DepositParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{40}
+}
+
+func (m *DepositParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_DepositParcelRequest.Unmarshal(m, b)
+}
+func (m *DepositParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_DepositParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *DepositParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_DepositParcelRequest.Merge(m, src)
+}
+func (m *DepositParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_DepositParcelRequest.Size(m)
+}
+func (m *DepositParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_DepositParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_DepositParcelRequest proto.InternalMessageInfo
+
+func (m *DepositParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *DepositParcelRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type CollectParcelRequest struct {
+	PickupPointId        string               `protobuf:"bytes,1,opt,name=pickupPointId,proto3" json:"pickupPointId,omitempty"`
+	Code                 string               `protobuf:"bytes,2,opt,name=code,proto3" json:"code,omitempty"`
+	Time                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *CollectParcelRequest) Reset()         { *m = CollectParcelRequest{} }
+func (m *CollectParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*CollectParcelRequest) ProtoMessage()    {}
+func (*CollectParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{41}
+}
+
+func (m *CollectParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CollectParcelRequest.Unmarshal(m, b)
+}
+func (m *CollectParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CollectParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *CollectParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CollectParcelRequest.Merge(m, src)
+}
+func (m *CollectParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_CollectParcelRequest.Size(m)
+}
+func (m *CollectParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CollectParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CollectParcelRequest proto.InternalMessageInfo
+
+func (m *CollectParcelRequest) GetPickupPointId() string {
+	if m != nil {
+		return m.PickupPointId
+	}
+	return ""
+}
+
+func (m *CollectParcelRequest) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *CollectParcelRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+type GetPickupCodeRequest struct {
+	ParcelId             string   `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetPickupCodeRequest) Reset()         { *m = GetPickupCodeRequest{} }
+func (m *GetPickupCodeRequest) String() string { return proto.CompactTextString(m) }
+func (*GetPickupCodeRequest) ProtoMessage()    {}
+func (*GetPickupCodeRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{42}
+}
+
+func (m *GetPickupCodeRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetPickupCodeRequest.Unmarshal(m, b)
+}
+func (m *GetPickupCodeRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetPickupCodeRequest.Marshal(b, m, deterministic)
+}
+func (m *GetPickupCodeRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetPickupCodeRequest.Merge(m, src)
+}
+func (m *GetPickupCodeRequest) XXX_Size() int {
+	return xxx_messageInfo_GetPickupCodeRequest.Size(m)
+}
+func (m *GetPickupCodeRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetPickupCodeRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetPickupCodeRequest proto.InternalMessageInfo
+
+func (m *GetPickupCodeRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+// Compartment is a pickup point's compartment allocated to a parcel.
+type Compartment struct {
+	Point    *PickupPoint `protobuf:"bytes,1,opt,name=point,proto3" json:"point,omitempty"`
+	ParcelId string       `protobuf:"bytes,2,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Number   int32        `protobuf:"varint,3,opt,name=number,proto3" json:"number,omitempty"`
+	// code is only returned to the parcel's recipient.
+	Code      string               `protobuf:"bytes,4,opt,name=code,proto3" json:"code,omitempty"`
+	ExpiresAt *timestamp.Timestamp `protobuf:"bytes,5,opt,name=expiresAt,proto3" json:"expiresAt,omitempty"`
+	// event is the event recorded by DepositParcel and CollectParcel.
+	Event                *Event   `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Compartment) Reset()         { *m = Compartment{} }
+func (m *Compartment) String() string { return proto.CompactTextString(m) }
+func (*Compartment) ProtoMessage()    {}
+func (*Compartment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{43}
+}
+
+func (m *Compartment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Compartment.Unmarshal(m, b)
+}
+func (m *Compartment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Compartment.Marshal(b, m, deterministic)
+}
+func (m *Compartment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Compartment.Merge(m, src)
+}
+func (m *Compartment) XXX_Size() int {
+	return xxx_messageInfo_Compartment.Size(m)
+}
+func (m *Compartment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Compartment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Compartment proto.InternalMessageInfo
+
+func (m *Compartment) GetPoint() *PickupPoint {
+	if m != nil {
+		return m.Point
+	}
+	return nil
+}
+
+func (m *Compartment) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *Compartment) GetNumber() int32 {
+	if m != nil {
+		return m.Number
+	}
+	return 0
+}
+
+func (m *Compartment) GetCode() string {
+	if m != nil {
+		return m.Code
+	}
+	return ""
+}
+
+func (m *Compartment) GetExpiresAt() *timestamp.Timestamp {
+	if m != nil {
+		return m.ExpiresAt
+	}
+	return nil
+}
+
+func (m *Compartment) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type ScanParcelRequest struct {
+	CenterId string `protobuf:"bytes,1,opt,name=centerId,proto3" json:"centerId,omitempty"`
+	ParcelId string `protobuf:"bytes,2,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// time is the time of the scan. If it is not set, the time at which the
+	// request is handled is used.
+	Time                 *timestamp.Timestamp `protobuf:"bytes,3,opt,name=time,proto3" json:"time,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *ScanParcelRequest) Reset()         { *m = ScanParcelRequest{} }
+func (m *ScanParcelRequest) String() string { return proto.CompactTextString(m) }
+func (*ScanParcelRequest) ProtoMessage()    {}
+func (*ScanParcelRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{44}
+}
+
+func (m *ScanParcelRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ScanParcelRequest.Unmarshal(m, b)
+}
+func (m *ScanParcelRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ScanParcelRequest.Marshal(b, m, deterministic)
+}
+func (m *ScanParcelRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ScanParcelRequest.Merge(m, src)
+}
+func (m *ScanParcelRequest) XXX_Size() int {
+	return xxx_messageInfo_ScanParcelRequest.Size(m)
+}
+func (m *ScanParcelRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ScanParcelRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ScanParcelRequest proto.InternalMessageInfo
+
+func (m *ScanParcelRequest) GetCenterId() string {
+	if m != nil {
+		return m.CenterId
+	}
+	return ""
+}
+
+func (m *ScanParcelRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *ScanParcelRequest) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+// SortingBin is a bin of a logistics center, which collects the parcels to
+// a planet or, if city is set, to a city on the planet.
+type SortingBin struct {
+	Id                   string   `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Label                string   `protobuf:"bytes,2,opt,name=label,proto3" json:"label,omitempty"`
+	Planet               string   `protobuf:"bytes,3,opt,name=planet,proto3" json:"planet,omitempty"`
+	City                 string   `protobuf:"bytes,4,opt,name=city,proto3" json:"city,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *SortingBin) Reset()         { *m = SortingBin{} }
+func (m *SortingBin) String() string { return proto.CompactTextString(m) }
+func (*SortingBin) ProtoMessage()    {}
+func (*SortingBin) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{45}
+}
+
+func (m *SortingBin) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_SortingBin.Unmarshal(m, b)
+}
+func (m *SortingBin) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_SortingBin.Marshal(b, m, deterministic)
+}
+func (m *SortingBin) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_SortingBin.Merge(m, src)
+}
+func (m *SortingBin) XXX_Size() int {
+	return xxx_messageInfo_SortingBin.Size(m)
+}
+func (m *SortingBin) XXX_DiscardUnknown() {
+	xxx_messageInfo_SortingBin.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_SortingBin proto.InternalMessageInfo
+
+func (m *SortingBin) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *SortingBin) GetLabel() string {
+	if m != nil {
+		return m.Label
+	}
+	return ""
+}
+
+func (m *SortingBin) GetPlanet() string {
+	if m != nil {
+		return m.Planet
+	}
+	return ""
+}
+
+func (m *SortingBin) GetCity() string {
+	if m != nil {
+		return m.City
+	}
+	return ""
+}
+
+type Scan struct {
+	Id       string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CenterId string               `protobuf:"bytes,2,opt,name=centerId,proto3" json:"centerId,omitempty"`
+	ParcelId string               `protobuf:"bytes,3,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Bin      *SortingBin          `protobuf:"bytes,4,opt,name=bin,proto3" json:"bin,omitempty"`
+	Time     *timestamp.Timestamp `protobuf:"bytes,5,opt,name=time,proto3" json:"time,omitempty"`
+	// event is only set, if the scan recorded the parcel's arrival.
+	Event                *Event   `protobuf:"bytes,6,opt,name=event,proto3" json:"event,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *Scan) Reset()         { *m = Scan{} }
+func (m *Scan) String() string { return proto.CompactTextString(m) }
+func (*Scan) ProtoMessage()    {}
+func (*Scan) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{46}
+}
+
+func (m *Scan) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Scan.Unmarshal(m, b)
+}
+func (m *Scan) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Scan.Marshal(b, m, deterministic)
+}
+func (m *Scan) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Scan.Merge(m, src)
+}
+func (m *Scan) XXX_Size() int {
+	return xxx_messageInfo_Scan.Size(m)
+}
+func (m *Scan) XXX_DiscardUnknown() {
+	xxx_messageInfo_Scan.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Scan proto.InternalMessageInfo
+
+func (m *Scan) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Scan) GetCenterId() string {
+	if m != nil {
+		return m.CenterId
+	}
+	return ""
+}
+
+func (m *Scan) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *Scan) GetBin() *SortingBin {
+	if m != nil {
+		return m.Bin
+	}
+	return nil
+}
+
+func (m *Scan) GetTime() *timestamp.Timestamp {
+	if m != nil {
+		return m.Time
+	}
+	return nil
+}
+
+func (m *Scan) GetEvent() *Event {
+	if m != nil {
+		return m.Event
+	}
+	return nil
+}
+
+type CreateConsignmentRequest struct {
+	// parcelIds are the tracking ids or tracking codes of the parcels.
+	ParcelIds []string `protobuf:"bytes,1,rep,name=parcelIds,proto3" json:"parcelIds,omitempty"`
+	// reference is the sender's optional reference, e.g. an order number.
+	Reference            string   `protobuf:"bytes,2,opt,name=reference,proto3" json:"reference,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *CreateConsignmentRequest) Reset()         { *m = CreateConsignmentRequest{} }
+func (m *CreateConsignmentRequest) String() string { return proto.CompactTextString(m) }
+func (*CreateConsignmentRequest) ProtoMessage()    {}
+func (*CreateConsignmentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{47}
+}
+
+func (m *CreateConsignmentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_CreateConsignmentRequest.Unmarshal(m, b)
+}
+func (m *CreateConsignmentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_CreateConsignmentRequest.Marshal(b, m, deterministic)
+}
+func (m *CreateConsignmentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_CreateConsignmentRequest.Merge(m, src)
+}
+func (m *CreateConsignmentRequest) XXX_Size() int {
+	return xxx_messageInfo_CreateConsignmentRequest.Size(m)
+}
+func (m *CreateConsignmentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_CreateConsignmentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_CreateConsignmentRequest proto.InternalMessageInfo
+
+func (m *CreateConsignmentRequest) GetParcelIds() []string {
+	if m != nil {
+		return m.ParcelIds
+	}
+	return nil
+}
+
+func (m *CreateConsignmentRequest) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+type ConsignmentParcel struct {
+	Parcel *Parcel           `protobuf:"bytes,1,opt,name=parcel,proto3" json:"parcel,omitempty"`
+	Status ConsignmentStatus `protobuf:"varint,2,opt,name=status,proto3,enum=grpc.ConsignmentStatus" json:"status,omitempty"`
+	// latest is the parcel's most recent tracking event.
+	Latest               *Event            `protobuf:"bytes,3,opt,name=latest,proto3" json:"latest,omitempty"`
+	Estimate             *DeliveryEstimate `protobuf:"bytes,4,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *ConsignmentParcel) Reset()         { *m = ConsignmentParcel{} }
+func (m *ConsignmentParcel) String() string { return proto.CompactTextString(m) }
+func (*ConsignmentParcel) ProtoMessage()    {}
+func (*ConsignmentParcel) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{48}
+}
+
+func (m *ConsignmentParcel) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ConsignmentParcel.Unmarshal(m, b)
+}
+func (m *ConsignmentParcel) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ConsignmentParcel.Marshal(b, m, deterministic)
+}
+func (m *ConsignmentParcel) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ConsignmentParcel.Merge(m, src)
+}
+func (m *ConsignmentParcel) XXX_Size() int {
+	return xxx_messageInfo_ConsignmentParcel.Size(m)
+}
+func (m *ConsignmentParcel) XXX_DiscardUnknown() {
+	xxx_messageInfo_ConsignmentParcel.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ConsignmentParcel proto.InternalMessageInfo
+
+func (m *ConsignmentParcel) GetParcel() *Parcel {
+	if m != nil {
+		return m.Parcel
+	}
+	return nil
+}
+
+func (m *ConsignmentParcel) GetStatus() ConsignmentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ConsignmentStatus_REGISTERED
+}
+
+func (m *ConsignmentParcel) GetLatest() *Event {
+	if m != nil {
+		return m.Latest
+	}
+	return nil
+}
+
+func (m *ConsignmentParcel) GetEstimate() *DeliveryEstimate {
+	if m != nil {
+		return m.Estimate
+	}
+	return nil
+}
+
+type Consignment struct {
+	Id string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	// trackingCode is the master tracking number, which is accepted wherever
+	// a parcel's tracking number is.
+	TrackingCode string               `protobuf:"bytes,2,opt,name=trackingCode,proto3" json:"trackingCode,omitempty"`
+	Reference    string               `protobuf:"bytes,3,opt,name=reference,proto3" json:"reference,omitempty"`
+	Created      *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	Status       ConsignmentStatus    `protobuf:"varint,5,opt,name=status,proto3,enum=grpc.ConsignmentStatus" json:"status,omitempty"`
+	Description  string               `protobuf:"bytes,6,opt,name=description,proto3" json:"description,omitempty"`
+	Parcels      []*ConsignmentParcel `protobuf:"bytes,7,rep,name=parcels,proto3" json:"parcels,omitempty"`
+	Delivered    int32                `protobuf:"varint,8,opt,name=delivered,proto3" json:"delivered,omitempty"`
+	Returned     int32                `protobuf:"varint,9,opt,name=returned,proto3" json:"returned,omitempty"`
+	Outstanding  int32                `protobuf:"varint,10,opt,name=outstanding,proto3" json:"outstanding,omitempty"`
+	// latest is the most recent event of any of the parcels.
+	Latest *Event `protobuf:"bytes,11,opt,name=latest,proto3" json:"latest,omitempty"`
+	// estimate is the delivery window of the last outstanding parcel.
+	Estimate             *DeliveryEstimate `protobuf:"bytes,12,opt,name=estimate,proto3" json:"estimate,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}          `json:"-"`
+	XXX_unrecognized     []byte            `json:"-"`
+	XXX_sizecache        int32             `json:"-"`
+}
+
+func (m *Consignment) Reset()         { *m = Consignment{} }
+func (m *Consignment) String() string { return proto.CompactTextString(m) }
+func (*Consignment) ProtoMessage()    {}
+func (*Consignment) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{49}
+}
+
+func (m *Consignment) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consignment.Unmarshal(m, b)
+}
+func (m *Consignment) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consignment.Marshal(b, m, deterministic)
+}
+func (m *Consignment) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consignment.Merge(m, src)
+}
+func (m *Consignment) XXX_Size() int {
+	return xxx_messageInfo_Consignment.Size(m)
+}
+func (m *Consignment) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consignment.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consignment proto.InternalMessageInfo
+
+func (m *Consignment) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Consignment) GetTrackingCode() string {
+	if m != nil {
+		return m.TrackingCode
+	}
+	return ""
+}
+
+func (m *Consignment) GetReference() string {
+	if m != nil {
+		return m.Reference
+	}
+	return ""
+}
+
+func (m *Consignment) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Consignment) GetStatus() ConsignmentStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ConsignmentStatus_REGISTERED
+}
+
+func (m *Consignment) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Consignment) GetParcels() []*ConsignmentParcel {
+	if m != nil {
+		return m.Parcels
+	}
+	return nil
+}
+
+func (m *Consignment) GetDelivered() int32 {
+	if m != nil {
+		return m.Delivered
+	}
+	return 0
+}
+
+func (m *Consignment) GetReturned() int32 {
+	if m != nil {
+		return m.Returned
+	}
+	return 0
+}
+
+func (m *Consignment) GetOutstanding() int32 {
+	if m != nil {
+		return m.Outstanding
+	}
+	return 0
+}
+
+func (m *Consignment) GetLatest() *Event {
+	if m != nil {
+		return m.Latest
+	}
+	return nil
+}
+
+func (m *Consignment) GetEstimate() *DeliveryEstimate {
+	if m != nil {
+		return m.Estimate
+	}
+	return nil
+}
+
+type Consignments struct {
+	Consignments         []*Consignment `protobuf:"bytes,1,rep,name=consignments,proto3" json:"consignments,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}       `json:"-"`
+	XXX_unrecognized     []byte         `json:"-"`
+	XXX_sizecache        int32          `json:"-"`
+}
+
+func (m *Consignments) Reset()         { *m = Consignments{} }
+func (m *Consignments) String() string { return proto.CompactTextString(m) }
+func (*Consignments) ProtoMessage()    {}
+func (*Consignments) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{50}
+}
+
+func (m *Consignments) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Consignments.Unmarshal(m, b)
+}
+func (m *Consignments) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Consignments.Marshal(b, m, deterministic)
+}
+func (m *Consignments) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Consignments.Merge(m, src)
+}
+func (m *Consignments) XXX_Size() int {
+	return xxx_messageInfo_Consignments.Size(m)
+}
+func (m *Consignments) XXX_DiscardUnknown() {
+	xxx_messageInfo_Consignments.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Consignments proto.InternalMessageInfo
+
+func (m *Consignments) GetConsignments() []*Consignment {
+	if m != nil {
+		return m.Consignments
+	}
+	return nil
+}
+
+// EvidenceFile is a PNG or JPEG image or a PDF document attached to a claim.
+type EvidenceFile struct {
+	Name                 string   `protobuf:"bytes,1,opt,name=name,proto3" json:"name,omitempty"`
+	Data                 []byte   `protobuf:"bytes,2,opt,name=data,proto3" json:"data,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *EvidenceFile) Reset()         { *m = EvidenceFile{} }
+func (m *EvidenceFile) String() string { return proto.CompactTextString(m) }
+func (*EvidenceFile) ProtoMessage()    {}
+func (*EvidenceFile) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{51}
+}
+
+func (m *EvidenceFile) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_EvidenceFile.Unmarshal(m, b)
+}
+func (m *EvidenceFile) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_EvidenceFile.Marshal(b, m, deterministic)
+}
+func (m *EvidenceFile) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_EvidenceFile.Merge(m, src)
+}
+func (m *EvidenceFile) XXX_Size() int {
+	return xxx_messageInfo_EvidenceFile.Size(m)
+}
+func (m *EvidenceFile) XXX_DiscardUnknown() {
+	xxx_messageInfo_EvidenceFile.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_EvidenceFile proto.InternalMessageInfo
+
+func (m *EvidenceFile) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *EvidenceFile) GetData() []byte {
+	if m != nil {
+		return m.Data
+	}
+	return nil
+}
+
+type FileClaimRequest struct {
+	// parcelId is the tracking id or tracking code of the insured parcel.
+	ParcelId    string    `protobuf:"bytes,1,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Kind        ClaimKind `protobuf:"varint,2,opt,name=kind,proto3,enum=grpc.ClaimKind" json:"kind,omitempty"`
+	Description string    `protobuf:"bytes,3,opt,name=description,proto3" json:"description,omitempty"`
+	// amount is given in cents and defaults to the parcel's declared value.
+	Amount int64 `protobuf:"varint,4,opt,name=amount,proto3" json:"amount,omitempty"`
+	// cardId identifies the user's credit card, to which the claim is
+	// refunded.
+	CardId               string          `protobuf:"bytes,5,opt,name=cardId,proto3" json:"cardId,omitempty"`
+	Evidence             []*EvidenceFile `protobuf:"bytes,6,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}        `json:"-"`
+	XXX_unrecognized     []byte          `json:"-"`
+	XXX_sizecache        int32           `json:"-"`
+}
+
+func (m *FileClaimRequest) Reset()         { *m = FileClaimRequest{} }
+func (m *FileClaimRequest) String() string { return proto.CompactTextString(m) }
+func (*FileClaimRequest) ProtoMessage()    {}
+func (*FileClaimRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{52}
+}
+
+func (m *FileClaimRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_FileClaimRequest.Unmarshal(m, b)
+}
+func (m *FileClaimRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_FileClaimRequest.Marshal(b, m, deterministic)
+}
+func (m *FileClaimRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_FileClaimRequest.Merge(m, src)
+}
+func (m *FileClaimRequest) XXX_Size() int {
+	return xxx_messageInfo_FileClaimRequest.Size(m)
+}
+func (m *FileClaimRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_FileClaimRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_FileClaimRequest proto.InternalMessageInfo
+
+func (m *FileClaimRequest) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *FileClaimRequest) GetKind() ClaimKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ClaimKind_DAMAGE
+}
+
+func (m *FileClaimRequest) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *FileClaimRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *FileClaimRequest) GetCardId() string {
+	if m != nil {
+		return m.CardId
+	}
+	return ""
+}
+
+func (m *FileClaimRequest) GetEvidence() []*EvidenceFile {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type Evidence struct {
+	Id                   string               `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Name                 string               `protobuf:"bytes,2,opt,name=name,proto3" json:"name,omitempty"`
+	ContentType          string               `protobuf:"bytes,3,opt,name=contentType,proto3" json:"contentType,omitempty"`
+	Uploaded             *timestamp.Timestamp `protobuf:"bytes,4,opt,name=uploaded,proto3" json:"uploaded,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Evidence) Reset()         { *m = Evidence{} }
+func (m *Evidence) String() string { return proto.CompactTextString(m) }
+func (*Evidence) ProtoMessage()    {}
+func (*Evidence) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{53}
+}
+
+func (m *Evidence) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Evidence.Unmarshal(m, b)
+}
+func (m *Evidence) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Evidence.Marshal(b, m, deterministic)
+}
+func (m *Evidence) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Evidence.Merge(m, src)
+}
+func (m *Evidence) XXX_Size() int {
+	return xxx_messageInfo_Evidence.Size(m)
+}
+func (m *Evidence) XXX_DiscardUnknown() {
+	xxx_messageInfo_Evidence.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Evidence proto.InternalMessageInfo
+
+func (m *Evidence) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Evidence) GetName() string {
+	if m != nil {
+		return m.Name
+	}
+	return ""
+}
+
+func (m *Evidence) GetContentType() string {
+	if m != nil {
+		return m.ContentType
+	}
+	return ""
+}
+
+func (m *Evidence) GetUploaded() *timestamp.Timestamp {
+	if m != nil {
+		return m.Uploaded
+	}
+	return nil
+}
+
+type Refund struct {
+	Id     string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	CardId string `protobuf:"bytes,2,opt,name=cardId,proto3" json:"cardId,omitempty"`
+	// amount is given in cents.
+	Amount               int64                `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,4,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Refund) Reset()         { *m = Refund{} }
+func (m *Refund) String() string { return proto.CompactTextString(m) }
+func (*Refund) ProtoMessage()    {}
+func (*Refund) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{54}
+}
+
+func (m *Refund) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Refund.Unmarshal(m, b)
+}
+func (m *Refund) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Refund.Marshal(b, m, deterministic)
+}
+func (m *Refund) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Refund.Merge(m, src)
+}
+func (m *Refund) XXX_Size() int {
+	return xxx_messageInfo_Refund.Size(m)
+}
+func (m *Refund) XXX_DiscardUnknown() {
+	xxx_messageInfo_Refund.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Refund proto.InternalMessageInfo
+
+func (m *Refund) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Refund) GetCardId() string {
+	if m != nil {
+		return m.CardId
+	}
+	return ""
+}
+
+func (m *Refund) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Refund) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+type Claim struct {
+	Id          string    `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	ParcelId    string    `protobuf:"bytes,2,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	Kind        ClaimKind `protobuf:"varint,3,opt,name=kind,proto3,enum=grpc.ClaimKind" json:"kind,omitempty"`
+	Description string    `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	// amount is given in cents.
+	Amount  int64                `protobuf:"varint,5,opt,name=amount,proto3" json:"amount,omitempty"`
+	CardId  string               `protobuf:"bytes,6,opt,name=cardId,proto3" json:"cardId,omitempty"`
+	Status  ClaimStatus          `protobuf:"varint,7,opt,name=status,proto3,enum=grpc.ClaimStatus" json:"status,omitempty"`
+	Created *timestamp.Timestamp `protobuf:"bytes,8,opt,name=created,proto3" json:"created,omitempty"`
+	// note is the reviewer's explanation of the decision.
+	Note    string               `protobuf:"bytes,9,opt,name=note,proto3" json:"note,omitempty"`
+	Decided *timestamp.Timestamp `protobuf:"bytes,10,opt,name=decided,proto3" json:"decided,omitempty"`
+	// refund is only set for approved claims.
+	Refund               *Refund     `protobuf:"bytes,11,opt,name=refund,proto3" json:"refund,omitempty"`
+	Evidence             []*Evidence `protobuf:"bytes,12,rep,name=evidence,proto3" json:"evidence,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}    `json:"-"`
+	XXX_unrecognized     []byte      `json:"-"`
+	XXX_sizecache        int32       `json:"-"`
+}
+
+func (m *Claim) Reset()         { *m = Claim{} }
+func (m *Claim) String() string { return proto.CompactTextString(m) }
+func (*Claim) ProtoMessage()    {}
+func (*Claim) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{55}
+}
+
+func (m *Claim) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Claim.Unmarshal(m, b)
+}
+func (m *Claim) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Claim.Marshal(b, m, deterministic)
+}
+func (m *Claim) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Claim.Merge(m, src)
+}
+func (m *Claim) XXX_Size() int {
+	return xxx_messageInfo_Claim.Size(m)
+}
+func (m *Claim) XXX_DiscardUnknown() {
+	xxx_messageInfo_Claim.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Claim proto.InternalMessageInfo
+
+func (m *Claim) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Claim) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *Claim) GetKind() ClaimKind {
+	if m != nil {
+		return m.Kind
+	}
+	return ClaimKind_DAMAGE
+}
+
+func (m *Claim) GetDescription() string {
+	if m != nil {
+		return m.Description
+	}
+	return ""
+}
+
+func (m *Claim) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *Claim) GetCardId() string {
+	if m != nil {
+		return m.CardId
+	}
+	return ""
+}
+
+func (m *Claim) GetStatus() ClaimStatus {
+	if m != nil {
+		return m.Status
+	}
+	return ClaimStatus_SUBMITTED
+}
+
+func (m *Claim) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+func (m *Claim) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+func (m *Claim) GetDecided() *timestamp.Timestamp {
+	if m != nil {
+		return m.Decided
+	}
+	return nil
+}
+
+func (m *Claim) GetRefund() *Refund {
+	if m != nil {
+		return m.Refund
+	}
+	return nil
+}
+
+func (m *Claim) GetEvidence() []*Evidence {
+	if m != nil {
+		return m.Evidence
+	}
+	return nil
+}
+
+type ClaimList struct {
+	Claims               []*Claim `protobuf:"bytes,1,rep,name=claims,proto3" json:"claims,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ClaimList) Reset()         { *m = ClaimList{} }
+func (m *ClaimList) String() string { return proto.CompactTextString(m) }
+func (*ClaimList) ProtoMessage()    {}
+func (*ClaimList) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{56}
+}
+
+func (m *ClaimList) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ClaimList.Unmarshal(m, b)
+}
+func (m *ClaimList) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ClaimList.Marshal(b, m, deterministic)
+}
+func (m *ClaimList) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ClaimList.Merge(m, src)
+}
+func (m *ClaimList) XXX_Size() int {
+	return xxx_messageInfo_ClaimList.Size(m)
+}
+func (m *ClaimList) XXX_DiscardUnknown() {
+	xxx_messageInfo_ClaimList.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ClaimList proto.InternalMessageInfo
+
+func (m *ClaimList) GetClaims() []*Claim {
+	if m != nil {
+		return m.Claims
+	}
+	return nil
+}
+
+type ReviewClaimRequest struct {
+	ClaimId string `protobuf:"bytes,1,opt,name=claimId,proto3" json:"claimId,omitempty"`
+	// approve approves the claim, otherwise it is rejected.
+	Approve bool `protobuf:"varint,2,opt,name=approve,proto3" json:"approve,omitempty"`
+	// amount is the refunded amount in cents, which defaults to the claimed
+	// amount.
+	Amount               int64    `protobuf:"varint,3,opt,name=amount,proto3" json:"amount,omitempty"`
+	Note                 string   `protobuf:"bytes,4,opt,name=note,proto3" json:"note,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *ReviewClaimRequest) Reset()         { *m = ReviewClaimRequest{} }
+func (m *ReviewClaimRequest) String() string { return proto.CompactTextString(m) }
+func (*ReviewClaimRequest) ProtoMessage()    {}
+func (*ReviewClaimRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{57}
+}
+
+func (m *ReviewClaimRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_ReviewClaimRequest.Unmarshal(m, b)
+}
+func (m *ReviewClaimRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_ReviewClaimRequest.Marshal(b, m, deterministic)
+}
+func (m *ReviewClaimRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_ReviewClaimRequest.Merge(m, src)
+}
+func (m *ReviewClaimRequest) XXX_Size() int {
+	return xxx_messageInfo_ReviewClaimRequest.Size(m)
+}
+func (m *ReviewClaimRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_ReviewClaimRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_ReviewClaimRequest proto.InternalMessageInfo
+
+func (m *ReviewClaimRequest) GetClaimId() string {
+	if m != nil {
+		return m.ClaimId
+	}
+	return ""
+}
+
+func (m *ReviewClaimRequest) GetApprove() bool {
+	if m != nil {
+		return m.Approve
+	}
+	return false
+}
+
+func (m *ReviewClaimRequest) GetAmount() int64 {
+	if m != nil {
+		return m.Amount
+	}
+	return 0
+}
+
+func (m *ReviewClaimRequest) GetNote() string {
+	if m != nil {
+		return m.Note
+	}
+	return ""
+}
+
+type Invoice struct {
+	Id       string `protobuf:"bytes,1,opt,name=id,proto3" json:"id,omitempty"`
+	Number   string `protobuf:"bytes,2,opt,name=number,proto3" json:"number,omitempty"`
+	ParcelId string `protobuf:"bytes,3,opt,name=parcelId,proto3" json:"parcelId,omitempty"`
+	// cardId is empty, once the charged credit card has been deleted, while
+	// card keeps the last digits of its number.
+	CardId string       `protobuf:"bytes,4,opt,name=cardId,proto3" json:"cardId,omitempty"`
+	Card   string       `protobuf:"bytes,5,opt,name=card,proto3" json:"card,omitempty"`
+	Lines  []*QuoteLine `protobuf:"bytes,6,rep,name=lines,proto3" json:"lines,omitempty"`
+	// net, tax and total are given in cents.
+	Net                  int64                `protobuf:"varint,7,opt,name=net,proto3" json:"net,omitempty"`
+	TaxName              string               `protobuf:"bytes,8,opt,name=taxName,proto3" json:"taxName,omitempty"`
+	TaxPercent           float64              `protobuf:"fixed64,9,opt,name=taxPercent,proto3" json:"taxPercent,omitempty"`
+	Tax                  int64                `protobuf:"varint,10,opt,name=tax,proto3" json:"tax,omitempty"`
+	Total                int64                `protobuf:"varint,11,opt,name=total,proto3" json:"total,omitempty"`
+	Currency             string               `protobuf:"bytes,12,opt,name=currency,proto3" json:"currency,omitempty"`
+	Created              *timestamp.Timestamp `protobuf:"bytes,13,opt,name=created,proto3" json:"created,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}             `json:"-"`
+	XXX_unrecognized     []byte               `json:"-"`
+	XXX_sizecache        int32                `json:"-"`
+}
+
+func (m *Invoice) Reset()         { *m = Invoice{} }
+func (m *Invoice) String() string { return proto.CompactTextString(m) }
+func (*Invoice) ProtoMessage()    {}
+func (*Invoice) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{58}
+}
+
+func (m *Invoice) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Invoice.Unmarshal(m, b)
+}
+func (m *Invoice) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Invoice.Marshal(b, m, deterministic)
+}
+func (m *Invoice) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Invoice.Merge(m, src)
+}
+func (m *Invoice) XXX_Size() int {
+	return xxx_messageInfo_Invoice.Size(m)
+}
+func (m *Invoice) XXX_DiscardUnknown() {
+	xxx_messageInfo_Invoice.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Invoice proto.InternalMessageInfo
+
+func (m *Invoice) GetId() string {
+	if m != nil {
+		return m.Id
+	}
+	return ""
+}
+
+func (m *Invoice) GetNumber() string {
+	if m != nil {
+		return m.Number
+	}
+	return ""
+}
+
+func (m *Invoice) GetParcelId() string {
+	if m != nil {
+		return m.ParcelId
+	}
+	return ""
+}
+
+func (m *Invoice) GetCardId() string {
+	if m != nil {
+		return m.CardId
+	}
+	return ""
+}
+
+func (m *Invoice) GetCard() string {
+	if m != nil {
+		return m.Card
+	}
+	return ""
+}
+
+func (m *Invoice) GetLines() []*QuoteLine {
+	if m != nil {
+		return m.Lines
+	}
+	return nil
+}
+
+func (m *Invoice) GetNet() int64 {
+	if m != nil {
+		return m.Net
+	}
+	return 0
+}
+
+func (m *Invoice) GetTaxName() string {
+	if m != nil {
+		return m.TaxName
+	}
+	return ""
+}
+
+func (m *Invoice) GetTaxPercent() float64 {
+	if m != nil {
+		return m.TaxPercent
+	}
+	return 0
+}
+
+func (m *Invoice) GetTax() int64 {
+	if m != nil {
+		return m.Tax
+	}
+	return 0
+}
+
+func (m *Invoice) GetTotal() int64 {
+	if m != nil {
+		return m.Total
+	}
+	return 0
+}
+
+func (m *Invoice) GetCurrency() string {
+	if m != nil {
+		return m.Currency
+	}
+	return ""
+}
+
+func (m *Invoice) GetCreated() *timestamp.Timestamp {
+	if m != nil {
+		return m.Created
+	}
+	return nil
+}
+
+type Invoices struct {
+	Invoices             []*Invoice `protobuf:"bytes,1,rep,name=invoices,proto3" json:"invoices,omitempty"`
+	XXX_NoUnkeyedLiteral struct{}   `json:"-"`
+	XXX_unrecognized     []byte     `json:"-"`
+	XXX_sizecache        int32      `json:"-"`
+}
+
+func (m *Invoices) Reset()         { *m = Invoices{} }
+func (m *Invoices) String() string { return proto.CompactTextString(m) }
+func (*Invoices) ProtoMessage()    {}
+func (*Invoices) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{59}
+}
+
+func (m *Invoices) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_Invoices.Unmarshal(m, b)
+}
+func (m *Invoices) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_Invoices.Marshal(b, m, deterministic)
+}
+func (m *Invoices) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_Invoices.Merge(m, src)
+}
+func (m *Invoices) XXX_Size() int {
+	return xxx_messageInfo_Invoices.Size(m)
+}
+func (m *Invoices) XXX_DiscardUnknown() {
+	xxx_messageInfo_Invoices.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_Invoices proto.InternalMessageInfo
+
+func (m *Invoices) GetInvoices() []*Invoice {
+	if m != nil {
+		return m.Invoices
+	}
+	return nil
+}
+
+type GetInvoiceDocumentRequest struct {
+	InvoiceId            string   `protobuf:"bytes,1,opt,name=invoiceId,proto3" json:"invoiceId,omitempty"`
+	XXX_NoUnkeyedLiteral struct{} `json:"-"`
+	XXX_unrecognized     []byte   `json:"-"`
+	XXX_sizecache        int32    `json:"-"`
+}
+
+func (m *GetInvoiceDocumentRequest) Reset()         { *m = GetInvoiceDocumentRequest{} }
+func (m *GetInvoiceDocumentRequest) String() string { return proto.CompactTextString(m) }
+func (*GetInvoiceDocumentRequest) ProtoMessage()    {}
+func (*GetInvoiceDocumentRequest) Descriptor() ([]byte, []int) {
+	return fileDescriptor_e433d43e56f7944c, []int{60}
+}
+
+func (m *GetInvoiceDocumentRequest) XXX_Unmarshal(b []byte) error {
+	return xxx_messageInfo_GetInvoiceDocumentRequest.Unmarshal(m, b)
+}
+func (m *GetInvoiceDocumentRequest) XXX_Marshal(b []byte, deterministic bool) ([]byte, error) {
+	return xxx_messageInfo_GetInvoiceDocumentRequest.Marshal(b, m, deterministic)
+}
+func (m *GetInvoiceDocumentRequest) XXX_Merge(src proto.Message) {
+	xxx_messageInfo_GetInvoiceDocumentRequest.Merge(m, src)
+}
+func (m *GetInvoiceDocumentRequest) XXX_Size() int {
+	return xxx_messageInfo_GetInvoiceDocumentRequest.Size(m)
+}
+func (m *GetInvoiceDocumentRequest) XXX_DiscardUnknown() {
+	xxx_messageInfo_GetInvoiceDocumentRequest.DiscardUnknown(m)
+}
+
+var xxx_messageInfo_GetInvoiceDocumentRequest proto.InternalMessageInfo
+
+func (m *GetInvoiceDocumentRequest) GetInvoiceId() string {
+	if m != nil {
+		return m.InvoiceId
+	}
+	return ""
+}
+
+func init() {
+	proto.RegisterEnum("grpc.Hazard", Hazard_name, Hazard_value)
+	proto.RegisterEnum("grpc.CustomsCategory", CustomsCategory_name, CustomsCategory_value)
+	proto.RegisterEnum("grpc.EventType", EventType_name, EventType_value)
+	proto.RegisterEnum("grpc.FailureReason", FailureReason_name, FailureReason_value)
+	proto.RegisterEnum("grpc.ServiceLevel", ServiceLevel_name, ServiceLevel_value)
+	proto.RegisterEnum("grpc.LabelFormat", LabelFormat_name, LabelFormat_value)
+	proto.RegisterEnum("grpc.PickupPointKind", PickupPointKind_name, PickupPointKind_value)
+	proto.RegisterEnum("grpc.ConsignmentStatus", ConsignmentStatus_name, ConsignmentStatus_value)
+	proto.RegisterEnum("grpc.ClaimKind", ClaimKind_name, ClaimKind_value)
+	proto.RegisterEnum("grpc.ClaimStatus", ClaimStatus_name, ClaimStatus_value)
+	proto.RegisterType((*LoginRequest)(nil), "grpc.LoginRequest")
+	proto.RegisterType((*LoginResponse)(nil), "grpc.LoginResponse")
+	proto.RegisterType((*PublicKey)(nil), "grpc.PublicKey")
+	proto.RegisterType((*CreditCard)(nil), "grpc.CreditCard")
+	proto.RegisterType((*CreditCards)(nil), "grpc.CreditCards")
+	proto.RegisterType((*Address)(nil), "grpc.Address")
+	proto.RegisterType((*Addresses)(nil), "grpc.Addresses")
+	proto.RegisterType((*SendParcelRequest)(nil), "grpc.SendParcelRequest")
+	proto.RegisterType((*Parcel)(nil), "grpc.Parcel")
+	proto.RegisterType((*ParcelAttributes)(nil), "grpc.ParcelAttributes")
+	proto.RegisterType((*CustomsItem)(nil), "grpc.CustomsItem")
+	proto.RegisterType((*CustomsDeclaration)(nil), "grpc.CustomsDeclaration")
+	proto.RegisterType((*TrackParcelRequest)(nil), "grpc.TrackParcelRequest")
+	proto.RegisterType((*Event)(nil), "grpc.Event")
+	proto.RegisterType((*TrackingInfo)(nil), "grpc.TrackingInfo")
+	proto.RegisterType((*DeliveryEstimate)(nil), "grpc.DeliveryEstimate")
+	proto.RegisterType((*AddParcelEventRequest)(nil), "grpc.AddParcelEventRequest")
+	proto.RegisterType((*QuoteRequest)(nil), "grpc.QuoteRequest")
+	proto.RegisterType((*QuoteLine)(nil), "grpc.QuoteLine")
+	proto.RegisterType((*Quote)(nil), "grpc.Quote")
+	proto.RegisterType((*GetLabelRequest)(nil), "grpc.GetLabelRequest")
+	proto.RegisterType((*Label)(nil), "grpc.Label")
+	proto.RegisterType((*ReturnParcelRequest)(nil), "grpc.ReturnParcelRequest")
+	proto.RegisterType((*RedirectParcelRequest)(nil), "grpc.RedirectParcelRequest")
+	proto.RegisterType((*RescheduleDeliveryRequest)(nil), "grpc.RescheduleDeliveryRequest")
+	proto.RegisterType((*HoldParcelRequest)(nil), "grpc.HoldParcelRequest")
+	proto.RegisterType((*ParcelSummary)(nil), "grpc.ParcelSummary")
+	proto.RegisterType((*ParcelSummaries)(nil), "grpc.ParcelSummaries")
+	proto.RegisterType((*Location)(nil), "grpc.Location")
+	proto.RegisterType((*DeliverParcelRequest)(nil), "grpc.DeliverParcelRequest")
+	proto.RegisterType((*PickupSlot)(nil), "grpc.PickupSlot")
+	proto.RegisterType((*PickupSlots)(nil), "grpc.PickupSlots")
+	proto.RegisterType((*GetPickupSlotsRequest)(nil), "grpc.GetPickupSlotsRequest")
+	proto.RegisterType((*Pickup)(nil), "grpc.Pickup")
+	proto.RegisterType((*BookPickupRequest)(nil), "grpc.BookPickupRequest")
+	proto.RegisterType((*CancelPickupRequest)(nil), "grpc.CancelPickupRequest")
+	proto.RegisterType((*ConfirmPickupRequest)(nil), "grpc.ConfirmPickupRequest")
+	proto.RegisterType((*PickupPoint)(nil), "grpc.PickupPoint")
+	proto.RegisterType((*PickupPoints)(nil), "grpc.PickupPoints")
+	proto.RegisterType((*GetPickupPointsRequest)(nil), "grpc.GetPickupPointsRequest")
+	proto.RegisterType((*DepositParcelRequest)(nil), "grpc.DepositParcelRequest")
+	proto.RegisterType((*CollectParcelRequest)(nil), "grpc.CollectParcelRequest")
+	proto.RegisterType((*GetPickupCodeRequest)(nil), "grpc.GetPickupCodeRequest")
+	proto.RegisterType((*Compartment)(nil), "grpc.Compartment")
+	proto.RegisterType((*ScanParcelRequest)(nil), "grpc.ScanParcelRequest")
+	proto.RegisterType((*SortingBin)(nil), "grpc.SortingBin")
+	proto.RegisterType((*Scan)(nil), "grpc.Scan")
+	proto.RegisterType((*CreateConsignmentRequest)(nil), "grpc.CreateConsignmentRequest")
+	proto.RegisterType((*ConsignmentParcel)(nil), "grpc.ConsignmentParcel")
+	proto.RegisterType((*Consignment)(nil), "grpc.Consignment")
+	proto.RegisterType((*Consignments)(nil), "grpc.Consignments")
+	proto.RegisterType((*EvidenceFile)(nil), "grpc.EvidenceFile")
+	proto.RegisterType((*FileClaimRequest)(nil), "grpc.FileClaimRequest")
+	proto.RegisterType((*Evidence)(nil), "grpc.Evidence")
+	proto.RegisterType((*Refund)(nil), "grpc.Refund")
+	proto.RegisterType((*Claim)(nil), "grpc.Claim")
+	proto.RegisterType((*ClaimList)(nil), "grpc.ClaimList")
+	proto.RegisterType((*ReviewClaimRequest)(nil), "grpc.ReviewClaimRequest")
+	proto.RegisterType((*Invoice)(nil), "grpc.Invoice")
+	proto.RegisterType((*Invoices)(nil), "grpc.Invoices")
+	proto.RegisterType((*GetInvoiceDocumentRequest)(nil), "grpc.GetInvoiceDocumentRequest")
+}
+
+func init() {
+	proto.RegisterFile("ipps.proto", fileDescriptor_e433d43e56f7944c)
+}
+
+var fileDescriptor_e433d43e56f7944c = []byte{
+	// 3998 bytes of a gzipped FileDescriptorProto
+	0x1f, 0x8b, 0x08, 0x00, 0x00, 0x00, 0x00, 0x00, 0x02, 0xff, 0xd4, 0x3b, 0x4d, 0x6f, 0x1b, 0x49,
+	0x76, 0xe2, 0x37, 0xf9, 0x48, 0x49, 0xad, 0xf2, 0x17, 0x4d, 0xcf, 0xee, 0x78, 0x7b, 0x9c, 0x1d,
+	0x5b, 0xbb, 0x23, 0x7b, 0x38, 0x6b, 0xef, 0xcc, 0x66, 0x07, 0x59, 0x9a, 0x6c, 0x49, 0x1d, 0x53,
+	0x24, 0xb7, 0xd8, 0x72, 0x3c, 0x73, 0x08, 0xd1, 0xea, 0x2e, 0x49, 0x0d, 0x93, 0xdd, 0x9c, 0xee,
+	0xa2, 0x6c, 0x6d, 0x80, 0x20, 0x97, 0x00, 0x9b, 0x05, 0xf2, 0x3b, 0x82, 0x04, 0x39, 0xe4, 0x92,
+	0x5f, 0x10, 0x2c, 0x90, 0x63, 0x2e, 0x39, 0x25, 0x40, 0x6e, 0x41, 0xce, 0xf9, 0x05, 0x41, 0x7d,
+	0xf4, 0x17, 0x9b, 0x92, 0xe8, 0x09, 0x72, 0xc8, 0x45, 0xe0, 0x7b, 0xf5, 0xea, 0xe3, 0x7d, 0xd7,
+	0x7b, 0xd5, 0x02, 0x70, 0xe6, 0xf3, 0x60, 0x6f, 0xee, 0x7b, 0xd4, 0x43, 0xc5, 0x33, 0x7f, 0x6e,
+	0xb5, 0x1e, 0x9c, 0x79, 0xde, 0xd9, 0x94, 0x3c, 0xe5, 0xb8, 0x93, 0xc5, 0xe9, 0x53, 0x32, 0x9b,
+	0xd3, 0x4b, 0x41, 0xd2, 0xfa, 0x78, 0x79, 0x90, 0x3a, 0x33, 0x12, 0x50, 0x73, 0x36, 0x17, 0x04,
+	0xea, 0x3e, 0x34, 0xfa, 0xde, 0x99, 0xe3, 0x62, 0xf2, 0xdd, 0x82, 0x04, 0x14, 0xb5, 0xa0, 0xba,
+	0x08, 0x88, 0xef, 0x9a, 0x33, 0xd2, 0xcc, 0x3d, 0xcc, 0x3d, 0xae, 0xe1, 0x08, 0x66, 0x63, 0x73,
+	0x33, 0x08, 0xde, 0x79, 0xbe, 0xdd, 0xcc, 0x3f, 0xcc, 0x3d, 0x6e, 0xe0, 0x08, 0x56, 0x3f, 0x83,
+	0x4d, 0xb9, 0x4e, 0x30, 0xf7, 0xdc, 0x80, 0xa0, 0x8f, 0xa0, 0x66, 0x2e, 0xe8, 0xb9, 0xe1, 0xbd,
+	0x25, 0xae, 0x5c, 0x29, 0x46, 0xa8, 0x3f, 0x80, 0xda, 0x68, 0x71, 0x32, 0x75, 0xac, 0x57, 0xe4,
+	0x12, 0x29, 0x50, 0x78, 0x4b, 0x2e, 0x25, 0x11, 0xfb, 0xa9, 0x3e, 0x02, 0xe8, 0xfa, 0xc4, 0x76,
+	0x68, 0xd7, 0xf4, 0x6d, 0x74, 0x17, 0xca, 0xee, 0x62, 0x76, 0x42, 0x7c, 0x49, 0x22, 0x21, 0xf5,
+	0x39, 0xd4, 0x63, 0xaa, 0x00, 0xfd, 0x18, 0x4a, 0x16, 0xfb, 0xd1, 0xcc, 0x3d, 0x2c, 0x3c, 0xae,
+	0xb7, 0x95, 0x3d, 0x26, 0x9e, 0xbd, 0x98, 0x02, 0x8b, 0x61, 0xf5, 0xaf, 0x72, 0x50, 0xe9, 0xd8,
+	0xb6, 0x4f, 0x82, 0x80, 0x2d, 0x1d, 0x50, 0x9f, 0x10, 0x1a, 0x2e, 0x2d, 0x20, 0x76, 0xa4, 0xdf,
+	0x38, 0x73, 0xce, 0x65, 0x0d, 0xb3, 0x9f, 0x08, 0x41, 0xd1, 0x72, 0xe8, 0x65, 0xb3, 0xc0, 0x51,
+	0xfc, 0x37, 0x6a, 0x42, 0xc5, 0xf2, 0x16, 0x2e, 0xf5, 0x2f, 0x9b, 0x45, 0x8e, 0x0e, 0x41, 0xb6,
+	0xee, 0x7c, 0x6a, 0xba, 0x84, 0x36, 0x4b, 0x62, 0x5d, 0x01, 0xa1, 0x2d, 0xc8, 0x3b, 0x76, 0xb3,
+	0xcc, 0x71, 0x79, 0xc7, 0x56, 0xbf, 0x84, 0x9a, 0x3c, 0x0a, 0x09, 0xd0, 0x4f, 0xa0, 0x66, 0x86,
+	0x80, 0x64, 0x62, 0x53, 0x30, 0x21, 0x69, 0x70, 0x3c, 0xae, 0xfe, 0xae, 0x00, 0x3b, 0x63, 0xe2,
+	0xda, 0x23, 0xd3, 0xb7, 0xc8, 0x34, 0x54, 0xdf, 0x63, 0xd8, 0xf6, 0x09, 0x5d, 0xf8, 0xae, 0x9c,
+	0xa1, 0xdb, 0x92, 0xb1, 0x65, 0x34, 0x6a, 0xc3, 0x6d, 0x9b, 0x04, 0xd4, 0x71, 0x4d, 0xea, 0x78,
+	0x09, 0x72, 0xc1, 0xf2, 0xca, 0x31, 0xf4, 0x1c, 0xb6, 0x5c, 0xf2, 0xae, 0x17, 0x0f, 0x71, 0x69,
+	0x64, 0x4e, 0xb9, 0x44, 0xc4, 0xc4, 0xf4, 0xdd, 0xc2, 0xa3, 0x44, 0xb7, 0x43, 0x31, 0x49, 0x10,
+	0xbd, 0x00, 0x30, 0x29, 0xf5, 0x9d, 0x93, 0x05, 0x25, 0x01, 0x17, 0x55, 0xbd, 0x7d, 0x57, 0x2c,
+	0x26, 0xf8, 0xea, 0x44, 0xa3, 0x38, 0x41, 0x89, 0xda, 0x50, 0xb1, 0x16, 0x01, 0xf5, 0x66, 0x01,
+	0x97, 0x65, 0xbd, 0xdd, 0x94, 0xca, 0x16, 0xc8, 0x1e, 0xb1, 0xa6, 0xa6, 0xcf, 0x37, 0xc7, 0x21,
+	0x21, 0x7a, 0x04, 0x9b, 0x73, 0xc7, 0x7a, 0xbb, 0x98, 0x8f, 0x3c, 0xc7, 0xa5, 0xba, 0xdd, 0xac,
+	0xf0, 0xb3, 0xa4, 0x91, 0x4c, 0x71, 0xcc, 0x4a, 0x74, 0xbb, 0x59, 0x15, 0x8a, 0x13, 0x10, 0x33,
+	0x67, 0x9f, 0x58, 0xce, 0xdc, 0x21, 0x2e, 0x6d, 0xd6, 0x84, 0x39, 0x47, 0x08, 0xf5, 0x2f, 0x0b,
+	0x50, 0x16, 0x07, 0x96, 0x1a, 0xce, 0x85, 0x1a, 0x46, 0x5f, 0xc0, 0x66, 0x4a, 0xf4, 0xcd, 0xfc,
+	0x2a, 0x91, 0xa5, 0x69, 0xd0, 0xd7, 0x80, 0xb2, 0x0a, 0x58, 0x2d, 0xec, 0x15, 0x84, 0xff, 0xaf,
+	0x04, 0xae, 0x42, 0x83, 0xfa, 0xa6, 0xf5, 0xd6, 0x71, 0xcf, 0xba, 0x9e, 0x4d, 0xa4, 0xd8, 0x53,
+	0x38, 0xf4, 0x29, 0x54, 0x1c, 0xf7, 0xc2, 0x73, 0x2c, 0xd2, 0xac, 0x25, 0x65, 0xa0, 0x0b, 0x24,
+	0x0e, 0x47, 0xd5, 0xbf, 0xcf, 0x83, 0xb2, 0xcc, 0x07, 0x53, 0xe9, 0x3b, 0xe2, 0x9c, 0x9d, 0x0b,
+	0x1f, 0xcf, 0x61, 0x09, 0x31, 0xfc, 0x94, 0xb8, 0x67, 0xf4, 0x9c, 0xab, 0x24, 0x87, 0x25, 0x84,
+	0x6e, 0x43, 0xe9, 0x9d, 0x63, 0xd3, 0x73, 0x2e, 0xef, 0x1c, 0x16, 0x00, 0xa3, 0x3e, 0x17, 0xab,
+	0x14, 0x05, 0xb5, 0x80, 0x18, 0x97, 0x36, 0xe7, 0x9e, 0xd8, 0xaf, 0xcd, 0xe9, 0x82, 0x70, 0xa1,
+	0x16, 0x70, 0x1a, 0xc9, 0x42, 0xa7, 0xe5, 0xb9, 0x94, 0xb8, 0x34, 0x90, 0xde, 0x1f, 0xc1, 0xe8,
+	0x05, 0x34, 0x02, 0xe2, 0x5f, 0x38, 0x16, 0xe9, 0x93, 0x0b, 0x32, 0xe5, 0x62, 0xda, 0x6a, 0x23,
+	0xc1, 0xe2, 0x38, 0x31, 0x82, 0x53, 0x74, 0xe8, 0xc7, 0x50, 0x39, 0x37, 0x7f, 0xc3, 0x23, 0x5e,
+	0xf5, 0x61, 0xe1, 0xf1, 0x56, 0xbb, 0x21, 0xa6, 0x1c, 0x72, 0x24, 0x0e, 0x07, 0x99, 0x35, 0x38,
+	0x6e, 0xb0, 0xf0, 0x89, 0xcd, 0xa5, 0x57, 0xc5, 0x21, 0xa8, 0xfe, 0x6d, 0x0e, 0xea, 0x52, 0x83,
+	0x3a, 0x25, 0x33, 0xf4, 0x10, 0xea, 0x36, 0x09, 0x2c, 0xdf, 0x99, 0x73, 0xe7, 0x16, 0x46, 0x9c,
+	0x44, 0x71, 0x29, 0x04, 0x5c, 0x4f, 0x22, 0x4e, 0x48, 0x88, 0xf1, 0xf7, 0xdd, 0xc2, 0x74, 0x69,
+	0x18, 0x21, 0x4b, 0x38, 0x82, 0x13, 0xf2, 0x2f, 0xa6, 0xe4, 0x7f, 0x1b, 0x4a, 0x17, 0x09, 0x89,
+	0x09, 0x80, 0x51, 0x7b, 0xbe, 0x73, 0xe6, 0xb8, 0x52, 0x4e, 0x12, 0x52, 0xe7, 0x80, 0xb2, 0xc6,
+	0x86, 0x3e, 0x87, 0xaa, 0x65, 0x52, 0x72, 0xe6, 0xf9, 0x22, 0x7f, 0x6c, 0xb5, 0xef, 0xa4, 0x0c,
+	0xb3, 0x2b, 0x07, 0x71, 0x44, 0x86, 0x3e, 0x85, 0x92, 0x43, 0xc9, 0x8c, 0x39, 0x22, 0x8b, 0xb0,
+	0x3b, 0x29, 0x7a, 0x26, 0x06, 0x2c, 0xc6, 0xd5, 0x47, 0x80, 0x0c, 0x66, 0x85, 0xe9, 0x08, 0xbb,
+	0xe4, 0xdf, 0xea, 0x7f, 0xe4, 0xa0, 0xa4, 0x5d, 0x10, 0x37, 0x33, 0x82, 0x3e, 0x81, 0x22, 0xbd,
+	0x9c, 0x0b, 0x49, 0x6d, 0xb5, 0xb7, 0xc5, 0x3e, 0x9c, 0xd4, 0xb8, 0x9c, 0x13, 0xcc, 0x07, 0x97,
+	0x45, 0x5e, 0xc8, 0x8a, 0x7c, 0x0f, 0x8a, 0x2c, 0x69, 0x73, 0xe1, 0xd5, 0xdb, 0xad, 0x3d, 0x91,
+	0xd1, 0xf7, 0xc2, 0x8c, 0xbe, 0x67, 0x84, 0x19, 0x1d, 0x73, 0x3a, 0xf4, 0x13, 0x28, 0xfb, 0xc4,
+	0x0c, 0x3c, 0x97, 0xcb, 0x75, 0xab, 0x7d, 0x4b, 0x6c, 0xbc, 0x6f, 0x3a, 0xd3, 0x85, 0x4f, 0x30,
+	0x1f, 0xc2, 0x92, 0x84, 0x79, 0x9f, 0x4d, 0xa6, 0xce, 0x05, 0xf1, 0x2f, 0x7b, 0x26, 0x25, 0x52,
+	0xe6, 0x29, 0x9c, 0xfa, 0xd7, 0x39, 0x68, 0x18, 0xd2, 0x1d, 0x75, 0xf7, 0xd4, 0x43, 0x8f, 0xa0,
+	0x3c, 0xe7, 0x32, 0xe1, 0xcc, 0xd6, 0x43, 0xbb, 0x93, 0x72, 0x92, 0x63, 0xe8, 0x13, 0x28, 0x93,
+	0x0b, 0x6e, 0xf0, 0x42, 0xd0, 0xf5, 0x84, 0x00, 0xb0, 0x1c, 0x42, 0x6d, 0xa8, 0xb2, 0xf0, 0x35,
+	0x63, 0x7b, 0x17, 0x92, 0xd1, 0xa8, 0x27, 0x4f, 0xa0, 0xc9, 0x51, 0x1c, 0xd1, 0xa9, 0xff, 0x9d,
+	0x03, 0x65, 0x79, 0x18, 0xbd, 0x80, 0x2a, 0x31, 0xfd, 0xa9, 0x43, 0x02, 0xda, 0xcc, 0xdd, 0x28,
+	0xa9, 0x88, 0x96, 0xcf, 0x7b, 0x3f, 0x27, 0x16, 0x25, 0x76, 0x33, 0xbf, 0xc6, 0x3c, 0x49, 0x8b,
+	0xda, 0x50, 0x9e, 0x9a, 0x94, 0xed, 0x56, 0xb8, 0x71, 0x96, 0xa4, 0x64, 0x39, 0x44, 0x0a, 0x96,
+	0x88, 0xc0, 0x5c, 0xc5, 0x31, 0x42, 0x64, 0x18, 0x96, 0x04, 0x1c, 0xf7, 0x8c, 0xab, 0xae, 0x8a,
+	0x63, 0x84, 0xfa, 0x8f, 0x39, 0xb8, 0xd3, 0xb1, 0x65, 0xb6, 0x17, 0x32, 0x8c, 0x6f, 0x6c, 0x42,
+	0xe2, 0x51, 0xae, 0x8f, 0xe0, 0xf5, 0x4c, 0x30, 0x34, 0xb0, 0xc2, 0x07, 0x1b, 0x58, 0xf1, 0x46,
+	0x03, 0x53, 0x7f, 0x9b, 0x87, 0xc6, 0xaf, 0x59, 0xf2, 0x09, 0x8f, 0xfb, 0x7f, 0x1b, 0x8d, 0x11,
+	0x14, 0x4f, 0x7d, 0x6f, 0x26, 0x6f, 0x5d, 0xfc, 0x37, 0xf3, 0x4b, 0xea, 0x85, 0x77, 0x2e, 0xea,
+	0x7d, 0xef, 0x78, 0x9b, 0x89, 0xf4, 0xd5, 0x55, 0x91, 0xfe, 0xea, 0x68, 0xab, 0x41, 0x8d, 0x4b,
+	0xa2, 0xef, 0xb8, 0x64, 0xbd, 0x50, 0x6b, 0xce, 0xd8, 0x75, 0x92, 0x0b, 0xa4, 0x80, 0x25, 0xa4,
+	0xfe, 0x2e, 0x0f, 0x25, 0xbe, 0x4e, 0x26, 0xe0, 0xfc, 0x14, 0x2a, 0xbe, 0x90, 0xb2, 0x34, 0x65,
+	0xc9, 0x53, 0x52, 0xfe, 0x38, 0x24, 0x41, 0x7f, 0x00, 0xa5, 0xa9, 0xe3, 0x12, 0x76, 0xad, 0x60,
+	0xee, 0xb9, 0x9d, 0xa0, 0x65, 0x27, 0xc4, 0x62, 0x94, 0xc9, 0x9f, 0x7a, 0xd4, 0x9c, 0x72, 0x41,
+	0x17, 0xb0, 0x00, 0x78, 0x3e, 0x5b, 0xf8, 0x3e, 0x71, 0xad, 0x4b, 0x29, 0xeb, 0x08, 0x46, 0x3f,
+	0x83, 0x8a, 0xe5, 0x13, 0x93, 0x79, 0x54, 0xf9, 0x46, 0x93, 0x0a, 0x49, 0xd9, 0x2c, 0xf2, 0x7e,
+	0xee, 0xf8, 0x24, 0x68, 0x56, 0x6e, 0x9e, 0x25, 0x49, 0xd5, 0x37, 0xb0, 0x7d, 0x40, 0x68, 0xdf,
+	0x3c, 0x21, 0xd3, 0x75, 0xfc, 0xe1, 0x09, 0x94, 0x4f, 0x3d, 0x7f, 0x66, 0x52, 0xe9, 0x11, 0x32,
+	0xf8, 0xf3, 0xf9, 0xfb, 0x7c, 0x00, 0x4b, 0x02, 0xf5, 0x6b, 0x28, 0x71, 0x34, 0x33, 0x29, 0xdb,
+	0xa4, 0x26, 0x5f, 0xab, 0x81, 0xf9, 0x6f, 0xa6, 0x3d, 0x99, 0xbe, 0x8d, 0xd0, 0xbd, 0x6a, 0x38,
+	0x89, 0x52, 0x3f, 0x87, 0x5b, 0x98, 0x3b, 0x6f, 0x3a, 0x7b, 0x5c, 0x73, 0x38, 0xf5, 0x1d, 0xdc,
+	0xc1, 0xc4, 0x76, 0x7c, 0x62, 0xd1, 0xb5, 0x27, 0xf1, 0x32, 0x6b, 0xe9, 0xee, 0x1e, 0x23, 0xb2,
+	0x57, 0xb0, 0xc2, 0x8a, 0x2b, 0x98, 0xfa, 0x0a, 0xee, 0x63, 0x12, 0x58, 0xe7, 0xc4, 0x5e, 0x4c,
+	0x49, 0x18, 0x59, 0xd7, 0xd9, 0x5c, 0x88, 0x26, 0xe4, 0x9f, 0xff, 0x56, 0x9f, 0xc2, 0xce, 0xa1,
+	0x37, 0xb5, 0xd7, 0x67, 0xfb, 0x5b, 0xd8, 0x14, 0xc4, 0xe3, 0xc5, 0x6c, 0x66, 0xfa, 0x97, 0xeb,
+	0xa7, 0x17, 0x19, 0x80, 0x85, 0xad, 0xa7, 0xd3, 0x8b, 0x18, 0x52, 0x7f, 0x05, 0xdb, 0xc9, 0xb5,
+	0x1d, 0x12, 0xa0, 0xcf, 0xa0, 0x22, 0x56, 0x08, 0x4b, 0xac, 0x5b, 0xc9, 0xe5, 0xe5, 0x19, 0x70,
+	0x48, 0xa3, 0xf6, 0xa0, 0xda, 0xf7, 0x2c, 0x71, 0xd9, 0x68, 0x41, 0x75, 0x6a, 0x52, 0x87, 0x2e,
+	0x6c, 0x22, 0x83, 0x57, 0x04, 0x33, 0x3d, 0x4c, 0x3d, 0xf7, 0x4c, 0x0c, 0x8a, 0x08, 0x16, 0x23,
+	0xd4, 0xff, 0xca, 0xc1, 0x6d, 0x29, 0xd8, 0xf5, 0x55, 0xfb, 0x08, 0x36, 0xa3, 0x0a, 0x63, 0x60,
+	0xce, 0xc4, 0xb2, 0x35, 0x9c, 0x46, 0xb2, 0x8d, 0x03, 0xe7, 0xcc, 0x35, 0xe9, 0xc2, 0x17, 0x21,
+	0xbc, 0x81, 0x63, 0x04, 0xf3, 0xde, 0xf9, 0xb9, 0x47, 0x3d, 0xee, 0xbd, 0x0d, 0x2c, 0x00, 0xb4,
+	0x0b, 0xd5, 0xa9, 0x64, 0x4a, 0xd6, 0x00, 0x5b, 0xd2, 0x11, 0x24, 0x16, 0x47, 0xe3, 0x51, 0x76,
+	0x28, 0xaf, 0x97, 0x1d, 0xd4, 0x7f, 0xcf, 0x01, 0x8c, 0xb8, 0x79, 0x8d, 0xa7, 0x5e, 0xf6, 0x52,
+	0x14, 0x96, 0xd1, 0xf9, 0x44, 0x19, 0x1d, 0x17, 0xcb, 0x85, 0x54, 0xb1, 0xfc, 0x0c, 0x4a, 0x01,
+	0x35, 0x7d, 0xba, 0xc6, 0xd5, 0x47, 0x10, 0xa2, 0x9f, 0x42, 0x81, 0xb8, 0x76, 0xb3, 0x74, 0x23,
+	0x3d, 0x23, 0xe3, 0x41, 0xcc, 0x9c, 0x9b, 0xfc, 0x3c, 0x65, 0x71, 0x69, 0x0d, 0x61, 0x76, 0xa6,
+	0x13, 0xcf, 0x7b, 0x4b, 0x44, 0xd5, 0x52, 0xc2, 0x12, 0x62, 0x3d, 0x87, 0x98, 0x3b, 0xde, 0x73,
+	0x08, 0xd8, 0x8f, 0x74, 0xcf, 0x21, 0xa6, 0xc0, 0x62, 0x58, 0xed, 0xc2, 0x9d, 0x03, 0x42, 0x13,
+	0x33, 0x43, 0x03, 0x08, 0xe5, 0x91, 0x5b, 0x29, 0x8f, 0x7c, 0x52, 0x1e, 0xea, 0xdf, 0xe5, 0xa0,
+	0x2c, 0x96, 0xc8, 0x88, 0x35, 0x69, 0x47, 0xf9, 0x8c, 0x1d, 0x15, 0xd9, 0x21, 0x64, 0x7e, 0xcf,
+	0x1e, 0x91, 0x8f, 0x8a, 0x4e, 0x88, 0x49, 0x17, 0x81, 0x2c, 0x19, 0x25, 0xc4, 0x2e, 0x48, 0x82,
+	0xf5, 0x0e, 0x5d, 0x43, 0xae, 0x11, 0xad, 0x7a, 0x00, 0x3b, 0x2f, 0x3d, 0xef, 0xad, 0xd8, 0x67,
+	0x1d, 0x73, 0x67, 0x07, 0x98, 0x7a, 0x34, 0x62, 0x40, 0x42, 0x2c, 0x92, 0x76, 0x4d, 0xd7, 0x22,
+	0xd3, 0xb5, 0x97, 0x52, 0x4f, 0xe0, 0x76, 0xd7, 0x73, 0x4f, 0x1d, 0x7f, 0x96, 0x9d, 0xc3, 0x11,
+	0x89, 0x39, 0x12, 0x8e, 0xec, 0x3c, 0xbf, 0xa6, 0x9d, 0xff, 0x43, 0x3e, 0xb4, 0x04, 0x1e, 0x46,
+	0x33, 0x1a, 0x79, 0x02, 0xc5, 0xb7, 0x8e, 0x6b, 0x37, 0xf3, 0xc9, 0xaa, 0x24, 0x31, 0xe1, 0x95,
+	0xe3, 0xda, 0x98, 0x93, 0x30, 0x1b, 0xe0, 0xfd, 0x36, 0xd9, 0x5a, 0x62, 0xbf, 0x13, 0x8d, 0xa9,
+	0xe2, 0xaa, 0xc6, 0x54, 0x29, 0xdb, 0x98, 0x2a, 0xaf, 0x6e, 0x4c, 0x55, 0xae, 0x6a, 0x4c, 0x55,
+	0x53, 0xbe, 0xa6, 0x42, 0xc3, 0x9b, 0x13, 0x76, 0xd5, 0x3c, 0xf4, 0x16, 0x7e, 0x20, 0x5b, 0x1c,
+	0x29, 0x1c, 0xa3, 0xb1, 0xbc, 0xd9, 0xdc, 0xf4, 0xe9, 0x8c, 0xdf, 0xeb, 0x81, 0x7b, 0x46, 0x0a,
+	0xc7, 0x44, 0xec, 0x59, 0xd6, 0x62, 0xee, 0x10, 0xbb, 0x59, 0x17, 0x3e, 0x15, 0xc2, 0xea, 0x57,
+	0xd0, 0x48, 0x08, 0x20, 0x60, 0xd9, 0x78, 0xce, 0x7f, 0x49, 0xef, 0xd9, 0xc9, 0x08, 0x09, 0x4b,
+	0x02, 0xb5, 0x07, 0x77, 0x23, 0xff, 0x11, 0xb3, 0xbf, 0x8f, 0x03, 0x9d, 0xb0, 0x28, 0x3c, 0xf7,
+	0x02, 0xe7, 0x03, 0x12, 0xec, 0x87, 0xda, 0xc5, 0x5f, 0xe4, 0x98, 0xf1, 0x4d, 0xa7, 0x99, 0x2c,
+	0x9e, 0xc9, 0xc5, 0xb9, 0x55, 0xed, 0x10, 0xc6, 0x4e, 0x5c, 0x5e, 0xf3, 0xdf, 0x1f, 0x7a, 0x41,
+	0x57, 0xdb, 0x70, 0x3b, 0x12, 0x16, 0xab, 0xce, 0xd7, 0x71, 0x99, 0x7f, 0x63, 0xad, 0x80, 0x58,
+	0x91, 0xac, 0x4a, 0xe6, 0xa2, 0x97, 0x39, 0x78, 0x85, 0x6a, 0xc4, 0xf8, 0xb5, 0x91, 0x27, 0x6e,
+	0xdc, 0x8a, 0x9e, 0x80, 0x84, 0x22, 0x26, 0x8b, 0x09, 0x26, 0xbf, 0x84, 0x9a, 0xbc, 0xd4, 0xad,
+	0x15, 0x68, 0x62, 0x62, 0xf4, 0x23, 0x28, 0xf1, 0x6a, 0xb2, 0x59, 0xce, 0x5e, 0x04, 0xc4, 0x88,
+	0xfa, 0x67, 0xb0, 0x33, 0xb6, 0xcc, 0xec, 0x5d, 0xcc, 0x22, 0x2e, 0x25, 0x7e, 0x2c, 0x8e, 0x10,
+	0xbe, 0x96, 0xab, 0x0f, 0x55, 0xc7, 0x9f, 0x02, 0x8c, 0x3d, 0x9f, 0x3a, 0xee, 0xd9, 0x4b, 0xc7,
+	0xcd, 0xc4, 0x89, 0xdb, 0x50, 0x9a, 0xb2, 0x7b, 0xa6, 0xdc, 0x46, 0x00, 0x57, 0xa6, 0xc4, 0xd0,
+	0xda, 0x8b, 0xb1, 0xb5, 0xab, 0xbf, 0xcf, 0x41, 0x91, 0x71, 0xb7, 0x2a, 0x29, 0x44, 0x0c, 0xe6,
+	0xaf, 0x61, 0xb0, 0xb0, 0xc4, 0xa0, 0x0a, 0x85, 0x13, 0xc7, 0x6d, 0x16, 0x93, 0xf9, 0x22, 0xe6,
+	0x00, 0xb3, 0xc1, 0x48, 0x08, 0xa5, 0x35, 0x8b, 0xc6, 0x35, 0x94, 0xf4, 0x1a, 0x9a, 0x5d, 0x5e,
+	0x0c, 0x74, 0x3d, 0x97, 0xdd, 0x60, 0x66, 0x89, 0x22, 0xf7, 0x23, 0xa8, 0x85, 0xc7, 0x13, 0xd1,
+	0xa2, 0x86, 0x63, 0x84, 0x28, 0x9d, 0x4f, 0x09, 0x2b, 0x3f, 0x42, 0xcf, 0x89, 0x11, 0xea, 0x3f,
+	0xe5, 0x60, 0x27, 0xb1, 0xa4, 0xec, 0xd3, 0xae, 0x77, 0xcb, 0x7c, 0x1a, 0x65, 0x45, 0x11, 0xc7,
+	0xef, 0x09, 0xaa, 0xc4, 0x72, 0x63, 0x3e, 0x1c, 0xa5, 0xcb, 0x4f, 0x96, 0xfa, 0x02, 0xab, 0xae,
+	0xa5, 0xa9, 0xae, 0x47, 0x71, 0xcd, 0xae, 0xc7, 0xbf, 0x14, 0xa0, 0x9e, 0xd8, 0x36, 0xa3, 0xec,
+	0xe5, 0x3e, 0x6a, 0x7e, 0x45, 0x1f, 0x35, 0x25, 0xa7, 0xc2, 0x92, 0x9c, 0x92, 0x75, 0x5b, 0x71,
+	0xfd, 0xba, 0x2d, 0x96, 0x50, 0x69, 0x3d, 0x09, 0x2d, 0x55, 0xbe, 0xe5, 0x6c, 0xe5, 0xfb, 0x79,
+	0x7c, 0x45, 0xaf, 0xf0, 0xc4, 0x90, 0x5d, 0x53, 0xaa, 0x29, 0xa4, 0x4b, 0xb7, 0x56, 0xaa, 0x3c,
+	0xd8, 0xc4, 0x08, 0x66, 0xec, 0xa2, 0x93, 0x22, 0x8b, 0xf2, 0x12, 0x8e, 0x60, 0x76, 0x1c, 0x6f,
+	0x41, 0x03, 0x6a, 0xba, 0x36, 0x6b, 0xbc, 0x88, 0x9c, 0x96, 0x44, 0x25, 0x54, 0x5a, 0x5f, 0x4f,
+	0xa5, 0x8d, 0x35, 0x55, 0xaa, 0x41, 0x23, 0xc1, 0x52, 0x80, 0x9e, 0xb3, 0xfc, 0x1a, 0xc3, 0xe9,
+	0xac, 0x98, 0x74, 0x8a, 0x14, 0x99, 0xfa, 0x02, 0x1a, 0xda, 0x85, 0x63, 0x33, 0x1d, 0xee, 0x3b,
+	0x53, 0x12, 0x5d, 0x27, 0x72, 0x89, 0xeb, 0x44, 0x58, 0xc4, 0xe6, 0xe3, 0x22, 0x56, 0xfd, 0xd7,
+	0x1c, 0x28, 0x6c, 0x42, 0x77, 0x6a, 0x3a, 0xb3, 0x35, 0xbb, 0x49, 0x89, 0x2b, 0x8d, 0x6c, 0x18,
+	0xf0, 0xd9, 0x89, 0xcb, 0xcc, 0xcd, 0x0d, 0xcd, 0xb8, 0xb1, 0x51, 0x4c, 0x36, 0x36, 0x12, 0x4f,
+	0x2f, 0xa5, 0xd4, 0xd3, 0xcb, 0x1e, 0x54, 0x89, 0xe4, 0xaf, 0x59, 0x7e, 0x58, 0x88, 0xfb, 0x1a,
+	0x49, 0xae, 0x71, 0x44, 0xa3, 0xfe, 0x36, 0x07, 0xd5, 0x70, 0x68, 0x55, 0xfd, 0xe1, 0xc6, 0xb5,
+	0x14, 0xff, 0xbd, 0x5c, 0xcd, 0x17, 0x32, 0xd5, 0x3c, 0xbb, 0x04, 0x2f, 0xe6, 0x53, 0xcf, 0xb4,
+	0xd7, 0xf2, 0x8d, 0x88, 0x56, 0xfd, 0x73, 0x28, 0x63, 0x72, 0xba, 0x70, 0xed, 0xcc, 0x39, 0x62,
+	0x66, 0xf3, 0x29, 0x66, 0x63, 0xe1, 0x14, 0x52, 0xc2, 0xf9, 0x5e, 0xce, 0xa9, 0xfe, 0x4d, 0x01,
+	0x4a, 0x5c, 0x41, 0x1f, 0x54, 0x30, 0x84, 0x7a, 0x2e, 0x7c, 0x80, 0x9e, 0x8b, 0xd7, 0xe9, 0xb9,
+	0x74, 0x85, 0x9e, 0xcb, 0x29, 0xd6, 0x9f, 0x44, 0x91, 0xa4, 0x92, 0x6c, 0xce, 0xf0, 0x8d, 0x97,
+	0x62, 0x48, 0x42, 0x1a, 0xd5, 0xf5, 0x43, 0x15, 0xd3, 0xbd, 0x47, 0x89, 0xbc, 0xdb, 0xf2, 0xdf,
+	0x6c, 0x25, 0x9b, 0x58, 0x0e, 0x53, 0x2c, 0xdc, 0xbc, 0x92, 0x24, 0x65, 0xc9, 0xc3, 0xe7, 0x7a,
+	0x6d, 0xd6, 0x93, 0xc9, 0x43, 0xe8, 0x1a, 0xcb, 0x31, 0x56, 0x66, 0x47, 0x86, 0xdb, 0x78, 0x58,
+	0x88, 0xcb, 0xec, 0xd0, 0x3a, 0x13, 0x46, 0xfb, 0x0c, 0x6a, 0x9c, 0xd1, 0xbe, 0x13, 0x50, 0x16,
+	0x71, 0x2c, 0x06, 0x84, 0x21, 0xa0, 0x9e, 0x90, 0x04, 0x96, 0x43, 0x2a, 0x05, 0x84, 0xc9, 0x85,
+	0x43, 0xde, 0xa5, 0xfc, 0x97, 0xdd, 0xfc, 0x19, 0x1c, 0xb9, 0x6f, 0x08, 0xb2, 0x11, 0x73, 0x3e,
+	0xf7, 0xbd, 0x0b, 0x61, 0xfc, 0x55, 0x1c, 0x82, 0x57, 0xda, 0x5c, 0x28, 0xaf, 0x62, 0x2c, 0x2f,
+	0xf5, 0x3f, 0xf3, 0x50, 0x91, 0xcf, 0x6e, 0xab, 0x6c, 0x5a, 0x5e, 0xf7, 0xf2, 0xc9, 0x77, 0xfa,
+	0x6b, 0xef, 0x1a, 0xb1, 0x31, 0x14, 0x53, 0xc6, 0xc0, 0x2e, 0x3a, 0xa6, 0x1f, 0x86, 0x02, 0xfe,
+	0x3b, 0xee, 0x58, 0x96, 0xaf, 0xed, 0x58, 0x2a, 0x50, 0x60, 0x17, 0xa7, 0x0a, 0xe7, 0x85, 0xfd,
+	0x64, 0xac, 0x53, 0xf3, 0x3d, 0xef, 0xa1, 0x88, 0xaa, 0x27, 0x04, 0xd1, 0x0f, 0x01, 0xa8, 0xf9,
+	0x7e, 0x44, 0x7c, 0x2b, 0x7c, 0xd7, 0xcd, 0xe1, 0x04, 0x86, 0xad, 0x45, 0xcd, 0xf7, 0xdc, 0x34,
+	0x0a, 0x98, 0xfd, 0x8c, 0xfb, 0xa1, 0xf5, 0xab, 0xfa, 0xa1, 0x8d, 0xab, 0xfb, 0xa1, 0x9b, 0xeb,
+	0xbb, 0xee, 0x73, 0xa8, 0x4a, 0x39, 0xb3, 0x42, 0xa9, 0x2a, 0x5f, 0x38, 0x97, 0xbe, 0x0b, 0x90,
+	0x14, 0x38, 0x1a, 0x56, 0xbf, 0x82, 0xfb, 0x07, 0x84, 0x4a, 0x7c, 0xcf, 0xb3, 0x16, 0x4b, 0xb7,
+	0x28, 0x49, 0x18, 0x99, 0x47, 0x8c, 0xd8, 0x7d, 0x07, 0x65, 0xf1, 0x74, 0x88, 0x36, 0xa1, 0x36,
+	0x18, 0x4e, 0x0e, 0x3b, 0xdf, 0x76, 0x70, 0x4f, 0xd9, 0x60, 0xe0, 0x7e, 0xbf, 0x73, 0x74, 0xd4,
+	0x79, 0xd9, 0xd7, 0x94, 0x1c, 0x03, 0xbb, 0x43, 0x8c, 0x87, 0x63, 0xfd, 0xb5, 0xa6, 0xe4, 0x51,
+	0x0d, 0x4a, 0xc6, 0xf0, 0x8d, 0xde, 0x55, 0x8a, 0x68, 0x1b, 0xea, 0xb8, 0xd3, 0xd3, 0x87, 0x9d,
+	0xae, 0xc1, 0xc6, 0xaa, 0xe8, 0x0e, 0xec, 0xf4, 0x75, 0xe3, 0x50, 0x3f, 0x3e, 0x9a, 0xbc, 0xec,
+	0x18, 0x86, 0x86, 0x75, 0x6d, 0xac, 0x28, 0x6c, 0x05, 0xed, 0xcd, 0xa8, 0x2f, 0x56, 0x78, 0xb8,
+	0x1b, 0xc0, 0xf6, 0xd2, 0x73, 0x1d, 0xda, 0x81, 0xcd, 0x71, 0xa7, 0xaf, 0x4d, 0x86, 0xfb, 0x93,
+	0x83, 0xe1, 0xb0, 0x37, 0x56, 0x36, 0x50, 0x15, 0x8a, 0x07, 0xfa, 0xbe, 0x21, 0x0e, 0xd0, 0x1b,
+	0x76, 0x8f, 0x8f, 0xb4, 0x81, 0x31, 0x56, 0xf2, 0x6c, 0x93, 0xee, 0xf0, 0xe8, 0x48, 0xc3, 0x5d,
+	0xbd, 0xd3, 0x9f, 0x8c, 0x3b, 0x47, 0xa3, 0xbe, 0xa6, 0x14, 0x10, 0x82, 0x2d, 0xac, 0x19, 0xc7,
+	0x78, 0xa0, 0xf5, 0xe4, 0x1a, 0x45, 0x76, 0xd6, 0xa1, 0x71, 0xa8, 0x61, 0xa5, 0xb4, 0xfb, 0xcf,
+	0x05, 0xa8, 0x45, 0x2f, 0x21, 0x6c, 0xbf, 0x5e, 0xc7, 0xe8, 0x4c, 0xb0, 0xd6, 0xd5, 0xf4, 0xd7,
+	0x1a, 0xe3, 0xfa, 0x0e, 0xec, 0xf4, 0xb4, 0xbe, 0xfe, 0x5a, 0xc3, 0x5a, 0x6f, 0x62, 0x0c, 0x27,
+	0xfa, 0x68, 0x34, 0x56, 0x72, 0xe8, 0x01, 0xdc, 0x4b, 0xa1, 0x47, 0x78, 0xd8, 0xd5, 0xc6, 0x63,
+	0x7d, 0x70, 0xa0, 0xe4, 0xd1, 0x5d, 0x40, 0xfd, 0x61, 0xa7, 0xa7, 0xf5, 0x26, 0xfa, 0xc0, 0x18,
+	0x4e, 0xf0, 0xb0, 0xfb, 0x4a, 0x33, 0x94, 0x02, 0xba, 0x07, 0xb7, 0x92, 0xf8, 0xd7, 0xda, 0xa1,
+	0xde, 0xed, 0x6b, 0x4a, 0x11, 0x7d, 0x04, 0xcd, 0xd4, 0x6a, 0x3d, 0x6d, 0x6c, 0xe8, 0x83, 0x8e,
+	0xa1, 0x0f, 0x07, 0x4a, 0x09, 0xdd, 0x82, 0xed, 0x43, 0xad, 0xdf, 0x9b, 0x74, 0x8c, 0x49, 0xf7,
+	0x78, 0x6c, 0x0c, 0x8f, 0xc6, 0x4a, 0x19, 0xdd, 0x87, 0x3b, 0x58, 0xeb, 0x6b, 0x9d, 0xb1, 0xd6,
+	0x9b, 0xec, 0xe3, 0xe1, 0x51, 0x34, 0x54, 0x61, 0xf4, 0x72, 0xb5, 0x6f, 0x26, 0xfb, 0x1d, 0xbd,
+	0xaf, 0xf5, 0x94, 0x2a, 0xba, 0x0d, 0x8a, 0x90, 0xc3, 0x44, 0x1f, 0xe8, 0x86, 0xde, 0x31, 0xb4,
+	0x9e, 0x52, 0x63, 0x27, 0x8d, 0xa4, 0x63, 0x0c, 0x27, 0x63, 0x6d, 0xd0, 0xd3, 0xb0, 0x02, 0x68,
+	0x0b, 0x00, 0x6b, 0x3d, 0x1d, 0x6b, 0x5d, 0x46, 0x57, 0x67, 0x52, 0x3c, 0x1c, 0xf6, 0x7b, 0x13,
+	0xac, 0xfd, 0xfa, 0x58, 0x1b, 0x33, 0x5c, 0x03, 0xb5, 0xe0, 0x6e, 0x77, 0xd8, 0xef, 0x73, 0x92,
+	0xc9, 0xcb, 0x6f, 0x98, 0xd0, 0xf4, 0x91, 0xae, 0x0d, 0x0c, 0x65, 0x13, 0x35, 0xe1, 0xf6, 0x48,
+	0xef, 0xbe, 0xd2, 0x7a, 0x93, 0xe3, 0x11, 0x1b, 0xeb, 0x0e, 0x8f, 0xb1, 0xae, 0x61, 0x65, 0x0b,
+	0xfd, 0x00, 0xee, 0xa7, 0x05, 0xa7, 0x77, 0x5f, 0x1d, 0x8f, 0x26, 0xa3, 0xa1, 0x3e, 0x30, 0x94,
+	0x6d, 0xf4, 0x31, 0x3c, 0x88, 0x17, 0xe5, 0x7c, 0xa5, 0x08, 0x14, 0xb6, 0x72, 0xc4, 0x1c, 0xd6,
+	0xc6, 0xdd, 0x43, 0xad, 0x77, 0xcc, 0x38, 0xdc, 0xd9, 0x7d, 0x03, 0x9b, 0xa9, 0xc7, 0x27, 0x69,
+	0xbf, 0x58, 0xeb, 0x8c, 0x87, 0x03, 0x65, 0x43, 0x48, 0x40, 0x1e, 0x71, 0xd2, 0x79, 0x39, 0x66,
+	0x27, 0xcd, 0x31, 0xfd, 0x76, 0x7a, 0x3d, 0xac, 0x8d, 0xc7, 0x93, 0xc1, 0xd0, 0x98, 0xec, 0x0f,
+	0x8f, 0x07, 0x3d, 0x25, 0x8f, 0xea, 0x50, 0xc1, 0xda, 0xfe, 0xf1, 0x58, 0xeb, 0x29, 0x85, 0xdd,
+	0x27, 0xd0, 0x48, 0x3e, 0x08, 0xa1, 0x06, 0x54, 0xc7, 0x46, 0x67, 0xd0, 0x13, 0x7e, 0x51, 0x87,
+	0x8a, 0xf6, 0x66, 0xc4, 0x56, 0x50, 0x72, 0xbb, 0x4f, 0xa0, 0x9e, 0x78, 0x46, 0x40, 0x15, 0x28,
+	0x8c, 0x7a, 0xfb, 0xca, 0x06, 0xfb, 0xf1, 0xed, 0xa8, 0xaf, 0xe4, 0x98, 0xfd, 0x76, 0x07, 0xed,
+	0x2f, 0x94, 0xfc, 0xee, 0xa7, 0xb0, 0xbd, 0xd4, 0x08, 0x62, 0x83, 0xe3, 0xc3, 0xe1, 0x48, 0xd9,
+	0x40, 0x00, 0xe5, 0x3e, 0x33, 0x1b, 0xac, 0xe4, 0x76, 0x9d, 0x54, 0xe1, 0x22, 0x72, 0xa0, 0xd0,
+	0xd0, 0x81, 0x3e, 0x36, 0x98, 0x20, 0x95, 0x0d, 0x06, 0xeb, 0x83, 0x89, 0x81, 0x3b, 0x83, 0xb1,
+	0xce, 0xf8, 0xba, 0x07, 0xb7, 0x46, 0x1d, 0x6c, 0xe8, 0x9d, 0x7e, 0xff, 0x9b, 0x49, 0x24, 0x71,
+	0x25, 0xcf, 0xdd, 0x26, 0x02, 0x0b, 0x8c, 0x97, 0xd0, 0x02, 0x94, 0xe2, 0xee, 0x8f, 0x64, 0xfe,
+	0xe1, 0xa7, 0x01, 0x28, 0xf7, 0x3a, 0x47, 0x9d, 0x03, 0x4d, 0xb8, 0x5d, 0x7f, 0xc8, 0x39, 0xfc,
+	0x12, 0xea, 0x89, 0x5c, 0xcc, 0x96, 0x1b, 0x1f, 0xbf, 0x3c, 0xd2, 0x0d, 0x83, 0x1f, 0xa3, 0x01,
+	0xd5, 0xce, 0x68, 0x84, 0x87, 0xcc, 0x79, 0x72, 0x62, 0xf1, 0x3f, 0x16, 0x46, 0x94, 0x6f, 0xff,
+	0x1e, 0x41, 0x91, 0xb9, 0x0f, 0x6a, 0x43, 0x89, 0x7f, 0x25, 0x86, 0x50, 0xd8, 0x6f, 0x8e, 0x3f,
+	0x3d, 0x6b, 0xdd, 0x4a, 0xe1, 0xc4, 0x67, 0x64, 0xea, 0x06, 0xfa, 0x0a, 0x1a, 0xac, 0x9b, 0x11,
+	0x7d, 0x2d, 0x76, 0x37, 0x13, 0x3d, 0x35, 0xf6, 0xc1, 0x5b, 0x4b, 0xa6, 0x83, 0x88, 0x50, 0xdd,
+	0x40, 0xcf, 0x01, 0x3a, 0xb6, 0x1d, 0x7e, 0x15, 0x93, 0xfe, 0x70, 0xa6, 0x75, 0xc5, 0x3a, 0xd1,
+	0x8e, 0xf1, 0x77, 0x59, 0x37, 0xec, 0x18, 0x11, 0xaa, 0x1b, 0xe8, 0x0f, 0x61, 0xb3, 0x63, 0xdb,
+	0x89, 0x6f, 0xd7, 0x32, 0x5f, 0xa1, 0x5d, 0xb3, 0xef, 0xd7, 0xb0, 0x75, 0x40, 0x68, 0xf2, 0x93,
+	0xb6, 0xab, 0x76, 0xde, 0x59, 0x5e, 0x35, 0x10, 0xdc, 0xc6, 0x1f, 0x84, 0xa1, 0x7b, 0xe1, 0x7b,
+	0xe6, 0xd2, 0x27, 0x62, 0xad, 0x54, 0xa1, 0xcb, 0x77, 0xad, 0x27, 0x3e, 0x73, 0x40, 0xf2, 0xc3,
+	0x9e, 0xec, 0x97, 0x0f, 0x2d, 0x94, 0x18, 0x91, 0x9f, 0x02, 0xa8, 0x1b, 0xe8, 0x97, 0xb0, 0x95,
+	0x7e, 0x97, 0x46, 0x0f, 0x22, 0xb1, 0x64, 0x5f, 0xab, 0x5b, 0xc9, 0xe2, 0x49, 0xdd, 0x40, 0x5f,
+	0x42, 0xfd, 0x4f, 0x4c, 0x6a, 0x9d, 0xdf, 0xb8, 0x79, 0x7a, 0xde, 0xb3, 0x1c, 0xfa, 0x0c, 0xaa,
+	0x07, 0x84, 0x8a, 0x87, 0xd0, 0x15, 0xef, 0x9c, 0xad, 0x7a, 0x02, 0xa7, 0x6e, 0xa0, 0x67, 0x9c,
+	0x5c, 0xbc, 0xe8, 0xc9, 0x66, 0xec, 0xd2, 0xc3, 0x61, 0x38, 0x83, 0xe3, 0xf8, 0xd1, 0x1a, 0xc9,
+	0x17, 0x3c, 0x74, 0x3f, 0xbc, 0xe3, 0x65, 0x5e, 0xf5, 0x96, 0x99, 0xfa, 0x25, 0x6c, 0xa5, 0x1f,
+	0xf2, 0x42, 0x91, 0xac, 0x7c, 0xde, 0x5b, 0x9e, 0xfd, 0x33, 0x80, 0xf8, 0x01, 0x2d, 0x54, 0x63,
+	0xe6, 0x49, 0x6d, 0x79, 0x56, 0x0f, 0x50, 0xf6, 0x0d, 0x0f, 0x7d, 0x1c, 0xee, 0x7b, 0xc5, 0xeb,
+	0xde, 0xf2, 0x2a, 0x1a, 0x20, 0x7e, 0x7b, 0xb0, 0xbc, 0x99, 0xe3, 0x9e, 0x8d, 0x64, 0x71, 0x7d,
+	0x95, 0x15, 0xde, 0xc9, 0xbe, 0x9c, 0x39, 0x24, 0x88, 0x96, 0x19, 0x2e, 0xe8, 0x99, 0xf7, 0xbf,
+	0x59, 0xe6, 0x17, 0xb0, 0x99, 0x7a, 0x34, 0x43, 0xad, 0x54, 0x49, 0x7d, 0xad, 0x3c, 0x5e, 0x72,
+	0x5f, 0x4a, 0x3e, 0xd5, 0x3c, 0x88, 0xb4, 0x9e, 0x7d, 0x86, 0x69, 0xed, 0x2c, 0xbf, 0x8a, 0x08,
+	0x87, 0x62, 0xce, 0x1c, 0xe3, 0x50, 0xe6, 0xed, 0xa4, 0x95, 0xc1, 0x08, 0x3f, 0x8c, 0x5f, 0x3e,
+	0x42, 0x05, 0x66, 0xde, 0x42, 0x22, 0x3f, 0xe4, 0x48, 0x11, 0x75, 0x92, 0xef, 0x1c, 0xa1, 0xbd,
+	0xad, 0x78, 0xfb, 0xc8, 0x4c, 0xfd, 0x05, 0x6c, 0xa6, 0xde, 0x3b, 0x42, 0x41, 0xad, 0x7a, 0x04,
+	0xc9, 0xaa, 0x7c, 0x7b, 0xa9, 0xb3, 0x8e, 0x3e, 0x5a, 0x92, 0x54, 0xaa, 0xe1, 0x1e, 0x86, 0x81,
+	0xe4, 0x10, 0xf7, 0x96, 0xad, 0x48, 0x56, 0x1c, 0x89, 0xb2, 0x2d, 0xe3, 0x56, 0x16, 0xa5, 0x6e,
+	0xa0, 0x5f, 0xc1, 0x66, 0xaa, 0x29, 0x1f, 0x6b, 0x39, 0xdb, 0xa9, 0x8f, 0x02, 0x5f, 0xdc, 0xa9,
+	0x16, 0x2b, 0xa4, 0x3a, 0xee, 0x31, 0xfb, 0xd9, 0x36, 0xfc, 0x95, 0x2b, 0xa4, 0x3a, 0xe6, 0xe1,
+	0x0a, 0xab, 0xda, 0xe8, 0xab, 0x57, 0xf8, 0x02, 0x20, 0xee, 0x30, 0x47, 0xc1, 0x77, 0xb9, 0xe7,
+	0xdc, 0x82, 0x78, 0x40, 0xdd, 0x40, 0x87, 0xb0, 0x93, 0xe9, 0x78, 0xa2, 0x1f, 0x46, 0xb1, 0x7d,
+	0x65, 0x2b, 0xb4, 0x95, 0xed, 0x07, 0xa9, 0x1b, 0xe8, 0x8f, 0xb8, 0x16, 0x53, 0xdd, 0xa4, 0xab,
+	0xdc, 0x0d, 0x65, 0xe6, 0x33, 0xfd, 0x75, 0x40, 0xe1, 0x51, 0x37, 0x79, 0x92, 0xab, 0xa3, 0xf1,
+	0xca, 0x33, 0xb4, 0xa1, 0x16, 0xb5, 0x93, 0x90, 0xec, 0x7e, 0x2d, 0xf7, 0x97, 0x5a, 0xc9, 0x52,
+	0x56, 0xdd, 0x40, 0x2f, 0xa0, 0xc6, 0xce, 0xcd, 0xa0, 0x1b, 0xf3, 0x6c, 0x54, 0x1f, 0xf3, 0xa4,
+	0xa5, 0x30, 0xdd, 0x10, 0xde, 0xa1, 0xfb, 0xf0, 0xe9, 0x2f, 0xa0, 0x9e, 0xa8, 0x9d, 0x43, 0x46,
+	0xb3, 0xe5, 0xf4, 0xf2, 0x71, 0x7f, 0x0e, 0xf5, 0xb8, 0xba, 0xba, 0x7a, 0xc7, 0xad, 0x54, 0x75,
+	0x16, 0x88, 0xf0, 0x9c, 0x2d, 0xcb, 0xc2, 0xf0, 0x7c, 0x65, 0xc1, 0xb6, 0x94, 0x92, 0x5e, 0x7e,
+	0xf5, 0xed, 0xcf, 0xcf, 0x1c, 0x3a, 0x35, 0x4f, 0xf6, 0xac, 0x60, 0xef, 0xd4, 0x5c, 0xec, 0xd9,
+	0xe4, 0xe9, 0xa9, 0xb9, 0x08, 0xa8, 0xf8, 0x6b, 0xd1, 0xd3, 0xcf, 0xda, 0xcf, 0xda, 0xcf, 0x9e,
+	0xb2, 0xff, 0x11, 0x78, 0xea, 0xb8, 0x94, 0xf8, 0xae, 0x39, 0x7d, 0xca, 0x96, 0x38, 0x29, 0xf3,
+	0x23, 0x7e, 0xf1, 0x3f, 0x03, 0x00, 0x85, 0xb5, 0x34, 0x80, 0x40, 0x30, 0x00, 0x00,
+}
+
+// Reference imports to suppress errors if they are not otherwise used.
+var _ context.Context
+var _ grpc.ClientConnInterface
+
+// This is a compile-time assertion to ensure that this generated file
+// is compatible with the grpc package it is being compiled against.
+const _ = grpc.SupportPackageIsVersion6
+
+// IPPSClient is the client API for IPPS service.
+//
+// For semantics around ctx use and closing/ending streaming RPCs, please refer to https://godoc.org/google.golang.org/grpc#ClientConn.NewStream.
+type IPPSClient interface {
+	Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error)
+	GetPublicKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PublicKey, error)
+	AddAddress(ctx context.Context, in *Address, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetAddresses(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Addresses, error)
+	AddCreditCard(ctx context.Context, in *CreditCard, opts ...grpc.CallOption) (*empty.Empty, error)
+	GetCreditCards(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CreditCards, error)
+	SendParcel(ctx context.Context, in *SendParcelRequest, opts ...grpc.CallOption) (*Parcel, error)
+	// TrackParcel does not require authentication. The customs declaration
+	// is only included for the authenticated sender and operators.
+	TrackParcel(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (*TrackingInfo, error)
+	AddParcelEvent(ctx context.Context, in *AddParcelEventRequest, opts ...grpc.CallOption) (*Event, error)
+	WatchParcel(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (IPPS_WatchParcelClient, error)
+	// GetQuote does not require authentication. The returned quote may be
+	// accepted by any user.
+	GetQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*Quote, error)
+	// GetLabel returns the shipping label of a parcel sent by the user. IPPS
+	// operators may get the labels of all parcels.
+	GetLabel(ctx context.Context, in *GetLabelRequest, opts ...grpc.CallOption) (*Label, error)
+	// ReturnParcel initiates the return of a parcel to its sender. It may be
+	// called by the parcel's sender and recipient.
+	ReturnParcel(ctx context.Context, in *ReturnParcelRequest, opts ...grpc.CallOption) (*Event, error)
+	// RedirectParcel and HoldParcel may only be called by the parcel's
+	// recipient, until the parcel has been loaded into a vehicle.
+	RedirectParcel(ctx context.Context, in *RedirectParcelRequest, opts ...grpc.CallOption) (*Event, error)
+	HoldParcel(ctx context.Context, in *HoldParcelRequest, opts ...grpc.CallOption) (*Event, error)
+	// RescheduleDelivery postpones the next delivery attempt after a failed
+	// one. It may only be called by the parcel's recipient.
+	RescheduleDelivery(ctx context.Context, in *RescheduleDeliveryRequest, opts ...grpc.CallOption) (*Event, error)
+	// GetIncomingParcels returns the parcels, whose sender has named the user
+	// as the recipient, GetOutgoingParcels those sent from any of the user's
+	// addresses. The most recently updated parcels are listed first.
+	GetIncomingParcels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ParcelSummaries, error)
+	GetOutgoingParcels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ParcelSummaries, error)
+	// DeliverParcel records the delivery of a parcel to its recipient, its
+	// return to the sender or its collection together with the proof of
+	// delivery. It requires the user to be an operator.
+	DeliverParcel(ctx context.Context, in *DeliverParcelRequest, opts ...grpc.CallOption) (*Event, error)
+	// GetPickupSlots returns the future time slots in a city, which can still
+	// be booked. AddPickupSlot requires the user to be an operator.
+	GetPickupSlots(ctx context.Context, in *GetPickupSlotsRequest, opts ...grpc.CallOption) (*PickupSlots, error)
+	AddPickupSlot(ctx context.Context, in *PickupSlot, opts ...grpc.CallOption) (*PickupSlot, error)
+	// BookPickup and CancelPickup may only be called by the parcel's sender,
+	// until the parcel has been handed over to us.
+	BookPickup(ctx context.Context, in *BookPickupRequest, opts ...grpc.CallOption) (*Pickup, error)
+	CancelPickup(ctx context.Context, in *CancelPickupRequest, opts ...grpc.CallOption) (*Pickup, error)
+	// ConfirmPickup records that a courier has collected the parcel of a
+	// booked pickup. It requires the user to be an operator.
+	ConfirmPickup(ctx context.Context, in *ConfirmPickupRequest, opts ...grpc.CallOption) (*Event, error)
+	// GetPickupPoints returns our shops and parcel lockers in a city.
+	// AddPickupPoint requires the user to be an operator.
+	GetPickupPoints(ctx context.Context, in *GetPickupPointsRequest, opts ...grpc.CallOption) (*PickupPoints, error)
+	AddPickupPoint(ctx context.Context, in *PickupPoint, opts ...grpc.CallOption) (*PickupPoint, error)
+	// DepositParcel allocates a compartment of the pickup point, to which a
+	// parcel is sent, and CollectParcel hands the parcel over to the
+	// recipient presenting the pickup code. Both require the user to be an
+	// operator.
+	DepositParcel(ctx context.Context, in *DepositParcelRequest, opts ...grpc.CallOption) (*Compartment, error)
+	CollectParcel(ctx context.Context, in *CollectParcelRequest, opts ...grpc.CallOption) (*Compartment, error)
+	// GetPickupCode returns the compartment and pickup code of a deposited
+	// parcel. It may only be called by the parcel's recipient.
+	GetPickupCode(ctx context.Context, in *GetPickupCodeRequest, opts ...grpc.CallOption) (*Compartment, error)
+	// ScanParcel records the scan of a parcel in a logistics center and
+	// returns the sorting bin, into which the parcel has to be dropped. It
+	// requires the user to be an operator.
+	ScanParcel(ctx context.Context, in *ScanParcelRequest, opts ...grpc.CallOption) (*Scan, error)
+	// CreateConsignment groups parcels sent by the user from the same return
+	// address to the same destination under a master tracking number.
+	// GetConsignments returns the user's consignments, starting with the
+	// most recently created one. TrackConsignment does not require
+	// authentication. The customs declarations are only included for the
+	// authenticated sender and operators.
+	CreateConsignment(ctx context.Context, in *CreateConsignmentRequest, opts ...grpc.CallOption) (*Consignment, error)
+	GetConsignments(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Consignments, error)
+	TrackConsignment(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (*Consignment, error)
+	// FileClaim files a damage or loss claim for an insured parcel sent by
+	// the user. GetClaims returns the user's claims, starting with the most
+	// recent one. GetPendingClaims and ReviewClaim require the user to be an
+	// operator.
+	FileClaim(ctx context.Context, in *FileClaimRequest, opts ...grpc.CallOption) (*Claim, error)
+	GetClaims(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClaimList, error)
+	GetPendingClaims(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClaimList, error)
+	ReviewClaim(ctx context.Context, in *ReviewClaimRequest, opts ...grpc.CallOption) (*Claim, error)
+	// GetInvoices returns the invoices paid by the user, starting with the
+	// most recent one. GetInvoiceDocument returns one of them as a PDF
+	// document.
+	GetInvoices(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Invoices, error)
+	GetInvoiceDocument(ctx context.Context, in *GetInvoiceDocumentRequest, opts ...grpc.CallOption) (*Label, error)
+}
+
+type iPPSClient struct {
+	cc grpc.ClientConnInterface
+}
+
+func NewIPPSClient(cc grpc.ClientConnInterface) IPPSClient {
+	return &iPPSClient{cc}
+}
+
+func (c *iPPSClient) Login(ctx context.Context, in *LoginRequest, opts ...grpc.CallOption) (*LoginResponse, error) {
+	out := new(LoginResponse)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/Login", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetPublicKey(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*PublicKey, error) {
+	out := new(PublicKey)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPublicKey", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) AddAddress(ctx context.Context, in *Address, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddAddress", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetAddresses(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Addresses, error) {
+	out := new(Addresses)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetAddresses", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) AddCreditCard(ctx context.Context, in *CreditCard, opts ...grpc.CallOption) (*empty.Empty, error) {
+	out := new(empty.Empty)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddCreditCard", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetCreditCards(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*CreditCards, error) {
+	out := new(CreditCards)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetCreditCards", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) SendParcel(ctx context.Context, in *SendParcelRequest, opts ...grpc.CallOption) (*Parcel, error) {
+	out := new(Parcel)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/SendParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) TrackParcel(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (*TrackingInfo, error) {
+	out := new(TrackingInfo)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/TrackParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) AddParcelEvent(ctx context.Context, in *AddParcelEventRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddParcelEvent", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) WatchParcel(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (IPPS_WatchParcelClient, error) {
+	stream, err := c.cc.NewStream(ctx, &_IPPS_serviceDesc.Streams[0], "/grpc.IPPS/WatchParcel", opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &iPPSWatchParcelClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type IPPS_WatchParcelClient interface {
+	Recv() (*Event, error)
+	grpc.ClientStream
+}
+
+type iPPSWatchParcelClient struct {
+	grpc.ClientStream
+}
+
+func (x *iPPSWatchParcelClient) Recv() (*Event, error) {
+	m := new(Event)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *iPPSClient) GetQuote(ctx context.Context, in *QuoteRequest, opts ...grpc.CallOption) (*Quote, error) {
+	out := new(Quote)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetQuote", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetLabel(ctx context.Context, in *GetLabelRequest, opts ...grpc.CallOption) (*Label, error) {
+	out := new(Label)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetLabel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) ReturnParcel(ctx context.Context, in *ReturnParcelRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/ReturnParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) RedirectParcel(ctx context.Context, in *RedirectParcelRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/RedirectParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) HoldParcel(ctx context.Context, in *HoldParcelRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/HoldParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) RescheduleDelivery(ctx context.Context, in *RescheduleDeliveryRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/RescheduleDelivery", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetIncomingParcels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ParcelSummaries, error) {
+	out := new(ParcelSummaries)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetIncomingParcels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetOutgoingParcels(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ParcelSummaries, error) {
+	out := new(ParcelSummaries)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetOutgoingParcels", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) DeliverParcel(ctx context.Context, in *DeliverParcelRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/DeliverParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetPickupSlots(ctx context.Context, in *GetPickupSlotsRequest, opts ...grpc.CallOption) (*PickupSlots, error) {
+	out := new(PickupSlots)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPickupSlots", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) AddPickupSlot(ctx context.Context, in *PickupSlot, opts ...grpc.CallOption) (*PickupSlot, error) {
+	out := new(PickupSlot)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddPickupSlot", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) BookPickup(ctx context.Context, in *BookPickupRequest, opts ...grpc.CallOption) (*Pickup, error) {
+	out := new(Pickup)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/BookPickup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) CancelPickup(ctx context.Context, in *CancelPickupRequest, opts ...grpc.CallOption) (*Pickup, error) {
+	out := new(Pickup)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/CancelPickup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) ConfirmPickup(ctx context.Context, in *ConfirmPickupRequest, opts ...grpc.CallOption) (*Event, error) {
+	out := new(Event)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/ConfirmPickup", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetPickupPoints(ctx context.Context, in *GetPickupPointsRequest, opts ...grpc.CallOption) (*PickupPoints, error) {
+	out := new(PickupPoints)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPickupPoints", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) AddPickupPoint(ctx context.Context, in *PickupPoint, opts ...grpc.CallOption) (*PickupPoint, error) {
+	out := new(PickupPoint)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/AddPickupPoint", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) DepositParcel(ctx context.Context, in *DepositParcelRequest, opts ...grpc.CallOption) (*Compartment, error) {
+	out := new(Compartment)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/DepositParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) CollectParcel(ctx context.Context, in *CollectParcelRequest, opts ...grpc.CallOption) (*Compartment, error) {
+	out := new(Compartment)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/CollectParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetPickupCode(ctx context.Context, in *GetPickupCodeRequest, opts ...grpc.CallOption) (*Compartment, error) {
+	out := new(Compartment)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPickupCode", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) ScanParcel(ctx context.Context, in *ScanParcelRequest, opts ...grpc.CallOption) (*Scan, error) {
+	out := new(Scan)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/ScanParcel", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) CreateConsignment(ctx context.Context, in *CreateConsignmentRequest, opts ...grpc.CallOption) (*Consignment, error) {
+	out := new(Consignment)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/CreateConsignment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetConsignments(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Consignments, error) {
+	out := new(Consignments)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetConsignments", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) TrackConsignment(ctx context.Context, in *TrackParcelRequest, opts ...grpc.CallOption) (*Consignment, error) {
+	out := new(Consignment)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/TrackConsignment", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) FileClaim(ctx context.Context, in *FileClaimRequest, opts ...grpc.CallOption) (*Claim, error) {
+	out := new(Claim)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/FileClaim", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetClaims(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClaimList, error) {
+	out := new(ClaimList)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetClaims", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetPendingClaims(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*ClaimList, error) {
+	out := new(ClaimList)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetPendingClaims", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) ReviewClaim(ctx context.Context, in *ReviewClaimRequest, opts ...grpc.CallOption) (*Claim, error) {
+	out := new(Claim)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/ReviewClaim", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetInvoices(ctx context.Context, in *empty.Empty, opts ...grpc.CallOption) (*Invoices, error) {
+	out := new(Invoices)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetInvoices", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *iPPSClient) GetInvoiceDocument(ctx context.Context, in *GetInvoiceDocumentRequest, opts ...grpc.CallOption) (*Label, error) {
+	out := new(Label)
+	err := c.cc.Invoke(ctx, "/grpc.IPPS/GetInvoiceDocument", in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// IPPSServer is the server API for IPPS service.
+type IPPSServer interface {
+	Login(context.Context, *LoginRequest) (*LoginResponse, error)
+	GetPublicKey(context.Context, *empty.Empty) (*PublicKey, error)
+	AddAddress(context.Context, *Address) (*empty.Empty, error)
+	GetAddresses(context.Context, *empty.Empty) (*Addresses, error)
+	AddCreditCard(context.Context, *CreditCard) (*empty.Empty, error)
+	GetCreditCards(context.Context, *empty.Empty) (*CreditCards, error)
+	SendParcel(context.Context, *SendParcelRequest) (*Parcel, error)
+	// TrackParcel does not require authentication. The customs declaration
+	// is only included for the authenticated sender and operators.
+	TrackParcel(context.Context, *TrackParcelRequest) (*TrackingInfo, error)
+	AddParcelEvent(context.Context, *AddParcelEventRequest) (*Event, error)
+	WatchParcel(*TrackParcelRequest, IPPS_WatchParcelServer) error
+	// GetQuote does not require authentication. The returned quote may be
+	// accepted by any user.
+	GetQuote(context.Context, *QuoteRequest) (*Quote, error)
+	// GetLabel returns the shipping label of a parcel sent by the user. IPPS
+	// operators may get the labels of all parcels.
+	GetLabel(context.Context, *GetLabelRequest) (*Label, error)
+	// ReturnParcel initiates the return of a parcel to its sender. It may be
+	// called by the parcel's sender and recipient.
+	ReturnParcel(context.Context, *ReturnParcelRequest) (*Event, error)
+	// RedirectParcel and HoldParcel may only be called by the parcel's
+	// recipient, until the parcel has been loaded into a vehicle.
+	RedirectParcel(context.Context, *RedirectParcelRequest) (*Event, error)
+	HoldParcel(context.Context, *HoldParcelRequest) (*Event, error)
+	// RescheduleDelivery postpones the next delivery attempt after a failed
+	// one. It may only be called by the parcel's recipient.
+	RescheduleDelivery(context.Context, *RescheduleDeliveryRequest) (*Event, error)
+	// GetIncomingParcels returns the parcels, whose sender has named the user
+	// as the recipient, GetOutgoingParcels those sent from any of the user's
+	// addresses. The most recently updated parcels are listed first.
+	GetIncomingParcels(context.Context, *empty.Empty) (*ParcelSummaries, error)
+	GetOutgoingParcels(context.Context, *empty.Empty) (*ParcelSummaries, error)
+	// DeliverParcel records the delivery of a parcel to its recipient, its
+	// return to the sender or its collection together with the proof of
+	// delivery. It requires the user to be an operator.
+	DeliverParcel(context.Context, *DeliverParcelRequest) (*Event, error)
+	// GetPickupSlots returns the future time slots in a city, which can still
+	// be booked. AddPickupSlot requires the user to be an operator.
+	GetPickupSlots(context.Context, *GetPickupSlotsRequest) (*PickupSlots, error)
+	AddPickupSlot(context.Context, *PickupSlot) (*PickupSlot, error)
+	// BookPickup and CancelPickup may only be called by the parcel's sender,
+	// until the parcel has been handed over to us.
+	BookPickup(context.Context, *BookPickupRequest) (*Pickup, error)
+	CancelPickup(context.Context, *CancelPickupRequest) (*Pickup, error)
+	// ConfirmPickup records that a courier has collected the parcel of a
+	// booked pickup. It requires the user to be an operator.
+	ConfirmPickup(context.Context, *ConfirmPickupRequest) (*Event, error)
+	// GetPickupPoints returns our shops and parcel lockers in a city.
+	// AddPickupPoint requires the user to be an operator.
+	GetPickupPoints(context.Context, *GetPickupPointsRequest) (*PickupPoints, error)
+	AddPickupPoint(context.Context, *PickupPoint) (*PickupPoint, error)
+	// DepositParcel allocates a compartment of the pickup point, to which a
+	// parcel is sent, and CollectParcel hands the parcel over to the
+	// recipient presenting the pickup code. Both require the user to be an
+	// operator.
+	DepositParcel(context.Context, *DepositParcelRequest) (*Compartment, error)
+	CollectParcel(context.Context, *CollectParcelRequest) (*Compartment, error)
+	// GetPickupCode returns the compartment and pickup code of a deposited
+	// parcel. It may only be called by the parcel's recipient.
+	GetPickupCode(context.Context, *GetPickupCodeRequest) (*Compartment, error)
+	// ScanParcel records the scan of a parcel in a logistics center and
+	// returns the sorting bin, into which the parcel has to be dropped. It
+	// requires the user to be an operator.
+	ScanParcel(context.Context, *ScanParcelRequest) (*Scan, error)
+	// CreateConsignment groups parcels sent by the user from the same return
+	// address to the same destination under a master tracking number.
+	// GetConsignments returns the user's consignments, starting with the
+	// most recently created one. TrackConsignment does not require
+	// authentication. The customs declarations are only included for the
+	// authenticated sender and operators.
+	CreateConsignment(context.Context, *CreateConsignmentRequest) (*Consignment, error)
+	GetConsignments(context.Context, *empty.Empty) (*Consignments, error)
+	TrackConsignment(context.Context, *TrackParcelRequest) (*Consignment, error)
+	// FileClaim files a damage or loss claim for an insured parcel sent by
+	// the user. GetClaims returns the user's claims, starting with the most
+	// recent one. GetPendingClaims and ReviewClaim require the user to be an
+	// operator.
+	FileClaim(context.Context, *FileClaimRequest) (*Claim, error)
+	GetClaims(context.Context, *empty.Empty) (*ClaimList, error)
+	GetPendingClaims(context.Context, *empty.Empty) (*ClaimList, error)
+	ReviewClaim(context.Context, *ReviewClaimRequest) (*Claim, error)
+	// GetInvoices returns the invoices paid by the user, starting with the
+	// most recent one. GetInvoiceDocument returns one of them as a PDF
+	// document.
+	GetInvoices(context.Context, *empty.Empty) (*Invoices, error)
+	GetInvoiceDocument(context.Context, *GetInvoiceDocumentRequest) (*Label, error)
+}
+
+// UnimplementedIPPSServer can be embedded to have forward compatible implementations.
+type UnimplementedIPPSServer struct {
+}
+
+func (*UnimplementedIPPSServer) Login(ctx context.Context, req *LoginRequest) (*LoginResponse, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method Login not implemented")
+}
+func (*UnimplementedIPPSServer) GetPublicKey(ctx context.Context, req *empty.Empty) (*PublicKey, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPublicKey not implemented")
+}
+func (*UnimplementedIPPSServer) AddAddress(ctx context.Context, req *Address) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddAddress not implemented")
+}
+func (*UnimplementedIPPSServer) GetAddresses(ctx context.Context, req *empty.Empty) (*Addresses, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetAddresses not implemented")
+}
+func (*UnimplementedIPPSServer) AddCreditCard(ctx context.Context, req *CreditCard) (*empty.Empty, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddCreditCard not implemented")
+}
+func (*UnimplementedIPPSServer) GetCreditCards(ctx context.Context, req *empty.Empty) (*CreditCards, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetCreditCards not implemented")
+}
+func (*UnimplementedIPPSServer) SendParcel(ctx context.Context, req *SendParcelRequest) (*Parcel, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method SendParcel not implemented")
+}
+func (*UnimplementedIPPSServer) TrackParcel(ctx context.Context, req *TrackParcelRequest) (*TrackingInfo, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrackParcel not implemented")
+}
+func (*UnimplementedIPPSServer) AddParcelEvent(ctx context.Context, req *AddParcelEventRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddParcelEvent not implemented")
+}
+func (*UnimplementedIPPSServer) WatchParcel(req *TrackParcelRequest, srv IPPS_WatchParcelServer) error {
+	return status.Errorf(codes.Unimplemented, "method WatchParcel not implemented")
+}
+func (*UnimplementedIPPSServer) GetQuote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetQuote not implemented")
+}
+func (*UnimplementedIPPSServer) GetLabel(ctx context.Context, req *GetLabelRequest) (*Label, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetLabel not implemented")
+}
+func (*UnimplementedIPPSServer) ReturnParcel(ctx context.Context, req *ReturnParcelRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReturnParcel not implemented")
+}
+func (*UnimplementedIPPSServer) RedirectParcel(ctx context.Context, req *RedirectParcelRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RedirectParcel not implemented")
+}
+func (*UnimplementedIPPSServer) HoldParcel(ctx context.Context, req *HoldParcelRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method HoldParcel not implemented")
+}
+func (*UnimplementedIPPSServer) RescheduleDelivery(ctx context.Context, req *RescheduleDeliveryRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method RescheduleDelivery not implemented")
+}
+func (*UnimplementedIPPSServer) GetIncomingParcels(ctx context.Context, req *empty.Empty) (*ParcelSummaries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetIncomingParcels not implemented")
+}
+func (*UnimplementedIPPSServer) GetOutgoingParcels(ctx context.Context, req *empty.Empty) (*ParcelSummaries, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetOutgoingParcels not implemented")
+}
+func (*UnimplementedIPPSServer) DeliverParcel(ctx context.Context, req *DeliverParcelRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DeliverParcel not implemented")
+}
+func (*UnimplementedIPPSServer) GetPickupSlots(ctx context.Context, req *GetPickupSlotsRequest) (*PickupSlots, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPickupSlots not implemented")
+}
+func (*UnimplementedIPPSServer) AddPickupSlot(ctx context.Context, req *PickupSlot) (*PickupSlot, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPickupSlot not implemented")
+}
+func (*UnimplementedIPPSServer) BookPickup(ctx context.Context, req *BookPickupRequest) (*Pickup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method BookPickup not implemented")
+}
+func (*UnimplementedIPPSServer) CancelPickup(ctx context.Context, req *CancelPickupRequest) (*Pickup, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CancelPickup not implemented")
+}
+func (*UnimplementedIPPSServer) ConfirmPickup(ctx context.Context, req *ConfirmPickupRequest) (*Event, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ConfirmPickup not implemented")
+}
+func (*UnimplementedIPPSServer) GetPickupPoints(ctx context.Context, req *GetPickupPointsRequest) (*PickupPoints, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPickupPoints not implemented")
+}
+func (*UnimplementedIPPSServer) AddPickupPoint(ctx context.Context, req *PickupPoint) (*PickupPoint, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method AddPickupPoint not implemented")
+}
+func (*UnimplementedIPPSServer) DepositParcel(ctx context.Context, req *DepositParcelRequest) (*Compartment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DepositParcel not implemented")
+}
+func (*UnimplementedIPPSServer) CollectParcel(ctx context.Context, req *CollectParcelRequest) (*Compartment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CollectParcel not implemented")
+}
+func (*UnimplementedIPPSServer) GetPickupCode(ctx context.Context, req *GetPickupCodeRequest) (*Compartment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPickupCode not implemented")
+}
+func (*UnimplementedIPPSServer) ScanParcel(ctx context.Context, req *ScanParcelRequest) (*Scan, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ScanParcel not implemented")
+}
+func (*UnimplementedIPPSServer) CreateConsignment(ctx context.Context, req *CreateConsignmentRequest) (*Consignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method CreateConsignment not implemented")
+}
+func (*UnimplementedIPPSServer) GetConsignments(ctx context.Context, req *empty.Empty) (*Consignments, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetConsignments not implemented")
+}
+func (*UnimplementedIPPSServer) TrackConsignment(ctx context.Context, req *TrackParcelRequest) (*Consignment, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method TrackConsignment not implemented")
+}
+func (*UnimplementedIPPSServer) FileClaim(ctx context.Context, req *FileClaimRequest) (*Claim, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method FileClaim not implemented")
+}
+func (*UnimplementedIPPSServer) GetClaims(ctx context.Context, req *empty.Empty) (*ClaimList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetClaims not implemented")
+}
+func (*UnimplementedIPPSServer) GetPendingClaims(ctx context.Context, req *empty.Empty) (*ClaimList, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetPendingClaims not implemented")
+}
+func (*UnimplementedIPPSServer) ReviewClaim(ctx context.Context, req *ReviewClaimRequest) (*Claim, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method ReviewClaim not implemented")
+}
+func (*UnimplementedIPPSServer) GetInvoices(ctx context.Context, req *empty.Empty) (*Invoices, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoices not implemented")
+}
+func (*UnimplementedIPPSServer) GetInvoiceDocument(ctx context.Context, req *GetInvoiceDocumentRequest) (*Label, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetInvoiceDocument not implemented")
+}
+
+func RegisterIPPSServer(s *grpc.Server, srv IPPSServer) {
+	s.RegisterService(&_IPPS_serviceDesc, srv)
+}
+
+func _IPPS_Login_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(LoginRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
 		return srv.(IPPSServer).Login(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/Login",
+		FullMethod: "/grpc.IPPS/Login",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).Login(ctx, req.(*LoginRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetPublicKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetPublicKey",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetPublicKey(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_AddAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(Address)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).AddAddress(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/AddAddress",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).AddAddress(ctx, req.(*Address))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetAddresses(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetAddresses",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetAddresses(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_AddCreditCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreditCard)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).AddCreditCard(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/AddCreditCard",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).AddCreditCard(ctx, req.(*CreditCard))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetCreditCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetCreditCards(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetCreditCards",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetCreditCards(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_SendParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SendParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).SendParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/SendParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).SendParcel(ctx, req.(*SendParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_TrackParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrackParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).TrackParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/TrackParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).TrackParcel(ctx, req.(*TrackParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_AddParcelEvent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(AddParcelEventRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).AddParcelEvent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/AddParcelEvent",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).AddParcelEvent(ctx, req.(*AddParcelEventRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_WatchParcel_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(TrackParcelRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(IPPSServer).WatchParcel(m, &iPPSWatchParcelServer{stream})
+}
+
+type IPPS_WatchParcelServer interface {
+	Send(*Event) error
+	grpc.ServerStream
+}
+
+type iPPSWatchParcelServer struct {
+	grpc.ServerStream
+}
+
+func (x *iPPSWatchParcelServer) Send(m *Event) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _IPPS_GetQuote_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(QuoteRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetQuote(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetQuote",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetQuote(ctx, req.(*QuoteRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetLabel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetLabelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetLabel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetLabel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetLabel(ctx, req.(*GetLabelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_ReturnParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReturnParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).ReturnParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/ReturnParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).ReturnParcel(ctx, req.(*ReturnParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_RedirectParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RedirectParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).RedirectParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/RedirectParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).RedirectParcel(ctx, req.(*RedirectParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_HoldParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(HoldParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).HoldParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/HoldParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).HoldParcel(ctx, req.(*HoldParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_RescheduleDelivery_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RescheduleDeliveryRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).RescheduleDelivery(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/RescheduleDelivery",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).RescheduleDelivery(ctx, req.(*RescheduleDeliveryRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetIncomingParcels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetIncomingParcels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetIncomingParcels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetIncomingParcels(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetOutgoingParcels_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetOutgoingParcels(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetOutgoingParcels",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetOutgoingParcels(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_DeliverParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeliverParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).DeliverParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/DeliverParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).DeliverParcel(ctx, req.(*DeliverParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetPickupSlots_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupSlotsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetPickupSlots(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetPickupSlots",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetPickupSlots(ctx, req.(*GetPickupSlotsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_AddPickupSlot_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickupSlot)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).AddPickupSlot(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/AddPickupSlot",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).AddPickupSlot(ctx, req.(*PickupSlot))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_BookPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BookPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).BookPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/BookPickup",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).Login(ctx, req.(*LoginRequest))
+		return srv.(IPPSServer).BookPickup(ctx, req.(*BookPickupRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IPPS_GetPublicKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _IPPS_CancelPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CancelPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).CancelPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/CancelPickup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).CancelPickup(ctx, req.(*CancelPickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_ConfirmPickup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ConfirmPickupRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).ConfirmPickup(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/ConfirmPickup",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).ConfirmPickup(ctx, req.(*ConfirmPickupRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetPickupPoints_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupPointsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetPickupPoints(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetPickupPoints",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetPickupPoints(ctx, req.(*GetPickupPointsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_AddPickupPoint_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(PickupPoint)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).AddPickupPoint(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/AddPickupPoint",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).AddPickupPoint(ctx, req.(*PickupPoint))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_DepositParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DepositParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).DepositParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/DepositParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).DepositParcel(ctx, req.(*DepositParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_CollectParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CollectParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).CollectParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/CollectParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).CollectParcel(ctx, req.(*CollectParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetPickupCode_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetPickupCodeRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetPickupCode(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetPickupCode",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetPickupCode(ctx, req.(*GetPickupCodeRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_ScanParcel_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ScanParcelRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).ScanParcel(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/ScanParcel",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).ScanParcel(ctx, req.(*ScanParcelRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_CreateConsignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateConsignmentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).CreateConsignment(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/CreateConsignment",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).CreateConsignment(ctx, req.(*CreateConsignmentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetConsignments_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IPPSServer).GetPublicKey(ctx, in)
+		return srv.(IPPSServer).GetConsignments(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/GetPublicKey",
+		FullMethod: "/grpc.IPPS/GetConsignments",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).GetPublicKey(ctx, req.(*empty.Empty))
+		return srv.(IPPSServer).GetConsignments(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IPPS_AddAddress_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(Address)
+func _IPPS_TrackConsignment_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(TrackParcelRequest)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IPPSServer).AddAddress(ctx, in)
+		return srv.(IPPSServer).TrackConsignment(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/AddAddress",
+		FullMethod: "/grpc.IPPS/TrackConsignment",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).AddAddress(ctx, req.(*Address))
+		return srv.(IPPSServer).TrackConsignment(ctx, req.(*TrackParcelRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IPPS_GetAddresses_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _IPPS_FileClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(FileClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).FileClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/FileClaim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).FileClaim(ctx, req.(*FileClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IPPSServer).GetAddresses(ctx, in)
+		return srv.(IPPSServer).GetClaims(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/GetAddresses",
+		FullMethod: "/grpc.IPPS/GetClaims",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).GetAddresses(ctx, req.(*empty.Empty))
+		return srv.(IPPSServer).GetClaims(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IPPS_AddCreditCard_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
-	in := new(CreditCard)
+func _IPPS_GetPendingClaims_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IPPSServer).AddCreditCard(ctx, in)
+		return srv.(IPPSServer).GetPendingClaims(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/AddCreditCard",
+		FullMethod: "/grpc.IPPS/GetPendingClaims",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).AddCreditCard(ctx, req.(*CreditCard))
+		return srv.(IPPSServer).GetPendingClaims(ctx, req.(*empty.Empty))
 	}
 	return interceptor(ctx, in, info, handler)
 }
 
-func _IPPS_GetCreditCards_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+func _IPPS_ReviewClaim_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReviewClaimRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).ReviewClaim(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/ReviewClaim",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).ReviewClaim(ctx, req.(*ReviewClaimRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetInvoices_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(empty.Empty)
 	if err := dec(in); err != nil {
 		return nil, err
 	}
 	if interceptor == nil {
-		return srv.(IPPSServer).GetCreditCards(ctx, in)
+		return srv.(IPPSServer).GetInvoices(ctx, in)
 	}
 	info := &grpc.UnaryServerInfo{
 		Server:     srv,
-		FullMethod: "/grpc.IPPS/GetCreditCards",
+		FullMethod: "/grpc.IPPS/GetInvoices",
 	}
 	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
-		return srv.(IPPSServer).GetCreditCards(ctx, req.(*empty.Empty))
+		return srv.(IPPSServer).GetInvoices(ctx, req.(*empty.Empty))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _IPPS_GetInvoiceDocument_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetInvoiceDocumentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(IPPSServer).GetInvoiceDocument(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: "/grpc.IPPS/GetInvoiceDocument",
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(IPPSServer).GetInvoiceDocument(ctx, req.(*GetInvoiceDocumentRequest))
 	}
 	return interceptor(ctx, in, info, handler)
 }
@@ -641,7 +5994,141 @@ var _IPPS_serviceDesc = grpc.ServiceDesc{
 			MethodName: "GetCreditCards",
 			Handler:    _IPPS_GetCreditCards_Handler,
 		},
+		{
+			MethodName: "SendParcel",
+			Handler:    _IPPS_SendParcel_Handler,
+		},
+		{
+			MethodName: "TrackParcel",
+			Handler:    _IPPS_TrackParcel_Handler,
+		},
+		{
+			MethodName: "AddParcelEvent",
+			Handler:    _IPPS_AddParcelEvent_Handler,
+		},
+		{
+			MethodName: "GetQuote",
+			Handler:    _IPPS_GetQuote_Handler,
+		},
+		{
+			MethodName: "GetLabel",
+			Handler:    _IPPS_GetLabel_Handler,
+		},
+		{
+			MethodName: "ReturnParcel",
+			Handler:    _IPPS_ReturnParcel_Handler,
+		},
+		{
+			MethodName: "RedirectParcel",
+			Handler:    _IPPS_RedirectParcel_Handler,
+		},
+		{
+			MethodName: "HoldParcel",
+			Handler:    _IPPS_HoldParcel_Handler,
+		},
+		{
+			MethodName: "RescheduleDelivery",
+			Handler:    _IPPS_RescheduleDelivery_Handler,
+		},
+		{
+			MethodName: "GetIncomingParcels",
+			Handler:    _IPPS_GetIncomingParcels_Handler,
+		},
+		{
+			MethodName: "GetOutgoingParcels",
+			Handler:    _IPPS_GetOutgoingParcels_Handler,
+		},
+		{
+			MethodName: "DeliverParcel",
+			Handler:    _IPPS_DeliverParcel_Handler,
+		},
+		{
+			MethodName: "GetPickupSlots",
+			Handler:    _IPPS_GetPickupSlots_Handler,
+		},
+		{
+			MethodName: "AddPickupSlot",
+			Handler:    _IPPS_AddPickupSlot_Handler,
+		},
+		{
+			MethodName: "BookPickup",
+			Handler:    _IPPS_BookPickup_Handler,
+		},
+		{
+			MethodName: "CancelPickup",
+			Handler:    _IPPS_CancelPickup_Handler,
+		},
+		{
+			MethodName: "ConfirmPickup",
+			Handler:    _IPPS_ConfirmPickup_Handler,
+		},
+		{
+			MethodName: "GetPickupPoints",
+			Handler:    _IPPS_GetPickupPoints_Handler,
+		},
+		{
+			MethodName: "AddPickupPoint",
+			Handler:    _IPPS_AddPickupPoint_Handler,
+		},
+		{
+			MethodName: "DepositParcel",
+			Handler:    _IPPS_DepositParcel_Handler,
+		},
+		{
+			MethodName: "CollectParcel",
+			Handler:    _IPPS_CollectParcel_Handler,
+		},
+		{
+			MethodName: "GetPickupCode",
+			Handler:    _IPPS_GetPickupCode_Handler,
+		},
+		{
+			MethodName: "ScanParcel",
+			Handler:    _IPPS_ScanParcel_Handler,
+		},
+		{
+			MethodName: "CreateConsignment",
+			Handler:    _IPPS_CreateConsignment_Handler,
+		},
+		{
+			MethodName: "GetConsignments",
+			Handler:    _IPPS_GetConsignments_Handler,
+		},
+		{
+			MethodName: "TrackConsignment",
+			Handler:    _IPPS_TrackConsignment_Handler,
+		},
+		{
+			MethodName: "FileClaim",
+			Handler:    _IPPS_FileClaim_Handler,
+		},
+		{
+			MethodName: "GetClaims",
+			Handler:    _IPPS_GetClaims_Handler,
+		},
+		{
+			MethodName: "GetPendingClaims",
+			Handler:    _IPPS_GetPendingClaims_Handler,
+		},
+		{
+			MethodName: "ReviewClaim",
+			Handler:    _IPPS_ReviewClaim_Handler,
+		},
+		{
+			MethodName: "GetInvoices",
+			Handler:    _IPPS_GetInvoices_Handler,
+		},
+		{
+			MethodName: "GetInvoiceDocument",
+			Handler:    _IPPS_GetInvoiceDocument_Handler,
+		},
+	},
+	Streams: []grpc.StreamDesc{
+		{
+			StreamName:    "WatchParcel",
+			Handler:       _IPPS_WatchParcel_Handler,
+			ServerStreams: true,
+		},
 	},
-	Streams:  []grpc.StreamDesc{},
 	Metadata: "ipps.proto",
 }
@@ -4,10 +4,25 @@ import (
 	"context"
 	"io/ioutil"
 	"net"
+	"strings"
+	"time"
 
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/google/uuid"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/label"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
@@ -24,13 +39,48 @@ type Server struct {
 	UnimplementedIPPSServer
 	config         Config
 	addressStorage address.Storage
-	creditStorage  credit.Storage
+	blobStore      blob.Store
+	centerService  *center.Service
+	// claimService files and reviews claims for insured parcels.
+	claimService *claim.Service
+	// consignmentService groups parcels to consignments and tracks them.
+	consignmentService *consignment.Service
+	creditStorage      credit.Storage
+	eventStorage       parcel.EventStorage
+	estimator          *eta.Estimator
+	lockerService      *locker.Service
+	// paymentService charges credit cards for parcels and issues invoices.
+	paymentService *payment.Service
+	pickupService  *pickup.Service
+	pricing        *pricing.Service
+	parcelStorage  parcel.Storage
+	subscriber     parcel.Subscriber
 	userStorage    user.Storage
 	privateKey     []byte
 	publicKey      []byte
 }
 
-func NewServer(config *Config, as address.Storage, cs credit.Storage, us user.Storage) (*Server, error) {
+// Dependencies are the storages and services used by the gRPC API. Their
+// fields correspond to the ones of Server.
+type Dependencies struct {
+	AddressStorage     address.Storage
+	BlobStore          blob.Store
+	CenterService      *center.Service
+	ClaimService       *claim.Service
+	ConsignmentService *consignment.Service
+	CreditStorage      credit.Storage
+	EventStorage       parcel.EventStorage
+	Estimator          *eta.Estimator
+	LockerService      *locker.Service
+	ParcelStorage      parcel.Storage
+	PaymentService     *payment.Service
+	PickupService      *pickup.Service
+	Pricing            *pricing.Service
+	Subscriber         parcel.Subscriber
+	UserStorage        user.Storage
+}
+
+func NewServer(config *Config, d *Dependencies) (*Server, error) {
 	sk, err := ioutil.ReadFile(config.JWTRSAPrivateKeyFile)
 	if err != nil {
 		return nil, err
@@ -40,12 +90,24 @@ func NewServer(config *Config, as address.Storage, cs credit.Storage, us user.St
 		return nil, err
 	}
 	s := &Server{
-		config:         *config,
-		addressStorage: as,
-		creditStorage:  cs,
-		userStorage:    us,
-		privateKey:     sk,
-		publicKey:      pk,
+		config:             *config,
+		addressStorage:     d.AddressStorage,
+		blobStore:          d.BlobStore,
+		centerService:      d.CenterService,
+		claimService:       d.ClaimService,
+		consignmentService: d.ConsignmentService,
+		creditStorage:      d.CreditStorage,
+		eventStorage:       d.EventStorage,
+		estimator:          d.Estimator,
+		lockerService:      d.LockerService,
+		paymentService:     d.PaymentService,
+		pickupService:      d.PickupService,
+		pricing:            d.Pricing,
+		parcelStorage:      d.ParcelStorage,
+		subscriber:         d.Subscriber,
+		userStorage:        d.UserStorage,
+		privateKey:         sk,
+		publicKey:          pk,
 	}
 
 	return s, nil
@@ -125,18 +187,27 @@ func (s *Server) GetAddresses(ctx context.Context, req *empty.Empty) (*Addresses
 
 	addrs := make([]*Address, 0, len(aa))
 	for _, a := range aa {
-		addrs = append(addrs, &Address{
-			Street:  a.Street,
-			Zip:     a.Zip,
-			City:    a.City,
-			Country: a.Country,
-			Planet:  a.Planet,
-		})
+		addrs = append(addrs, newAddress(a))
 	}
 
 	return &Addresses{Addresses: addrs}, nil
 }
 
+func newAddress(a *address.Address) *Address {
+	if a == nil {
+		return nil
+	}
+
+	return &Address{
+		Id:      a.ID.String(),
+		Street:  a.Street,
+		Zip:     a.Zip,
+		City:    a.City,
+		Country: a.Country,
+		Planet:  a.Planet,
+	}
+}
+
 // AddCreditCard adds a credit card to the current user's payment options.
 func (s *Server) AddCreditCard(ctx context.Context, card *CreditCard) (*empty.Empty, error) {
 	u := user.MustFromContext(ctx)
@@ -171,3 +242,1630 @@ func (s *Server) GetCreditCards(ctx context.Context, req *empty.Empty) (*CreditC
 
 	return &CreditCards{Cards: cards}, nil
 }
+
+// SendParcel registers a new parcel sent by the current user, returning
+// the parcel including its tracking id.
+func (s *Server) SendParcel(ctx context.Context, req *SendParcelRequest) (*Parcel, error) {
+	u := user.MustFromContext(ctx)
+	sr := &parcel.SendRequest{Sender: u, Recipient: strings.TrimSpace(req.Recipient)}
+	var err error
+	sr.ReturnAddressID, err = uuid.Parse(req.ReturnAddressId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidAddressID.Error())
+	}
+	if req.DestinationAddressId != "" {
+		sr.DestinationAddressID, err = uuid.Parse(req.DestinationAddressId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidAddressID.Error())
+		}
+	} else if nd := req.GetNewDestination(); nd != nil {
+		sr.NewDestination, err = address.NewForUser(u)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		sr.NewDestination.Street = nd.Street
+		sr.NewDestination.Zip = nd.Zip
+		sr.NewDestination.City = nd.City
+		sr.NewDestination.Country = nd.Country
+		sr.NewDestination.Planet = nd.Planet
+	}
+	if a := req.GetAttributes(); a != nil {
+		sr.Attributes = parcel.Attributes{
+			Weight:        a.Weight,
+			Length:        a.Length,
+			Width:         a.Width,
+			Height:        a.Height,
+			DeclaredValue: parcel.Cents(a.DeclaredValue),
+			Contents:      a.Contents,
+			ServiceLevel:  parcel.ServiceLevel(a.ServiceLevel),
+			Insured:       a.Insured,
+		}
+		for _, h := range a.Hazards {
+			sr.Attributes.Hazards |= parcel.Hazards(h)
+		}
+	}
+	if c := req.GetCustoms(); c != nil {
+		sr.Customs = &parcel.CustomsDeclaration{Category: parcel.CustomsCategory(c.Category)}
+		for _, it := range c.Items {
+			sr.Customs.Items = append(sr.Customs.Items, parcel.CustomsItem{
+				Description: it.Description,
+				HSCode:      parcel.NormalizeHSCode(it.HsCode),
+				Quantity:    int(it.Quantity),
+				Weight:      it.Weight,
+				Value:       parcel.Cents(it.Value),
+				Origin:      it.Origin,
+			})
+		}
+	}
+	if req.QuoteId != "" {
+		sr.QuoteID, err = uuid.Parse(req.QuoteId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidQuoteID.Error())
+		}
+	}
+	if req.CardId != "" {
+		sr.CardID, err = uuid.Parse(req.CardId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidCardID.Error())
+		}
+	}
+	if req.PickupPointId != "" {
+		sr.PickupPointID, err = uuid.Parse(req.PickupPointId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidPointID.Error())
+		}
+		err = s.lockerService.Resolve(sr)
+		if err == locker.ErrPointNotFound {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		} else if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	p, inv, err := s.paymentService.Send(sr, s.addressStorage, s.userStorage, s.pricing,
+		s.parcelStorage)
+	if parcel.IsInvalid(err) || pricing.IsRejection(err) || err == payment.ErrForeignCard {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err == payment.ErrDeclined {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pp := newParcel(p, true)
+	if inv != nil {
+		pp.Invoice, err = newInvoice(inv)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return pp, nil
+}
+
+// newParcel returns the protobuf representation of p, which includes its
+// customs declaration, if customs is true. Only the parcel's sender and
+// operators may see it.
+func newParcel(p *parcel.Parcel, customs bool) *Parcel {
+	pp := &Parcel{
+		Id:                 p.ID.String(),
+		TrackingCode:       p.TrackingCode(),
+		ReturnAddress:      newAddress(p.ReturnAddress),
+		DestinationAddress: newAddress(p.DestinationAddress),
+		Attributes:         newParcelAttributes(&p.Attributes),
+	}
+	if p.QuoteID != nil {
+		pp.QuoteId = p.QuoteID.String()
+	}
+	if customs && p.Customs != nil {
+		pp.Customs = newCustomsDeclaration(p.Customs)
+	}
+	if p.PickupPointID != nil {
+		pp.PickupPointId = p.PickupPointID.String()
+	}
+
+	return pp
+}
+
+var (
+	ErrOperatorRequired = status.Error(codes.PermissionDenied,
+		"only IPPS operators are allowed to call this method")
+	ErrInvalidTrackingID = status.Error(codes.InvalidArgument, "the tracking number is invalid")
+	ErrParcelNotFound    = status.Error(codes.NotFound,
+		"a parcel with that tracking number does not exist")
+	ErrUnexpectedReason = status.Error(codes.InvalidArgument,
+		"a reason may only be given for failed delivery attempts")
+)
+
+// TrackParcel returns the parcel identified by the request's tracking id
+// together with its tracking events.
+func (s *Server) TrackParcel(ctx context.Context, req *TrackParcelRequest) (*TrackingInfo, error) {
+	id, err := parcel.ParseTrackingID(req.Id)
+	if err == parcel.ErrTrackingCodeTypo {
+		return nil, s.typoError(req.Id)
+	} else if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	ee, err := s.eventStorage.ByParcel(p)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	u, _ := user.FromContext(ctx)
+	customs, err := parcel.CustomsVisible(u, p, s.addressStorage)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	ti := &TrackingInfo{
+		Parcel: newParcel(p, customs),
+		Events: make([]*Event, 0, len(ee)),
+	}
+	for _, e := range ee {
+		ev, err := newEvent(e)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		ti.Events = append(ti.Events, ev)
+	}
+	ti.Estimate, err = newDeliveryEstimate(s.estimator.Estimate(p, ee, time.Now()))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ti, nil
+}
+
+// typoError returns the status for the tracking code with a mismatching
+// check character, which suggests the tracking codes of similar parcels.
+func (s *Server) typoError(code string) error {
+	pp, err := parcel.SuggestTrackingCodes(code, s.parcelStorage)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	} else if len(pp) == 0 {
+		return status.Error(codes.InvalidArgument, parcel.ErrTrackingCodeTypo.Error())
+	}
+	cc := make([]string, 0, len(pp))
+	for _, p := range pp {
+		cc = append(cc, p.TrackingCode())
+	}
+
+	return status.Errorf(codes.InvalidArgument, "%v, did you mean %s?",
+		parcel.ErrTrackingCodeTypo, strings.Join(cc, " or "))
+}
+
+func newDeliveryEstimate(e *eta.Estimate) (*DeliveryEstimate, error) {
+	earliest, err := ptypes.TimestampProto(e.Earliest)
+	if err != nil {
+		return nil, err
+	}
+	expected, err := ptypes.TimestampProto(e.Expected)
+	if err != nil {
+		return nil, err
+	}
+	latest, err := ptypes.TimestampProto(e.Latest)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DeliveryEstimate{
+		Earliest:  earliest,
+		Expected:  expected,
+		Latest:    latest,
+		Delivered: e.Delivered,
+		Returning: e.Returning,
+	}, nil
+}
+
+func newEvent(e *parcel.Event) (*Event, error) {
+	t, err := ptypes.TimestampProto(e.Time)
+	if err != nil {
+		return nil, err
+	}
+
+	ev := &Event{
+		Id:          e.ID.String(),
+		Type:        EventType(e.Type),
+		Description: e.Type.String(),
+		Time:        t,
+		Reason:      FailureReason(e.Reason),
+	}
+	if e.DeliveryDate != nil {
+		ev.DeliveryDate = e.DeliveryDate.Format("2006-01-02")
+	}
+
+	return ev, nil
+}
+
+// AddParcelEvent records a tracking event for a parcel. It is used by
+// logistics staff and scanners and requires the user to be an operator.
+func (s *Server) AddParcelEvent(ctx context.Context, req *AddParcelEventRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	t := parcel.EventType(req.Type)
+	if _, err := t.MarshalText(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if t.AtPickupPoint() {
+		return nil, status.Error(codes.FailedPrecondition, parcel.ErrPickupPointEvent.Error())
+	}
+	reason := parcel.FailureReason(req.Reason)
+	if _, err := reason.MarshalText(); err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if reason != parcel.NoReason && t != parcel.DeliveryFailed {
+		return nil, ErrUnexpectedReason
+	}
+	var at time.Time
+	if req.Time != nil {
+		at, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	var e *parcel.Event
+	if t == parcel.DeliveryFailed {
+		e, err = parcel.RecordFailure(s.eventStorage, p, reason, at)
+	} else {
+		e, err = parcel.Record(s.eventStorage, p, t, at)
+	}
+	if err == parcel.ErrInvalidTransition || err == parcel.ErrEventTimeOrder {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err == parcel.ErrEventInFuture {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// DeliverParcel records the event completing a parcel's delivery together
+// with the proof of delivery captured by the courier. It requires the user
+// to be an operator.
+func (s *Server) DeliverParcel(ctx context.Context, req *DeliverParcelRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	dr := &parcel.DeliveryRequest{
+		RecipientName: req.RecipientName,
+		Signature:     req.Signature,
+		Photo:         req.Photo,
+	}
+	if l := req.Location; l != nil {
+		dr.Location = &parcel.Location{Latitude: l.Latitude, Longitude: l.Longitude}
+	}
+	if req.Time != nil {
+		dr.Time, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	pr, err := parcel.Deliver(p, dr, s.parcelStorage, s.eventStorage, s.blobStore)
+	switch err {
+	case nil:
+	case parcel.ErrNotOutForDelivery, parcel.ErrInvalidTransition, parcel.ErrEventTimeOrder:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case parcel.ErrRecipientNameEmpty, parcel.ErrNoEvidence, parcel.ErrInvalidImage,
+		parcel.ErrImageTooLarge, parcel.ErrInvalidLocation, parcel.ErrEventInFuture:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ev, err := newEvent(pr.Event)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+var ErrNotInvolved = status.Error(codes.PermissionDenied, parcel.ErrNotInvolved.Error())
+
+// ReturnParcel initiates the return of a parcel to its sender on behalf of
+// the parcel's sender or recipient.
+func (s *Server) ReturnParcel(ctx context.Context, req *ReturnParcelRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	e, err := parcel.RequestReturn(u, p, s.addressStorage, s.eventStorage)
+	if err == parcel.ErrNotInvolved {
+		return nil, ErrNotInvolved
+	} else if err == parcel.ErrNotReturnable {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// RedirectParcel changes the destination of a parcel to another address
+// of its recipient.
+func (s *Server) RedirectParcel(ctx context.Context, req *RedirectParcelRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	var to uuid.UUID
+	if req.PickupPointId != "" {
+		to, err = uuid.Parse(req.PickupPointId)
+		if err != nil {
+			return nil, ErrInvalidPointID
+		}
+	} else {
+		to, err = uuid.Parse(req.AddressId)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidAddressID.Error())
+		}
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	var e *parcel.Event
+	if req.PickupPointId != "" {
+		e, err = s.lockerService.Redirect(u, p, to)
+	} else {
+		e, err = parcel.Redirect(u, p, to, s.addressStorage, s.parcelStorage, s.eventStorage)
+	}
+	if err != nil {
+		return nil, redirectError(err)
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// HoldParcel asks us to hold a parcel for collection by its recipient.
+func (s *Server) HoldParcel(ctx context.Context, req *HoldParcelRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	e, err := parcel.Hold(u, p, s.eventStorage)
+	if err != nil {
+		return nil, redirectError(err)
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// GetIncomingParcels returns the parcels, whose sender has named the user
+// as the recipient, together with their latest events.
+func (s *Server) GetIncomingParcels(ctx context.Context, req *empty.Empty) (*ParcelSummaries, error) {
+	u := user.MustFromContext(ctx)
+	ss, err := parcel.Incoming(u, s.parcelStorage, s.eventStorage)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return newParcelSummaries(ss, u.Operator)
+}
+
+// GetOutgoingParcels returns the parcels sent from any of the user's
+// addresses together with their latest events.
+func (s *Server) GetOutgoingParcels(ctx context.Context, req *empty.Empty) (*ParcelSummaries, error) {
+	u := user.MustFromContext(ctx)
+	ss, err := parcel.Outgoing(u, s.addressStorage, s.parcelStorage, s.eventStorage)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return newParcelSummaries(ss, true)
+}
+
+// newParcelSummaries returns the protobuf representation of ss, which
+// includes the customs declarations, if customs is true.
+func newParcelSummaries(ss []*parcel.Summary, customs bool) (*ParcelSummaries, error) {
+	ps := &ParcelSummaries{Parcels: make([]*ParcelSummary, 0, len(ss))}
+	for _, sum := range ss {
+		p := &ParcelSummary{Parcel: newParcel(sum.Parcel, customs)}
+		if sum.Latest != nil {
+			e, err := newEvent(sum.Latest)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+			p.Latest = e
+		}
+		ps.Parcels = append(ps.Parcels, p)
+	}
+
+	return ps, nil
+}
+
+// RescheduleDelivery postpones the next attempt to deliver a parcel to the
+// requested day.
+func (s *Server) RescheduleDelivery(ctx context.Context, req *RescheduleDeliveryRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	date, err := time.ParseInLocation("2006-01-02", req.Date, time.Local)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, parcel.ErrInvalidDeliveryDate.Error())
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	e, err := parcel.Reschedule(u, p, date, s.eventStorage)
+	if err != nil {
+		return nil, redirectError(err)
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// redirectError returns the status of the error err returned when
+// redirecting, holding or rescheduling the delivery of a parcel.
+func redirectError(err error) error {
+	switch err {
+	case parcel.ErrNotRecipient:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case locker.ErrPointNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case parcel.ErrNotRedirectable, parcel.ErrAlreadyHeld, parcel.ErrNotReschedulable:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case parcel.ErrInvalidRecipient, parcel.ErrSameDestination, parcel.ErrRedirectPlanet,
+		parcel.ErrInvalidDeliveryDate:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// WatchParcel streams the tracking events of the parcel identified by the
+// request's tracking id. It sends the parcel's existing events first and
+// then every new event, until the parcel has reached the end of its
+// lifecycle or the client cancels the call.
+func (s *Server) WatchParcel(req *TrackParcelRequest, stream IPPS_WatchParcelServer) error {
+	id, err := parcel.ParseTrackingID(req.Id)
+	if err != nil {
+		return ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return ErrParcelNotFound
+	}
+
+	// Subscribe before retrieving the existing events, so no event is lost.
+	ch, cancel := s.subscriber.Subscribe(p.ID)
+	defer cancel()
+	ee, err := s.eventStorage.ByParcel(p)
+	if err != nil {
+		return status.Error(codes.Internal, err.Error())
+	}
+	st := parcel.NewState(p, nil)
+	sent := make(map[uuid.UUID]bool, len(ee))
+	send := func(e *parcel.Event) error {
+		ev, err := newEvent(e)
+		if err != nil {
+			return status.Error(codes.Internal, err.Error())
+		}
+		sent[e.ID] = true
+		st.Apply(e)
+
+		return stream.Send(ev)
+	}
+	for _, e := range ee {
+		err = send(e)
+		if err != nil {
+			return err
+		}
+	}
+
+	for len(st.Next()) > 0 {
+		select {
+		case e := <-ch:
+			if sent[e.ID] {
+				continue
+			}
+			err = send(e)
+			if err != nil {
+				return err
+			}
+		case <-stream.Context().Done():
+			return status.FromContextError(stream.Context().Err()).Err()
+		}
+	}
+
+	return nil
+}
+
+func newParcelAttributes(a *parcel.Attributes) *ParcelAttributes {
+	pa := &ParcelAttributes{
+		Weight:        a.Weight,
+		Length:        a.Length,
+		Width:         a.Width,
+		Height:        a.Height,
+		DeclaredValue: int64(a.DeclaredValue),
+		Contents:      a.Contents,
+		ServiceLevel:  ServiceLevel(a.ServiceLevel),
+		Insured:       a.Insured,
+	}
+	for h := parcel.Flammable; h <= parcel.Explosive; h <<= 1 {
+		if a.Hazards.Has(h) {
+			pa.Hazards = append(pa.Hazards, Hazard(h))
+		}
+	}
+
+	return pa
+}
+
+func newCustomsDeclaration(d *parcel.CustomsDeclaration) *CustomsDeclaration {
+	cd := &CustomsDeclaration{Category: CustomsCategory(d.Category)}
+	for _, it := range d.Items {
+		cd.Items = append(cd.Items, &CustomsItem{
+			Description: it.Description,
+			HsCode:      it.HSCode,
+			Quantity:    int32(it.Quantity),
+			Weight:      it.Weight,
+			Value:       int64(it.Value),
+			Origin:      it.Origin,
+		})
+	}
+
+	return cd
+}
+
+// GetQuote is the RPC call that computes the price of sending a parcel. The
+// quote is stored, so that it may be referenced when sending the parcel.
+func (s *Server) GetQuote(ctx context.Context, req *QuoteRequest) (*Quote, error) {
+	r := &pricing.Request{
+		Weight:        req.Weight,
+		Length:        req.Length,
+		Width:         req.Width,
+		Height:        req.Height,
+		From:          req.From,
+		To:            req.To,
+		ServiceLevel:  parcel.ServiceLevel(req.ServiceLevel),
+		DeclaredValue: pricing.Amount(req.DeclaredValue),
+		Insured:       req.Insured,
+	}
+	q, err := s.pricing.Quote(r, nil)
+	if pricing.IsInvalid(err) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return newQuote(q)
+}
+
+func newQuote(q *pricing.Quote) (*Quote, error) {
+	created, err := ptypes.TimestampProto(q.Created)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	expires, err := ptypes.TimestampProto(q.Expires)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	r := &q.Request
+	qq := &Quote{
+		Id: q.ID.String(),
+		Request: &QuoteRequest{
+			Weight:        r.Weight,
+			Length:        r.Length,
+			Width:         r.Width,
+			Height:        r.Height,
+			From:          r.From,
+			To:            r.To,
+			ServiceLevel:  ServiceLevel(r.ServiceLevel),
+			DeclaredValue: int64(r.DeclaredValue),
+			Insured:       r.Insured,
+		},
+		Lines:    make([]*QuoteLine, 0, len(q.Lines)),
+		Total:    int64(q.Total),
+		Currency: q.Currency,
+		Created:  created,
+		Expires:  expires,
+	}
+	for _, l := range q.Lines {
+		qq.Lines = append(qq.Lines, &QuoteLine{Description: l.Description, Amount: int64(l.Amount)})
+	}
+
+	return qq, nil
+}
+
+var ErrLabelForeign = status.Error(codes.PermissionDenied, "you have not sent this parcel")
+
+// GetLabel is the RPC call that renders a parcel's shipping label.
+func (s *Server) GetLabel(ctx context.Context, req *GetLabelRequest) (*Label, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	f := label.Format(req.Format)
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	if !u.Operator {
+		sent, err := p.SentBy(u, s.addressStorage)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		} else if !sent {
+			return nil, ErrLabelForeign
+		}
+	}
+
+	l, err := label.New(p)
+	if err == label.ErrAddressMissing {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	b, err := l.Render(f)
+	if err == label.ErrUnknownFormat {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	} else if err == label.ErrNoCustoms {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &Label{Data: b, ContentType: f.ContentType()}, nil
+}
+
+var (
+	ErrInvalidSlotID   = status.Error(codes.InvalidArgument, "the time slot id is invalid")
+	ErrSlotNotFound    = status.Error(codes.NotFound, "a time slot with that id does not exist")
+	ErrInvalidPickupID = status.Error(codes.InvalidArgument, "the pickup id is invalid")
+	ErrPickupNotFound  = status.Error(codes.NotFound, "a pickup with that id does not exist")
+)
+
+// GetPickupSlots returns the future time slots in the requested city, which
+// can still be booked.
+func (s *Server) GetPickupSlots(ctx context.Context, req *GetPickupSlotsRequest) (*PickupSlots, error) {
+	ss, err := s.pickupService.Available(req.City, req.Planet)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	slots := &PickupSlots{Slots: make([]*PickupSlot, 0, len(ss))}
+	for _, sl := range ss {
+		slot, err := newPickupSlot(sl)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		slots.Slots = append(slots.Slots, slot)
+	}
+
+	return slots, nil
+}
+
+// AddPickupSlot adds a new time slot for pickups. It requires the user to
+// be an operator.
+func (s *Server) AddPickupSlot(ctx context.Context, req *PickupSlot) (*PickupSlot, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	start, err := ptypes.Timestamp(req.Start)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	end, err := ptypes.Timestamp(req.End)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	sl, err := s.pickupService.AddSlot(req.City, req.Planet, start, end, int(req.Capacity))
+	if err != nil {
+		return nil, pickupError(err)
+	}
+	slot, err := newPickupSlot(sl)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return slot, nil
+}
+
+// BookPickup books the pickup of a parcel sent by the user.
+func (s *Server) BookPickup(ctx context.Context, req *BookPickupRequest) (*Pickup, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	slotID, err := uuid.Parse(req.SlotId)
+	if err != nil {
+		return nil, ErrInvalidSlotID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	sl, err := s.pickupService.Pickups.SlotByID(slotID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if sl == nil {
+		return nil, ErrSlotNotFound
+	}
+
+	pk, err := s.pickupService.Book(u, p, sl)
+	if err != nil {
+		return nil, pickupError(err)
+	}
+
+	return newPickup(pk)
+}
+
+// CancelPickup cancels the booked pickup of a parcel sent by the user.
+func (s *Server) CancelPickup(ctx context.Context, req *CancelPickupRequest) (*Pickup, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	pk, err := s.pickupService.Cancel(u, p)
+	if err != nil {
+		return nil, pickupError(err)
+	}
+
+	return newPickup(pk)
+}
+
+// ConfirmPickup records that a courier has collected the parcel of a booked
+// pickup. It requires the user to be an operator.
+func (s *Server) ConfirmPickup(ctx context.Context, req *ConfirmPickupRequest) (*Event, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := uuid.Parse(req.PickupId)
+	if err != nil {
+		return nil, ErrInvalidPickupID
+	}
+	var at time.Time
+	if req.Time != nil {
+		at, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	pk, err := s.pickupService.Pickups.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if pk == nil {
+		return nil, ErrPickupNotFound
+	}
+
+	e, err := s.pickupService.Confirm(pk, at)
+	if err != nil {
+		return nil, pickupError(err)
+	}
+	ev, err := newEvent(e)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return ev, nil
+}
+
+// pickupError returns the status of the error err returned by the pickup
+// service.
+func pickupError(err error) error {
+	switch err {
+	case pickup.ErrNotSender:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case pickup.ErrNotAwaitingPickup, pickup.ErrAlreadyBooked, pickup.ErrNotBooked,
+		pickup.ErrCancellationClosed, pickup.ErrSlotInPast, parcel.ErrEventTimeOrder:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case pickup.ErrSlotFull:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case pickup.ErrWrongCity, pickup.ErrCityEmpty, pickup.ErrInvalidCapacity, pickup.ErrInvalidSlotTime,
+		parcel.ErrEventInFuture:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func newPickupSlot(sl *pickup.Slot) (*PickupSlot, error) {
+	start, err := ptypes.TimestampProto(sl.Start)
+	if err != nil {
+		return nil, err
+	}
+	end, err := ptypes.TimestampProto(sl.End)
+	if err != nil {
+		return nil, err
+	}
+
+	return &PickupSlot{
+		Id:       sl.ID.String(),
+		City:     sl.City,
+		Planet:   sl.Planet,
+		Start:    start,
+		End:      end,
+		Capacity: int32(sl.Capacity),
+		Booked:   int32(sl.Booked),
+	}, nil
+}
+
+func newPickup(pk *pickup.Pickup) (*Pickup, error) {
+	slot, err := newPickupSlot(pk.Slot)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	bookedAt, err := ptypes.TimestampProto(pk.BookedAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return &Pickup{
+		Id:       pk.ID.String(),
+		ParcelId: pk.ParcelID.String(),
+		Slot:     slot,
+		Status:   string(pk.Status),
+		BookedAt: bookedAt,
+	}, nil
+}
+
+var ErrInvalidPointID = status.Error(codes.InvalidArgument, "the pickup point id is invalid")
+
+// GetPickupPoints returns the shops and parcel lockers in the requested
+// city.
+func (s *Server) GetPickupPoints(ctx context.Context, req *GetPickupPointsRequest) (*PickupPoints, error) {
+	pp, err := s.lockerService.Points.Points(req.City, req.Planet)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	points := &PickupPoints{Points: make([]*PickupPoint, 0, len(pp))}
+	for _, pt := range pp {
+		points.Points = append(points.Points, newPickupPoint(pt))
+	}
+
+	return points, nil
+}
+
+// AddPickupPoint adds a shop or parcel locker to the registry of pickup
+// points. It requires the user to be an operator.
+func (s *Server) AddPickupPoint(ctx context.Context, req *PickupPoint) (*PickupPoint, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	pt := &locker.Point{
+		Kind:         locker.Shop,
+		Name:         req.Name,
+		Street:       req.Street,
+		Zip:          req.Zip,
+		City:         req.City,
+		Country:      req.Country,
+		Planet:       req.Planet,
+		OpeningHours: req.OpeningHours,
+		Compartments: int(req.Compartments),
+	}
+	if req.Kind == PickupPointKind_LOCKER {
+		pt.Kind = locker.Locker
+	}
+
+	err := s.lockerService.AddPoint(pt)
+	if err != nil {
+		return nil, lockerError(err)
+	}
+
+	return newPickupPoint(pt), nil
+}
+
+// DepositParcel allocates a compartment of the pickup point, to which a
+// parcel is sent, and records that the parcel has been deposited in it. It
+// requires the user to be an operator.
+func (s *Server) DepositParcel(ctx context.Context, req *DepositParcelRequest) (*Compartment, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	var at time.Time
+	if req.Time != nil {
+		at, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	a, e, err := s.lockerService.Deposit(p, at)
+	if err != nil {
+		return nil, lockerError(err)
+	}
+
+	return newCompartment(a, e, false)
+}
+
+// CollectParcel hands the parcel, whose pickup code at the requested
+// pickup point is the request's code, over to its recipient. It requires
+// the user to be an operator.
+func (s *Server) CollectParcel(ctx context.Context, req *CollectParcelRequest) (*Compartment, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := uuid.Parse(req.PickupPointId)
+	if err != nil {
+		return nil, ErrInvalidPointID
+	}
+	var at time.Time
+	if req.Time != nil {
+		at, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+
+	a, e, err := s.lockerService.Collect(id, req.Code, at)
+	if err != nil {
+		return nil, lockerError(err)
+	}
+
+	return newCompartment(a, e, false)
+}
+
+// GetPickupCode returns the compartment and pickup code of a parcel sent to
+// the user, which is ready for collection.
+func (s *Server) GetPickupCode(ctx context.Context, req *GetPickupCodeRequest) (*Compartment, error) {
+	u := user.MustFromContext(ctx)
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	a, err := s.lockerService.Allocation(u, p)
+	if err != nil {
+		return nil, lockerError(err)
+	}
+
+	return newCompartment(a, nil, true)
+}
+
+// lockerError returns the status of the error err returned by the locker
+// service.
+func lockerError(err error) error {
+	switch err {
+	case locker.ErrNotRecipient:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case locker.ErrPointNotFound, locker.ErrNotDeposited:
+		return status.Error(codes.NotFound, err.Error())
+	case locker.ErrNoPickupPoint, locker.ErrNotOutForPickup, locker.ErrAlreadyAllocated, locker.ErrInvalidCode,
+		parcel.ErrEventTimeOrder:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case locker.ErrPointFull:
+		return status.Error(codes.ResourceExhausted, err.Error())
+	case locker.ErrUnknownKind, locker.ErrNameEmpty, locker.ErrIncompleteAddress,
+		locker.ErrInvalidCompartments, parcel.ErrEventInFuture:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+func newPickupPoint(pt *locker.Point) *PickupPoint {
+	pp := &PickupPoint{
+		Id:           pt.ID.String(),
+		Kind:         PickupPointKind_SHOP,
+		Name:         pt.Name,
+		Street:       pt.Street,
+		Zip:          pt.Zip,
+		City:         pt.City,
+		Country:      pt.Country,
+		Planet:       pt.Planet,
+		OpeningHours: pt.OpeningHours,
+		Compartments: int32(pt.Compartments),
+		Occupied:     int32(pt.Occupied),
+	}
+	if pt.Kind == locker.Locker {
+		pp.Kind = PickupPointKind_LOCKER
+	}
+
+	return pp
+}
+
+// newCompartment returns the compartment allocated by a together with the
+// event e, which may be nil. The pickup code is only included, if withCode
+// is true.
+func newCompartment(a *locker.Allocation, e *parcel.Event, withCode bool) (*Compartment, error) {
+	expiresAt, err := ptypes.TimestampProto(a.ExpiresAt)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	c := &Compartment{
+		Point:     newPickupPoint(a.Point),
+		ParcelId:  a.ParcelID.String(),
+		Number:    int32(a.Compartment),
+		ExpiresAt: expiresAt,
+	}
+	if withCode {
+		c.Code = a.Code
+	}
+	if e != nil {
+		c.Event, err = newEvent(e)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return c, nil
+}
+
+var ErrInvalidCenterID = status.Error(codes.InvalidArgument, "the logistics center id is invalid")
+
+// ScanParcel records the scan of a parcel in a logistics center and returns
+// the sorting bin, into which the parcel has to be dropped. It requires the
+// user to be an operator.
+func (s *Server) ScanParcel(ctx context.Context, req *ScanParcelRequest) (*Scan, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	cid, err := uuid.Parse(req.CenterId)
+	if err != nil {
+		return nil, ErrInvalidCenterID
+	}
+	id, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	var at time.Time
+	if req.Time != nil {
+		at, err = ptypes.Timestamp(req.Time)
+		if err != nil {
+			return nil, status.Error(codes.InvalidArgument, err.Error())
+		}
+	}
+	p, err := s.parcelStorage.ByID(id)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	sc, err := s.centerService.Scan(cid, p, at)
+	switch err {
+	case nil:
+	case center.ErrCenterNotFound:
+		return nil, status.Error(codes.NotFound, err.Error())
+	case center.ErrNotExpected, center.ErrWrongPlanet, center.ErrNoBin, parcel.ErrEventTimeOrder:
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case parcel.ErrEventInFuture:
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	return newScan(sc)
+}
+
+func newScan(sc *center.Scan) (*Scan, error) {
+	t, err := ptypes.TimestampProto(sc.Time)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	res := &Scan{
+		Id:       sc.ID.String(),
+		CenterId: sc.Center.String(),
+		ParcelId: sc.ParcelID.String(),
+		Bin: &SortingBin{
+			Id:     sc.Bin.ID.String(),
+			Label:  sc.Bin.Label,
+			Planet: sc.Bin.Planet,
+			City:   sc.Bin.City,
+		},
+		Time: t,
+	}
+	if sc.Event != nil {
+		res.Event, err = newEvent(sc.Event)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return res, nil
+}
+
+// CreateConsignment groups the parcels identified by the request to a new
+// consignment. It may only be called by the parcels' sender.
+func (s *Server) CreateConsignment(ctx context.Context, req *CreateConsignmentRequest) (*Consignment, error) {
+	u := user.MustFromContext(ctx)
+	ids := make([]uuid.UUID, 0, len(req.ParcelIds))
+	for _, pid := range req.ParcelIds {
+		id, err := parcel.ParseTrackingID(pid)
+		if err != nil {
+			return nil, ErrInvalidTrackingID
+		}
+		ids = append(ids, id)
+	}
+
+	t, err := s.consignmentService.Create(u, ids, req.Reference)
+	if err != nil {
+		return nil, consignmentError(err)
+	}
+
+	return newConsignment(t, true)
+}
+
+// GetConsignments returns the consignments created by the user.
+func (s *Server) GetConsignments(ctx context.Context, req *empty.Empty) (*Consignments, error) {
+	u := user.MustFromContext(ctx)
+	tt, err := s.consignmentService.Sent(u)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	cc := &Consignments{Consignments: make([]*Consignment, 0, len(tt))}
+	for _, t := range tt {
+		c, err := newConsignment(t, true)
+		if err != nil {
+			return nil, err
+		}
+		cc.Consignments = append(cc.Consignments, c)
+	}
+
+	return cc, nil
+}
+
+// TrackConsignment returns the consolidated tracking information of the
+// consignment identified by the request's master tracking number.
+func (s *Server) TrackConsignment(ctx context.Context, req *TrackParcelRequest) (*Consignment, error) {
+	id, err := parcel.ParseTrackingID(req.Id)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	t, err := s.consignmentService.Track(id)
+	if err != nil {
+		return nil, consignmentError(err)
+	}
+	u, ok := user.FromContext(ctx)
+
+	return newConsignment(t, ok && (u.Operator || u.ID == t.Sender))
+}
+
+func consignmentError(err error) error {
+	switch err {
+	case consignment.ErrNotSender:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case consignment.ErrConsignmentNotFound, consignment.ErrParcelNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case consignment.ErrAlreadyConsigned:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case consignment.ErrTooFewParcels, consignment.ErrTooManyParcels, consignment.ErrDuplicateParcel,
+		consignment.ErrReferenceTooLong, consignment.ErrDifferentAddresses:
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// consignmentStatuses maps the statuses of the consignment package to their
+// protobuf representation.
+var consignmentStatuses = map[consignment.Status]ConsignmentStatus{
+	consignment.Registered:         ConsignmentStatus_REGISTERED,
+	consignment.InTransit:          ConsignmentStatus_IN_TRANSIT,
+	consignment.PartiallyDelivered: ConsignmentStatus_PARTIALLY_DELIVERED,
+	consignment.Delivered:          ConsignmentStatus_DELIVERED,
+	consignment.Returned:           ConsignmentStatus_RETURNED,
+}
+
+// newConsignment returns the protobuf representation of t, which includes
+// the parcels' customs declarations, if customs is true.
+func newConsignment(t *consignment.Tracking, customs bool) (*Consignment, error) {
+	created, err := ptypes.TimestampProto(t.Created)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	c := &Consignment{
+		Id:           t.ID.String(),
+		TrackingCode: t.TrackingCode,
+		Reference:    t.Reference,
+		Created:      created,
+		Status:       consignmentStatuses[t.Status],
+		Description:  t.Status.String(),
+		Parcels:      make([]*ConsignmentParcel, 0, len(t.Parcels)),
+		Delivered:    int32(t.Delivered),
+		Returned:     int32(t.Returned),
+		Outstanding:  int32(t.Outstanding),
+	}
+	for _, m := range t.Parcels {
+		cp := &ConsignmentParcel{
+			Parcel: newParcel(m.Parcel, customs),
+			Status: consignmentStatuses[m.Status],
+		}
+		if m.Latest != nil {
+			cp.Latest, err = newEvent(m.Latest)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+		if m.Estimate != nil {
+			cp.Estimate, err = newDeliveryEstimate(m.Estimate)
+			if err != nil {
+				return nil, status.Error(codes.Internal, err.Error())
+			}
+		}
+		c.Parcels = append(c.Parcels, cp)
+	}
+	if t.Latest != nil {
+		c.Latest, err = newEvent(t.Latest)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if t.Estimate != nil {
+		c.Estimate, err = newDeliveryEstimate(t.Estimate)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+
+	return c, nil
+}
+
+// FileClaim files a damage or loss claim for an insured parcel sent by the
+// user.
+func (s *Server) FileClaim(ctx context.Context, req *FileClaimRequest) (*Claim, error) {
+	u := user.MustFromContext(ctx)
+	pid, err := parcel.ParseTrackingID(req.ParcelId)
+	if err != nil {
+		return nil, ErrInvalidTrackingID
+	}
+	cardID, err := uuid.Parse(req.CardId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, claim.ErrForeignCard.Error())
+	}
+	kind, ok := claimKindNames[req.Kind]
+	if !ok {
+		return nil, status.Error(codes.InvalidArgument, claim.ErrUnknownKind.Error())
+	}
+	cr := &claim.Request{
+		ParcelID:    pid,
+		Kind:        kind,
+		Description: req.Description,
+		Amount:      parcel.Cents(req.Amount),
+		CardID:      cardID,
+		Evidence:    make([]*claim.Upload, 0, len(req.Evidence)),
+	}
+	for _, f := range req.Evidence {
+		cr.Evidence = append(cr.Evidence, &claim.Upload{Name: f.Name, Data: f.Data})
+	}
+
+	c, err := s.claimService.File(u, cr)
+	if err != nil {
+		return nil, claimError(err)
+	}
+
+	return newClaim(c)
+}
+
+// GetClaims returns the claims filed by the user.
+func (s *Server) GetClaims(ctx context.Context, req *empty.Empty) (*ClaimList, error) {
+	cc, err := s.claimService.Filed(user.MustFromContext(ctx))
+	if err != nil {
+		return nil, claimError(err)
+	}
+
+	return newClaims(cc)
+}
+
+// GetPendingClaims returns the claims under review, starting with the
+// oldest one. It requires the user to be an operator.
+func (s *Server) GetPendingClaims(ctx context.Context, req *empty.Empty) (*ClaimList, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	cc, err := s.claimService.Pending(u)
+	if err != nil {
+		return nil, claimError(err)
+	}
+
+	return newClaims(cc)
+}
+
+// ReviewClaim approves or rejects a claim. Approved claims are refunded to
+// the claimant's credit card. It requires the user to be an operator.
+func (s *Server) ReviewClaim(ctx context.Context, req *ReviewClaimRequest) (*Claim, error) {
+	u := user.MustFromContext(ctx)
+	if !u.Operator {
+		return nil, ErrOperatorRequired
+	}
+	id, err := uuid.Parse(req.ClaimId)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, claim.ErrClaimNotFound.Error())
+	}
+	c, err := s.claimService.ByID(u, id)
+	if err != nil {
+		return nil, claimError(err)
+	}
+	err = s.claimService.Review(u, c, &claim.Review{
+		Approve: req.Approve,
+		Amount:  parcel.Cents(req.Amount),
+		Note:    req.Note,
+	})
+	if err != nil {
+		return nil, claimError(err)
+	}
+
+	return newClaim(c)
+}
+
+func claimError(err error) error {
+	switch {
+	case err == claim.ErrNotSender || err == claim.ErrNotClaimant || err == claim.ErrNotOperator:
+		return status.Error(codes.PermissionDenied, err.Error())
+	case err == claim.ErrClaimNotFound || err == claim.ErrParcelNotFound || err == claim.ErrEvidenceNotFound:
+		return status.Error(codes.NotFound, err.Error())
+	case err == claim.ErrAlreadyClaimed:
+		return status.Error(codes.AlreadyExists, err.Error())
+	case err == claim.ErrAlreadyDecided:
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case claim.IsInvalid(err):
+		return status.Error(codes.InvalidArgument, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
+
+// claimKinds and claimStatuses map the kinds and statuses of the claim
+// package to their protobuf representation and claimKindNames maps the
+// kinds back.
+var (
+	claimKinds = map[claim.Kind]ClaimKind{
+		claim.Damage: ClaimKind_DAMAGE,
+		claim.Loss:   ClaimKind_LOSS,
+	}
+	claimKindNames = map[ClaimKind]claim.Kind{
+		ClaimKind_DAMAGE: claim.Damage,
+		ClaimKind_LOSS:   claim.Loss,
+	}
+	claimStatuses = map[claim.Status]ClaimStatus{
+		claim.Submitted: ClaimStatus_SUBMITTED,
+		claim.Approved:  ClaimStatus_APPROVED,
+		claim.Rejected:  ClaimStatus_REJECTED,
+	}
+)
+
+func newClaims(cc []*claim.Claim) (*ClaimList, error) {
+	pc := &ClaimList{Claims: make([]*Claim, 0, len(cc))}
+	for _, c := range cc {
+		p, err := newClaim(c)
+		if err != nil {
+			return nil, err
+		}
+		pc.Claims = append(pc.Claims, p)
+	}
+
+	return pc, nil
+}
+
+func newClaim(c *claim.Claim) (*Claim, error) {
+	created, err := ptypes.TimestampProto(c.Created)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	pc := &Claim{
+		Id:          c.ID.String(),
+		ParcelId:    c.ParcelID.String(),
+		Kind:        claimKinds[c.Kind],
+		Description: c.Description,
+		Amount:      int64(c.Amount),
+		Status:      claimStatuses[c.Status],
+		Created:     created,
+		Note:        c.Note,
+		Evidence:    make([]*Evidence, 0, len(c.Evidence)),
+	}
+	if c.CardID != nil {
+		pc.CardId = c.CardID.String()
+	}
+	if c.Decided != nil {
+		pc.Decided, err = ptypes.TimestampProto(*c.Decided)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	if r := c.Refund; r != nil {
+		pc.Refund = &Refund{Id: r.ID.String(), Amount: int64(r.Amount)}
+		if r.CardID != nil {
+			pc.Refund.CardId = r.CardID.String()
+		}
+		pc.Refund.Created, err = ptypes.TimestampProto(r.Created)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+	}
+	for _, e := range c.Evidence {
+		uploaded, err := ptypes.TimestampProto(e.Uploaded)
+		if err != nil {
+			return nil, status.Error(codes.Internal, err.Error())
+		}
+		pc.Evidence = append(pc.Evidence, &Evidence{
+			Id:          e.ID.String(),
+			Name:        e.Name,
+			ContentType: e.ContentType,
+			Uploaded:    uploaded,
+		})
+	}
+
+	return pc, nil
+}
+
+var (
+	ErrInvalidInvoiceID = status.Error(codes.InvalidArgument, "the invoice id is invalid")
+	ErrInvoiceNotFound  = status.Error(codes.NotFound, "an invoice with that id does not exist")
+)
+
+// GetInvoices returns the invoices paid by the user.
+func (s *Server) GetInvoices(ctx context.Context, req *empty.Empty) (*Invoices, error) {
+	ii, err := s.paymentService.Paid(user.MustFromContext(ctx))
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pi := &Invoices{Invoices: make([]*Invoice, 0, len(ii))}
+	for _, inv := range ii {
+		p, err := newInvoice(inv)
+		if err != nil {
+			return nil, err
+		}
+		pi.Invoices = append(pi.Invoices, p)
+	}
+
+	return pi, nil
+}
+
+// GetInvoiceDocument returns one of the user's invoices as a PDF document.
+func (s *Server) GetInvoiceDocument(ctx context.Context, req *GetInvoiceDocumentRequest) (*Label, error) {
+	id, err := uuid.Parse(req.InvoiceId)
+	if err != nil {
+		return nil, ErrInvalidInvoiceID
+	}
+	inv, err := s.paymentService.ByID(user.MustFromContext(ctx), id)
+	if err == payment.ErrInvoiceNotFound {
+		return nil, ErrInvoiceNotFound
+	} else if err == payment.ErrNotPayer {
+		return nil, status.Error(codes.PermissionDenied, err.Error())
+	} else if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+	p, err := s.parcelStorage.ByID(inv.ParcelID)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+
+	return &Label{Data: label.Invoice(inv, p), ContentType: "application/pdf"}, nil
+}
+
+func newInvoice(inv *payment.Invoice) (*Invoice, error) {
+	created, err := ptypes.TimestampProto(inv.Created)
+	if err != nil {
+		return nil, status.Error(codes.Internal, err.Error())
+	}
+
+	pi := &Invoice{
+		Id:         inv.ID.String(),
+		Number:     inv.Number,
+		ParcelId:   inv.ParcelID.String(),
+		Card:       inv.Card,
+		Lines:      make([]*QuoteLine, 0, len(inv.Lines)),
+		Net:        int64(inv.Net),
+		TaxName:    inv.TaxName,
+		TaxPercent: inv.TaxPercent,
+		Tax:        int64(inv.Tax),
+		Total:      int64(inv.Total),
+		Currency:   inv.Currency,
+		Created:    created,
+	}
+	if inv.CardID != nil {
+		pi.CardId = inv.CardID.String()
+	}
+	for _, l := range inv.Lines {
+		pi.Lines = append(pi.Lines, &QuoteLine{Description: l.Description, Amount: int64(l.Amount)})
+	}
+
+	return pi, nil
+}
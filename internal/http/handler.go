@@ -2,22 +2,39 @@ package http
 
 import (
 	"encoding/gob"
+	"errors"
 	"fmt"
 	"html/template"
+	"io"
+	"io/ioutil"
 	"log"
 	"net/http"
 	"net/mail"
 	"strconv"
+	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"github.com/gorilla/sessions"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/session"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/feedback"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/label"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/webhook"
 )
 
 func init() {
@@ -441,14 +458,18 @@ func (h *addAddressHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 type findParcelHandler struct {
 	Storage parcel.Storage
+	// Consignments is used to look up master tracking numbers, which
+	// identify consignments instead of parcels.
+	Consignments consignment.Storage
 }
 
 func (h *findParcelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	sess := session.MustFromContext(r.Context())
 	err := r.ParseForm()
 	if err != nil {
-		sess.AddFlash(err.Error())
+		sess.AddFlash(err.Error(), "errors")
 		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
 	}
 	ids, ok := r.PostForm["tracking-id"]
 	if !ok || len(ids) < 1 {
@@ -457,38 +478,111 @@ func (h *findParcelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := uuid.Parse(ids[0])
-	if err != nil {
+	id, err := parcel.ParseTrackingID(ids[0])
+	if err == parcel.ErrTrackingCodeTypo {
+		pp, err := parcel.SuggestTrackingCodes(ids[0], h.Storage)
+		if err != nil {
+			log.Println(err)
+		}
+		if len(pp) > 0 {
+			cc := make([]string, 0, len(pp))
+			for _, p := range pp {
+				cc = append(cc, p.TrackingCode())
+			}
+			sess.AddFlash(fmt.Sprintf("The tracking number you provided contains a typo."+
+				" Did you mean %s?", strings.Join(cc, " or ")), "errors")
+		} else {
+			sess.AddFlash("The tracking number you provided contains a typo", "errors")
+		}
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	} else if err != nil {
 		sess.AddFlash("The tracking number you provided is invalid", "errors")
 		http.Redirect(w, r, "/tracking", http.StatusFound)
 		return
 	}
 	p, err := h.Storage.ByID(id)
 	if err != nil {
+		log.Println(err)
 		sess.AddFlash("An internal server error occured, please try again later", "errors")
 		http.Redirect(w, r, "/tracking", http.StatusFound)
 		return
 	} else if p == nil {
-		sess.AddFlash("A parcel with that tracking number does not exist in our database."+
-			" Please make sure that the tracking number you entered is correct or"+
-			" contact the sender.", "warnings")
-		http.Redirect(w, r, "/tracking", http.StatusFound)
-		return
+		c, err := h.Consignments.ByID(id)
+		if err != nil {
+			log.Println(err)
+			sess.AddFlash("An internal server error occured, please try again later", "errors")
+			http.Redirect(w, r, "/tracking", http.StatusFound)
+			return
+		} else if c == nil {
+			sess.AddFlash("A parcel with that tracking number does not exist in our database."+
+				" Please make sure that the tracking number you entered is correct or"+
+				" contact the sender.", "warnings")
+			http.Redirect(w, r, "/tracking", http.StatusFound)
+			return
+		}
 	}
 
 	http.Redirect(w, r, fmt.Sprintf("/tracking/%s", id.String()), http.StatusFound)
 }
 
 type trackingHandler struct {
-	templates     *template.Template
-	eventStorage  parcel.EventAccesser
-	parcelStorage parcel.Accesser
+	templates      *template.Template
+	addressStorage address.Accesser
+	eventStorage   parcel.EventAccesser
+	parcelStorage  parcel.Accesser
+	proofStorage   parcel.ProofAccesser
+	lockerService  *locker.Service
+	// consignmentService tracks the consignments, whose master tracking
+	// numbers are accepted instead of the parcels' ones.
+	consignmentService *consignment.Service
+	estimator          *eta.Estimator
+	maxAttempts        int
 }
 
 type trackingPage struct {
 	*Page
-	Parcel *parcel.Parcel
-	Events []*parcel.Event
+	Parcel   *parcel.Parcel
+	Events   []*parcel.Event
+	Estimate *eta.Estimate
+	// Proof is the proof of delivery, which is only set, if the user is
+	// the parcel's sender or recipient.
+	Proof *parcel.Proof
+	// Customs is the parcel's customs declaration, which is only set, if
+	// the user is the parcel's sender or an operator.
+	Customs *parcel.CustomsDeclaration
+	// Allocation is the compartment of the pickup point, in which the
+	// parcel awaits collection. It is only set, if the user is the
+	// parcel's recipient.
+	Allocation *locker.Allocation
+	// Failure is only set, if the parcel's latest delivery attempt has
+	// failed and it has not been delivered since.
+	Failure *failureNotice
+	// ConsignmentCode is the master tracking number of the consignment, to
+	// which the parcel belongs, or empty.
+	ConsignmentCode string
+}
+
+// consignmentPage shows the consolidated tracking information of a
+// consignment.
+type consignmentPage struct {
+	*Page
+	Tracking *consignment.Tracking
+}
+
+// failureNotice informs about the failed attempts to deliver a parcel.
+type failureNotice struct {
+	// Event is the latest DeliveryFailed event.
+	Event       *parcel.Event
+	Attempts    int
+	MaxAttempts int
+	Returning   bool
+	// DeliveryDate is the day chosen by the recipient for the next
+	// delivery attempt or the zero time.
+	DeliveryDate time.Time
+	// Recipient is true, if the user is the parcel's recipient, who may
+	// choose a new delivery date or pickup point.
+	Recipient bool
 }
 
 func (h *trackingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -501,7 +595,7 @@ func (h *trackingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	id, err := uuid.Parse(idStr)
+	id, err := parcel.ParseTrackingID(idStr)
 	if err != nil {
 		sess.AddFlash("The tracking number you supplied is invalid", "errors")
 		http.Redirect(w, r, "/tracking", http.StatusFound)
@@ -515,10 +609,7 @@ func (h *trackingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		http.Redirect(w, r, "/tracking", http.StatusFound)
 		return
 	} else if p == nil {
-		sess.AddFlash("A parcel with that tracking number does not exist in our database."+
-			" Please make sure that the tracking number you entered is correct or"+
-			" contact the sender.", "warnings")
-		http.Redirect(w, r, "/tracking", http.StatusFound)
+		h.serveConsignment(w, r, id)
 		return
 	}
 
@@ -531,12 +622,1463 @@ func (h *trackingHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	err = h.templates.ExecuteTemplate(w, "parcel_events.html", &trackingPage{
-		Page:   NewPage("Tracking Information", r),
-		Parcel: p,
-		Events: ee,
+	pr, err := h.proof(r, p)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	u, _ := user.FromContext(r.Context())
+	customs, err := parcel.CustomsVisible(u, p, h.addressStorage)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	a, err := h.allocation(r, p)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	f, err := h.failure(r, p, ee)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	c, err := h.consignmentService.ByParcel(p)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	tp := &trackingPage{
+		Page:       NewPage("Tracking Information", r),
+		Parcel:     p,
+		Events:     ee,
+		Estimate:   h.estimator.Estimate(p, ee, time.Now()),
+		Proof:      pr,
+		Allocation: a,
+		Failure:    f,
+	}
+	if customs {
+		tp.Customs = p.Customs
+	}
+	if c != nil {
+		tp.ConsignmentCode = consignment.TrackingCode(c, p)
+	}
+	err = h.templates.ExecuteTemplate(w, "parcel_events.html", tp)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// serveConsignment shows the consolidated tracking information of the
+// consignment identified by id, which does not identify any parcel.
+func (h *trackingHandler) serveConsignment(w http.ResponseWriter, r *http.Request, id uuid.UUID) {
+	sess := session.MustFromContext(r.Context())
+	t, err := h.consignmentService.Track(id)
+	if err == consignment.ErrConsignmentNotFound {
+		sess.AddFlash("A parcel with that tracking number does not exist in our database."+
+			" Please make sure that the tracking number you entered is correct or"+
+			" contact the sender.", "warnings")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later",
+			"errors")
+		http.Redirect(w, r, "/tracking", http.StatusFound)
+		return
+	}
+
+	err = h.templates.ExecuteTemplate(w, "consignment.html", &consignmentPage{
+		Page:     NewPage("Consignment Tracking", r),
+		Tracking: t,
 	})
 	if err != nil {
 		log.Println(err)
 	}
 }
+
+// proof returns the proof of p's delivery, if the logged in user may view
+// it, and nil otherwise.
+func (h *trackingHandler) proof(r *http.Request, p *parcel.Parcel) (*parcel.Proof, error) {
+	u, _ := user.FromContext(r.Context())
+	ok, err := parcel.ProofVisible(u, p, h.addressStorage)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	return h.proofStorage.ProofByParcel(p)
+}
+
+// allocation returns the compartment, in which p awaits collection at its
+// pickup point, if the logged in user is p's recipient, and nil otherwise.
+func (h *trackingHandler) allocation(r *http.Request, p *parcel.Parcel) (*locker.Allocation, error) {
+	u, ok := user.FromContext(r.Context())
+	if !ok || p.PickupPointID == nil {
+		return nil, nil
+	}
+	a, err := h.lockerService.Allocation(u, p)
+	if err == locker.ErrNotRecipient || err == locker.ErrNotDeposited {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
+
+// failure returns the notice about the failed attempts to deliver p, if
+// its latest delivery attempt has failed and it has neither been delivered
+// nor returned since, and nil otherwise.
+func (h *trackingHandler) failure(r *http.Request, p *parcel.Parcel, ee []*parcel.Event) (*failureNotice, error) {
+	st := parcel.NewState(p, ee)
+	if st.LastFailure == nil || len(st.Next()) == 0 || !st.Redirectable() && !st.Returning {
+		return nil, nil
+	}
+	f := &failureNotice{
+		Event:        st.LastFailure,
+		Attempts:     st.FailedAttempts,
+		MaxAttempts:  h.maxAttempts,
+		Returning:    st.Returning,
+		DeliveryDate: st.DeliveryDate,
+	}
+	if f.MaxAttempts <= 0 {
+		f.MaxAttempts = parcel.DefaultMaxDeliveryAttempts
+	}
+	if u, ok := user.FromContext(r.Context()); ok {
+		f.Recipient = p.SentTo(u)
+	}
+
+	return f, nil
+}
+
+type proofImageStorage interface {
+	parcel.Accesser
+	parcel.ProofAccesser
+}
+
+// proofImageHandler sends the signature or, if Photo is set, the photo
+// captured as proof of delivery of the parcel identified by the id route
+// variable. Only the parcel's sender and recipient may view them, to
+// everyone else they do not exist.
+type proofImageHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  proofImageStorage
+	Blobs          blob.Store
+	Photo          bool
+}
+
+func (h *proofImageHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		http.NotFound(w, r)
+		return
+	}
+	u, _ := user.FromContext(r.Context())
+	ok, err := parcel.ProofVisible(u, p, h.AddressStorage)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if !ok {
+		http.NotFound(w, r)
+		return
+	}
+	pr, err := h.ParcelStorage.ProofByParcel(p)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if pr == nil {
+		http.NotFound(w, r)
+		return
+	}
+	key := pr.Signature
+	if h.Photo {
+		key = pr.Photo
+	}
+	if key == "" {
+		http.NotFound(w, r)
+		return
+	}
+
+	rc, err := h.Blobs.Get(key)
+	if err == blob.ErrNotFound {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+	b, err := ioutil.ReadAll(rc)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", http.DetectContentType(b))
+	w.Header().Set("Cache-Control", "private")
+	_, err = w.Write(b)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type sendParcelPage struct {
+	*Page
+	Addresses []*address.Address
+	// PickupPoints are the shops and parcel lockers, to which the parcel
+	// may be sent instead of a destination address.
+	PickupPoints []*locker.Point
+	// Cards are the credit cards, which may be charged for the parcel.
+	Cards []*credit.Card
+	// Quote is the ID of the quote, which is accepted by default.
+	Quote             string
+	Hazards           []string
+	CustomsCategories []string
+	// CustomsRows contains an element for every row of customs items
+	// shown in the form.
+	CustomsRows []int
+}
+
+// customsFormRows is the number of rows of customs items shown in the form
+// for sending parcels.
+const customsFormRows = 5
+
+type sendParcelFormHandler struct {
+	Templates      *template.Template
+	AddressStorage address.Storage
+	PointStorage   locker.Storage
+	CreditStorage  credit.Accesser
+}
+
+func (h *sendParcelFormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	aa, err := h.AddressStorage.ByUser(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	pp, err := h.PointStorage.Points("", "")
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	cards, err := h.CreditStorage.ByUser(u)
+	if err != nil && err != credit.ErrNoCards {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &sendParcelPage{
+		Page:              NewPage("Send a Parcel", r),
+		Addresses:         aa,
+		PickupPoints:      pp,
+		Cards:             cards,
+		Quote:             r.FormValue("quote"),
+		Hazards:           parcel.HazardList(),
+		CustomsCategories: parcel.CustomsCategoryList(),
+		CustomsRows:       make([]int, customsFormRows),
+	}
+	err = h.Templates.ExecuteTemplate(w, "send_parcel.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type sendParcelHandler struct {
+	AddressStorage address.Storage
+	UserStorage    user.Accesser
+	ParcelStorage  parcel.Storage
+	QuoteAccepter  parcel.QuoteAccepter
+	LockerService  *locker.Service
+	PaymentService *payment.Service
+}
+
+func (h *sendParcelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	req, err := parcel.ParseSendForm(r, u)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	}
+	err = h.LockerService.Resolve(req)
+	if err == locker.ErrPointNotFound {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later", "errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	}
+
+	p, inv, err := h.PaymentService.Send(req, h.AddressStorage, h.UserStorage, h.QuoteAccepter,
+		h.ParcelStorage)
+	if parcel.IsInvalid(err) || pricing.IsRejection(err) || err == payment.ErrForeignCard {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	} else if err == payment.ErrDeclined {
+		// Nothing is wrong with the form, but the card cannot be charged.
+		sess.AddFlash("Your credit card has been declined. Please choose another one or pay at the shop.",
+			"errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later", "errors")
+		http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+		return
+	}
+
+	sess.AddFlash(fmt.Sprintf("Your parcel has been registered! Its tracking number is %s.",
+		p.TrackingCode()), "success")
+	if inv != nil {
+		sess.AddFlash(fmt.Sprintf("%s %s have been charged to your credit card ending in %s.",
+			inv.Total, inv.Currency, inv.Card), "success")
+	}
+	http.Redirect(w, r, "/profile/send-parcel", http.StatusFound)
+}
+
+// deliveryLogSize is the number of recent webhook deliveries shown to users.
+const deliveryLogSize = 50
+
+type webhookPage struct {
+	*Page
+	Webhooks   []*webhook.Subscription
+	Deliveries []*webhook.Delivery
+	EventTypes []parcel.EventType
+}
+
+type webhookHandler struct {
+	Templates       *template.Template
+	Storage         webhook.Storage
+	DeliveryStorage webhook.DeliveryStorage
+}
+
+func (h *webhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	ww, err := h.Storage.ByUser(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	dd, err := h.DeliveryStorage.ByUser(u, deliveryLogSize)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &webhookPage{
+		Page:       NewPage("Webhooks", r),
+		Webhooks:   ww,
+		Deliveries: dd,
+		EventTypes: parcel.EventTypes(),
+	}
+	err = h.Templates.ExecuteTemplate(w, "webhooks.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type addWebhookHandler struct {
+	Storage webhook.Storage
+}
+
+func (h *addWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	s, err := webhook.NewFromForm(r, u)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+		return
+	}
+
+	err = h.Storage.Insert(s)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later", "errors")
+		http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+		return
+	}
+
+	sess.AddFlash("Your webhook has been added successfully!", "success")
+	http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+}
+
+type deleteWebhookHandler struct {
+	Storage webhook.Storage
+}
+
+func (h *deleteWebhookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := uuid.Parse(r.PostFormValue("id"))
+	if err != nil {
+		sess.AddFlash("The webhook is invalid", "errors")
+		http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+		return
+	}
+
+	err = h.Storage.Delete(&webhook.Subscription{ID: id, User: u})
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later", "errors")
+		http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+		return
+	}
+
+	sess.AddFlash("Your webhook has been deleted.", "success")
+	http.Redirect(w, r, "/profile/webhooks", http.StatusFound)
+}
+
+type quotePage struct {
+	*Page
+	Planets []*orbit.Planet
+	Quote   *pricing.Quote
+}
+
+type quoteFormHandler struct {
+	Templates *template.Template
+}
+
+func (h *quoteFormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := &quotePage{
+		Page:    NewPage("Shipping Rates", r),
+		Planets: orbit.Planets(),
+	}
+	err := h.Templates.ExecuteTemplate(w, "quote.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type quoteHandler struct {
+	Templates *template.Template
+	Pricing   *pricing.Service
+}
+
+func (h *quoteHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	p := &quotePage{
+		Page:    NewPage("Shipping Rates", r),
+		Planets: orbit.Planets(),
+	}
+	req, err := pricing.ParseQuoteForm(r)
+	if err == nil {
+		// Quotes requested anonymously may be accepted by any customer.
+		u, _ := user.FromContext(r.Context())
+		p.Quote, err = h.Pricing.Quote(req, u)
+	}
+	if err != nil && req != nil && !pricing.IsInvalid(err) {
+		log.Println(err)
+		p.Errors = append(p.Errors, "An internal server error occurred, please try again later")
+	} else if err != nil {
+		p.Errors = append(p.Errors, err.Error())
+	}
+
+	err = h.Templates.ExecuteTemplate(w, "quote.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type labelHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Accesser
+}
+
+// ServeHTTP sends the label of the parcel identified by the id query
+// parameter in the format given by the format parameter. Customers may only
+// download the labels of the parcels they have sent, while operators may
+// download all labels.
+func (h *labelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	f, err := label.ParseFormat(r.FormValue("format"))
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	id, err := parcel.ParseTrackingID(r.FormValue("id"))
+	if err != nil {
+		sess.AddFlash("The tracking number you provided is invalid", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	sent := false
+	if p != nil && !u.Operator {
+		sent, err = p.SentBy(u, h.AddressStorage)
+		if err != nil {
+			log.Println(err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+	if p == nil || !(u.Operator || sent) {
+		sess.AddFlash("You have not sent a parcel with that tracking number", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+
+	l, err := label.New(p)
+	if err == label.ErrAddressMissing {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	b, err := l.Render(f)
+	if err == label.ErrNoCustoms {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", f.ContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf("attachment; filename=\"label-%s%s\"", p.ID.String(), f.Extension()))
+	_, err = w.Write(b)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type returnParcelHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Accesser
+	EventStorage   parcel.EventStorage
+}
+
+// ServeHTTP initiates the return of the parcel identified by the id form
+// value to its sender. Only the parcel's sender and recipient may request
+// its return.
+func (h *returnParcelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(r.PostFormValue("id"))
+	if err != nil {
+		sess.AddFlash("The tracking number you provided is invalid", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		sess.AddFlash(parcel.ErrNotInvolved.Error(), "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+
+	_, err = parcel.RequestReturn(u, p, h.AddressStorage, h.EventStorage)
+	if err == parcel.ErrNotInvolved || err == parcel.ErrNotReturnable {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	sess.AddFlash("The parcel is being returned to its sender.", "success")
+	http.Redirect(w, r, "/profile", http.StatusFound)
+}
+
+type deliveryOptionsPage struct {
+	*Page
+	Addresses    []*address.Address
+	PickupPoints []*locker.Point
+	// ID is the tracking number filled into the forms, e.g. when the
+	// recipient follows the link on the tracking page.
+	ID string
+	// MinDate and MaxDate bound the new delivery date.
+	MinDate, MaxDate time.Time
+}
+
+type deliveryOptionsHandler struct {
+	Templates    *template.Template
+	Storage      address.Storage
+	PointStorage locker.Storage
+}
+
+func (h *deliveryOptionsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	aa, err := h.Storage.ByUser(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	pp, err := h.PointStorage.Points("", "")
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	now := time.Now()
+	p := &deliveryOptionsPage{
+		Page:         NewPage("Delivery Options", r),
+		Addresses:    aa,
+		PickupPoints: pp,
+		ID:           r.FormValue("id"),
+		MinDate:      now.AddDate(0, 0, 1),
+		MaxDate:      now.AddDate(0, 0, parcel.MaxRescheduleDays),
+	}
+	err = h.Templates.ExecuteTemplate(w, "delivery_options.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type redirectParcelHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Storage
+	EventStorage   parcel.EventStorage
+	LockerService  *locker.Service
+	// Hold is true, if the parcel is to be held for collection instead of
+	// being redirected.
+	Hold bool
+}
+
+// ServeHTTP redirects the parcel identified by the id form value to the
+// recipient's address identified by the address form value or the pickup
+// point identified by the pickup-point form value, or has it held for
+// collection.
+func (h *redirectParcelHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(r.PostFormValue("id"))
+	if err != nil {
+		sess.AddFlash("The tracking number you provided is invalid", "errors")
+		http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		sess.AddFlash(parcel.ErrNotRecipient.Error(), "errors")
+		http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+		return
+	}
+
+	msg := "The parcel is going to be held for collection."
+	if h.Hold {
+		_, err = parcel.Hold(u, p, h.EventStorage)
+	} else if point := r.PostFormValue("pickup-point"); point != "" {
+		msg = "The parcel has been redirected to the pickup point."
+		var to uuid.UUID
+		to, err = uuid.Parse(point)
+		if err != nil {
+			err = locker.ErrPointNotFound
+		} else {
+			_, err = h.LockerService.Redirect(u, p, to)
+		}
+	} else {
+		msg = "The parcel has been redirected."
+		var to uuid.UUID
+		to, err = uuid.Parse(r.PostFormValue("address"))
+		if err != nil {
+			err = parcel.ErrInvalidAddressID
+		} else {
+			_, err = parcel.Redirect(u, p, to, h.AddressStorage, h.ParcelStorage, h.EventStorage)
+		}
+	}
+	switch err {
+	case nil:
+		sess.AddFlash(msg, "success")
+	case parcel.ErrInvalidAddressID, parcel.ErrNotRecipient, parcel.ErrNotRedirectable, parcel.ErrAlreadyHeld,
+		parcel.ErrInvalidRecipient, parcel.ErrSameDestination, parcel.ErrRedirectPlanet, locker.ErrPointNotFound:
+		sess.AddFlash(err.Error(), "errors")
+	default:
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+}
+
+type rescheduleHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Storage
+	EventStorage   parcel.EventStorage
+}
+
+// ServeHTTP postpones the next attempt to deliver the parcel identified by
+// the id form value to the day given by the date form value.
+func (h *rescheduleHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(r.PostFormValue("id"))
+	if err != nil {
+		sess.AddFlash("The tracking number you provided is invalid", "errors")
+		http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+		return
+	}
+	date, err := time.ParseInLocation("2006-01-02", r.PostFormValue("date"), time.Local)
+	if err != nil {
+		sess.AddFlash(parcel.ErrInvalidDeliveryDate.Error(), "errors")
+		http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		sess.AddFlash(parcel.ErrNotRecipient.Error(), "errors")
+		http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+		return
+	}
+
+	_, err = parcel.Reschedule(u, p, date, h.EventStorage)
+	switch err {
+	case nil:
+		sess.AddFlash("The parcel is going to be delivered on "+date.Format("Jan _2, 2006")+".", "success")
+	case parcel.ErrNotRecipient, parcel.ErrNotReschedulable, parcel.ErrInvalidDeliveryDate:
+		sess.AddFlash(err.Error(), "errors")
+	default:
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/delivery-options", http.StatusFound)
+}
+
+type parcelsPage struct {
+	*Page
+	Parcels []*parcel.Summary
+	// Outgoing is true for the list of parcels sent by the user and false
+	// for the list of parcels sent to the user.
+	Outgoing bool
+}
+
+type parcelsHandler struct {
+	Templates      *template.Template
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Accesser
+	EventStorage   parcel.EventAccesser
+	Outgoing       bool
+}
+
+// ServeHTTP lists the parcels sent to the user or from any of the user's
+// addresses together with their latest status.
+func (h *parcelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	title := "Incoming Parcels"
+	var ss []*parcel.Summary
+	var err error
+	if h.Outgoing {
+		title = "Outgoing Parcels"
+		ss, err = parcel.Outgoing(u, h.AddressStorage, h.ParcelStorage, h.EventStorage)
+	} else {
+		ss, err = parcel.Incoming(u, h.ParcelStorage, h.EventStorage)
+	}
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &parcelsPage{
+		Page:     NewPage(title, r),
+		Parcels:  ss,
+		Outgoing: h.Outgoing,
+	}
+	err = h.Templates.ExecuteTemplate(w, "parcels.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type importParcelsPage struct {
+	*Page
+	Addresses []*address.Address
+	Columns   []string
+}
+
+type importParcelsFormHandler struct {
+	Templates *template.Template
+	Storage   address.Accesser
+}
+
+func (h *importParcelsFormHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	aa, err := h.Storage.ByUser(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &importParcelsPage{
+		Page:      NewPage("Import Parcels", r),
+		Addresses: aa,
+		Columns:   parcel.ImportColumns(),
+	}
+	err = h.Templates.ExecuteTemplate(w, "import_parcels.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type importParcelsHandler struct {
+	AddressStorage address.Accesser
+	ParcelStorage  parcel.Importer
+}
+
+// ServeHTTP imports the parcels of the uploaded file and sends the results
+// as a download, which lists the tracking numbers of the new parcels and
+// the errors of the rejected ones.
+func (h *importParcelsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	req, err := parcel.ParseImportForm(r, u)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/import-parcels", http.StatusFound)
+		return
+	}
+	rr, err := parcel.Import(req, h.AddressStorage, h.ParcelStorage)
+	if err != nil {
+		log.Println(err)
+		sess.AddFlash("An internal server error occurred, please try again later", "errors")
+		http.Redirect(w, r, "/profile/import-parcels", http.StatusFound)
+		return
+	}
+
+	w.Header().Set("Content-Type", req.Format.ContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="import-results.%s"`, req.Format.Extension()))
+	err = parcel.WriteImportResults(w, req.Format, rr)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type pickupsPage struct {
+	*Page
+	Options []*pickup.Option
+}
+
+type pickupsHandler struct {
+	Templates *template.Template
+	Service   *pickup.Service
+}
+
+// ServeHTTP lists the user's parcels, which may still be picked up, with
+// their booked pickups or the time slots available to them.
+func (h *pickupsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	oo, err := h.Service.Options(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &pickupsPage{
+		Page:    NewPage("Pickups", r),
+		Options: oo,
+	}
+	err = h.Templates.ExecuteTemplate(w, "pickups.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type bookPickupHandler struct {
+	ParcelStorage parcel.Accesser
+	Service       *pickup.Service
+	// Cancel is true, if the booked pickup is to be cancelled instead.
+	Cancel bool
+}
+
+// ServeHTTP books the pickup of the parcel identified by the id form value
+// in the time slot identified by the slot form value, or cancels the
+// parcel's booked pickup.
+func (h *bookPickupHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(r.PostFormValue("id"))
+	if err != nil {
+		sess.AddFlash("The tracking number you provided is invalid", "errors")
+		http.Redirect(w, r, "/profile/pickups", http.StatusFound)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(id)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		sess.AddFlash(pickup.ErrNotSender.Error(), "errors")
+		http.Redirect(w, r, "/profile/pickups", http.StatusFound)
+		return
+	}
+
+	msg := "The pickup has been cancelled."
+	if h.Cancel {
+		_, err = h.Service.Cancel(u, p)
+	} else {
+		msg = "The pickup has been booked."
+		err = h.book(u, p, r.PostFormValue("slot"))
+	}
+	switch err {
+	case nil:
+		sess.AddFlash(msg, "success")
+	case errInvalidSlot, pickup.ErrNotSender, pickup.ErrNotAwaitingPickup, pickup.ErrAlreadyBooked,
+		pickup.ErrSlotFull, pickup.ErrSlotInPast, pickup.ErrWrongCity, pickup.ErrNotBooked,
+		pickup.ErrCancellationClosed:
+		sess.AddFlash(err.Error(), "errors")
+	default:
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/pickups", http.StatusFound)
+}
+
+var errInvalidSlot = errors.New("the time slot you selected does not exist")
+
+func (h *bookPickupHandler) book(u *user.User, p *parcel.Parcel, slot string) error {
+	id, err := uuid.Parse(slot)
+	if err != nil {
+		return errInvalidSlot
+	}
+	sl, err := h.Service.Pickups.SlotByID(id)
+	if err != nil {
+		return err
+	} else if sl == nil {
+		return errInvalidSlot
+	}
+	_, err = h.Service.Book(u, p, sl)
+
+	return err
+}
+
+type centersPage struct {
+	*Page
+	Centers []*center.Center
+	// Dashboard is the dashboard of the selected center or nil.
+	Dashboard *center.Dashboard
+}
+
+type centersHandler struct {
+	Templates *template.Template
+	Service   *center.Service
+}
+
+// ServeHTTP lists the logistics centers and the parcels being processed at
+// the center identified by the id form value. Only operators may view it.
+func (h *centersHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	if !u.Operator {
+		sess.AddFlash("Only operators may view the logistics centers", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	cc, err := h.Service.Centers.Centers()
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &centersPage{
+		Page:    NewPage("Logistics Centers", r),
+		Centers: cc,
+	}
+	if idStr := r.FormValue("id"); idStr != "" {
+		id, err := uuid.Parse(idStr)
+		if err != nil {
+			sess.AddFlash("The logistics center id is invalid", "errors")
+			http.Redirect(w, r, "/profile/centers", http.StatusFound)
+			return
+		}
+		p.Dashboard, err = h.Service.Dashboard(id)
+		if err == center.ErrCenterNotFound {
+			sess.AddFlash(err.Error(), "errors")
+			http.Redirect(w, r, "/profile/centers", http.StatusFound)
+			return
+		} else if err != nil {
+			log.Println(err)
+			http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+			return
+		}
+	}
+	err = h.Templates.ExecuteTemplate(w, "centers.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type consignmentsPage struct {
+	*Page
+	Consignments []*consignment.Tracking
+	// Consignable are the user's parcels, which may be grouped to a new
+	// consignment.
+	Consignable []*parcel.Parcel
+}
+
+type consignmentsHandler struct {
+	Templates *template.Template
+	Service   *consignment.Service
+}
+
+// ServeHTTP lists the user's consignments and the parcels, which may be
+// grouped to a new one.
+func (h *consignmentsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	tt, err := h.Service.Sent(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	pp, err := h.Service.Consignable(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &consignmentsPage{
+		Page:         NewPage("Consignments", r),
+		Consignments: tt,
+		Consignable:  pp,
+	}
+	err = h.Templates.ExecuteTemplate(w, "consignments.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type createConsignmentHandler struct {
+	Service *consignment.Service
+}
+
+// ServeHTTP groups the parcels identified by the parcel form values to a
+// new consignment with the optional reference form value.
+func (h *createConsignmentHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	err := r.ParseForm()
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/consignments", http.StatusFound)
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(r.PostForm["parcel"]))
+	for _, s := range r.PostForm["parcel"] {
+		id, err := parcel.ParseTrackingID(s)
+		if err != nil {
+			sess.AddFlash("The tracking number you provided is invalid", "errors")
+			http.Redirect(w, r, "/profile/consignments", http.StatusFound)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	t, err := h.Service.Create(u, ids, r.PostFormValue("reference"))
+	switch err {
+	case nil:
+		sess.AddFlash(fmt.Sprintf("Your consignment has been created. Its master tracking number is %s.",
+			t.TrackingCode), "success")
+	case consignment.ErrTooFewParcels, consignment.ErrTooManyParcels, consignment.ErrDuplicateParcel,
+		consignment.ErrReferenceTooLong, consignment.ErrDifferentAddresses, consignment.ErrParcelNotFound,
+		consignment.ErrNotSender, consignment.ErrAlreadyConsigned:
+		sess.AddFlash(err.Error(), "errors")
+	default:
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/consignments", http.StatusFound)
+}
+
+type claimsPage struct {
+	*Page
+	Claims []*claim.Claim
+	// Claimable are the user's insured parcels and Cards the credit cards,
+	// to which refunds may be paid.
+	Claimable []*parcel.Parcel
+	Cards     []*credit.Card
+}
+
+type claimsHandler struct {
+	Templates *template.Template
+	Service   *claim.Service
+}
+
+// ServeHTTP lists the user's claims and the form for filing a new one.
+func (h *claimsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	cc, err := h.Service.Filed(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	pp, err := h.Service.Claimable(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	cards, err := h.Service.Cards.ByUser(u)
+	if err != nil && err != credit.ErrNoCards {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &claimsPage{
+		Page:      NewPage("Claims", r),
+		Claims:    cc,
+		Claimable: pp,
+		Cards:     cards,
+	}
+	err = h.Templates.ExecuteTemplate(w, "claims.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type fileClaimHandler struct {
+	Service *claim.Service
+}
+
+// ServeHTTP files the claim given by the multipart form.
+func (h *fileClaimHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	req, err := claim.ParseClaimForm(r)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/claims", http.StatusFound)
+		return
+	}
+
+	_, err = h.Service.File(u, req)
+	if err == nil {
+		sess.AddFlash("Your claim has been filed. We will review it as soon as possible.", "success")
+	} else if !addClaimFlash(sess, err) {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/claims", http.StatusFound)
+}
+
+// addClaimFlash adds the error err returned by the claim service as a
+// flash and reports whether it is shown to the user.
+func addClaimFlash(sess *sessions.Session, err error) bool {
+	switch {
+	case claim.IsInvalid(err), err == claim.ErrParcelNotFound, err == claim.ErrClaimNotFound,
+		err == claim.ErrNotSender, err == claim.ErrNotClaimant, err == claim.ErrNotOperator,
+		err == claim.ErrAlreadyClaimed:
+		sess.AddFlash(err.Error(), "errors")
+		return true
+	default:
+		return false
+	}
+}
+
+type addEvidenceHandler struct {
+	Service *claim.Service
+}
+
+// ServeHTTP attaches the evidence files of the multipart form to the claim
+// identified by the claim form value.
+func (h *addEvidenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	uu, err := claim.ParseEvidenceForm(r)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/claims", http.StatusFound)
+		return
+	}
+	id, err := uuid.Parse(r.PostFormValue("claim"))
+	if err != nil {
+		sess.AddFlash(claim.ErrClaimNotFound.Error(), "errors")
+		http.Redirect(w, r, "/profile/claims", http.StatusFound)
+		return
+	}
+	c, err := h.Service.ByID(u, id)
+	for _, up := range uu {
+		if err != nil {
+			break
+		}
+		_, err = h.Service.AddEvidence(u, c, up)
+	}
+	if err == nil {
+		sess.AddFlash("The files have been attached to your claim.", "success")
+	} else if !addClaimFlash(sess, err) {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/claims", http.StatusFound)
+}
+
+type claimEvidenceHandler struct {
+	Service *claim.Service
+}
+
+// ServeHTTP sends the file identified by the id query parameter, which is
+// attached to the claim identified by the claim query parameter, to the
+// claimant or an operator.
+func (h *claimEvidenceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	claimID, err := uuid.Parse(r.FormValue("claim"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	id, err := uuid.Parse(r.FormValue("id"))
+	if err != nil {
+		http.NotFound(w, r)
+		return
+	}
+	c, err := h.Service.ByID(u, claimID)
+	if err == claim.ErrClaimNotFound || err == claim.ErrNotClaimant {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	e, rc, err := h.Service.Evidence(u, c, id)
+	if err == claim.ErrEvidenceNotFound {
+		http.NotFound(w, r)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", e.ContentType)
+	w.Header().Set("Cache-Control", "private")
+	_, err = io.Copy(w, rc)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type reviewClaimsPage struct {
+	*Page
+	Claims []*claim.Claim
+}
+
+type reviewClaimsHandler struct {
+	Templates *template.Template
+	Service   *claim.Service
+}
+
+// ServeHTTP lists the claims under review to operators.
+func (h *reviewClaimsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	if !u.Operator {
+		sess.AddFlash("Only operators may review claims", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	cc, err := h.Service.Pending(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &reviewClaimsPage{
+		Page:   NewPage("Review Claims", r),
+		Claims: cc,
+	}
+	err = h.Templates.ExecuteTemplate(w, "review_claims.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type reviewClaimHandler struct {
+	Service *claim.Service
+}
+
+// ServeHTTP approves or rejects the claim identified by the claim form
+// value according to the form values decision, amount and note.
+func (h *reviewClaimHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	if !u.Operator {
+		sess.AddFlash("Only operators may review claims", "errors")
+		http.Redirect(w, r, "/profile", http.StatusFound)
+		return
+	}
+	rv, err := claim.ParseReviewForm(r)
+	if err != nil {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/claims/review", http.StatusFound)
+		return
+	}
+	id, err := uuid.Parse(r.PostFormValue("claim"))
+	if err != nil {
+		sess.AddFlash(claim.ErrClaimNotFound.Error(), "errors")
+		http.Redirect(w, r, "/profile/claims/review", http.StatusFound)
+		return
+	}
+	c, err := h.Service.ByID(u, id)
+	if err == nil {
+		err = h.Service.Review(u, c, rv)
+	}
+	if err == nil {
+		sess.AddFlash(fmt.Sprintf("The claim has been %s.", c.Status), "success")
+	} else if !addClaimFlash(sess, err) {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, "/profile/claims/review", http.StatusFound)
+}
+
+type invoicesPage struct {
+	*Page
+	Invoices []*payment.Invoice
+}
+
+type invoicesHandler struct {
+	Templates *template.Template
+	Service   *payment.Service
+}
+
+// ServeHTTP lists the invoices paid by the user.
+func (h *invoicesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	ii, err := h.Service.Paid(u)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	p := &invoicesPage{
+		Page:     NewPage("Invoices", r),
+		Invoices: ii,
+	}
+	err = h.Templates.ExecuteTemplate(w, "invoices.html", p)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+type invoiceHandler struct {
+	Service       *payment.Service
+	ParcelStorage parcel.Accesser
+}
+
+// ServeHTTP sends the invoice identified by the id query parameter as a
+// PDF document. Customers may only download the invoices they have paid.
+func (h *invoiceHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	sess := session.MustFromContext(r.Context())
+	id, err := uuid.Parse(r.FormValue("id"))
+	if err != nil {
+		sess.AddFlash(payment.ErrInvoiceNotFound.Error(), "errors")
+		http.Redirect(w, r, "/profile/invoices", http.StatusFound)
+		return
+	}
+	inv, err := h.Service.ByID(u, id)
+	if err == payment.ErrInvoiceNotFound || err == payment.ErrNotPayer {
+		sess.AddFlash(err.Error(), "errors")
+		http.Redirect(w, r, "/profile/invoices", http.StatusFound)
+		return
+	} else if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+	p, err := h.ParcelStorage.ByID(inv.ParcelID)
+	if err != nil {
+		log.Println(err)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	} else if p == nil {
+		log.Printf("parcel %s of invoice %s does not exist\n", inv.ParcelID, inv.Number)
+		http.Error(w, "Internal Server Error", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/pdf")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pdf\"", inv.Number))
+	_, err = w.Write(label.Invoice(inv, p))
+	if err != nil {
+		log.Println(err)
+	}
+}
@@ -10,10 +10,22 @@ import (
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/json"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/session"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/feedback"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/webhook"
 )
 
 type Config struct {
@@ -29,6 +41,11 @@ type Duration struct {
 	duration time.Duration
 }
 
+// Duration returns the wrapped duration.
+func (d Duration) Duration() time.Duration {
+	return d.duration
+}
+
 func (d *Duration) MarshalText() ([]byte, error) {
 	return []byte(d.duration.String()), nil
 }
@@ -44,12 +61,44 @@ func (d *Duration) UnmarshalText(text []byte) error {
 }
 
 type Server struct {
-	AddressStorage  address.Storage
-	CreditStorage   credit.Storage
-	EventStorage    parcel.EventStorage
+	AddressStorage address.Storage
+	// BlobStore stores the images captured as proof of delivery and the
+	// evidence attached to claims.
+	BlobStore blob.Store
+	// CenterService sorts the parcels scanned in logistics centers.
+	CenterService *center.Service
+	// ClaimService files and reviews claims for insured parcels.
+	ClaimService *claim.Service
+	// ConsignmentService groups parcels to consignments and tracks them.
+	ConsignmentService *consignment.Service
+	CreditStorage      credit.Storage
+	EventStorage       parcel.EventStorage
+	// Estimator estimates the delivery times shown to customers.
+	Estimator       *eta.Estimator
 	FeedbackStorage feedback.Storage
-	ParcelStorage   parcel.Storage
-	UserStorage     user.Storage
+	// FleetService assigns parcels to rocket launches.
+	FleetService *fleet.Service
+	// LockerService manages the shops and parcel lockers, at which
+	// recipients collect parcels.
+	LockerService *locker.Service
+	// MaxDeliveryAttempts is the number of failed delivery attempts shown
+	// on the tracking page. It defaults to parcel.DefaultMaxDeliveryAttempts.
+	MaxDeliveryAttempts int
+	ParcelStorage       parcel.Storage
+	// PaymentService charges credit cards for parcels and issues invoices.
+	PaymentService *payment.Service
+	// PickupService books the collection of parcels by couriers.
+	PickupService *pickup.Service
+	// Pricing computes the prices of parcels and accepts quotes.
+	Pricing       *pricing.Service
+	RocketStorage fleet.RocketStorage
+	// RouteService plans the routes of delivery vehicles.
+	RouteService *route.Service
+	// Subscriber is used to notify clients about new tracking events.
+	Subscriber             parcel.Subscriber
+	UserStorage            user.Storage
+	WebhookStorage         webhook.Storage
+	WebhookDeliveryStorage webhook.DeliveryStorage
 }
 
 func (s *Server) ListenAndServe(config *Config, sessionConfig *session.Config) error {
@@ -88,8 +137,34 @@ func (s *Server) newRouter(sessionConfig *session.Config) (*mux.Router, error) {
 	}).Methods("GET")
 	r.Handle("/tracking",
 		newTemplateHandler(t, "tracking.html", "Tracking")).Methods("GET")
-	r.Handle("/tracking", &findParcelHandler{Storage: s.ParcelStorage}).Methods("POST")
-	r.Handle("/tracking/{id}", &trackingHandler{parcelStorage: nil})
+	r.Handle("/tracking", &findParcelHandler{
+		Storage:      s.ParcelStorage,
+		Consignments: s.ConsignmentService.Consignments,
+	}).Methods("POST")
+	r.Handle("/tracking/{id}", &trackingHandler{
+		templates:          t,
+		addressStorage:     s.AddressStorage,
+		eventStorage:       s.EventStorage,
+		parcelStorage:      s.ParcelStorage,
+		proofStorage:       s.ParcelStorage,
+		lockerService:      s.LockerService,
+		estimator:          s.Estimator,
+		maxAttempts:        s.MaxDeliveryAttempts,
+		consignmentService: s.ConsignmentService,
+	}).Methods("GET")
+	r.Handle("/tracking/{id}/signature", &proofImageHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		Blobs:          s.BlobStore,
+	}).Methods("GET")
+	r.Handle("/tracking/{id}/photo", &proofImageHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		Blobs:          s.BlobStore,
+		Photo:          true,
+	}).Methods("GET")
+	r.Handle("/quote", &quoteFormHandler{Templates: t}).Methods("GET")
+	r.Handle("/quote", &quoteHandler{Templates: t, Pricing: s.Pricing}).Methods("POST")
 	r.Handle("/feedback", &addFeedbackHandler{
 		Storage: s.FeedbackStorage,
 	}).Methods("POST")
@@ -100,12 +175,134 @@ func (s *Server) newRouter(sessionConfig *session.Config) (*mux.Router, error) {
 	pr.Handle("/update", &updateProfileHandler{UserStorage: s.UserStorage})
 	pr.Handle("/addresses", &addressHandler{Templates: t, Storage: s.AddressStorage})
 	pr.Handle("/addresses/add", &addAddressHandler{Storage: s.AddressStorage})
+	pr.Handle("/send-parcel", &sendParcelFormHandler{
+		Templates:      t,
+		AddressStorage: s.AddressStorage,
+		PointStorage:   s.LockerService.Points,
+		CreditStorage:  s.CreditStorage,
+	}).Methods("GET")
+	pr.Handle("/send-parcel", &sendParcelHandler{
+		AddressStorage: s.AddressStorage,
+		UserStorage:    s.UserStorage,
+		ParcelStorage:  s.ParcelStorage,
+		QuoteAccepter:  s.Pricing,
+		LockerService:  s.LockerService,
+		PaymentService: s.PaymentService,
+	}).Methods("POST")
+	pr.Handle("/import-parcels", &importParcelsFormHandler{
+		Templates: t,
+		Storage:   s.AddressStorage,
+	}).Methods("GET")
+	pr.Handle("/import-parcels", &importParcelsHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+	}).Methods("POST")
+	pr.Handle("/label", &labelHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+	}).Methods("GET")
+	pr.Handle("/return-parcel", &returnParcelHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+	}).Methods("POST")
+	pr.Handle("/incoming-parcels", &parcelsHandler{
+		Templates:      t,
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+	}).Methods("GET")
+	pr.Handle("/outgoing-parcels", &parcelsHandler{
+		Templates:      t,
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+		Outgoing:       true,
+	}).Methods("GET")
+	pr.Handle("/delivery-options", &deliveryOptionsHandler{
+		Templates:    t,
+		Storage:      s.AddressStorage,
+		PointStorage: s.LockerService.Points,
+	}).Methods("GET")
+	pr.Handle("/delivery-options/redirect", &redirectParcelHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+		LockerService:  s.LockerService,
+	}).Methods("POST")
+	pr.Handle("/delivery-options/hold", &redirectParcelHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+		Hold:           true,
+	}).Methods("POST")
+	pr.Handle("/delivery-options/reschedule", &rescheduleHandler{
+		AddressStorage: s.AddressStorage,
+		ParcelStorage:  s.ParcelStorage,
+		EventStorage:   s.EventStorage,
+	}).Methods("POST")
+	pr.Handle("/pickups", &pickupsHandler{Templates: t, Service: s.PickupService}).Methods("GET")
+	pr.Handle("/pickups/book", &bookPickupHandler{
+		ParcelStorage: s.ParcelStorage,
+		Service:       s.PickupService,
+	}).Methods("POST")
+	pr.Handle("/pickups/cancel", &bookPickupHandler{
+		ParcelStorage: s.ParcelStorage,
+		Service:       s.PickupService,
+		Cancel:        true,
+	}).Methods("POST")
+	pr.Handle("/consignments", &consignmentsHandler{
+		Templates: t,
+		Service:   s.ConsignmentService,
+	}).Methods("GET")
+	pr.Handle("/consignments", &createConsignmentHandler{
+		Service: s.ConsignmentService,
+	}).Methods("POST")
+	pr.Handle("/claims", &claimsHandler{Templates: t, Service: s.ClaimService}).Methods("GET")
+	pr.Handle("/claims", &fileClaimHandler{Service: s.ClaimService}).Methods("POST")
+	pr.Handle("/claims/evidence", &claimEvidenceHandler{Service: s.ClaimService}).Methods("GET")
+	pr.Handle("/claims/evidence", &addEvidenceHandler{Service: s.ClaimService}).Methods("POST")
+	pr.Handle("/claims/review", &reviewClaimsHandler{Templates: t, Service: s.ClaimService}).Methods("GET")
+	pr.Handle("/claims/review", &reviewClaimHandler{Service: s.ClaimService}).Methods("POST")
+	pr.Handle("/invoices", &invoicesHandler{Templates: t, Service: s.PaymentService}).Methods("GET")
+	pr.Handle("/invoice", &invoiceHandler{
+		Service:       s.PaymentService,
+		ParcelStorage: s.ParcelStorage,
+	}).Methods("GET")
+	pr.Handle("/centers", &centersHandler{Templates: t, Service: s.CenterService}).Methods("GET")
+	pr.Handle("/webhooks", &webhookHandler{
+		Templates:       t,
+		Storage:         s.WebhookStorage,
+		DeliveryStorage: s.WebhookDeliveryStorage,
+	}).Methods("GET")
+	pr.Handle("/webhooks/add", &addWebhookHandler{Storage: s.WebhookStorage}).Methods("POST")
+	pr.Handle("/webhooks/delete", &deleteWebhookHandler{Storage: s.WebhookStorage}).Methods("POST")
 	pr.Handle("/payment-options", &paymentOptionsHandler{CardStorage: s.CreditStorage, Templates: t})
 	pr.Handle("/add-payment-option", &addPaymantOptionHandler{CardStorage: s.CreditStorage}).
 		Methods("POST")
 
 	ar := r.PathPrefix("/api").Subrouter()
-	json.AddAPIRoutes(ar, s.AddressStorage, s.CreditStorage, s.FeedbackStorage, s.UserStorage)
+	json.AddAPIRoutes(ar, &json.Dependencies{
+		AddressStorage:     s.AddressStorage,
+		BlobStore:          s.BlobStore,
+		CenterService:      s.CenterService,
+		ClaimService:       s.ClaimService,
+		ConsignmentService: s.ConsignmentService,
+		CreditStorage:      s.CreditStorage,
+		EventStorage:       s.EventStorage,
+		Estimator:          s.Estimator,
+		FeedbackStorage:    s.FeedbackStorage,
+		FleetService:       s.FleetService,
+		LockerService:      s.LockerService,
+		ParcelStorage:      s.ParcelStorage,
+		PaymentService:     s.PaymentService,
+		PickupService:      s.PickupService,
+		Pricing:            s.Pricing,
+		RocketStorage:      s.RocketStorage,
+		RouteService:       s.RouteService,
+		Subscriber:         s.Subscriber,
+		UserStorage:        s.UserStorage,
+	})
 
 	return r, nil
 }
@@ -0,0 +1,188 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	errInvalidCenterID = errors.New("the logistics center id is invalid")
+	errCenterNotFound  = errors.New("a logistics center with that id does not exist")
+)
+
+// centerStatus returns the HTTP status code for the error err returned by
+// the center service.
+func centerStatus(err error) int {
+	switch err {
+	case center.ErrCenterNotFound:
+		return http.StatusNotFound
+	case center.ErrNotExpected, center.ErrWrongPlanet, center.ErrNoBin, center.ErrLabelTaken,
+		parcel.ErrEventTimeOrder:
+		return http.StatusConflict
+	case center.ErrNameEmpty, center.ErrPlanetEmpty, center.ErrLabelEmpty, parcel.ErrEventInFuture:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// scan is the JSON representation of a parcel's scan in a logistics center.
+type scan struct {
+	*center.Scan
+	// Event is only set, if the scan recorded the parcel's arrival.
+	Event *event `json:"event,omitempty"`
+}
+
+func (h *APIHandler) serveCenters(w http.ResponseWriter, r *http.Request) {
+	cc, err := h.cn.Centers.Centers()
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, cc)
+}
+
+func (h *APIHandler) addCenter(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	c, err := center.NewCenter(r.PostFormValue("name"), r.PostFormValue("city"), r.PostFormValue("planet"))
+	if err != nil {
+		sendError(w, centerStatus(err), err)
+		return
+	}
+	err = h.cn.Centers.InsertCenter(c)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, c)
+}
+
+func (h *APIHandler) serveBins(w http.ResponseWriter, r *http.Request) {
+	c := h.requestCenter(w, r)
+	if c == nil {
+		return
+	}
+	bb, err := h.cn.Centers.Bins(c.ID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, bb)
+}
+
+// addBin adds a sorting bin labelled by the label form value to the
+// center, which collects the parcels to the city and planet given by the
+// form values.
+func (h *APIHandler) addBin(w http.ResponseWriter, r *http.Request) {
+	c := h.requestCenter(w, r)
+	if c == nil {
+		return
+	}
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	b, err := center.NewBin(c, r.PostFormValue("label"), r.PostFormValue("planet"), r.PostFormValue("city"))
+	if err != nil {
+		sendError(w, centerStatus(err), err)
+		return
+	}
+	err = h.cn.Centers.InsertBin(b)
+	if err != nil {
+		sendError(w, centerStatus(err), err)
+		return
+	}
+
+	sendResult(w, b)
+}
+
+// scanParcel records the scan of the parcel identified by the parcel form
+// value in the center at the time given by the optional time form value
+// and sends the sorting bin, into which the parcel has to be dropped.
+func (h *APIHandler) scanParcel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidCenterID)
+		return
+	}
+	at, ok := parseEventTime(w, r)
+	if !ok {
+		return
+	}
+	pid, err := parcel.ParseTrackingID(r.PostFormValue("parcel"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	p, err := h.ps.ByID(pid)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	sc, err := h.cn.Scan(id, p, at)
+	if err != nil {
+		sendError(w, centerStatus(err), err)
+		return
+	}
+	res := scan{Scan: sc}
+	if sc.Event != nil {
+		res.Event = &event{Event: sc.Event, Description: sc.Event.Type.String()}
+	}
+
+	sendResult(w, res)
+}
+
+// serveCenterParcels sends the parcels being processed at the center by
+// their sorting bins.
+func (h *APIHandler) serveCenterParcels(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidCenterID)
+		return
+	}
+	d, err := h.cn.Dashboard(id)
+	if err != nil {
+		sendError(w, centerStatus(err), err)
+		return
+	}
+
+	sendResult(w, d)
+}
+
+// requestCenter returns the logistics center identified by the id route
+// variable, sending an error and returning nil, if there is no such
+// center.
+func (h *APIHandler) requestCenter(w http.ResponseWriter, r *http.Request) *center.Center {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidCenterID)
+		return nil
+	}
+	c, err := h.cn.Centers.CenterByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return nil
+	} else if c == nil {
+		sendError(w, http.StatusNotFound, errCenterNotFound)
+		return nil
+	}
+
+	return c
+}
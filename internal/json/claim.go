@@ -0,0 +1,208 @@
+package json
+
+import (
+	"errors"
+	"io"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	errInvalidClaimID    = errors.New("the claim ID is invalid")
+	errInvalidEvidenceID = errors.New("the evidence ID is invalid")
+	errUnknownStatus     = errors.New("the status must be submitted, approved or rejected")
+)
+
+// claimStatus returns the HTTP status code for the error err returned by
+// the claim service.
+func claimStatus(err error) int {
+	switch {
+	case err == claim.ErrNotSender || err == claim.ErrNotClaimant || err == claim.ErrNotOperator:
+		return http.StatusForbidden
+	case err == claim.ErrClaimNotFound || err == claim.ErrParcelNotFound || err == claim.ErrEvidenceNotFound:
+		return http.StatusNotFound
+	case err == claim.ErrAlreadyClaimed || err == claim.ErrAlreadyDecided:
+		return http.StatusConflict
+	case claim.IsInvalid(err) || err == parcel.ErrInvalidTrackingID || err == parcel.ErrTrackingCodeTypo:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// claimInfo is the JSON representation of a claim.
+type claimInfo struct {
+	*claim.Claim
+	StatusDescription string `json:"statusDescription"`
+}
+
+func newClaimInfo(c *claim.Claim) *claimInfo {
+	return &claimInfo{Claim: c, StatusDescription: c.Status.String()}
+}
+
+func newClaimInfos(cc []*claim.Claim) []*claimInfo {
+	ci := make([]*claimInfo, 0, len(cc))
+	for _, c := range cc {
+		ci = append(ci, newClaimInfo(c))
+	}
+
+	return ci
+}
+
+// loadClaim returns the claim identified by the id path variable, if u may
+// access it. Otherwise, the error is sent and nil returned.
+func (h *APIHandler) loadClaim(w http.ResponseWriter, r *http.Request, u *user.User) *claim.Claim {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidClaimID)
+		return nil
+	}
+	c, err := h.cl.ByID(u, id)
+	if err != nil {
+		sendError(w, claimStatus(err), err)
+		return nil
+	}
+
+	return c
+}
+
+// serveClaims sends the claims filed by the user.
+func (h *APIHandler) serveClaims(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	cc, err := h.cl.Filed(u)
+	if err != nil {
+		sendError(w, claimStatus(err), err)
+		return
+	}
+
+	sendResult(w, newClaimInfos(cc))
+}
+
+// fileClaim files a claim for an insured parcel sent by the user. The
+// multipart form contains the fields parcel, kind, description, card, the
+// optional amount and up to five evidence files.
+func (h *APIHandler) fileClaim(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	req, err := claim.ParseClaimForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	c, err := h.cl.File(u, req)
+	if err != nil {
+		sendError(w, claimStatus(err), err)
+		return
+	}
+
+	sendResult(w, newClaimInfo(c))
+}
+
+// addEvidence attaches the evidence files of the multipart form to one of
+// the user's claims under review.
+func (h *APIHandler) addEvidence(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	c := h.loadClaim(w, r, u)
+	if c == nil {
+		return
+	}
+	uu, err := claim.ParseEvidenceForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if len(uu) == 0 {
+		sendError(w, http.StatusBadRequest, claim.ErrInvalidEvidence)
+		return
+	}
+	for _, up := range uu {
+		_, err = h.cl.AddEvidence(u, c, up)
+		if err != nil {
+			sendError(w, claimStatus(err), err)
+			return
+		}
+	}
+
+	sendResult(w, newClaimInfo(c))
+}
+
+// serveEvidence sends a file attached to a claim to its claimant or an
+// operator.
+func (h *APIHandler) serveEvidence(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	c := h.loadClaim(w, r, u)
+	if c == nil {
+		return
+	}
+	id, err := uuid.Parse(mux.Vars(r)["evidence"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidEvidenceID)
+		return
+	}
+	e, rc, err := h.cl.Evidence(u, c, id)
+	if err != nil {
+		sendError(w, claimStatus(err), err)
+		return
+	}
+	defer rc.Close()
+
+	w.Header().Set("Content-Type", e.ContentType)
+	w.Header().Set("Cache-Control", "private")
+	io.Copy(w, rc)
+}
+
+// serveReviewClaims sends the claims with the status given by the optional
+// status query parameter, which defaults to submitted.
+func (h *APIHandler) serveReviewClaims(w http.ResponseWriter, r *http.Request) {
+	st := claim.Status(r.URL.Query().Get("status"))
+	switch st {
+	case "":
+		st = claim.Submitted
+	case claim.Submitted, claim.Approved, claim.Rejected:
+	default:
+		sendError(w, http.StatusBadRequest, errUnknownStatus)
+		return
+	}
+	cc, err := h.cl.Claims.ByStatus(st)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, newClaimInfos(cc))
+}
+
+// serveClaim sends a claim to its claimant or an operator.
+func (h *APIHandler) serveClaim(w http.ResponseWriter, r *http.Request) {
+	c := h.loadClaim(w, r, user.MustFromContext(r.Context()))
+	if c == nil {
+		return
+	}
+
+	sendResult(w, newClaimInfo(c))
+}
+
+// reviewClaim approves or rejects a claim according to the form values
+// decision, note and the optional amount of the refund.
+func (h *APIHandler) reviewClaim(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	c := h.loadClaim(w, r, u)
+	if c == nil {
+		return
+	}
+	rv, err := claim.ParseReviewForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	err = h.cl.Review(u, c, rv)
+	if err != nil {
+		sendError(w, claimStatus(err), err)
+		return
+	}
+
+	sendResult(w, newClaimInfo(c))
+}
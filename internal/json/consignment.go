@@ -0,0 +1,152 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var errConsignmentNotFound = errors.New("a consignment with that tracking number does not exist")
+
+// consignmentStatus returns the HTTP status code for the error err
+// returned by the consignment service.
+func consignmentStatus(err error) int {
+	switch err {
+	case consignment.ErrNotSender:
+		return http.StatusForbidden
+	case consignment.ErrConsignmentNotFound, consignment.ErrParcelNotFound:
+		return http.StatusNotFound
+	case consignment.ErrAlreadyConsigned:
+		return http.StatusConflict
+	case consignment.ErrTooFewParcels, consignment.ErrTooManyParcels, consignment.ErrDuplicateParcel,
+		consignment.ErrReferenceTooLong, consignment.ErrDifferentAddresses:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// consignmentMember is the JSON representation of a consignment's parcel.
+type consignmentMember struct {
+	Parcel   trackedParcel      `json:"parcel"`
+	Status   consignment.Status `json:"status"`
+	Latest   *event             `json:"latest"`
+	Estimate *eta.Estimate      `json:"estimate"`
+}
+
+// consignmentInfo is the JSON representation of a consignment's
+// consolidated tracking information.
+type consignmentInfo struct {
+	*consignment.Tracking
+	Description string              `json:"description"`
+	Parcels     []consignmentMember `json:"parcels"`
+	Latest      *event              `json:"latest"`
+}
+
+// newConsignmentInfo returns the JSON representation of t, which includes
+// the parcels' customs declarations, if customs is true.
+func newConsignmentInfo(t *consignment.Tracking, customs bool) *consignmentInfo {
+	ci := &consignmentInfo{
+		Tracking:    t,
+		Description: t.Status.String(),
+		Parcels:     make([]consignmentMember, 0, len(t.Parcels)),
+		Latest:      newEvent(t.Latest),
+	}
+	for _, m := range t.Parcels {
+		cm := consignmentMember{
+			Parcel:   newTrackedParcel(m.Parcel),
+			Status:   m.Status,
+			Latest:   newEvent(m.Latest),
+			Estimate: m.Estimate,
+		}
+		if customs {
+			cm.Parcel = cm.Parcel.withCustoms()
+		}
+		ci.Parcels = append(ci.Parcels, cm)
+	}
+
+	return ci
+}
+
+// newEvent returns the JSON representation of e or nil, if e is nil.
+func newEvent(e *parcel.Event) *event {
+	if e == nil {
+		return nil
+	}
+
+	return &event{Event: e, Description: e.Type.String()}
+}
+
+// serveConsignment sends the consolidated tracking information of the
+// consignment identified by its master tracking number.
+func (h *APIHandler) serveConsignment(w http.ResponseWriter, r *http.Request) {
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	t, err := h.co.Track(id)
+	if err == consignment.ErrConsignmentNotFound {
+		sendError(w, http.StatusNotFound, errConsignmentNotFound)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+	// Only the consignment's sender and operators see the customs
+	// declarations, like those of single parcels.
+	u, ok := user.FromContext(r.Context())
+	customs := ok && (u.Operator || u.ID == t.Sender)
+
+	sendResult(w, newConsignmentInfo(t, customs))
+}
+
+// serveConsignments sends the consignments created by the user.
+func (h *APIHandler) serveConsignments(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	tt, err := h.co.Sent(u)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+	cc := make([]*consignmentInfo, 0, len(tt))
+	for _, t := range tt {
+		cc = append(cc, newConsignmentInfo(t, true))
+	}
+
+	sendResult(w, cc)
+}
+
+// createConsignment groups the parcels given by the parcel form values to a
+// new consignment with the optional reference form value.
+func (h *APIHandler) createConsignment(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	ids := make([]uuid.UUID, 0, len(r.PostForm["parcel"]))
+	for _, s := range r.PostForm["parcel"] {
+		id, err := parcel.ParseTrackingID(s)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+			return
+		}
+		ids = append(ids, id)
+	}
+
+	t, err := h.co.Create(u, ids, r.PostFormValue("reference"))
+	if err != nil {
+		sendError(w, consignmentStatus(err), err)
+		return
+	}
+
+	sendResult(w, newConsignmentInfo(t, true))
+}
@@ -0,0 +1,222 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// maxWindowHorizon limits the time span searched for transfer windows.
+const maxWindowHorizon = 10 * 365 * 24 * time.Hour
+
+var (
+	errInvalidRocketID = errors.New("the rocket id is invalid")
+	errRocketNotFound  = errors.New("a rocket with that id does not exist")
+	errInvalidLaunchID = errors.New("the launch id is invalid")
+	errLaunchNotFound  = errors.New("a launch with that id does not exist")
+	errInvalidHorizon  = errors.New("the number of days must be between 1 and 3650")
+)
+
+func (h *APIHandler) serveRockets(w http.ResponseWriter, r *http.Request) {
+	rr, err := h.rs.All()
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if rr == nil {
+		rr = []*fleet.Rocket{}
+	}
+
+	sendResult(w, rr)
+}
+
+func (h *APIHandler) addRocket(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	capacity, err := strconv.Atoi(r.PostFormValue("capacity"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, fleet.ErrInvalidCapacity)
+		return
+	}
+	rocket, err := fleet.NewRocket(r.PostFormValue("name"), capacity)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	err = h.rs.Insert(rocket)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, rocket)
+}
+
+func (h *APIHandler) serveLaunches(w http.ResponseWriter, r *http.Request) {
+	from, to := canonicalPlanet(r.FormValue("from")), canonicalPlanet(r.FormValue("to"))
+	ll, err := h.fl.Launches.Scheduled(from, to)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if ll == nil {
+		ll = []*fleet.Launch{}
+	}
+
+	sendResult(w, ll)
+}
+
+// canonicalPlanet returns the name of the planet called name as used by
+// the launch schedule.
+func canonicalPlanet(name string) string {
+	p := orbit.Lookup(name)
+	if p == nil {
+		return name
+	}
+
+	return p.Name
+}
+
+func (h *APIHandler) scheduleLaunch(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	id, err := uuid.Parse(r.PostFormValue("rocket"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidRocketID)
+		return
+	}
+	departure, err := time.Parse(time.RFC3339, r.PostFormValue("departure"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	rocket, err := h.rs.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if rocket == nil {
+		sendError(w, http.StatusNotFound, errRocketNotFound)
+		return
+	}
+
+	l, err := h.fl.Schedule(rocket, r.PostFormValue("from"), r.PostFormValue("to"), departure)
+	switch err {
+	case nil:
+		sendResult(w, l)
+	case fleet.ErrUnknownPlanet, fleet.ErrSamePlanet, fleet.ErrDepartureInPast,
+		fleet.ErrOutsideTransferWindow:
+		sendError(w, http.StatusBadRequest, err)
+	case fleet.ErrRocketBusy:
+		sendError(w, http.StatusConflict, err)
+	default:
+		sendError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (h *APIHandler) departLaunch(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidLaunchID)
+		return
+	}
+	err = r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	at := time.Now()
+	if ts := r.PostFormValue("time"); ts != "" {
+		at, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	l, err := h.fl.Launches.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if l == nil {
+		sendError(w, http.StatusNotFound, errLaunchNotFound)
+		return
+	}
+
+	err = h.fl.Depart(l, at)
+	if err == fleet.ErrLaunchDeparted {
+		sendError(w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, l)
+}
+
+func (h *APIHandler) assignParcel(w http.ResponseWriter, r *http.Request) {
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	l, err := h.fl.Assign(p)
+	switch err {
+	case nil:
+		sendResult(w, l)
+	case fleet.ErrNotAwaitingRocket, fleet.ErrNoLaunch:
+		sendError(w, http.StatusConflict, err)
+	case fleet.ErrUnknownPlanet:
+		sendError(w, http.StatusBadRequest, err)
+	default:
+		sendError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func (h *APIHandler) serveTransferWindows(w http.ResponseWriter, r *http.Request) {
+	a, b := orbit.Lookup(r.FormValue("from")), orbit.Lookup(r.FormValue("to"))
+	if a == nil || b == nil {
+		sendError(w, http.StatusBadRequest, fleet.ErrUnknownPlanet)
+		return
+	} else if a == b {
+		sendError(w, http.StatusBadRequest, fleet.ErrSamePlanet)
+		return
+	}
+	horizon := 3 * 365 * 24 * time.Hour
+	if ds := r.FormValue("days"); ds != "" {
+		days, err := strconv.Atoi(ds)
+		horizon = time.Duration(days) * 24 * time.Hour
+		if err != nil || days < 1 || horizon > maxWindowHorizon {
+			sendError(w, http.StatusBadRequest, errInvalidHorizon)
+			return
+		}
+	}
+
+	ww := fleet.TransferWindows(a, b, time.Now(), horizon)
+	if ww == nil {
+		ww = []fleet.Window{}
+	}
+
+	sendResult(w, ww)
+}
@@ -2,31 +2,77 @@ package json
 
 import (
 	"encoding/json"
+	"fmt"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"github.com/google/uuid"
 	"github.com/gorilla/mux"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/internal/session"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/feedback"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 )
 
 type APIHandler struct {
-	as address.Storage
-	cs credit.Storage
-	fs feedback.Storage
-	us user.Storage
+	as  address.Storage
+	bs  blob.Store
+	cl  *claim.Service
+	cn  *center.Service
+	co  *consignment.Service
+	cs  credit.Storage
+	es  parcel.EventStorage
+	est *eta.Estimator
+	fl  *fleet.Service
+	fs  feedback.Storage
+	lk  *locker.Service
+	pk  *pickup.Service
+	pm  *payment.Service
+	pr  *pricing.Service
+	ps  parcel.Storage
+	rs  fleet.RocketStorage
+	rt  *route.Service
+	sub parcel.Subscriber
+	us  user.Storage
 }
 
-func NewAPIHandler(as address.Storage, cs credit.Storage, fs feedback.Storage, us user.Storage) *APIHandler {
+func NewAPIHandler(d *Dependencies) *APIHandler {
 	return &APIHandler{
-		as: as,
-		cs: cs,
-		fs: fs,
-		us: us,
+		as:  d.AddressStorage,
+		bs:  d.BlobStore,
+		cl:  d.ClaimService,
+		cn:  d.CenterService,
+		co:  d.ConsignmentService,
+		cs:  d.CreditStorage,
+		es:  d.EventStorage,
+		est: d.Estimator,
+		fl:  d.FleetService,
+		fs:  d.FeedbackStorage,
+		lk:  d.LockerService,
+		pk:  d.PickupService,
+		pm:  d.PaymentService,
+		pr:  d.Pricing,
+		ps:  d.ParcelStorage,
+		rs:  d.RocketStorage,
+		rt:  d.RouteService,
+		sub: d.Subscriber,
+		us:  d.UserStorage,
 	}
 }
 
@@ -194,6 +240,507 @@ func (h *APIHandler) serveAddresses(w http.ResponseWriter, r *http.Request) {
 	sendResult(w, aa)
 }
 
+func (h *APIHandler) sendParcel(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	err := r.ParseMultipartForm(0)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	req, err := parcel.ParseSendForm(r, u)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	err = h.lk.Resolve(req)
+	if err == locker.ErrPointNotFound {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	p, inv, err := h.pm.Send(req, h.as, h.us, h.pr, h.ps)
+	if parcel.IsInvalid(err) || pricing.IsRejection(err) || err == payment.ErrForeignCard {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if err == payment.ErrDeclined {
+		sendError(w, http.StatusPaymentRequired, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	tp := newTrackedParcel(p).withCustoms()
+	tp.Invoice = inv
+	sendResult(w, tp)
+}
+
+// trackedParcel is the JSON representation of a parcel together with its
+// human-friendly tracking code.
+type trackedParcel struct {
+	*parcel.Parcel
+	TrackingCode string `json:"trackingCode"`
+	// Customs hides the parcel's customs declaration, unless it is set by
+	// withCustoms.
+	Customs *parcel.CustomsDeclaration `json:"customs,omitempty"`
+	// Invoice is the invoice of a new parcel paid by credit card.
+	Invoice *payment.Invoice `json:"invoice,omitempty"`
+}
+
+func newTrackedParcel(p *parcel.Parcel) trackedParcel {
+	return trackedParcel{Parcel: p, TrackingCode: p.TrackingCode()}
+}
+
+// withCustoms returns tp including the parcel's customs declaration, which
+// may only be sent to the parcel's sender and to operators.
+func (tp trackedParcel) withCustoms() trackedParcel {
+	tp.Customs = tp.Parcel.Customs
+	return tp
+}
+
+// importParcels imports the parcels of an uploaded CSV or JSON lines file
+// and sends the results as a file of the same format, which contains the
+// tracking IDs of the new parcels and the errors of rejected rows.
+func (h *APIHandler) importParcels(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	req, err := parcel.ParseImportForm(r, u)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	rr, err := parcel.Import(req, h.as, h.ps)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", req.Format.ContentType())
+	w.Header().Set("Content-Disposition",
+		fmt.Sprintf(`attachment; filename="import-results.%s"`, req.Format.Extension()))
+	err = parcel.WriteImportResults(w, req.Format, rr)
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// parcelSummary is the JSON representation of a parcel together with its
+// latest tracking event.
+type parcelSummary struct {
+	trackedParcel
+	Latest *event `json:"latest"`
+}
+
+// newParcelSummaries returns the JSON representations of ss, which include
+// the customs declarations, if customs is true.
+func newParcelSummaries(ss []*parcel.Summary, customs bool) []parcelSummary {
+	ps := make([]parcelSummary, 0, len(ss))
+	for _, s := range ss {
+		p := parcelSummary{trackedParcel: newTrackedParcel(s.Parcel)}
+		if customs {
+			p.trackedParcel = p.withCustoms()
+		}
+		if s.Latest != nil {
+			p.Latest = &event{Event: s.Latest, Description: s.Latest.Type.String()}
+		}
+		ps = append(ps, p)
+	}
+
+	return ps
+}
+
+// serveIncomingParcels sends the parcels, whose sender has named the user
+// as the recipient, together with their latest events.
+func (h *APIHandler) serveIncomingParcels(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	ss, err := parcel.Incoming(u, h.ps, h.es)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, newParcelSummaries(ss, u.Operator))
+}
+
+// serveOutgoingParcels sends the parcels sent from any of the user's
+// addresses together with their latest events.
+func (h *APIHandler) serveOutgoingParcels(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	ss, err := parcel.Outgoing(u, h.as, h.ps, h.es)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, newParcelSummaries(ss, true))
+}
+
+// returnParcel initiates the return of a parcel to its sender on behalf of
+// the parcel's sender or recipient.
+func (h *APIHandler) returnParcel(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	e, err := parcel.RequestReturn(u, p, h.as, h.es)
+	if err == parcel.ErrNotInvolved {
+		sendError(w, http.StatusForbidden, err)
+		return
+	} else if err == parcel.ErrNotReturnable {
+		sendError(w, http.StatusConflict, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// redirectParcel changes the destination of a parcel to another address of
+// its recipient, which is identified by the address form value, or to the
+// pickup point identified by the pickup-point form value.
+func (h *APIHandler) redirectParcel(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	err = r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	point := r.PostFormValue("pickup-point")
+	var to uuid.UUID
+	if point != "" {
+		to, err = uuid.Parse(point)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, errInvalidPointID)
+			return
+		}
+	} else {
+		to, err = uuid.Parse(r.PostFormValue("address"))
+		if err != nil {
+			sendError(w, http.StatusBadRequest, parcel.ErrInvalidAddressID)
+			return
+		}
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	var e *parcel.Event
+	if point != "" {
+		e, err = h.lk.Redirect(u, p, to)
+	} else {
+		e, err = parcel.Redirect(u, p, to, h.as, h.ps, h.es)
+	}
+	if err != nil {
+		sendError(w, redirectStatus(err), err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// holdParcel asks us to hold a parcel for collection by its recipient.
+func (h *APIHandler) holdParcel(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	e, err := parcel.Hold(u, p, h.es)
+	if err != nil {
+		sendError(w, redirectStatus(err), err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// rescheduleDelivery postpones the next attempt to deliver a parcel to the
+// day given by the date form value in the format YYYY-MM-DD.
+func (h *APIHandler) rescheduleDelivery(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	err = r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	date, err := time.ParseInLocation("2006-01-02", r.PostFormValue("date"), time.Local)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, parcel.ErrInvalidDeliveryDate)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	e, err := parcel.Reschedule(u, p, date, h.es)
+	if err != nil {
+		sendError(w, redirectStatus(err), err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// redirectStatus returns the HTTP status code of the error err returned
+// when redirecting, holding or rescheduling a parcel.
+func redirectStatus(err error) int {
+	switch err {
+	case parcel.ErrNotRecipient:
+		return http.StatusForbidden
+	case locker.ErrPointNotFound:
+		return http.StatusNotFound
+	case parcel.ErrNotRedirectable, parcel.ErrAlreadyHeld, parcel.ErrNotReschedulable:
+		return http.StatusConflict
+	case parcel.ErrInvalidRecipient, parcel.ErrSameDestination, parcel.ErrRedirectPlanet,
+		parcel.ErrInvalidDeliveryDate:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// event is the JSON representation of a parcel's tracking event.
+type event struct {
+	*parcel.Event
+	Description string `json:"description"`
+}
+
+type trackingInfo struct {
+	Parcel   trackedParcel `json:"parcel"`
+	Events   []event       `json:"events"`
+	Estimate *eta.Estimate `json:"estimate"`
+}
+
+func (h *APIHandler) serveParcelEvents(w http.ResponseWriter, r *http.Request) {
+	v := mux.Vars(r)
+	id, err := parcel.ParseTrackingID(v["id"])
+	if err == parcel.ErrTrackingCodeTypo {
+		h.sendTypoError(w, v["id"])
+		return
+	} else if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+	ee, err := h.es.ByParcel(p)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	u, _ := user.FromContext(r.Context())
+	customs, err := parcel.CustomsVisible(u, p, h.as)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	ti := &trackingInfo{
+		Parcel:   newTrackedParcel(p),
+		Events:   make([]event, 0, len(ee)),
+		Estimate: h.est.Estimate(p, ee, time.Now()),
+	}
+	if customs {
+		ti.Parcel = ti.Parcel.withCustoms()
+	}
+	for _, e := range ee {
+		ti.Events = append(ti.Events, event{Event: e, Description: e.Type.String()})
+	}
+
+	sendResult(w, ti)
+}
+
+// sendTypoError sends the error for the tracking code with a mismatching
+// check character, which suggests the tracking codes of similar parcels.
+func (h *APIHandler) sendTypoError(w http.ResponseWriter, code string) {
+	pp, err := parcel.SuggestTrackingCodes(code, h.ps)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if len(pp) == 0 {
+		sendError(w, http.StatusBadRequest, parcel.ErrTrackingCodeTypo)
+		return
+	}
+	cc := make([]string, 0, len(pp))
+	for _, p := range pp {
+		cc = append(cc, p.TrackingCode())
+	}
+
+	sendError(w, http.StatusBadRequest, fmt.Errorf("%v, did you mean %s?",
+		parcel.ErrTrackingCodeTypo, strings.Join(cc, " or ")))
+}
+
+func (h *APIHandler) addParcelEvent(w http.ResponseWriter, r *http.Request) {
+	v := mux.Vars(r)
+	id, err := parcel.ParseTrackingID(v["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	err = r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	var t parcel.EventType
+	err = t.UnmarshalText([]byte(r.PostFormValue("type")))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if t.AtPickupPoint() {
+		sendError(w, http.StatusConflict, parcel.ErrPickupPointEvent)
+		return
+	}
+	var reason parcel.FailureReason
+	err = reason.UnmarshalText([]byte(r.PostFormValue("reason")))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if reason != parcel.NoReason && t != parcel.DeliveryFailed {
+		sendError(w, http.StatusBadRequest, errUnexpectedReason)
+		return
+	}
+	var at time.Time
+	if ts := r.PostFormValue("time"); ts != "" {
+		at, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+	var e *parcel.Event
+	if t == parcel.DeliveryFailed {
+		e, err = parcel.RecordFailure(h.es, p, reason, at)
+	} else {
+		e, err = parcel.Record(h.es, p, t, at)
+	}
+	if err == parcel.ErrInvalidTransition || err == parcel.ErrEventTimeOrder {
+		sendError(w, http.StatusConflict, err)
+		return
+	} else if err == parcel.ErrEventInFuture {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// delivery is the JSON representation of a proof of delivery.
+type delivery struct {
+	*parcel.Proof
+	Event        event `json:"event"`
+	HasSignature bool  `json:"hasSignature"`
+	HasPhoto     bool  `json:"hasPhoto"`
+}
+
+func (h *APIHandler) deliverParcel(w http.ResponseWriter, r *http.Request) {
+	v := mux.Vars(r)
+	id, err := parcel.ParseTrackingID(v["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	req, err := parcel.ParseDeliveryForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+	pr, err := parcel.Deliver(p, req, h.ps, h.es, h.bs)
+	switch err {
+	case nil:
+	case parcel.ErrNotOutForDelivery, parcel.ErrInvalidTransition, parcel.ErrEventTimeOrder:
+		sendError(w, http.StatusConflict, err)
+		return
+	case parcel.ErrRecipientNameEmpty, parcel.ErrNoEvidence, parcel.ErrInvalidImage,
+		parcel.ErrImageTooLarge, parcel.ErrInvalidLocation, parcel.ErrEventInFuture:
+		sendError(w, http.StatusBadRequest, err)
+		return
+	default:
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, &delivery{
+		Proof:        pr,
+		Event:        event{Event: pr.Event, Description: pr.Event.Type.String()},
+		HasSignature: pr.Signature != "",
+		HasPhoto:     pr.Photo != "",
+	})
+}
+
 func sendResult(w http.ResponseWriter, result interface{}) {
 	jw := json.NewEncoder(w)
 
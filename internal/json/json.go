@@ -2,27 +2,156 @@
 package json
 
 import (
+	"errors"
+	"net/http"
+
 	"github.com/gorilla/mux"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/feedback"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 )
 
+var (
+	errInvalidTrackingID    = errors.New("the tracking number is invalid")
+	errParcelNotFound       = errors.New("a parcel with that tracking number does not exist")
+	errStreamingUnsupported = errors.New("streaming is not supported")
+	errUnexpectedReason     = errors.New("a reason may only be given for failed delivery attempts")
+)
+
 type Response struct {
 	Error  string      `json:"error,omitempty"`
 	Result interface{} `json:"result,omitempty"`
 }
 
-func AddAPIRoutes(r *mux.Router, as address.Storage, cs credit.Storage, fs feedback.Storage, us user.Storage) {
-	h := NewAPIHandler(as, cs, fs, us)
+// Dependencies are the storages and services used by the JSON API.
+type Dependencies struct {
+	AddressStorage address.Storage
+	// BlobStore stores the images captured as proof of delivery and the
+	// evidence attached to claims.
+	BlobStore blob.Store
+	// CenterService sorts the parcels scanned in logistics centers.
+	CenterService *center.Service
+	// ClaimService files and reviews claims for insured parcels.
+	ClaimService *claim.Service
+	// ConsignmentService groups parcels to consignments and tracks them.
+	ConsignmentService *consignment.Service
+	CreditStorage      credit.Storage
+	EventStorage       parcel.EventStorage
+	// Estimator estimates the delivery times shown to customers.
+	Estimator       *eta.Estimator
+	FeedbackStorage feedback.Storage
+	// FleetService assigns parcels to rocket launches.
+	FleetService *fleet.Service
+	// LockerService manages the shops and parcel lockers, at which
+	// recipients collect parcels.
+	LockerService *locker.Service
+	ParcelStorage parcel.Storage
+	// PaymentService charges credit cards for parcels and issues invoices.
+	PaymentService *payment.Service
+	// PickupService books the collection of parcels by couriers.
+	PickupService *pickup.Service
+	// Pricing computes the prices of parcels and accepts quotes.
+	Pricing       *pricing.Service
+	RocketStorage fleet.RocketStorage
+	// RouteService plans the routes of delivery vehicles.
+	RouteService *route.Service
+	// Subscriber is used to notify clients about new tracking events.
+	Subscriber  parcel.Subscriber
+	UserStorage user.Storage
+}
+
+func AddAPIRoutes(r *mux.Router, d *Dependencies) {
+	h := NewAPIHandler(d)
 
 	r.HandleFunc("/login", h.login).Methods("POST")
 	r.HandleFunc("/recent-feedback", h.serveRecentFeedback).Methods("GET")
+	r.HandleFunc("/parcels/{id}/events", h.serveParcelEvents).Methods("GET")
+	r.Handle("/parcels/{id}/events", operatorChecker(http.HandlerFunc(h.addParcelEvent))).
+		Methods("POST")
+	r.Handle("/parcels/{id}/deliver", operatorChecker(http.HandlerFunc(h.deliverParcel))).
+		Methods("POST")
+	r.HandleFunc("/parcels/{id}/events/stream", h.streamParcelEvents).Methods("GET")
+	r.Handle("/parcels/{id}/launch", operatorChecker(http.HandlerFunc(h.assignParcel))).
+		Methods("POST")
+	r.Handle("/rockets", operatorChecker(http.HandlerFunc(h.serveRockets))).Methods("GET")
+	r.Handle("/rockets", operatorChecker(http.HandlerFunc(h.addRocket))).Methods("POST")
+	r.HandleFunc("/launches", h.serveLaunches).Methods("GET")
+	r.Handle("/launches", operatorChecker(http.HandlerFunc(h.scheduleLaunch))).Methods("POST")
+	r.Handle("/launches/{id}/depart", operatorChecker(http.HandlerFunc(h.departLaunch))).
+		Methods("POST")
+	r.HandleFunc("/transfer-windows", h.serveTransferWindows).Methods("GET")
+	r.HandleFunc("/pickup-slots", h.servePickupSlots).Methods("GET")
+	r.Handle("/pickup-slots", operatorChecker(http.HandlerFunc(h.addPickupSlot))).Methods("POST")
+	r.Handle("/pickups/{id}/confirm", operatorChecker(http.HandlerFunc(h.confirmPickup))).
+		Methods("POST")
+	r.HandleFunc("/pickup-points", h.servePickupPoints).Methods("GET")
+	r.Handle("/pickup-points", operatorChecker(http.HandlerFunc(h.addPickupPoint))).Methods("POST")
+	r.Handle("/pickup-points/{id}/collect", operatorChecker(http.HandlerFunc(h.collectParcel))).
+		Methods("POST")
+	r.Handle("/parcels/{id}/deposit", operatorChecker(http.HandlerFunc(h.depositParcel))).
+		Methods("POST")
+	r.Handle("/centers", operatorChecker(http.HandlerFunc(h.serveCenters))).Methods("GET")
+	r.Handle("/centers", operatorChecker(http.HandlerFunc(h.addCenter))).Methods("POST")
+	r.Handle("/centers/{id}/bins", operatorChecker(http.HandlerFunc(h.serveBins))).Methods("GET")
+	r.Handle("/centers/{id}/bins", operatorChecker(http.HandlerFunc(h.addBin))).Methods("POST")
+	r.Handle("/centers/{id}/scans", operatorChecker(http.HandlerFunc(h.scanParcel))).Methods("POST")
+	r.Handle("/centers/{id}/parcels", operatorChecker(http.HandlerFunc(h.serveCenterParcels))).
+		Methods("GET")
+	r.Handle("/vehicles", operatorChecker(http.HandlerFunc(h.serveVehicles))).Methods("GET")
+	r.Handle("/vehicles", operatorChecker(http.HandlerFunc(h.addVehicle))).Methods("POST")
+	r.Handle("/places", operatorChecker(http.HandlerFunc(h.addPlace))).Methods("POST")
+	r.Handle("/routes", operatorChecker(http.HandlerFunc(h.planRoute))).Methods("POST")
+	r.HandleFunc("/quotes", h.requestQuote).Methods("POST")
+	r.HandleFunc("/consignments/{id}", h.serveConsignment).Methods("GET")
+	r.Handle("/claims", operatorChecker(http.HandlerFunc(h.serveReviewClaims))).Methods("GET")
+	r.Handle("/claims/{id}", operatorChecker(http.HandlerFunc(h.serveClaim))).Methods("GET")
+	r.Handle("/claims/{id}/review", operatorChecker(http.HandlerFunc(h.reviewClaim))).Methods("POST")
 
 	ur := r.PathPrefix("/user/{user}").Subrouter()
 	ur.HandleFunc("/add-address", h.addAddress).Methods("POST")
 	ur.HandleFunc("/get-addresses", h.serveAddresses).Methods("GET")
 	ur.HandleFunc("/add-credit-card", h.addCreditCard).Methods("POST")
 	ur.HandleFunc("/get-credit-cards", h.serveCreditCards).Methods("GET")
+	ur.Handle("/send-parcel", loginChecker(http.HandlerFunc(h.sendParcel))).Methods("POST")
+	ur.Handle("/import-parcels", loginChecker(http.HandlerFunc(h.importParcels))).Methods("POST")
+	ur.Handle("/incoming-parcels", loginChecker(http.HandlerFunc(h.serveIncomingParcels))).Methods("GET")
+	ur.Handle("/outgoing-parcels", loginChecker(http.HandlerFunc(h.serveOutgoingParcels))).Methods("GET")
+	ur.Handle("/parcels/{id}/return", loginChecker(http.HandlerFunc(h.returnParcel))).Methods("POST")
+	ur.Handle("/parcels/{id}/redirect", loginChecker(http.HandlerFunc(h.redirectParcel))).Methods("POST")
+	ur.Handle("/parcels/{id}/hold", loginChecker(http.HandlerFunc(h.holdParcel))).Methods("POST")
+	ur.Handle("/parcels/{id}/reschedule", loginChecker(http.HandlerFunc(h.rescheduleDelivery))).Methods("POST")
+	ur.Handle("/parcels/{id}/pickup", loginChecker(http.HandlerFunc(h.servePickup))).Methods("GET")
+	ur.Handle("/parcels/{id}/pickup", loginChecker(http.HandlerFunc(h.bookPickup))).Methods("POST")
+	ur.Handle("/parcels/{id}/pickup/cancel", loginChecker(http.HandlerFunc(h.cancelPickup))).
+		Methods("POST")
+	ur.Handle("/parcels/{id}/pickup-code", loginChecker(http.HandlerFunc(h.servePickupCode))).Methods("GET")
+	ur.Handle("/consignments", loginChecker(http.HandlerFunc(h.serveConsignments))).Methods("GET")
+	ur.Handle("/consignments", loginChecker(http.HandlerFunc(h.createConsignment))).Methods("POST")
+	ur.Handle("/claims", loginChecker(http.HandlerFunc(h.serveClaims))).Methods("GET")
+	ur.Handle("/claims", loginChecker(http.HandlerFunc(h.fileClaim))).Methods("POST")
+	ur.Handle("/claims/{id}", loginChecker(http.HandlerFunc(h.serveClaim))).Methods("GET")
+	ur.Handle("/claims/{id}/evidence", loginChecker(http.HandlerFunc(h.addEvidence))).Methods("POST")
+	ur.Handle("/claims/{id}/evidence/{evidence}", loginChecker(http.HandlerFunc(h.serveEvidence))).
+		Methods("GET")
+	ur.Handle("/invoices", loginChecker(http.HandlerFunc(h.serveInvoices))).Methods("GET")
+	ur.Handle("/invoices/{id}", loginChecker(http.HandlerFunc(h.serveInvoice))).Methods("GET")
+	ur.Handle("/routes", loginChecker(http.HandlerFunc(h.serveRoutes))).Methods("GET")
+	ur.Handle("/routes/{id}", loginChecker(http.HandlerFunc(h.serveRoute))).Methods("GET")
+	ur.Handle("/routes/{id}/stops/{stop}/deliver", loginChecker(http.HandlerFunc(h.deliverStop))).
+		Methods("POST")
+	ur.Handle("/routes/{id}/stops/{stop}/fail", loginChecker(http.HandlerFunc(h.failStop))).
+		Methods("POST")
 }
@@ -0,0 +1,185 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var errInvalidPointID = errors.New("the pickup point id is invalid")
+
+// lockerStatus returns the HTTP status code for the error err returned by
+// the locker service.
+func lockerStatus(err error) int {
+	switch err {
+	case locker.ErrNotRecipient:
+		return http.StatusForbidden
+	case locker.ErrPointNotFound, locker.ErrNotDeposited:
+		return http.StatusNotFound
+	case locker.ErrNoPickupPoint, locker.ErrNotOutForPickup, locker.ErrPointFull, locker.ErrAlreadyAllocated,
+		locker.ErrInvalidCode, parcel.ErrEventTimeOrder:
+		return http.StatusConflict
+	case locker.ErrUnknownKind, locker.ErrNameEmpty, locker.ErrIncompleteAddress,
+		locker.ErrInvalidCompartments, parcel.ErrEventInFuture:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// deposit is the JSON representation of a parcel deposited at a pickup
+// point. It does not contain the pickup code, which is only shown to the
+// recipient.
+type deposit struct {
+	Point       *locker.Point `json:"point"`
+	Compartment int           `json:"compartment"`
+	ExpiresAt   time.Time     `json:"expiresAt"`
+	Event       event         `json:"event"`
+}
+
+// collection is the JSON representation of a parcel collected from a
+// pickup point.
+type collection struct {
+	ParcelID    uuid.UUID `json:"parcelId"`
+	Compartment int       `json:"compartment"`
+	Event       event     `json:"event"`
+}
+
+// servePickupPoints sends the pickup points in the city and on the planet
+// given by the form values.
+func (h *APIHandler) servePickupPoints(w http.ResponseWriter, r *http.Request) {
+	pp, err := h.lk.Points.Points(r.FormValue("city"), r.FormValue("planet"))
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, pp)
+}
+
+func (h *APIHandler) addPickupPoint(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	pt, err := locker.ParsePointForm(r)
+	if err != nil {
+		sendError(w, lockerStatus(err), err)
+		return
+	}
+
+	err = h.lk.AddPoint(pt)
+	if err != nil {
+		sendError(w, lockerStatus(err), err)
+		return
+	}
+
+	sendResult(w, pt)
+}
+
+// depositParcel allocates a compartment of the parcel's pickup point and
+// records that the parcel has been deposited in it.
+func (h *APIHandler) depositParcel(w http.ResponseWriter, r *http.Request) {
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	at, ok := parseEventTime(w, r)
+	if !ok {
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	a, e, err := h.lk.Deposit(p, at)
+	if err != nil {
+		sendError(w, lockerStatus(err), err)
+		return
+	}
+
+	sendResult(w, deposit{
+		Point:       a.Point,
+		Compartment: a.Compartment,
+		ExpiresAt:   a.ExpiresAt,
+		Event:       event{Event: e, Description: e.Type.String()},
+	})
+}
+
+// collectParcel hands over the parcel, whose pickup code at the pickup
+// point is given by the code form value, to its recipient.
+func (h *APIHandler) collectParcel(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidPointID)
+		return
+	}
+	at, ok := parseEventTime(w, r)
+	if !ok {
+		return
+	}
+
+	a, e, err := h.lk.Collect(id, r.PostFormValue("code"), at)
+	if err != nil {
+		sendError(w, lockerStatus(err), err)
+		return
+	}
+
+	sendResult(w, collection{
+		ParcelID:    a.ParcelID,
+		Compartment: a.Compartment,
+		Event:       event{Event: e, Description: e.Type.String()},
+	})
+}
+
+// servePickupCode sends the compartment and the pickup code of a parcel
+// sent to the user, which is ready for collection.
+func (h *APIHandler) servePickupCode(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	p := h.pickupParcel(w, r)
+	if p == nil {
+		return
+	}
+
+	a, err := h.lk.Allocation(u, p)
+	if err != nil {
+		sendError(w, lockerStatus(err), err)
+		return
+	}
+
+	sendResult(w, a)
+}
+
+// parseEventTime parses the multipart form of r and returns its optional
+// time form value. It sends an error and returns false, if the form is
+// invalid.
+func parseEventTime(w http.ResponseWriter, r *http.Request) (time.Time, bool) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return time.Time{}, false
+	}
+	var at time.Time
+	if ts := r.PostFormValue("time"); ts != "" {
+		at, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, err)
+			return time.Time{}, false
+		}
+	}
+
+	return at, true
+}
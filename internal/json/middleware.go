@@ -28,3 +28,22 @@ func loginChecker(next http.Handler) http.Handler {
 		next.ServeHTTP(w, r)
 	})
 }
+
+// operatorChecker is the middleware that denies access to the API,
+// unless the request is from a logged in operator.
+func operatorChecker(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		u, ok := user.FromContext(r.Context())
+		if !ok {
+			http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+			return
+		}
+		if !u.Operator {
+			http.Error(w, "Only IPPS operators are allowed to access this resource",
+				http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
@@ -0,0 +1,72 @@
+package json
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/label"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	errInvalidInvoiceID = errors.New("the invoice ID is invalid")
+	errInvoiceFormat    = errors.New("the format must be json or pdf")
+)
+
+// serveInvoices sends the invoices paid by the user.
+func (h *APIHandler) serveInvoices(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	ii, err := h.pm.Paid(u)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, ii)
+}
+
+// serveInvoice sends one of the user's invoices in the format given by the
+// optional format query parameter, which is either json, the default, or
+// pdf.
+func (h *APIHandler) serveInvoice(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidInvoiceID)
+		return
+	}
+	inv, err := h.pm.ByID(u, id)
+	if err == payment.ErrInvoiceNotFound {
+		sendError(w, http.StatusNotFound, err)
+		return
+	} else if err == payment.ErrNotPayer {
+		sendError(w, http.StatusForbidden, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	switch r.URL.Query().Get("format") {
+	case "", "json":
+		sendResult(w, inv)
+	case "pdf":
+		p, err := h.ps.ByID(inv.ParcelID)
+		if err != nil {
+			sendError(w, http.StatusInternalServerError, err)
+			return
+		} else if p == nil {
+			sendError(w, http.StatusInternalServerError, fmt.Errorf("parcel %s does not exist", inv.ParcelID))
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pdf\"", inv.Number))
+		w.Write(label.Invoice(inv, p))
+	default:
+		sendError(w, http.StatusBadRequest, errInvoiceFormat)
+	}
+}
@@ -0,0 +1,221 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	errInvalidSlotID     = errors.New("the time slot id is invalid")
+	errSlotNotFound      = errors.New("a time slot with that id does not exist")
+	errInvalidPickupID   = errors.New("the pickup id is invalid")
+	errPickupNotFound    = errors.New("a pickup with that id does not exist")
+	errNoPickupScheduled = errors.New("no pickup of the parcel has been booked")
+)
+
+// pickupStatus returns the HTTP status code for the error err returned by
+// the pickup service.
+func pickupStatus(err error) int {
+	switch err {
+	case pickup.ErrNotSender:
+		return http.StatusForbidden
+	case pickup.ErrNotAwaitingPickup, pickup.ErrAlreadyBooked, pickup.ErrSlotFull, pickup.ErrNotBooked,
+		pickup.ErrCancellationClosed, pickup.ErrSlotInPast, parcel.ErrEventTimeOrder:
+		return http.StatusConflict
+	case pickup.ErrWrongCity, pickup.ErrCityEmpty, pickup.ErrInvalidCapacity, pickup.ErrInvalidSlotTime,
+		parcel.ErrEventInFuture:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// servePickupSlots sends the future time slots in the city and on the
+// planet given by the form values, which can still be booked.
+func (h *APIHandler) servePickupSlots(w http.ResponseWriter, r *http.Request) {
+	ss, err := h.pk.Available(r.FormValue("city"), r.FormValue("planet"))
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, ss)
+}
+
+func (h *APIHandler) addPickupSlot(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	capacity, err := strconv.Atoi(r.PostFormValue("capacity"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, pickup.ErrInvalidCapacity)
+		return
+	}
+	start, err := time.Parse(time.RFC3339, r.PostFormValue("start"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	end, err := time.Parse(time.RFC3339, r.PostFormValue("end"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	sl, err := h.pk.AddSlot(r.PostFormValue("city"), r.PostFormValue("planet"), start, end, capacity)
+	if err != nil {
+		sendError(w, pickupStatus(err), err)
+		return
+	}
+
+	sendResult(w, sl)
+}
+
+// confirmPickup records that a courier has collected the parcel of the
+// pickup at the time given by the optional time form value.
+func (h *APIHandler) confirmPickup(w http.ResponseWriter, r *http.Request) {
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidPickupID)
+		return
+	}
+	err = r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	var at time.Time
+	if ts := r.PostFormValue("time"); ts != "" {
+		at, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			sendError(w, http.StatusBadRequest, err)
+			return
+		}
+	}
+	pk, err := h.pk.Pickups.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if pk == nil {
+		sendError(w, http.StatusNotFound, errPickupNotFound)
+		return
+	}
+
+	e, err := h.pk.Confirm(pk, at)
+	if err != nil {
+		sendError(w, pickupStatus(err), err)
+		return
+	}
+
+	sendResult(w, event{Event: e, Description: e.Type.String()})
+}
+
+// pickupParcel returns the parcel identified by the id route variable,
+// sending an error and returning nil, if there is no such parcel.
+func (h *APIHandler) pickupParcel(w http.ResponseWriter, r *http.Request) *parcel.Parcel {
+	id, err := parcel.ParseTrackingID(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return nil
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return nil
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return nil
+	}
+
+	return p
+}
+
+// servePickup sends the booked pickup of a parcel sent by the user.
+func (h *APIHandler) servePickup(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	p := h.pickupParcel(w, r)
+	if p == nil {
+		return
+	}
+	ok, err := p.SentBy(u, h.as)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if !ok {
+		sendError(w, http.StatusForbidden, pickup.ErrNotSender)
+		return
+	}
+
+	pk, err := h.pk.Pickups.ByParcel(p.ID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if pk == nil {
+		sendError(w, http.StatusNotFound, errNoPickupScheduled)
+		return
+	}
+
+	sendResult(w, pk)
+}
+
+// bookPickup books the pickup of a parcel in the time slot identified by
+// the slot form value.
+func (h *APIHandler) bookPickup(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	slotID, err := uuid.Parse(r.PostFormValue("slot"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidSlotID)
+		return
+	}
+	p := h.pickupParcel(w, r)
+	if p == nil {
+		return
+	}
+	sl, err := h.pk.Pickups.SlotByID(slotID)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if sl == nil {
+		sendError(w, http.StatusNotFound, errSlotNotFound)
+		return
+	}
+
+	pk, err := h.pk.Book(u, p, sl)
+	if err != nil {
+		sendError(w, pickupStatus(err), err)
+		return
+	}
+
+	sendResult(w, pk)
+}
+
+func (h *APIHandler) cancelPickup(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	p := h.pickupParcel(w, r)
+	if p == nil {
+		return
+	}
+
+	pk, err := h.pk.Cancel(u, p)
+	if err != nil {
+		sendError(w, pickupStatus(err), err)
+		return
+	}
+
+	sendResult(w, pk)
+}
@@ -0,0 +1,34 @@
+package json
+
+import (
+	"net/http"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+func (h *APIHandler) requestQuote(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	req, err := pricing.ParseQuoteForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	// Quotes requested anonymously may be accepted by any customer.
+	u, _ := user.FromContext(r.Context())
+	q, err := h.pr.Quote(req, u)
+	if pricing.IsInvalid(err) {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, q)
+}
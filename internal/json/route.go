@@ -0,0 +1,245 @@
+package json
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	errInvalidVehicleID = errors.New("the vehicle id is invalid")
+	errInvalidRouteID   = errors.New("the route id is invalid")
+	errInvalidStopID    = errors.New("the stop id is invalid")
+	errInvalidLocation  = errors.New("the latitude and longitude must be numbers")
+)
+
+// routeStatus returns the HTTP status code for the error err returned by
+// the route service.
+func routeStatus(err error) int {
+	switch err {
+	case route.ErrNotDriver:
+		return http.StatusForbidden
+	case route.ErrVehicleNotFound, route.ErrRouteNotFound, route.ErrStopNotFound, center.ErrCenterNotFound:
+		return http.StatusNotFound
+	case route.ErrWrongPlanet, route.ErrNoParcels, route.ErrVehicleBusy, route.ErrStopServed,
+		parcel.ErrInvalidTransition, parcel.ErrEventTimeOrder, parcel.ErrNotOutForDelivery, locker.ErrPointFull,
+		locker.ErrNotOutForPickup, locker.ErrAlreadyAllocated:
+		return http.StatusConflict
+	case route.ErrVehicleNameEmpty, route.ErrCityEmpty, route.ErrInvalidCapacity, route.ErrInvalidLocation,
+		route.ErrReasonRequired, parcel.ErrEventInFuture, parcel.ErrRecipientNameEmpty, parcel.ErrNoEvidence,
+		parcel.ErrInvalidImage, parcel.ErrImageTooLarge, parcel.ErrInvalidLocation:
+		return http.StatusBadRequest
+	default:
+		return http.StatusInternalServerError
+	}
+}
+
+// stop is the JSON representation of a served stop.
+type stop struct {
+	*route.Stop
+	Event event `json:"event"`
+}
+
+func (h *APIHandler) serveVehicles(w http.ResponseWriter, r *http.Request) {
+	vv, err := h.rt.Routes.Vehicles()
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, vv)
+}
+
+func (h *APIHandler) addVehicle(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	capacity, err := strconv.Atoi(r.PostFormValue("capacity"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, route.ErrInvalidCapacity)
+		return
+	}
+	v, err := route.NewVehicle(r.PostFormValue("name"), r.PostFormValue("city"), r.PostFormValue("planet"),
+		capacity)
+	if err != nil {
+		sendError(w, routeStatus(err), err)
+		return
+	}
+	err = h.rt.Routes.InsertVehicle(v)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, v)
+}
+
+// addPlace adds the location of a zip code given by the latitude and
+// longitude form values to the geodata table.
+func (h *APIHandler) addPlace(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	lat, err := strconv.ParseFloat(r.PostFormValue("latitude"), 64)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidLocation)
+		return
+	}
+	lon, err := strconv.ParseFloat(r.PostFormValue("longitude"), 64)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidLocation)
+		return
+	}
+	p, err := route.NewPlace(r.PostFormValue("planet"), r.PostFormValue("city"), r.PostFormValue("zip"),
+		lat, lon)
+	if err != nil {
+		sendError(w, routeStatus(err), err)
+		return
+	}
+	err = h.rt.Routes.InsertPlace(p)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, p)
+}
+
+// planRoute plans the route of the vehicle identified by the vehicle form
+// value from the center identified by the center form value, which is
+// driven by the user called by the driver form value.
+func (h *APIHandler) planRoute(w http.ResponseWriter, r *http.Request) {
+	at, ok := parseEventTime(w, r)
+	if !ok {
+		return
+	}
+	cid, err := uuid.Parse(r.PostFormValue("center"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidCenterID)
+		return
+	}
+	vid, err := uuid.Parse(r.PostFormValue("vehicle"))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidVehicleID)
+		return
+	}
+	driver, err := h.us.ByUsername(r.PostFormValue("driver"))
+	if err == user.ErrUserNotExists {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	} else if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	rt, err := h.rt.Plan(cid, vid, driver, at)
+	if err != nil {
+		sendError(w, routeStatus(err), err)
+		return
+	}
+
+	sendResult(w, rt)
+}
+
+// serveRoutes sends the user's routes from today on.
+func (h *APIHandler) serveRoutes(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	rr, err := h.rt.Routes.ByDriver(u.ID, time.Now())
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	sendResult(w, rr)
+}
+
+func (h *APIHandler) serveRoute(w http.ResponseWriter, r *http.Request) {
+	u := user.MustFromContext(r.Context())
+	id, err := uuid.Parse(mux.Vars(r)["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidRouteID)
+		return
+	}
+	rt, err := h.rt.Route(u, id)
+	if err != nil {
+		sendError(w, routeStatus(err), err)
+		return
+	}
+
+	sendResult(w, rt)
+}
+
+// deliverStop records the delivery of a stop's parcel together with the
+// proof of delivery contained in the multipart form.
+func (h *APIHandler) deliverStop(w http.ResponseWriter, r *http.Request) {
+	req, err := parcel.ParseDeliveryForm(r)
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.serveStop(w, r, func(u *user.User, id, sid uuid.UUID, at time.Time) (*route.Stop, *parcel.Event, error) {
+		return h.rt.Deliver(u, id, sid, req)
+	})
+}
+
+// failStop records a failed attempt to deliver a stop's parcel for the
+// reason given by the reason form value.
+func (h *APIHandler) failStop(w http.ResponseWriter, r *http.Request) {
+	err := r.ParseMultipartForm(0)
+	if err != nil && err != http.ErrNotMultipart {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+	var reason parcel.FailureReason
+	err = reason.UnmarshalText([]byte(r.PostFormValue("reason")))
+	if err != nil {
+		sendError(w, http.StatusBadRequest, err)
+		return
+	}
+
+	h.serveStop(w, r, func(u *user.User, id, sid uuid.UUID, at time.Time) (*route.Stop, *parcel.Event, error) {
+		return h.rt.Fail(u, id, sid, reason, at)
+	})
+}
+
+func (h *APIHandler) serveStop(w http.ResponseWriter, r *http.Request,
+	serve func(*user.User, uuid.UUID, uuid.UUID, time.Time) (*route.Stop, *parcel.Event, error)) {
+	u := user.MustFromContext(r.Context())
+	v := mux.Vars(r)
+	id, err := uuid.Parse(v["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidRouteID)
+		return
+	}
+	sid, err := uuid.Parse(v["stop"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidStopID)
+		return
+	}
+	at, ok := parseEventTime(w, r)
+	if !ok {
+		return
+	}
+
+	st, e, err := serve(u, id, sid, at)
+	if err != nil {
+		sendError(w, routeStatus(err), err)
+		return
+	}
+
+	sendResult(w, stop{Stop: st, Event: event{Event: e, Description: e.Type.String()}})
+}
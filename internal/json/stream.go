@@ -0,0 +1,107 @@
+package json
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/mux"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// maxStreamDuration is the time after which event streams are closed.
+	// It is shorter than the server's write timeout, so streams are closed
+	// cleanly. Clients using EventSource reconnect automatically and resume
+	// the stream using the Last-Event-ID header.
+	maxStreamDuration = 10 * time.Second
+	// streamRetry is the reconnection delay sent to clients in milliseconds.
+	streamRetry = 1000
+)
+
+// streamParcelEvents streams the parcel's tracking events to the client as
+// server-sent events. The parcel's existing events are sent first, unless
+// the client resumes a stream by sending the Last-Event-ID header.
+func (h *APIHandler) streamParcelEvents(w http.ResponseWriter, r *http.Request) {
+	v := mux.Vars(r)
+	id, err := parcel.ParseTrackingID(v["id"])
+	if err != nil {
+		sendError(w, http.StatusBadRequest, errInvalidTrackingID)
+		return
+	}
+	f, ok := w.(http.Flusher)
+	if !ok {
+		sendError(w, http.StatusInternalServerError, errStreamingUnsupported)
+		return
+	}
+	p, err := h.ps.ByID(id)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	} else if p == nil {
+		sendError(w, http.StatusNotFound, errParcelNotFound)
+		return
+	}
+
+	// Subscribe before retrieving the existing events, so no event is lost.
+	ch, cancel := h.sub.Subscribe(p.ID)
+	defer cancel()
+	ee, err := h.es.ByParcel(p)
+	if err != nil {
+		sendError(w, http.StatusInternalServerError, err)
+		return
+	}
+	if lastID := r.Header.Get("Last-Event-ID"); lastID != "" {
+		for i, e := range ee {
+			if e.ID.String() == lastID {
+				ee = ee[i+1:]
+				break
+			}
+		}
+	}
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	fmt.Fprintf(w, "retry: %d\n\n", streamRetry)
+	sent := make(map[uuid.UUID]bool, len(ee))
+	for _, e := range ee {
+		err = writeServerSentEvent(w, e)
+		if err != nil {
+			return
+		}
+		sent[e.ID] = true
+	}
+	f.Flush()
+
+	timeout := time.NewTimer(maxStreamDuration)
+	defer timeout.Stop()
+	for {
+		select {
+		case e := <-ch:
+			if sent[e.ID] {
+				continue
+			}
+			err = writeServerSentEvent(w, e)
+			if err != nil {
+				return
+			}
+			f.Flush()
+		case <-timeout.C:
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func writeServerSentEvent(w http.ResponseWriter, e *parcel.Event) error {
+	b, err := json.Marshal(event{Event: e, Description: e.Type.String()})
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(w, "id: %s\nevent: parcel-event\ndata: %s\n\n", e.ID, b)
+
+	return err
+}
@@ -77,6 +77,19 @@ func (w *responseWriter) WriteHeader(statusCode int) {
 	w.w.WriteHeader(statusCode)
 }
 
+// Flush sends any buffered data to the client, if the underlying
+// ResponseWriter implements the http.Flusher interface.
+func (w *responseWriter) Flush() {
+	if !w.saved {
+		w.saveSession()
+	}
+
+	f, ok := w.w.(http.Flusher)
+	if ok {
+		f.Flush()
+	}
+}
+
 func (w *responseWriter) saveSession() {
 	if w.saved {
 		return
@@ -35,6 +35,13 @@ func NewForUser(u *user.User) (*Address, error) {
 	}, nil
 }
 
+// SameAs reports whether a and b are the same postal address, even if they
+// belong to different users.
+func (a *Address) SameAs(b *Address) bool {
+	return a.Street == b.Street && a.Zip == b.Zip && a.City == b.City && a.Country == b.Country &&
+		a.Planet == b.Planet
+}
+
 var formDecoder = schema.NewDecoder()
 
 // NewFromFormForUser parses r's post form, decoding it into an Address,
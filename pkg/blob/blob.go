@@ -0,0 +1,84 @@
+// Package blob defines the interface of stores for binary objects, like
+// images uploaded as proof of delivery, and implements it on top of the
+// file system.
+package blob
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/google/uuid"
+)
+
+var (
+	ErrNotFound   = errors.New("blob: the object does not exist")
+	ErrInvalidKey = errors.New("blob: invalid key")
+)
+
+// Store stores binary objects under keys.
+//
+// Put stores the data read from r under key, replacing any object with
+// the same key. Get returns a reader of the object stored under key, which
+// must be closed by the caller, or ErrNotFound. Delete removes the object
+// stored under key and does nothing, if it does not exist.
+type Store interface {
+	Put(key string, r io.Reader) error
+	Get(key string) (io.ReadCloser, error)
+	Delete(key string) error
+}
+
+// Config selects and configures the implementation of Store.
+type Config struct {
+	// Driver is the name of the implementation. Only "filesystem" is
+	// supported, which is also the default.
+	Driver string
+	// Dir is the directory used by the filesystem driver.
+	Dir string
+}
+
+// DefaultDir is the directory used by the filesystem driver, unless
+// configured otherwise.
+const DefaultDir = "./blobs"
+
+// Open returns the store configured by conf, which may be nil.
+func Open(conf *Config) (Store, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	switch conf.Driver {
+	case "", "filesystem":
+		dir := conf.Dir
+		if dir == "" {
+			dir = DefaultDir
+		}
+		return NewFileStore(dir)
+	default:
+		return nil, fmt.Errorf("blob: unknown driver %q", conf.Driver)
+	}
+}
+
+// NewKey returns a new random key.
+func NewKey() (string, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+
+	return id.String(), nil
+}
+
+// validKey reports whether key only consists of ASCII letters, digits and
+// hyphens, so that it can safely be used as a file name.
+func validKey(key string) bool {
+	if key == "" {
+		return false
+	}
+	for _, r := range key {
+		if !(r >= 'a' && r <= 'z' || r >= 'A' && r <= 'Z' || r >= '0' && r <= '9' || r == '-') {
+			return false
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,78 @@
+package blob
+
+import (
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+)
+
+// FileStore is a Store, which stores every object in a file named like its
+// key in a directory.
+type FileStore struct {
+	dir string
+}
+
+// NewFileStore returns a FileStore storing objects in dir, which is
+// created, if it does not exist.
+func NewFileStore(dir string) (*FileStore, error) {
+	err := os.MkdirAll(dir, 0700)
+	if err != nil {
+		return nil, err
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// Put writes the object to a temporary file first, which is renamed once
+// it is complete, so that readers never see partially written objects.
+func (s *FileStore) Put(key string, r io.Reader) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	f, err := ioutil.TempFile(s.dir, ".put-")
+	if err != nil {
+		return err
+	}
+	_, err = io.Copy(f, r)
+	if err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	err = f.Close()
+	if err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	err = os.Rename(f.Name(), filepath.Join(s.dir, key))
+	if err != nil {
+		os.Remove(f.Name())
+	}
+
+	return err
+}
+
+func (s *FileStore) Get(key string) (io.ReadCloser, error) {
+	if !validKey(key) {
+		return nil, ErrInvalidKey
+	}
+	f, err := os.Open(filepath.Join(s.dir, key))
+	if os.IsNotExist(err) {
+		return nil, ErrNotFound
+	}
+
+	return f, err
+}
+
+func (s *FileStore) Delete(key string) error {
+	if !validKey(key) {
+		return ErrInvalidKey
+	}
+	err := os.Remove(filepath.Join(s.dir, key))
+	if os.IsNotExist(err) {
+		return nil
+	}
+
+	return err
+}
@@ -0,0 +1,122 @@
+// Package center manages the logistics centers, in which parcels are
+// sorted, their sorting bins and the scans of parcels arriving in them.
+package center
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrNameEmpty   = errors.New("center: a logistics center's name must not be empty")
+	ErrPlanetEmpty = errors.New("center: the planet must not be empty")
+	ErrLabelEmpty  = errors.New("center: a sorting bin's label must not be empty")
+)
+
+// Center is a logistics center, in which parcels are sorted.
+type Center struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	City   string    `json:"city"`
+	Planet string    `json:"planet"`
+}
+
+// NewCenter returns a new logistics center called name in the given city
+// on planet.
+func NewCenter(name, city, planet string) (*Center, error) {
+	name, planet = strings.TrimSpace(name), strings.TrimSpace(planet)
+	if name == "" {
+		return nil, ErrNameEmpty
+	} else if planet == "" {
+		return nil, ErrPlanetEmpty
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Center{ID: id, Name: name, City: strings.TrimSpace(city), Planet: planet}, nil
+}
+
+// Bin is a sorting bin of a logistics center, which collects the parcels
+// leaving the center on the same route.
+type Bin struct {
+	ID     uuid.UUID `json:"id"`
+	Center uuid.UUID `json:"center"`
+	// Label is printed on the bin, e.g. "A3".
+	Label string `json:"label"`
+	// Planet is the destination planet of the parcels in the bin.
+	Planet string `json:"planet"`
+	// City restricts the bin to parcels delivered to the city on Planet. It
+	// is empty for bins collecting all parcels to Planet, e.g. those
+	// loaded into rockets.
+	City string `json:"city,omitempty"`
+}
+
+// NewBin returns a new sorting bin of c labelled label, which collects the
+// parcels to the given city on planet or to all of planet, if city is
+// empty.
+func NewBin(c *Center, label, planet, city string) (*Bin, error) {
+	label, planet = strings.TrimSpace(label), strings.TrimSpace(planet)
+	if label == "" {
+		return nil, ErrLabelEmpty
+	} else if planet == "" {
+		return nil, ErrPlanetEmpty
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Bin{ID: id, Center: c.ID, Label: label, Planet: planet, City: strings.TrimSpace(city)}, nil
+}
+
+// Sort returns the bin of bb, into which parcels to dest are sorted, or
+// nil, if there is none. Bins for dest's city take precedence over bins for
+// all of its planet.
+func Sort(bb []*Bin, dest *address.Address) *Bin {
+	if dest == nil {
+		return nil
+	}
+	var match *Bin
+	for _, b := range bb {
+		if !strings.EqualFold(b.Planet, strings.TrimSpace(dest.Planet)) {
+			continue
+		}
+		if b.City == "" && match == nil {
+			match = b
+		} else if b.City != "" && strings.EqualFold(b.City, strings.TrimSpace(dest.City)) {
+			return b
+		}
+	}
+
+	return match
+}
+
+// Scan is the scan of a parcel by a handheld device in a logistics center.
+type Scan struct {
+	ID       uuid.UUID `json:"id"`
+	Center   uuid.UUID `json:"center"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	// Bin is the sorting bin, into which the parcel has been dropped.
+	Bin  *Bin      `json:"bin"`
+	Time time.Time `json:"time"`
+	// Event is the event recorded by the scan. It is nil, if the parcel had
+	// already arrived at the center before, and it is not loaded by
+	// Storage.
+	Event *parcel.Event `json:"-"`
+}
+
+func newScan(c *Center, p *parcel.Parcel, b *Bin, at time.Time) (*Scan, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Scan{ID: id, Center: c.ID, ParcelID: p.ID, Bin: b, Time: at}, nil
+}
@@ -0,0 +1,157 @@
+package center
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrCenterNotFound = errors.New("center: the logistics center does not exist")
+	ErrNotExpected    = errors.New("center: the parcel is not expected at a logistics center")
+	ErrWrongPlanet    = errors.New("center: the parcel is not expected on the logistics center's planet")
+	ErrNoBin          = errors.New("center: the logistics center has no sorting bin for the parcel's destination")
+)
+
+// Service sorts the parcels scanned in logistics centers.
+type Service struct {
+	Centers Storage
+	// Events must publish the recorded events, so that customers are
+	// notified about their parcels' arrival.
+	Events parcel.EventStorage
+}
+
+// Scan records the scan of p in the logistics center identified by id at
+// time at, or just now, if at is the zero time, and returns the sorting
+// bin, into which p has to be dropped. The first scan in a center records
+// p's DeliveredToProcessing event. Parcels may be scanned again, e.g. after
+// they have been redirected, as long as they are being processed at the
+// center.
+func (s *Service) Scan(id uuid.UUID, p *parcel.Parcel, at time.Time) (*Scan, error) {
+	c, err := s.Centers.CenterByID(id)
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, ErrCenterNotFound
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+	st := parcel.NewState(p, ee)
+
+	arrival := true
+	err = st.Check(parcel.DeliveredToProcessing, at)
+	if err == parcel.ErrInvalidTransition {
+		arrival = false
+		err = s.checkRescan(c, st)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if arrival {
+		st.Apply(&parcel.Event{Parcel: p, Type: parcel.DeliveredToProcessing, Time: at})
+	}
+	if st.Planet != "" && !strings.EqualFold(st.Planet, c.Planet) {
+		return nil, ErrWrongPlanet
+	}
+
+	bb, err := s.Centers.Bins(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	b := Sort(bb, st.Destination())
+	if b == nil {
+		return nil, ErrNoBin
+	}
+	sc, err := newScan(c, p, b, at.Local())
+	if err != nil {
+		return nil, err
+	}
+	if arrival {
+		sc.Event, err = parcel.Record(s.Events, p, parcel.DeliveredToProcessing, at)
+		if err != nil {
+			return nil, err
+		}
+	}
+	err = s.Centers.InsertScan(sc)
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
+
+// checkRescan returns ErrNotExpected, unless the parcel in state st is
+// being processed at c.
+func (s *Service) checkRescan(c *Center, st *parcel.State) error {
+	switch st.Position() {
+	case parcel.DeliveredToProcessing, parcel.HeldAtCustoms, parcel.ReleasedFromCustoms:
+	default:
+		return ErrNotExpected
+	}
+	last, err := s.Centers.LastScan(st.Parcel.ID)
+	if err != nil {
+		return err
+	} else if last == nil || last.Center != c.ID {
+		return ErrNotExpected
+	}
+
+	return nil
+}
+
+// Load is the content of a sorting bin.
+type Load struct {
+	Bin *Bin `json:"bin"`
+	// Parcels are the ids of the parcels in the bin.
+	Parcels []uuid.UUID `json:"parcels"`
+}
+
+// Dashboard lists the parcels being processed at a logistics center by
+// their sorting bins.
+type Dashboard struct {
+	Center *Center `json:"center"`
+	Bins   []*Load `json:"bins"`
+	// Total is the number of parcels at the center.
+	Total int `json:"total"`
+}
+
+// Dashboard returns the dashboard of the logistics center identified by
+// id.
+func (s *Service) Dashboard(id uuid.UUID) (*Dashboard, error) {
+	c, err := s.Centers.CenterByID(id)
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, ErrCenterNotFound
+	}
+	bb, err := s.Centers.Bins(c.ID)
+	if err != nil {
+		return nil, err
+	}
+	ss, err := s.Centers.Parcels(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	d := &Dashboard{Center: c, Bins: make([]*Load, 0, len(bb)), Total: len(ss)}
+	loads := make(map[uuid.UUID]*Load, len(bb))
+	for _, b := range bb {
+		l := &Load{Bin: b, Parcels: []uuid.UUID{}}
+		loads[b.ID] = l
+		d.Bins = append(d.Bins, l)
+	}
+	for _, sc := range ss {
+		if l, ok := loads[sc.Bin.ID]; ok {
+			l.Parcels = append(l.Parcels, sc.ParcelID)
+		}
+	}
+
+	return d, nil
+}
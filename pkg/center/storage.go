@@ -0,0 +1,42 @@
+package center
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrLabelTaken is returned by InsertBin, if the center already has a
+// sorting bin with the same label.
+var ErrLabelTaken = errors.New("center: the logistics center already has a sorting bin with that label")
+
+// Storage is the interface for managing logistics centers, their sorting
+// bins and scans.
+//
+// CenterByID returns nil, if there is no logistics center identified by
+// id.
+//
+// Centers returns all logistics centers ordered by their planets and
+// names.
+//
+// InsertBin returns ErrLabelTaken, if the bin's center already has a bin
+// with the same label.
+//
+// Bins returns the sorting bins of the center identified by id ordered by
+// their labels.
+//
+// LastScan returns the latest scan of the parcel identified by id or nil.
+//
+// Parcels returns the latest scans of the parcels, which are still being
+// processed at the center identified by id, i.e. which have neither been
+// scanned elsewhere nor moved on since, ordered by their time.
+type Storage interface {
+	InsertCenter(c *Center) error
+	CenterByID(id uuid.UUID) (*Center, error)
+	Centers() ([]*Center, error)
+	InsertBin(b *Bin) error
+	Bins(id uuid.UUID) ([]*Bin, error)
+	InsertScan(s *Scan) error
+	LastScan(id uuid.UUID) (*Scan, error)
+	Parcels(id uuid.UUID) ([]*Scan, error)
+}
@@ -0,0 +1,210 @@
+// Package claim implements damage and loss claims for insured parcels,
+// which are filed by the parcels' senders together with evidence and
+// reviewed by our staff. Approved claims are refunded to the credit card
+// chosen by the claimant.
+package claim
+
+import (
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// MaxDescriptionLength and MaxNoteLength limit the length of the
+	// claimant's description and the reviewer's note.
+	MaxDescriptionLength = 2000
+	MaxNoteLength        = 1000
+	// MaxEvidence is the maximum number of files attached to a claim.
+	MaxEvidence = 5
+	// MaxEvidenceSize is the maximum size of a file in bytes.
+	MaxEvidenceSize = 4 << 20
+	// LossPeriod is the time without any tracking event, after which an
+	// undelivered parcel may be claimed as lost.
+	LossPeriod = 14 * 24 * time.Hour
+)
+
+var (
+	ErrUnknownKind         = errors.New("claim: the kind of claim must be damage or loss")
+	ErrDescriptionEmpty    = errors.New("claim: a description of the damage or loss is required")
+	ErrDescriptionTooLong  = errors.New("claim: the description must not be longer than 2000 characters")
+	ErrInvalidAmount       = errors.New("claim: the amount must be positive and must not exceed the declared value")
+	ErrTooMuchEvidence     = errors.New("claim: at most 5 files may be attached to a claim")
+	ErrInvalidEvidence     = errors.New("claim: evidence must be PNG or JPEG images or PDF documents")
+	ErrEvidenceTooLarge    = errors.New("claim: evidence must not be larger than 4 MiB")
+	ErrEvidenceNameTooLong = errors.New("claim: the file name must not be longer than 255 characters")
+	ErrNoteTooLong         = errors.New("claim: the note must not be longer than 1000 characters")
+)
+
+// Kind is the kind of a claim.
+type Kind string
+
+const (
+	// Damage claims are filed for parcels, which have been delivered
+	// damaged.
+	Damage Kind = "damage"
+	// Loss claims are filed for parcels, which have not been delivered and
+	// have not been seen for LossPeriod.
+	Loss Kind = "loss"
+)
+
+func (k Kind) String() string {
+	switch k {
+	case Damage:
+		return "Damage"
+	case Loss:
+		return "Loss"
+	default:
+		return "Unknown"
+	}
+}
+
+// Status is the status of a claim in the review process.
+type Status string
+
+const (
+	Submitted Status = "submitted"
+	Approved  Status = "approved"
+	Rejected  Status = "rejected"
+)
+
+func (s Status) String() string {
+	switch s {
+	case Submitted:
+		return "Under review"
+	case Approved:
+		return "Approved"
+	case Rejected:
+		return "Rejected"
+	default:
+		return "Unknown"
+	}
+}
+
+// Claim is a claim for the insured value of a damaged or lost parcel.
+type Claim struct {
+	ID       uuid.UUID `json:"id"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	// Claimant is the ID of the user, who has filed the claim.
+	Claimant    uuid.UUID `json:"-"`
+	Kind        Kind      `json:"kind"`
+	Description string    `json:"description"`
+	// Amount is the claimed amount, which does not exceed the parcel's
+	// declared value.
+	Amount parcel.Cents `json:"amount"`
+	// CardID identifies the credit card, to which the claim is refunded.
+	// It is nil, once the card has been deleted.
+	CardID  *uuid.UUID `json:"cardId,omitempty"`
+	Status  Status     `json:"status"`
+	Created time.Time  `json:"created"`
+	// Reviewer is the ID of the operator, who has decided on the claim, or
+	// nil.
+	Reviewer *uuid.UUID `json:"-"`
+	// Note is the reviewer's explanation of the decision.
+	Note    string     `json:"note,omitempty"`
+	Decided *time.Time `json:"decided,omitempty"`
+	// Refund is the refund of an approved claim or nil.
+	Refund   *Refund     `json:"refund,omitempty"`
+	Evidence []*Evidence `json:"evidence"`
+}
+
+// Evidence is a file attached to a claim, e.g. a photo of the damaged
+// parcel.
+type Evidence struct {
+	ID      uuid.UUID `json:"id"`
+	ClaimID uuid.UUID `json:"-"`
+	// Name is the file name given by the claimant.
+	Name        string `json:"name"`
+	ContentType string `json:"contentType"`
+	// Key is the key of the file in the blob store.
+	Key      string    `json:"-"`
+	Uploaded time.Time `json:"uploaded"`
+}
+
+// Refund is the money refunded for an approved claim.
+type Refund struct {
+	ID      uuid.UUID `json:"id"`
+	ClaimID uuid.UUID `json:"-"`
+	// CardID identifies the credit card, to which the amount is refunded.
+	// It is nil, once the card has been deleted.
+	CardID  *uuid.UUID   `json:"cardId,omitempty"`
+	Amount  parcel.Cents `json:"amount"`
+	Created time.Time    `json:"created"`
+}
+
+// Upload is a file uploaded as evidence.
+type Upload struct {
+	Name string
+	Data []byte
+}
+
+// contentType returns the content type of the upload or ErrInvalidEvidence,
+// unless it is a PNG or JPEG image or a PDF document.
+func (up *Upload) contentType() (string, error) {
+	if len(up.Data) == 0 {
+		return "", ErrInvalidEvidence
+	} else if len(up.Data) > MaxEvidenceSize {
+		return "", ErrEvidenceTooLarge
+	} else if len(up.Name) > 255 {
+		return "", ErrEvidenceNameTooLong
+	}
+	switch ct := http.DetectContentType(up.Data); ct {
+	case "image/png", "image/jpeg", "application/pdf":
+		return ct, nil
+	default:
+		return "", ErrInvalidEvidence
+	}
+}
+
+// Request is a claim filed by the sender of a parcel.
+type Request struct {
+	ParcelID    uuid.UUID
+	Kind        Kind
+	Description string
+	// Amount defaults to the parcel's declared value, if it is zero.
+	Amount   parcel.Cents
+	CardID   uuid.UUID
+	Evidence []*Upload
+}
+
+// Validate returns an error, unless r is a well-formed claim.
+func (r *Request) Validate() error {
+	if r.Kind != Damage && r.Kind != Loss {
+		return ErrUnknownKind
+	}
+	d := strings.TrimSpace(r.Description)
+	if d == "" {
+		return ErrDescriptionEmpty
+	} else if len([]rune(d)) > MaxDescriptionLength {
+		return ErrDescriptionTooLong
+	} else if r.Amount < 0 {
+		return ErrInvalidAmount
+	} else if len(r.Evidence) > MaxEvidence {
+		return ErrTooMuchEvidence
+	}
+	for _, up := range r.Evidence {
+		_, err := up.contentType()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// IsInvalid reports whether err has been returned by one of the package's
+// functions, because the request is invalid.
+func IsInvalid(err error) bool {
+	switch err {
+	case ErrUnknownKind, ErrDescriptionEmpty, ErrDescriptionTooLong, ErrInvalidAmount, ErrTooMuchEvidence,
+		ErrInvalidEvidence, ErrEvidenceTooLarge, ErrEvidenceNameTooLong, ErrNoteTooLong, ErrNotInsured,
+		ErrNotDelivered, ErrNotLost, ErrForeignCard, ErrAlreadyDecided, ErrCardDeleted, ErrUnknownDecision:
+		return true
+	default:
+		return false
+	}
+}
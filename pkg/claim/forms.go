@@ -0,0 +1,135 @@
+package claim
+
+import (
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var ErrUnknownDecision = errors.New("claim: the decision must be approve or reject")
+
+// ParseClaimForm parses a claim from the multipart form of r, which
+// contains the fields parcel, kind, description, card and the optional
+// amount in the currency's main unit, and up to MaxEvidence files named
+// evidence.
+func ParseClaimForm(r *http.Request) (*Request, error) {
+	err := r.ParseMultipartForm(2 * MaxEvidenceSize)
+	if err != nil {
+		return nil, err
+	}
+	req := &Request{
+		Kind:        Kind(r.PostFormValue("kind")),
+		Description: r.PostFormValue("description"),
+	}
+	req.ParcelID, err = parcel.ParseTrackingID(r.PostFormValue("parcel"))
+	if err != nil {
+		return nil, err
+	}
+	req.CardID, err = uuid.Parse(r.PostFormValue("card"))
+	if err != nil {
+		return nil, ErrForeignCard
+	}
+	req.Amount, err = parseAmount(r.PostFormValue("amount"))
+	if err != nil {
+		return nil, err
+	}
+	req.Evidence, err = formFiles(r.MultipartForm, "evidence")
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// ParseEvidenceForm parses the files named evidence from the multipart
+// form of r.
+func ParseEvidenceForm(r *http.Request) ([]*Upload, error) {
+	err := r.ParseMultipartForm(2 * MaxEvidenceSize)
+	if err != nil {
+		return nil, err
+	}
+
+	return formFiles(r.MultipartForm, "evidence")
+}
+
+// formFiles returns the files uploaded as the form field name.
+func formFiles(f *multipart.Form, name string) ([]*Upload, error) {
+	if f == nil {
+		return nil, nil
+	} else if len(f.File[name]) > MaxEvidence {
+		return nil, ErrTooMuchEvidence
+	}
+	uu := make([]*Upload, 0, len(f.File[name]))
+	for _, fh := range f.File[name] {
+		file, err := fh.Open()
+		if err != nil {
+			return nil, err
+		}
+		data, err := ioutil.ReadAll(io.LimitReader(file, MaxEvidenceSize+1))
+		file.Close()
+		if err != nil {
+			return nil, err
+		} else if len(data) > MaxEvidenceSize {
+			return nil, ErrEvidenceTooLarge
+		}
+		uu = append(uu, &Upload{Name: fh.Filename, Data: data})
+	}
+
+	return uu, nil
+}
+
+// parseAmount parses an amount given in the currency's main unit, e.g.
+// "12.50", and returns it in cents. The empty string is parsed as zero.
+func parseAmount(s string) (parcel.Cents, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return 0, nil
+	}
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil || f < 0 || math.IsNaN(f) || math.IsInf(f, 0) {
+		return 0, ErrInvalidAmount
+	}
+
+	return parcel.Cents(math.Round(f * 100)), nil
+}
+
+// Review is an operator's decision on a claim.
+type Review struct {
+	Approve bool
+	// Amount is the refunded amount of approved claims, which defaults to
+	// the claimed amount, if it is zero.
+	Amount parcel.Cents
+	Note   string
+}
+
+// ParseReviewForm parses r's post form, which contains the fields decision
+// (approve or reject), note and the optional amount in the currency's main
+// unit, into a review.
+func ParseReviewForm(r *http.Request) (*Review, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	rv := &Review{Note: r.PostFormValue("note")}
+	switch r.PostFormValue("decision") {
+	case "approve":
+		rv.Approve = true
+	case "reject":
+	default:
+		return nil, ErrUnknownDecision
+	}
+	rv.Amount, err = parseAmount(r.PostFormValue("amount"))
+	if err != nil {
+		return nil, err
+	}
+
+	return rv, nil
+}
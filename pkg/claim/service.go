@@ -0,0 +1,351 @@
+package claim
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrClaimNotFound    = errors.New("claim: the claim does not exist")
+	ErrEvidenceNotFound = errors.New("claim: the file does not exist")
+	ErrParcelNotFound   = errors.New("claim: the parcel does not exist")
+	ErrNotSender        = errors.New("claim: only the sender of a parcel may claim it")
+	ErrNotClaimant      = errors.New("claim: only the claimant and our staff may access a claim")
+	ErrNotInsured       = errors.New("claim: the parcel is not insured")
+	ErrNotDelivered     = errors.New("claim: damage may only be claimed, once the parcel has been delivered")
+	ErrNotLost          = errors.New("claim: the parcel may only be claimed as lost, if it has not been seen for 14 days")
+	ErrForeignCard      = errors.New("claim: the credit card does not belong to the claimant")
+	ErrCardDeleted      = errors.New("claim: the claimant has deleted the credit card")
+	ErrNotOperator      = errors.New("claim: only operators may review claims")
+)
+
+// Service files claims on behalf of the senders of insured parcels and
+// lets operators review them.
+type Service struct {
+	Claims    Storage
+	Addresses address.Accesser
+	Parcels   parcel.Accesser
+	Events    parcel.EventAccesser
+	Cards     credit.Accesser
+	Blobs     blob.Store
+}
+
+// File files the claim req on behalf of u, who must have sent the insured
+// parcel. Damage may be claimed once the parcel has been delivered or
+// returned, loss once the parcel has not been seen for LossPeriod. The
+// refund is paid to one of u's credit cards.
+func (s *Service) File(u *user.User, req *Request) (*Claim, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	p, err := s.Parcels.ByID(req.ParcelID)
+	if err != nil {
+		return nil, err
+	} else if p == nil {
+		return nil, ErrParcelNotFound
+	}
+	ok, err := p.SentBy(u, s.Addresses)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrNotSender
+	} else if !p.Attributes.Insured {
+		return nil, ErrNotInsured
+	}
+	amount := req.Amount
+	if amount == 0 {
+		amount = p.Attributes.DeclaredValue
+	} else if amount > p.Attributes.DeclaredValue {
+		return nil, ErrInvalidAmount
+	}
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+	err = checkKind(req.Kind, parcel.NewState(p, ee), time.Now())
+	if err != nil {
+		return nil, err
+	}
+	err = s.checkCard(u, req.CardID)
+	if err != nil {
+		return nil, err
+	}
+
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	cardID := req.CardID
+	c := &Claim{
+		ID:          id,
+		ParcelID:    p.ID,
+		Claimant:    u.ID,
+		Kind:        req.Kind,
+		Description: strings.TrimSpace(req.Description),
+		Amount:      amount,
+		CardID:      &cardID,
+		Status:      Submitted,
+		Created:     time.Now(),
+		Evidence:    make([]*Evidence, 0, len(req.Evidence)),
+	}
+	for _, up := range req.Evidence {
+		e, err := s.store(c, up)
+		if err != nil {
+			s.deleteEvidence(c.Evidence)
+			return nil, err
+		}
+		c.Evidence = append(c.Evidence, e)
+	}
+	err = s.Claims.Insert(c)
+	if err != nil {
+		s.deleteEvidence(c.Evidence)
+		return nil, err
+	}
+
+	return c, nil
+}
+
+// checkKind returns an error, unless a claim of kind k may be filed for a
+// parcel in the state st at time now.
+func checkKind(k Kind, st *parcel.State, now time.Time) error {
+	switch k {
+	case Damage:
+		if st.Last == nil || len(st.Next()) > 0 {
+			return ErrNotDelivered
+		}
+	case Loss:
+		if st.Last == nil || st.Last.Type == parcel.DataReceived || len(st.Next()) == 0 ||
+			now.Sub(st.Last.Time) < LossPeriod {
+			return ErrNotLost
+		}
+	default:
+		return ErrUnknownKind
+	}
+
+	return nil
+}
+
+// checkCard returns ErrForeignCard, unless the credit card identified by
+// id belongs to u.
+func (s *Service) checkCard(u *user.User, id uuid.UUID) error {
+	cc, err := s.Cards.ByUser(u)
+	if err == credit.ErrNoCards {
+		return ErrForeignCard
+	} else if err != nil {
+		return err
+	}
+	for _, c := range cc {
+		if c.ID == id {
+			return nil
+		}
+	}
+
+	return ErrForeignCard
+}
+
+// AddEvidence attaches the file up to c on behalf of u, who must have filed
+// the claim, as long as it is under review.
+func (s *Service) AddEvidence(u *user.User, c *Claim, up *Upload) (*Evidence, error) {
+	if c.Claimant != u.ID {
+		return nil, ErrNotClaimant
+	} else if c.Status != Submitted {
+		return nil, ErrAlreadyDecided
+	} else if len(c.Evidence) >= MaxEvidence {
+		return nil, ErrTooMuchEvidence
+	}
+	e, err := s.store(c, up)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Claims.InsertEvidence(e)
+	if err != nil {
+		s.deleteEvidence([]*Evidence{e})
+		return nil, err
+	}
+	c.Evidence = append(c.Evidence, e)
+
+	return e, nil
+}
+
+// store stores the file up in the blob store and returns the evidence of
+// c referencing it.
+func (s *Service) store(c *Claim, up *Upload) (*Evidence, error) {
+	ct, err := up.contentType()
+	if err != nil {
+		return nil, err
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	key, err := blob.NewKey()
+	if err != nil {
+		return nil, err
+	}
+	err = s.Blobs.Put(key, bytes.NewReader(up.Data))
+	if err != nil {
+		return nil, err
+	}
+
+	return &Evidence{
+		ID:          id,
+		ClaimID:     c.ID,
+		Name:        strings.TrimSpace(up.Name),
+		ContentType: ct,
+		Key:         key,
+		Uploaded:    time.Now(),
+	}, nil
+}
+
+// deleteEvidence removes the files of ee from the blob store.
+func (s *Service) deleteEvidence(ee []*Evidence) {
+	for _, e := range ee {
+		s.Blobs.Delete(e.Key)
+	}
+}
+
+// ByID returns the claim identified by id, if u may access it, i.e. u has
+// filed it or is an operator.
+func (s *Service) ByID(u *user.User, id uuid.UUID) (*Claim, error) {
+	c, err := s.Claims.ByID(id)
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, ErrClaimNotFound
+	} else if c.Claimant != u.ID && !u.Operator {
+		return nil, ErrNotClaimant
+	}
+
+	return c, nil
+}
+
+// Filed returns the claims filed by u, starting with the most recent one.
+func (s *Service) Filed(u *user.User) ([]*Claim, error) {
+	return s.Claims.ByClaimant(u.ID)
+}
+
+// Pending returns the claims under review, starting with the oldest one.
+// Only operators may list them.
+func (s *Service) Pending(u *user.User) ([]*Claim, error) {
+	if !u.Operator {
+		return nil, ErrNotOperator
+	}
+
+	return s.Claims.ByStatus(Submitted)
+}
+
+// Claimable returns the insured parcels sent by u.
+func (s *Service) Claimable(u *user.User) ([]*parcel.Parcel, error) {
+	ss, err := parcel.Outgoing(u, s.Addresses, s.Parcels, s.Events)
+	if err != nil {
+		return nil, err
+	}
+	pp := make([]*parcel.Parcel, 0, len(ss))
+	for _, sum := range ss {
+		if sum.Attributes.Insured {
+			pp = append(pp, sum.Parcel)
+		}
+	}
+
+	return pp, nil
+}
+
+// Evidence returns the file identified by id, which is attached to c,
+// together with a reader of its contents, which must be closed by the
+// caller. Only the claimant and operators may read it.
+func (s *Service) Evidence(u *user.User, c *Claim, id uuid.UUID) (*Evidence, io.ReadCloser, error) {
+	if c.Claimant != u.ID && !u.Operator {
+		return nil, nil, ErrNotClaimant
+	}
+	for _, e := range c.Evidence {
+		if e.ID != id {
+			continue
+		}
+		r, err := s.Blobs.Get(e.Key)
+		if err == blob.ErrNotFound {
+			return nil, nil, ErrEvidenceNotFound
+		} else if err != nil {
+			return nil, nil, err
+		}
+		return e, r, nil
+	}
+
+	return nil, nil, ErrEvidenceNotFound
+}
+
+// Approve approves c on behalf of the operator reviewer and refunds amount
+// to the claimant's credit card. The amount defaults to the claimed one,
+// if it is zero, and must not exceed it.
+func (s *Service) Approve(reviewer *user.User, c *Claim, amount parcel.Cents, note string) error {
+	if amount == 0 {
+		amount = c.Amount
+	} else if amount < 0 || amount > c.Amount {
+		return ErrInvalidAmount
+	}
+	if c.CardID == nil {
+		return ErrCardDeleted
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	cardID := *c.CardID
+	refund := &Refund{
+		ID:      id,
+		ClaimID: c.ID,
+		CardID:  &cardID,
+		Amount:  amount,
+		Created: time.Now(),
+	}
+
+	return s.decide(reviewer, c, Approved, note, refund)
+}
+
+// Reject rejects c on behalf of the operator reviewer. The parcel may be
+// claimed again afterwards.
+func (s *Service) Reject(reviewer *user.User, c *Claim, note string) error {
+	return s.decide(reviewer, c, Rejected, note, nil)
+}
+
+// Review approves or rejects c according to rv on behalf of the operator
+// reviewer.
+func (s *Service) Review(reviewer *user.User, c *Claim, rv *Review) error {
+	if rv.Approve {
+		return s.Approve(reviewer, c, rv.Amount, rv.Note)
+	}
+
+	return s.Reject(reviewer, c, rv.Note)
+}
+
+func (s *Service) decide(reviewer *user.User, c *Claim, st Status, note string, refund *Refund) error {
+	note = strings.TrimSpace(note)
+	if !reviewer.Operator {
+		return ErrNotOperator
+	} else if c.Status != Submitted {
+		return ErrAlreadyDecided
+	} else if len([]rune(note)) > MaxNoteLength {
+		return ErrNoteTooLong
+	}
+
+	d := *c
+	now := time.Now()
+	d.Status, d.Reviewer, d.Note, d.Decided, d.Refund = st, &reviewer.ID, note, &now, refund
+	err := s.Claims.Decide(&d)
+	if err != nil {
+		return err
+	}
+	*c = d
+
+	return nil
+}
@@ -0,0 +1,161 @@
+package claim
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// decisions is a Storage, which only records the claims decided on.
+type decisions struct {
+	Storage
+	decided []*Claim
+}
+
+func (s *decisions) Decide(c *Claim) error {
+	for _, d := range s.decided {
+		if d.ID == c.ID {
+			return ErrAlreadyDecided
+		}
+	}
+	s.decided = append(s.decided, c)
+
+	return nil
+}
+
+// state returns the state of a parcel sent within Earth after the events of
+// the types tt, the last of which occurred at last.
+func state(last time.Time, tt ...parcel.EventType) *parcel.State {
+	p := &parcel.Parcel{
+		ID:                 uuid.New(),
+		ReturnAddress:      &address.Address{City: "Erlangen", Planet: "Earth"},
+		DestinationAddress: &address.Address{City: "Berlin", Planet: "Earth"},
+	}
+	ee := make([]*parcel.Event, len(tt))
+	for i, t := range tt {
+		at := last.Add(time.Duration(i-len(tt)+1) * time.Minute)
+		ee[i] = &parcel.Event{ID: uuid.New(), Parcel: p, Type: t, Time: at}
+	}
+
+	return parcel.NewState(p, ee)
+}
+
+func TestCheckKind(t *testing.T) {
+	now := time.Date(2020, 7, 10, 12, 0, 0, 0, time.UTC)
+	old := now.Add(-LossPeriod)
+	recent := now.Add(-LossPeriod + time.Hour)
+	inVehicle := []parcel.EventType{parcel.DataReceived, parcel.DeliveredToIPPS, parcel.DeliveredToProcessing,
+		parcel.LoadedIntoVehicle}
+	delivered := append(inVehicle[:len(inVehicle):len(inVehicle)], parcel.DeliveredToDestination)
+
+	if err := checkKind(Damage, state(recent, delivered...), now); err != nil {
+		t.Errorf("damage of a delivered parcel may not be claimed: %v", err)
+	}
+	if err := checkKind(Damage, state(old, inVehicle...), now); err != ErrNotDelivered {
+		t.Errorf("damage of a parcel out for delivery returned %v, want %v", err, ErrNotDelivered)
+	}
+	if err := checkKind(Damage, state(now), now); err != ErrNotDelivered {
+		t.Errorf("damage of a parcel without events returned %v, want %v", err, ErrNotDelivered)
+	}
+
+	if err := checkKind(Loss, state(old, inVehicle...), now); err != nil {
+		t.Errorf("a parcel not seen for the loss period may not be claimed as lost: %v", err)
+	}
+	if err := checkKind(Loss, state(recent, inVehicle...), now); err != ErrNotLost {
+		t.Errorf("loss of a parcel seen recently returned %v, want %v", err, ErrNotLost)
+	}
+	if err := checkKind(Loss, state(old, parcel.DataReceived), now); err != ErrNotLost {
+		t.Errorf("loss of a parcel never handed over returned %v, want %v", err, ErrNotLost)
+	}
+	if err := checkKind(Loss, state(old, delivered...), now); err != ErrNotLost {
+		t.Errorf("loss of a delivered parcel returned %v, want %v", err, ErrNotLost)
+	}
+
+	if err := checkKind("theft", state(old, inVehicle...), now); err != ErrUnknownKind {
+		t.Errorf("an unknown kind returned %v, want %v", err, ErrUnknownKind)
+	}
+}
+
+func TestApprove(t *testing.T) {
+	reviewer := &user.User{ID: uuid.New(), Operator: true}
+	card := uuid.New()
+	newClaim := func() *Claim {
+		cardID := card
+		return &Claim{ID: uuid.New(), Amount: 5000, CardID: &cardID, Status: Submitted}
+	}
+
+	ds := &decisions{}
+	s := &Service{Claims: ds}
+	c := newClaim()
+	if err := s.Approve(reviewer, c, 0, " ok "); err != nil {
+		t.Fatalf("Approve() returned %v", err)
+	}
+	if c.Status != Approved || c.Refund == nil || c.Refund.Amount != 5000 || *c.Refund.CardID != card {
+		t.Errorf("Approve() without an amount refunded %+v, want the claimed amount to the claim's card",
+			c.Refund)
+	}
+	if c.Note != "ok" || c.Reviewer == nil || *c.Reviewer != reviewer.ID || c.Decided == nil {
+		t.Errorf("Approve() did not record the note, reviewer and time of the decision: %+v", c)
+	}
+
+	c = newClaim()
+	if err := s.Approve(reviewer, c, 1200, ""); err != nil {
+		t.Fatalf("Approve() of a partial amount returned %v", err)
+	} else if c.Refund.Amount != 1200 {
+		t.Errorf("Approve() refunded %d, want 1200", c.Refund.Amount)
+	}
+
+	for _, amount := range []parcel.Cents{-1, 5001} {
+		c = newClaim()
+		if err := s.Approve(reviewer, c, amount, ""); err != ErrInvalidAmount {
+			t.Errorf("Approve() of %d returned %v, want %v", amount, err, ErrInvalidAmount)
+		}
+		if c.Status != Submitted || c.Refund != nil {
+			t.Errorf("Approve() of %d changed the claim to %+v", amount, c)
+		}
+	}
+
+	c = newClaim()
+	c.CardID = nil
+	if err := s.Approve(reviewer, c, 0, ""); err != ErrCardDeleted {
+		t.Errorf("Approve() without a card returned %v, want %v", err, ErrCardDeleted)
+	}
+	if err := s.Approve(&user.User{ID: uuid.New()}, newClaim(), 0, ""); err != ErrNotOperator {
+		t.Errorf("Approve() by a customer returned %v, want %v", err, ErrNotOperator)
+	}
+	if len(ds.decided) != 2 {
+		t.Errorf("%d claims have been decided, want 2", len(ds.decided))
+	}
+}
+
+func TestDecideTwice(t *testing.T) {
+	reviewer := &user.User{ID: uuid.New(), Operator: true}
+	cardID := uuid.New()
+	c := &Claim{ID: uuid.New(), Amount: 5000, CardID: &cardID, Status: Submitted}
+	ds := &decisions{}
+	s := &Service{Claims: ds}
+
+	if err := s.Reject(reviewer, c, "no evidence"); err != nil {
+		t.Fatalf("Reject() returned %v", err)
+	}
+	if err := s.Approve(reviewer, c, 0, ""); err != ErrAlreadyDecided {
+		t.Errorf("approving a rejected claim returned %v, want %v", err, ErrAlreadyDecided)
+	}
+	if c.Status != Rejected || c.Refund != nil || c.Note != "no evidence" {
+		t.Errorf("the rejected claim has been changed to %+v", c)
+	}
+
+	// A stale copy of the claim, which is still submitted, is refused by
+	// the storage and left unchanged.
+	stale := &Claim{ID: c.ID, Amount: 5000, CardID: &cardID, Status: Submitted}
+	if err := s.Approve(reviewer, stale, 0, ""); err != ErrAlreadyDecided {
+		t.Errorf("approving a stale copy returned %v, want %v", err, ErrAlreadyDecided)
+	}
+	if stale.Status != Submitted || stale.Refund != nil || stale.Decided != nil {
+		t.Errorf("the stale copy has been changed to %+v", stale)
+	}
+}
@@ -0,0 +1,42 @@
+package claim
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+var (
+	// ErrAlreadyClaimed is returned by Insert, if there is another claim
+	// for the parcel, which has not been rejected.
+	ErrAlreadyClaimed = errors.New("claim: the parcel has already been claimed")
+	// ErrAlreadyDecided is returned by Decide, if the claim is no longer
+	// under review.
+	ErrAlreadyDecided = errors.New("claim: the claim has already been decided")
+)
+
+// Storage is the interface for managing claims.
+//
+// Insert inserts c together with its evidence. It returns
+// ErrAlreadyClaimed, if the parcel has been claimed before and that claim
+// has not been rejected.
+//
+// InsertEvidence attaches e to its claim.
+//
+// ByID returns nil, if there is no claim identified by id. The claims
+// returned by ByID, ByClaimant and ByStatus include their evidence and
+// refund. ByClaimant returns the claims filed by the user identified by
+// id, starting with the most recent one. ByStatus returns the claims with
+// the status s, starting with the oldest one.
+//
+// Decide stores the decision on c, i.e. its status, reviewer, note and
+// time of decision, together with its refund, if it is not nil. It
+// returns ErrAlreadyDecided, unless c is still submitted in the storage.
+type Storage interface {
+	Insert(c *Claim) error
+	InsertEvidence(e *Evidence) error
+	ByID(id uuid.UUID) (*Claim, error)
+	ByClaimant(id uuid.UUID) ([]*Claim, error)
+	ByStatus(s Status) ([]*Claim, error)
+	Decide(c *Claim) error
+}
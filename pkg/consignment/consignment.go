@@ -0,0 +1,267 @@
+// Package consignment groups parcels, which are shipped together from the
+// same sender to the same destination, under a master tracking number and
+// derives the status of the consignment from its parcels' tracking events.
+package consignment
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// MinParcels and MaxParcels limit the number of parcels in a
+	// consignment.
+	MinParcels = 2
+	MaxParcels = 100
+	// MaxReferenceLength limits the length of the sender's reference.
+	MaxReferenceLength = 64
+)
+
+var (
+	ErrTooFewParcels      = errors.New("consignment: a consignment consists of at least two parcels")
+	ErrTooManyParcels     = errors.New("consignment: a consignment consists of at most 100 parcels")
+	ErrDuplicateParcel    = errors.New("consignment: a parcel must only be listed once")
+	ErrReferenceTooLong   = errors.New("consignment: the reference must not be longer than 64 characters")
+	ErrDifferentAddresses = errors.New("consignment: all parcels must be sent from the same return address to the same destination")
+)
+
+// Consignment is a group of parcels shipped together.
+type Consignment struct {
+	ID uuid.UUID `json:"id"`
+	// Sender is the ID of the user, who has created the consignment.
+	Sender uuid.UUID `json:"-"`
+	// Reference is the sender's optional reference, e.g. an order number.
+	Reference string    `json:"reference,omitempty"`
+	Created   time.Time `json:"created"`
+	// ParcelIDs identifies the consignment's parcels in the order, in which
+	// they have been listed by the sender.
+	ParcelIDs []uuid.UUID `json:"parcelIds"`
+}
+
+// New returns a new consignment of the parcels pp created by the user
+// identified by sender. The parcels must be sent from the same return
+// address to the same destination.
+func New(sender uuid.UUID, pp []*parcel.Parcel, reference string) (*Consignment, error) {
+	reference = strings.TrimSpace(reference)
+	if len(pp) < MinParcels {
+		return nil, ErrTooFewParcels
+	} else if len(pp) > MaxParcels {
+		return nil, ErrTooManyParcels
+	} else if len([]rune(reference)) > MaxReferenceLength {
+		return nil, ErrReferenceTooLong
+	}
+	ids := make([]uuid.UUID, 0, len(pp))
+	seen := make(map[uuid.UUID]bool)
+	for _, p := range pp {
+		if seen[p.ID] {
+			return nil, ErrDuplicateParcel
+		} else if !sameRoute(pp[0], p) {
+			return nil, ErrDifferentAddresses
+		}
+		seen[p.ID] = true
+		ids = append(ids, p.ID)
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Consignment{
+		ID:        id,
+		Sender:    sender,
+		Reference: reference,
+		Created:   time.Now(),
+		ParcelIDs: ids,
+	}, nil
+}
+
+// sameRoute reports whether p and q are sent from the same return address
+// to the same destination address or pickup point.
+func sameRoute(p, q *parcel.Parcel) bool {
+	if p.ReturnAddress == nil || q.ReturnAddress == nil ||
+		p.DestinationAddress == nil || q.DestinationAddress == nil {
+		return false
+	} else if (p.PickupPointID == nil) != (q.PickupPointID == nil) ||
+		p.PickupPointID != nil && *p.PickupPointID != *q.PickupPointID {
+		return false
+	}
+
+	return p.ReturnAddress.ID == q.ReturnAddress.ID &&
+		p.DestinationAddress.ID == q.DestinationAddress.ID
+}
+
+// Status is the aggregated delivery status of a consignment or the status
+// of one of its parcels.
+type Status string
+
+const (
+	// Registered consignments have not been handed over to us yet.
+	Registered Status = "registered"
+	InTransit  Status = "in-transit"
+	// PartiallyDelivered consignments have some parcels delivered, while
+	// the others are still on their way or have been returned.
+	PartiallyDelivered Status = "partially-delivered"
+	Delivered          Status = "delivered"
+	Returned           Status = "returned"
+)
+
+func (s Status) String() string {
+	switch s {
+	case Registered:
+		return "Registered"
+	case InTransit:
+		return "In transit"
+	case PartiallyDelivered:
+		return "Partially delivered"
+	case Delivered:
+		return "Delivered"
+	case Returned:
+		return "Returned to sender"
+	default:
+		return "Unknown"
+	}
+}
+
+// Member is a parcel of a consignment together with its delivery status.
+type Member struct {
+	Parcel *parcel.Parcel `json:"parcel"`
+	Status Status         `json:"status"`
+	// Latest is the parcel's most recent event or nil, if the parcel has
+	// no events.
+	Latest   *parcel.Event `json:"latest"`
+	Estimate *eta.Estimate `json:"estimate"`
+}
+
+// newMember returns the member p with the tracking events ee, which must
+// be ordered by their time.
+func newMember(p *parcel.Parcel, ee []*parcel.Event, est *eta.Estimator, now time.Time) *Member {
+	m := &Member{Parcel: p, Status: InTransit, Estimate: est.Estimate(p, ee, now)}
+	st := parcel.NewState(p, ee)
+	m.Latest = st.Last
+	switch {
+	case st.Last == nil || st.Last.Type == parcel.DataReceived:
+		m.Status = Registered
+	case len(st.Next()) > 0:
+		m.Status = InTransit
+	case st.Returning:
+		m.Status = Returned
+	default:
+		m.Status = Delivered
+	}
+
+	return m
+}
+
+// Tracking is the consolidated tracking information of a consignment.
+type Tracking struct {
+	*Consignment
+	// TrackingCode is the human-friendly master tracking number, which is
+	// accepted wherever a parcel's tracking number is.
+	TrackingCode string    `json:"trackingCode"`
+	Status       Status    `json:"status"`
+	Parcels      []*Member `json:"parcels"`
+	// Delivered, Returned and Outstanding count the parcels, which have
+	// been delivered, returned to the sender or are still on their way.
+	Delivered   int `json:"delivered"`
+	Returned    int `json:"returned"`
+	Outstanding int `json:"outstanding"`
+	// Latest is the most recent event of any of the parcels or nil.
+	Latest *parcel.Event `json:"latest"`
+	// Estimate is the estimated window, in which the last of the
+	// outstanding parcels is delivered. It refers to all parcels, once
+	// none is outstanding.
+	Estimate *eta.Estimate `json:"estimate"`
+}
+
+// NewTracking aggregates the members mm of c.
+func NewTracking(c *Consignment, mm []*Member) *Tracking {
+	t := &Tracking{Consignment: c, Parcels: mm}
+	registered := 0
+	for _, m := range mm {
+		switch m.Status {
+		case Registered:
+			registered++
+			t.Outstanding++
+		case Delivered:
+			t.Delivered++
+		case Returned:
+			t.Returned++
+		default:
+			t.Outstanding++
+		}
+		if m.Latest != nil && (t.Latest == nil || m.Latest.Time.After(t.Latest.Time)) {
+			t.Latest = m.Latest
+		}
+	}
+	if len(mm) > 0 {
+		t.TrackingCode = TrackingCode(c, mm[0].Parcel)
+	}
+
+	switch {
+	case registered == len(mm):
+		t.Status = Registered
+	case t.Delivered == len(mm):
+		t.Status = Delivered
+	case t.Returned == len(mm):
+		t.Status = Returned
+	case t.Delivered > 0:
+		t.Status = PartiallyDelivered
+	default:
+		t.Status = InTransit
+	}
+	t.Estimate = aggregateEstimate(mm, t.Outstanding > 0)
+
+	return t
+}
+
+// aggregateEstimate returns the latest delivery window of the members mm,
+// which are still outstanding, if outstanding is true.
+func aggregateEstimate(mm []*Member, outstanding bool) *eta.Estimate {
+	var agg *eta.Estimate
+	returning := true
+	for _, m := range mm {
+		if m.Estimate == nil || outstanding && (m.Status == Delivered || m.Status == Returned) {
+			continue
+		}
+		returning = returning && m.Estimate.Returning
+		if agg == nil {
+			e := *m.Estimate
+			agg = &e
+			continue
+		}
+		agg.Delivered = agg.Delivered && m.Estimate.Delivered
+		if m.Estimate.Earliest.After(agg.Earliest) {
+			agg.Earliest = m.Estimate.Earliest
+		}
+		if m.Estimate.Expected.After(agg.Expected) {
+			agg.Expected = m.Estimate.Expected
+		}
+		if m.Estimate.Latest.After(agg.Latest) {
+			agg.Latest = m.Estimate.Latest
+		}
+	}
+	if agg != nil {
+		agg.Returning = returning
+	}
+
+	return agg
+}
+
+// TrackingCode returns the master tracking number of c, which p belongs to.
+func TrackingCode(c *Consignment, p *parcel.Parcel) string {
+	return parcel.FormatTrackingCode(c.ID, planet(p))
+}
+
+// planet returns the planet, to which p is sent.
+func planet(p *parcel.Parcel) string {
+	if p.DestinationAddress == nil {
+		return ""
+	}
+
+	return p.DestinationAddress.Planet
+}
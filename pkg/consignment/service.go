@@ -0,0 +1,156 @@
+package consignment
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/eta"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrConsignmentNotFound = errors.New("consignment: the consignment does not exist")
+	ErrParcelNotFound      = errors.New("consignment: one of the parcels does not exist")
+	ErrNotSender           = errors.New("consignment: only the sender of all parcels may group them")
+)
+
+// Service groups parcels to consignments on behalf of their senders and
+// tracks the consignments.
+type Service struct {
+	Consignments Storage
+	Addresses    address.Accesser
+	Parcels      parcel.Accesser
+	Events       parcel.EventAccesser
+	Estimator    *eta.Estimator
+}
+
+// Create groups the parcels identified by ids to a new consignment with
+// the optional reference on behalf of u, who must have sent all of them.
+// Every parcel belongs to at most one consignment.
+func (s *Service) Create(u *user.User, ids []uuid.UUID, reference string) (*Tracking, error) {
+	if len(ids) > MaxParcels {
+		return nil, ErrTooManyParcels
+	}
+	pp := make([]*parcel.Parcel, 0, len(ids))
+	for _, id := range ids {
+		p, err := s.Parcels.ByID(id)
+		if err != nil {
+			return nil, err
+		} else if p == nil {
+			return nil, ErrParcelNotFound
+		}
+		ok, err := p.SentBy(u, s.Addresses)
+		if err != nil {
+			return nil, err
+		} else if !ok {
+			return nil, ErrNotSender
+		}
+		pp = append(pp, p)
+	}
+	c, err := New(u.ID, pp, reference)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Consignments.Insert(c)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.track(c, pp)
+}
+
+// Track returns the tracking information of the consignment identified by
+// id.
+func (s *Service) Track(id uuid.UUID) (*Tracking, error) {
+	c, err := s.Consignments.ByID(id)
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, ErrConsignmentNotFound
+	}
+
+	return s.load(c)
+}
+
+// Sent returns the tracking information of the consignments created by u,
+// starting with the most recently created one.
+func (s *Service) Sent(u *user.User) ([]*Tracking, error) {
+	cc, err := s.Consignments.BySender(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	tt := make([]*Tracking, 0, len(cc))
+	for _, c := range cc {
+		t, err := s.load(c)
+		if err != nil {
+			return nil, err
+		}
+		tt = append(tt, t)
+	}
+
+	return tt, nil
+}
+
+// Consignable returns the parcels sent by u, which have neither been
+// delivered nor returned and do not belong to any consignment yet.
+func (s *Service) Consignable(u *user.User) ([]*parcel.Parcel, error) {
+	ss, err := parcel.Outgoing(u, s.Addresses, s.Parcels, s.Events)
+	if err != nil {
+		return nil, err
+	}
+	pp := make([]*parcel.Parcel, 0, len(ss))
+	for _, sum := range ss {
+		ee, err := s.Events.ByParcel(sum.Parcel)
+		if err != nil {
+			return nil, err
+		} else if len(parcel.NewState(sum.Parcel, ee).Next()) == 0 {
+			continue
+		}
+		c, err := s.Consignments.ByParcel(sum.ID)
+		if err != nil {
+			return nil, err
+		} else if c == nil {
+			pp = append(pp, sum.Parcel)
+		}
+	}
+
+	return pp, nil
+}
+
+// ByParcel returns the consignment, to which p belongs, or nil.
+func (s *Service) ByParcel(p *parcel.Parcel) (*Consignment, error) {
+	return s.Consignments.ByParcel(p.ID)
+}
+
+// load loads the parcels of c and returns c's tracking information.
+func (s *Service) load(c *Consignment) (*Tracking, error) {
+	pp := make([]*parcel.Parcel, 0, len(c.ParcelIDs))
+	for _, id := range c.ParcelIDs {
+		p, err := s.Parcels.ByID(id)
+		if err != nil {
+			return nil, err
+		} else if p != nil {
+			pp = append(pp, p)
+		}
+	}
+
+	return s.track(c, pp)
+}
+
+// track returns the tracking information of c with the parcels pp.
+func (s *Service) track(c *Consignment, pp []*parcel.Parcel) (*Tracking, error) {
+	now := time.Now()
+	mm := make([]*Member, 0, len(pp))
+	for _, p := range pp {
+		ee, err := s.Events.ByParcel(p)
+		if err != nil {
+			return nil, err
+		}
+		mm = append(mm, newMember(p, ee, s.Estimator, now))
+	}
+
+	return NewTracking(c, mm), nil
+}
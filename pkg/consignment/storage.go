@@ -0,0 +1,31 @@
+package consignment
+
+import (
+	"errors"
+
+	"github.com/google/uuid"
+)
+
+// ErrAlreadyConsigned is returned by Insert, if one of the parcels already
+// belongs to another consignment.
+var ErrAlreadyConsigned = errors.New("consignment: a parcel already belongs to another consignment")
+
+// Storage is the interface for managing consignments.
+//
+// Insert inserts c together with its parcels. It returns
+// ErrAlreadyConsigned, if one of c's parcels belongs to another
+// consignment.
+//
+// ByID returns nil, if there is no consignment identified by id.
+//
+// BySender returns the consignments created by the user identified by id,
+// starting with the most recently created one.
+//
+// ByParcel returns the consignment of the parcel identified by id or nil,
+// if the parcel does not belong to any consignment.
+type Storage interface {
+	Insert(c *Consignment) error
+	ByID(id uuid.UUID) (*Consignment, error)
+	BySender(id uuid.UUID) ([]*Consignment, error)
+	ByParcel(id uuid.UUID) (*Consignment, error)
+}
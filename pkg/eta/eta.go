@@ -0,0 +1,214 @@
+// Package eta estimates when parcels are going to be delivered.
+//
+// The estimate is derived from the parcel's tracking events by assuming
+// that the parcel takes the regular course of delivery from its current
+// state. Each step takes the configured processing time of the preceding
+// event type, except for the flight of a delivery rocket, whose duration
+// is derived from the distance between the planets at the time of launch.
+package eta
+
+import (
+	"fmt"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// maxSteps limits the number of simulated events, in case the lifecycle
+// of parcels contains cycles.
+const maxSteps = 32
+
+type Config struct {
+	// ProcessingTimes maps the names of event types to the usual time
+	// until the parcel's next event, e.g. DeliveredToIPPS = "12h".
+	ProcessingTimes map[string]string `toml:"processing_times"`
+	// RocketSpeed is the average speed of delivery rockets in astronomical
+	// units per day.
+	RocketSpeed float64 `toml:"rocket_speed"`
+	// UnknownTransit is the assumed flight time to or from planets, which
+	// are unknown to the orbital model.
+	UnknownTransit string `toml:"unknown_transit"`
+	// Uncertainty is the relative uncertainty of the remaining delivery
+	// time, which determines the width of the delivery window.
+	Uncertainty float64
+}
+
+// DefaultConfig is used for all settings missing in the configuration.
+var DefaultConfig = Config{
+	ProcessingTimes: map[string]string{
+		"DataReceived":          "48h",
+		"DeliveredToIPPS":       "12h",
+		"PickedUpByCourier":     "12h",
+		"DeliveredToProcessing": "24h",
+		"LoadedIntoVehicle":     "8h",
+		"HeldAtCustoms":         "72h",
+		"ReleasedFromCustoms":   "12h",
+		"DeliveryFailed":        "24h",
+		"ReturnInitiated":       "12h",
+	},
+	RocketSpeed:    0.05,
+	UnknownTransit: "720h",
+	Uncertainty:    0.2,
+}
+
+// Estimate is the estimated delivery window of a parcel.
+type Estimate struct {
+	Earliest time.Time `json:"earliest"`
+	Expected time.Time `json:"expected"`
+	Latest   time.Time `json:"latest"`
+	// Delivered is true, if the parcel has already been delivered. All
+	// times are the time of delivery then.
+	Delivered bool `json:"delivered"`
+	// Returning is true, if the parcel is being returned to its sender.
+	// The estimate refers to the delivery to the sender then.
+	Returning bool `json:"returning"`
+}
+
+// Estimator estimates delivery times.
+type Estimator struct {
+	processing     map[parcel.EventType]time.Duration
+	rocketSpeed    float64
+	unknownTransit time.Duration
+	uncertainty    float64
+}
+
+// New returns an estimator configured by conf. Settings missing in conf,
+// which may be nil, are taken from DefaultConfig.
+func New(conf *Config) (*Estimator, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	e := &Estimator{
+		processing:  make(map[parcel.EventType]time.Duration),
+		rocketSpeed: conf.RocketSpeed,
+		uncertainty: conf.Uncertainty,
+	}
+	if e.rocketSpeed <= 0 {
+		e.rocketSpeed = DefaultConfig.RocketSpeed
+	}
+	if e.uncertainty <= 0 {
+		e.uncertainty = DefaultConfig.Uncertainty
+	}
+	transit := conf.UnknownTransit
+	if transit == "" {
+		transit = DefaultConfig.UnknownTransit
+	}
+	var err error
+	e.unknownTransit, err = time.ParseDuration(transit)
+	if err != nil {
+		return nil, fmt.Errorf("eta: invalid unknown transit time: %v", err)
+	}
+
+	for _, tt := range []map[string]string{DefaultConfig.ProcessingTimes, conf.ProcessingTimes} {
+		for n, ds := range tt {
+			var t parcel.EventType
+			err := t.UnmarshalText([]byte(n))
+			if err != nil {
+				return nil, fmt.Errorf("eta: invalid event type %q", n)
+			}
+			d, err := time.ParseDuration(ds)
+			if err != nil {
+				return nil, fmt.Errorf("eta: invalid processing time of %s: %v", n, err)
+			}
+			e.processing[t] = d
+		}
+	}
+
+	return e, nil
+}
+
+// Estimate returns the estimated delivery window of p with the tracking
+// events ee, which must be ordered by their time, as seen at time now.
+func (est *Estimator) Estimate(p *parcel.Parcel, ee []*parcel.Event, now time.Time) *Estimate {
+	// from is the planet from which the last rocket has been launched.
+	var from string
+	st := parcel.NewState(p, nil)
+	for _, e := range ee {
+		if e.Type == parcel.LoadedIntoRocket {
+			from = st.Planet
+		}
+		st.Apply(e)
+	}
+	if st.Last != nil && len(st.Next()) == 0 {
+		return &Estimate{
+			Earliest:  st.Last.Time,
+			Expected:  st.Last.Time,
+			Latest:    st.Last.Time,
+			Delivered: true,
+			Returning: st.Returning,
+		}
+	}
+
+	t := now
+	if st.Last != nil {
+		t = st.Last.Time
+	}
+	for i := 0; i < maxSteps; i++ {
+		nn := st.Next()
+		if len(nn) == 0 {
+			break
+		}
+		t = t.Add(est.duration(st, from, t))
+		if t.Before(now) {
+			// The parcel is late, but the next step may happen any time.
+			t = now
+		}
+		// The regular course of delivery is listed first.
+		if nn[0] == parcel.LoadedIntoRocket {
+			from = st.Planet
+		} else if nn[0] == parcel.LoadedIntoVehicle && t.Before(st.DeliveryDate) {
+			// The recipient has chosen a later delivery date.
+			t = st.DeliveryDate
+		}
+		st.Apply(&parcel.Event{Parcel: p, Type: nn[0], Time: t})
+	}
+
+	remaining := float64(t.Sub(now))
+	return &Estimate{
+		Earliest:  now.Add(time.Duration(remaining * (1 - est.uncertainty))),
+		Expected:  t,
+		Latest:    now.Add(time.Duration(remaining * (1 + est.uncertainty))),
+		Returning: st.Returning,
+	}
+}
+
+// duration returns the time from st's last event until the next event,
+// which occurs after the last event at time t. Requests, which do not move
+// the parcel, e.g. Redirected or HoldRequested, take as long as the latest
+// event, which did, unless they have a processing time of their own.
+func (est *Estimator) duration(st *parcel.State, from string, t time.Time) time.Duration {
+	if st.Last == nil {
+		return 0
+	} else if st.Position() != parcel.LoadedIntoRocket {
+		if d, ok := est.processing[st.Last.Type]; ok {
+			return d
+		}
+		return est.processing[st.Position()]
+	}
+
+	return est.Flight(from, destinationPlanet(st), t)
+}
+
+// Flight returns the duration of a rocket's flight from the planet called
+// from to the planet called to, which is launched at time t.
+func (est *Estimator) Flight(from, to string, t time.Time) time.Duration {
+	a, b := orbit.Lookup(from), orbit.Lookup(to)
+	if a == nil || b == nil {
+		return est.unknownTransit
+	}
+	days := orbit.Distance(a, b, t) / est.rocketSpeed
+
+	return time.Duration(days * 24 * float64(time.Hour))
+}
+
+// destinationPlanet returns the planet to which the parcel in state st is
+// being delivered.
+func destinationPlanet(st *parcel.State) string {
+	dest := st.Destination()
+	if dest == nil {
+		return ""
+	}
+
+	return dest.Planet
+}
@@ -0,0 +1,47 @@
+package eta
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+func TestEstimateAfterRequests(t *testing.T) {
+	est, err := New(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	p := &parcel.Parcel{
+		ID:                 uuid.New(),
+		ReturnAddress:      &address.Address{City: "Erlangen", Planet: "Earth"},
+		DestinationAddress: &address.Address{City: "Berlin", Planet: "Earth"},
+	}
+	// The default processing times of DeliveredToProcessing and
+	// LoadedIntoVehicle add up to 32 hours.
+	tests := []struct {
+		name string
+		last []parcel.EventType
+		want time.Duration
+	}{
+		{"processing", nil, 32 * time.Hour},
+		{"redirected", []parcel.EventType{parcel.Redirected}, 32 * time.Hour},
+		{"hold requested", []parcel.EventType{parcel.HoldRequested}, 24 * time.Hour},
+		{"returning", []parcel.EventType{parcel.ReturnInitiated}, 12*time.Hour + 8*time.Hour},
+	}
+	for _, tt := range tests {
+		at := time.Date(2020, 7, 10, 12, 0, 0, 0, time.UTC)
+		var ee []*parcel.Event
+		for _, typ := range append([]parcel.EventType{parcel.DataReceived, parcel.DeliveredToIPPS,
+			parcel.DeliveredToProcessing}, tt.last...) {
+			at = at.Add(time.Hour)
+			ee = append(ee, &parcel.Event{ID: uuid.New(), Parcel: p, Type: typ, Time: at})
+		}
+		e := est.Estimate(p, ee, at)
+		if got := e.Expected.Sub(at); got != tt.want {
+			t.Errorf("%s: Estimate() expects the delivery after %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
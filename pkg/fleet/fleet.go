@@ -0,0 +1,152 @@
+// Package fleet manages the delivery rockets, which carry parcels between
+// planets, and their launch schedule.
+package fleet
+
+import (
+	"errors"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+)
+
+var (
+	ErrInvalidCapacity       = errors.New("fleet: a rocket's capacity must be positive")
+	ErrRocketNameEmpty       = errors.New("fleet: a rocket's name must not be empty")
+	ErrUnknownPlanet         = errors.New("fleet: the planet is unknown")
+	ErrSamePlanet            = errors.New("fleet: a launch's origin and destination must differ")
+	ErrDepartureInPast       = errors.New("fleet: the departure must be in the future")
+	ErrOutsideTransferWindow = errors.New("fleet: the departure is outside of a transfer window")
+	ErrRocketBusy            = errors.New("fleet: the rocket already has a scheduled launch")
+	ErrLaunchFull            = errors.New("fleet: the launch has no capacity left")
+	ErrAlreadyAssigned       = errors.New("fleet: the parcel has already been assigned to the launch")
+	ErrLaunchDeparted        = errors.New("fleet: the launch has already departed")
+)
+
+// Rocket is a delivery rocket.
+type Rocket struct {
+	ID   uuid.UUID `json:"id"`
+	Name string    `json:"name"`
+	// Capacity is the number of parcels the rocket can carry.
+	Capacity int `json:"capacity"`
+}
+
+// NewRocket returns a new rocket called name, which carries up to capacity
+// parcels.
+func NewRocket(name string, capacity int) (*Rocket, error) {
+	if name == "" {
+		return nil, ErrRocketNameEmpty
+	} else if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Rocket{ID: id, Name: name, Capacity: capacity}, nil
+}
+
+// LaunchStatus is the status of a launch.
+type LaunchStatus string
+
+const (
+	Scheduled LaunchStatus = "scheduled"
+	Departed  LaunchStatus = "departed"
+)
+
+// Launch is a rocket's scheduled flight from one planet to another.
+type Launch struct {
+	ID     uuid.UUID `json:"id"`
+	Rocket *Rocket   `json:"rocket"`
+	// From and To are the names of the planets as known to package orbit.
+	From      string       `json:"from"`
+	To        string       `json:"to"`
+	Departure time.Time    `json:"departure"`
+	Arrival   time.Time    `json:"arrival"`
+	Status    LaunchStatus `json:"status"`
+	// Parcels is the number of parcels assigned to the launch.
+	Parcels int `json:"parcels"`
+}
+
+// NewLaunch returns a new launch of r from the planet called from to the
+// planet called to, departing at departure. The departure must be within a
+// transfer window.
+func NewLaunch(r *Rocket, from, to string, departure time.Time) (*Launch, error) {
+	a, b := orbit.Lookup(from), orbit.Lookup(to)
+	if a == nil || b == nil {
+		return nil, ErrUnknownPlanet
+	} else if a == b {
+		return nil, ErrSamePlanet
+	} else if departure.Before(time.Now()) {
+		return nil, ErrDepartureInPast
+	} else if !InTransferWindow(a, b, departure) {
+		return nil, ErrOutsideTransferWindow
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Launch{
+		ID:        id,
+		Rocket:    r,
+		From:      a.Name,
+		To:        b.Name,
+		Departure: departure,
+		Arrival:   departure.Add(orbit.TransferTime(a, b)),
+		Status:    Scheduled,
+	}, nil
+}
+
+// Free returns the number of parcels which may still be assigned to l.
+func (l *Launch) Free() int {
+	return l.Rocket.Capacity - l.Parcels
+}
+
+// windowTolerance is the maximum deviation in radians of the planets'
+// phase from the ideal transfer angle during a transfer window.
+const windowTolerance = 5 * math.Pi / 180
+
+// windowStep is the resolution at which transfer windows are computed.
+const windowStep = 6 * time.Hour
+
+// Window is a period of time during which launches between two planets
+// are permitted.
+type Window struct {
+	Opens  time.Time `json:"opens"`
+	Closes time.Time `json:"closes"`
+}
+
+// InTransferWindow reports whether a launch from a to b may depart at t.
+func InTransferWindow(a, b *orbit.Planet, t time.Time) bool {
+	d := orbit.Phase(a, b, t) - orbit.TransferAngle(a, b)
+	d = math.Abs(math.Remainder(d, 2*math.Pi))
+
+	return d <= windowTolerance
+}
+
+// TransferWindows returns the transfer windows from a to b, which are open
+// between start and start+horizon.
+func TransferWindows(a, b *orbit.Planet, start time.Time, horizon time.Duration) []Window {
+	var ww []Window
+	var open *Window
+	end := start.Add(horizon)
+	for t := start; !t.After(end); t = t.Add(windowStep) {
+		in := InTransferWindow(a, b, t)
+		if in && open == nil {
+			open = &Window{Opens: t}
+		} else if !in && open != nil {
+			open.Closes = t
+			ww = append(ww, *open)
+			open = nil
+		}
+	}
+	if open != nil {
+		open.Closes = end
+		ww = append(ww, *open)
+	}
+
+	return ww
+}
@@ -0,0 +1,182 @@
+package fleet
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrNotAwaitingRocket = errors.New("fleet: the parcel is not waiting to be loaded into a rocket")
+	ErrNoLaunch          = errors.New("fleet: there is no suitable launch, the parcel is waiting for one")
+)
+
+// Service assigns parcels to launches and records their loading into the
+// rockets on departure.
+type Service struct {
+	Launches LaunchStorage
+	Parcels  parcel.Accesser
+	// Events must publish the recorded events, so that customers are
+	// notified about their parcels' departure.
+	Events parcel.EventStorage
+}
+
+// Schedule schedules a new launch of r and assigns the parcels waiting
+// for such a launch to it.
+func (s *Service) Schedule(r *Rocket, from, to string, departure time.Time) (*Launch, error) {
+	l, err := NewLaunch(r, from, to, departure)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Launches.Insert(l)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := s.Launches.Waiting(l.From, l.To)
+	if err != nil {
+		return nil, err
+	}
+	for _, id := range ids {
+		err = s.Launches.Assign(l, id)
+		if err == ErrLaunchFull {
+			break
+		} else if err == ErrAlreadyAssigned {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		l.Parcels++
+	}
+
+	return l, nil
+}
+
+// Assign assigns p to the next launch from its current planet to its
+// destination's planet, which has capacity left. If there is no such
+// launch, p is added to the waiting parcels and ErrNoLaunch is returned.
+func (s *Service) Assign(p *parcel.Parcel) (*Launch, error) {
+	l, err := s.Launches.ScheduledByParcel(p.ID)
+	if err != nil {
+		return nil, err
+	} else if l != nil {
+		return l, nil
+	}
+
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+	from, to, err := route(parcel.NewState(p, ee))
+	if err != nil {
+		return nil, err
+	}
+
+	ll, err := s.Launches.Scheduled(from, to)
+	if err != nil {
+		return nil, err
+	}
+	for _, l := range ll {
+		if l.Free() <= 0 {
+			continue
+		}
+		err = s.Launches.Assign(l, p.ID)
+		if err == ErrLaunchFull || err == ErrLaunchDeparted {
+			continue
+		} else if err == ErrAlreadyAssigned {
+			return l, nil
+		} else if err != nil {
+			return nil, err
+		}
+		l.Parcels++
+		return l, nil
+	}
+
+	err = s.Launches.Wait(p.ID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	return nil, ErrNoLaunch
+}
+
+// route returns the names of the planets between which the parcel in
+// state st has to be flown.
+func route(st *parcel.State) (from, to string, err error) {
+	awaiting := false
+	for _, t := range st.Next() {
+		if t == parcel.LoadedIntoRocket {
+			awaiting = true
+		}
+	}
+	dest := st.Destination()
+	if !awaiting || dest == nil {
+		return "", "", ErrNotAwaitingRocket
+	}
+	a, b := orbit.Lookup(st.Planet), orbit.Lookup(dest.Planet)
+	if a == nil || b == nil {
+		return "", "", ErrUnknownPlanet
+	} else if a == b {
+		return "", "", ErrNotAwaitingRocket
+	}
+
+	return a.Name, b.Name, nil
+}
+
+// Depart marks l as departed at time at and records that its parcels have
+// been loaded into the rocket.
+func (s *Service) Depart(l *Launch, at time.Time) error {
+	if l.Status != Scheduled {
+		return ErrLaunchDeparted
+	}
+	ids, err := s.Launches.Depart(l)
+	if err != nil {
+		return err
+	}
+	l.Status = Departed
+
+	for _, id := range ids {
+		err := s.load(id, at)
+		if err != nil {
+			// The parcel may e.g. have been redirected in the meantime.
+			log.Printf("fleet: cannot load parcel %s into launch %s: %v\n", id, l.ID, err)
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) load(id uuid.UUID, at time.Time) error {
+	p, err := s.Parcels.ByID(id)
+	if err != nil {
+		return err
+	} else if p == nil {
+		return errors.New("the parcel does not exist")
+	}
+	_, err = parcel.Record(s.Events, p, parcel.LoadedIntoRocket, at)
+
+	return err
+}
+
+// AutoAssigner is a parcel.Publisher, which assigns parcels to launches
+// as soon as they have been delivered to or released from customs in a
+// logistics center, from which they have to be flown to another planet.
+type AutoAssigner struct {
+	Service *Service
+}
+
+func (a *AutoAssigner) Publish(e *parcel.Event) error {
+	if e.Type != parcel.DeliveredToProcessing && e.Type != parcel.ReleasedFromCustoms {
+		return nil
+	}
+	_, err := a.Service.Assign(e.Parcel)
+	if err == ErrNotAwaitingRocket || err == ErrNoLaunch || err == ErrUnknownPlanet {
+		return nil
+	}
+
+	return err
+}
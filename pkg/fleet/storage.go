@@ -0,0 +1,55 @@
+package fleet
+
+import (
+	"github.com/google/uuid"
+)
+
+// RocketStorage is the interface for managing rockets.
+//
+// ByID returns nil, if there is no rocket identified by id.
+type RocketStorage interface {
+	Insert(r *Rocket) error
+	ByID(id uuid.UUID) (*Rocket, error)
+	All() ([]*Rocket, error)
+}
+
+// LaunchStorage is the interface for managing launches and the parcels
+// assigned to them.
+//
+// Insert returns ErrRocketBusy, if the launch's rocket already has a
+// scheduled launch.
+//
+// ByID returns nil, if there is no launch identified by id.
+//
+// Scheduled returns the launches from the planet called from to the planet
+// called to, which have not departed yet, ordered by their departure.
+// Empty planet names match all planets.
+//
+// ScheduledByParcel returns the scheduled launch, to which the parcel
+// identified by id has been assigned, or nil.
+//
+// Assign assigns the parcel identified by id to l and removes it from the
+// waiting parcels. It returns ErrLaunchFull, if l has no capacity left,
+// ErrAlreadyAssigned, if the parcel has already been assigned to l, and
+// ErrLaunchDeparted, if l is no longer scheduled.
+//
+// Depart marks l as departed and returns the ids of the parcels assigned to
+// it. No parcel is assigned to l in between. It returns ErrLaunchDeparted,
+// if l is no longer scheduled.
+//
+// Wait adds the parcel identified by id to the parcels waiting for a launch
+// from the planet called from to the planet called to.
+//
+// Waiting returns the ids of the parcels waiting for a launch from the
+// planet called from to the planet called to, ordered by their waiting
+// time.
+type LaunchStorage interface {
+	Insert(l *Launch) error
+	ByID(id uuid.UUID) (*Launch, error)
+	Scheduled(from, to string) ([]*Launch, error)
+	ScheduledByParcel(id uuid.UUID) (*Launch, error)
+	Assign(l *Launch, id uuid.UUID) error
+	Depart(l *Launch) ([]uuid.UUID, error)
+	Wait(id uuid.UUID, from, to string) error
+	Waiting(from, to string) ([]uuid.UUID, error)
+}
@@ -0,0 +1,150 @@
+package label
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// a4Width and a4Height are the dimensions of an A4 page in points.
+	a4Width  = 595.28
+	a4Height = 841.89
+	// cn23Margin is the margin around the document's contents in points.
+	cn23Margin = 40.0
+	// maxDescriptionLength is the number of characters of an item's
+	// description fitting into its column.
+	maxDescriptionLength = 38
+)
+
+// cn23Columns are the columns of the table of customs items.
+var cn23Columns = []struct {
+	title string
+	x     float64
+}{
+	{"Detailed description of contents", cn23Margin},
+	{"Quantity", 235},
+	{"Net weight (kg)", 285},
+	{"Value", 360},
+	{"HS tariff number", 410},
+	{"Country of origin", 485},
+}
+
+// cn23Categories are the categories listed on the declaration in the
+// order in which they are shown.
+var cn23Categories = []struct {
+	category parcel.CustomsCategory
+	title    string
+}{
+	{parcel.Gift, "Gift"},
+	{parcel.Documents, "Documents"},
+	{parcel.CommercialSample, "Commercial sample"},
+	{parcel.ReturnedGoods, "Returned goods"},
+	{parcel.SaleOfGoods, "Sale of goods"},
+	{parcel.OtherCategory, "Other"},
+}
+
+// CN23 renders l's customs declaration as an A4 sized PDF document in the
+// style of the CN 23 form. It returns ErrNoCustoms, if the parcel has not
+// been declared to customs.
+func (l *Label) CN23() ([]byte, error) {
+	d := l.Customs
+	if d == nil {
+		return nil, ErrNoCustoms
+	}
+	widths, err := Code128(l.barcodeData())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &pdfCanvas{}
+	right := a4Width - cn23Margin
+	y := a4Height - cn23Margin - 18
+	c.text("F2", 18, cn23Margin, y, "CUSTOMS DECLARATION")
+	c.text("F2", 18, right-60, y, "CN 23")
+	y -= 14
+	c.text("F1", 9, cn23Margin, y, "IPPS - Interplanetary Parcel Service")
+	c.text("F1", 9, right-75, y, "Routing "+l.Routing)
+
+	y -= 70
+	c.barcode(widths, cn23Margin, y, 50)
+	c.text("F1", 9, cn23Margin, y-12, "Tracking number "+l.TrackingCode)
+
+	y -= 30
+	half := (right - cn23Margin) / 2
+	for i, b := range []struct {
+		title string
+		a     []string
+	}{
+		{"From", addressLines(l.From)},
+		{"To", addressLines(l.To)},
+	} {
+		x := cn23Margin + float64(i)*half
+		c.box(x, y-90, half-8, 90)
+		c.text("F2", 8, x+6, y-12, b.title)
+		for j, s := range b.a {
+			c.text("F1", 10, x+6, y-28-float64(j)*14, s)
+		}
+	}
+
+	y -= 115
+	for _, col := range cn23Columns {
+		c.text("F2", 8, col.x, y, col.title)
+	}
+	y -= 5
+	c.line(cn23Margin, y, right, y)
+	for _, it := range d.Items {
+		y -= 14
+		desc := []rune(it.Description)
+		if len(desc) > maxDescriptionLength {
+			desc = append(desc[:maxDescriptionLength-3], '.', '.', '.')
+		}
+		for i, s := range []string{
+			string(desc),
+			strconv.Itoa(it.Quantity),
+			fmt.Sprintf("%.3f", it.Weight),
+			it.Value.String(),
+			it.HSCode,
+			it.Origin,
+		} {
+			c.text("F1", 9, cn23Columns[i].x, y, s)
+		}
+	}
+	y -= 6
+	c.line(cn23Margin, y, right, y)
+	y -= 14
+	c.text("F2", 9, cn23Margin, y, "Total")
+	c.text("F2", 9, cn23Columns[2].x, y, fmt.Sprintf("%.3f", d.Weight()))
+	c.text("F2", 9, cn23Columns[3].x, y, d.Value().String())
+	y -= 14
+	c.text("F1", 9, cn23Margin, y, fmt.Sprintf("Total gross weight: %.3f kg", l.Weight))
+
+	y -= 30
+	c.text("F2", 8, cn23Margin, y, "Category of items")
+	for i, cat := range cn23Categories {
+		x := cn23Margin + float64(i%3)*half*2/3
+		cy := y - 16 - float64(i/3)*16
+		c.box(x, cy-2, 9, 9)
+		if cat.category == d.Category {
+			c.text("F2", 9, x+1.5, cy, "X")
+		}
+		c.text("F1", 9, x+14, cy, cat.title)
+	}
+
+	y -= 80
+	c.text("F1", 8, cn23Margin, y,
+		"I certify that the particulars given in this customs declaration are correct and that this item does")
+	y -= 10
+	c.text("F1", 8, cn23Margin, y,
+		"not contain any dangerous article or articles prohibited by legislation or by postal or customs regulations.")
+	y -= 40
+	c.line(cn23Margin, y, cn23Margin+200, y)
+	c.line(right-200, y, right, y)
+	y -= 10
+	c.text("F1", 8, cn23Margin, y, "Date: "+time.Now().Format("2006-01-02"))
+	c.text("F1", 8, right-200, y, "Sender's signature")
+
+	return c.document(a4Width, a4Height), nil
+}
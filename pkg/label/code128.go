@@ -0,0 +1,56 @@
+package label
+
+import (
+	"errors"
+)
+
+var ErrUnencodable = errors.New("label: only printable ASCII characters can be encoded")
+
+// code128Patterns contains the widths of the alternating bars and spaces of
+// the Code 128 symbols, indexed by their values. Every symbol is 11 modules
+// wide, except for the stop symbol, which is 13 modules wide.
+var code128Patterns = [...]string{
+	"212222", "222122", "222221", "121223", "121322", "131222", "122213", "122312", "132212", "221213",
+	"221312", "231212", "112232", "122132", "122231", "113222", "123122", "123221", "223211", "221132",
+	"221231", "213212", "223112", "312131", "311222", "321122", "321221", "312212", "322112", "322211",
+	"212123", "212321", "232121", "111323", "131123", "131321", "112313", "132113", "132311", "211313",
+	"231113", "231311", "112133", "112331", "132131", "113123", "113321", "133121", "313121", "211331",
+	"231131", "213113", "213311", "213131", "311123", "311321", "331121", "312113", "312311", "332111",
+	"314111", "221411", "431111", "111224", "111422", "121124", "121421", "141122", "141221", "112214",
+	"112412", "122114", "122411", "142112", "142211", "241211", "221114", "413111", "241112", "134111",
+	"111242", "121142", "121241", "114212", "124112", "124211", "411212", "421112", "421211", "212141",
+	"214121", "412121", "111143", "111341", "131141", "114113", "114311", "411113", "411311", "113141",
+	"114131", "311141", "411131", "211412", "211214", "211232", "2331112",
+}
+
+const (
+	code128StartB = 104
+	code128Stop   = 106
+)
+
+// Code128 encodes data using Code 128 code set B. It returns the widths in
+// modules of the barcode's alternating bars and spaces, starting with a
+// bar. The quiet zones are not included.
+func Code128(data string) ([]int, error) {
+	values := []int{code128StartB}
+	checksum := code128StartB
+	for i := 0; i < len(data); i++ {
+		c := data[i]
+		if c < ' ' || c > '~' {
+			return nil, ErrUnencodable
+		}
+		v := int(c - ' ')
+		values = append(values, v)
+		checksum += (i + 1) * v
+	}
+	values = append(values, checksum%103, code128Stop)
+
+	var widths []int
+	for _, v := range values {
+		for _, w := range code128Patterns[v] {
+			widths = append(widths, int(w-'0'))
+		}
+	}
+
+	return widths, nil
+}
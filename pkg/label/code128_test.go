@@ -0,0 +1,69 @@
+package label
+
+import (
+	"reflect"
+	"testing"
+)
+
+// widths returns the module widths given by the digits of the patterns pp.
+func widths(pp ...string) []int {
+	var ww []int
+	for _, p := range pp {
+		for _, c := range p {
+			ww = append(ww, int(c-'0'))
+		}
+	}
+
+	return ww
+}
+
+func TestCode128(t *testing.T) {
+	const start, stop = "211214", "2331112"
+	tests := []struct {
+		data string
+		want []int
+		err  error
+	}{
+		// The check symbol is the start symbol's value 104 modulo 103.
+		{"", widths(start, "222122", stop), nil},
+		// A is 33, the check symbol (104 + 33) % 103 = 34.
+		{"A", widths(start, "111323", "131123", stop), nil},
+		// H is 40 and i is 73, the check symbol (104 + 40 + 2*73) % 103 = 84.
+		{"Hi", widths(start, "231113", "142112", "124112", stop), nil},
+		// Space is 0 and ~ is 94, the check symbol (104 + 0 + 2*94) % 103 = 86.
+		{" ~", widths(start, "212222", "131141", "411212", stop), nil},
+		{"\n", nil, ErrUnencodable},
+		{"A\x7f", nil, ErrUnencodable},
+		{"Zürich", nil, ErrUnencodable},
+	}
+	for _, tt := range tests {
+		got, err := Code128(tt.data)
+		if err != tt.err {
+			t.Errorf("Code128(%q) returned %v, want %v", tt.data, err, tt.err)
+		} else if !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Code128(%q) = %v, want %v", tt.data, got, tt.want)
+		}
+	}
+}
+
+func TestCode128Modules(t *testing.T) {
+	for _, data := range []string{"", "IPPS", "EA-10DZT0NWZ-58BV9Q2H5-YWKCZNBPX"} {
+		ww, err := Code128(data)
+		if err != nil {
+			t.Errorf("Code128(%q) returned %v", data, err)
+			continue
+		}
+		sum := 0
+		for _, w := range ww {
+			sum += w
+		}
+		// The start, data and check symbols are 11 modules wide, the stop
+		// symbol is 13 modules wide.
+		if want := 11*(len(data)+2) + 13; sum != want {
+			t.Errorf("Code128(%q) is %d modules wide, want %d", data, sum, want)
+		}
+		if len(ww)%2 == 0 {
+			t.Errorf("Code128(%q) ends with a space", data)
+		}
+	}
+}
@@ -0,0 +1,91 @@
+package label
+
+import (
+	"fmt"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+)
+
+const (
+	// invoiceMargin is the margin around the invoice's contents in points.
+	invoiceMargin = 50.0
+	// maxLineLength is the number of characters of a line's description
+	// fitting into its column.
+	maxLineLength = 70
+	// digitWidth is the width of Helvetica's digits relative to the font
+	// size, which is used to right-align amounts.
+	digitWidth = 0.556
+)
+
+// Invoice renders inv, which has been issued for p, as an A4 sized PDF
+// document. The sender's return address is printed as the billing address,
+// unless it has been deleted.
+func Invoice(inv *payment.Invoice, p *parcel.Parcel) []byte {
+	c := &pdfCanvas{}
+	right := a4Width - invoiceMargin
+	y := a4Height - invoiceMargin - 22
+	c.text("F2", 22, invoiceMargin, y, "IPPS")
+	c.text("F2", 18, right-75, y, "INVOICE")
+	y -= 14
+	c.text("F1", 9, invoiceMargin, y, "Interplanetary Parcel Service")
+
+	y -= 50
+	c.text("F2", 8, invoiceMargin, y, "Bill to")
+	if p.ReturnAddress != nil {
+		for i, s := range addressLines(p.ReturnAddress) {
+			c.text("F1", 10, invoiceMargin, y-14-float64(i)*13, s)
+		}
+	}
+	for i, f := range []struct{ title, value string }{
+		{"Invoice number", inv.Number},
+		{"Date", inv.Created.Format("2006-01-02")},
+		{"Tracking number", p.TrackingCode()},
+	} {
+		fy := y - float64(i)*14
+		c.text("F2", 9, right-230, fy, f.title)
+		c.text("F1", 9, right-130, fy, f.value)
+	}
+
+	y -= 90
+	c.text("F2", 9, invoiceMargin, y, "Description")
+	amountRight(c, "F2", 9, right, y, "Amount ("+inv.Currency+")")
+	y -= 5
+	c.line(invoiceMargin, y, right, y)
+	for _, l := range inv.Lines {
+		y -= 16
+		desc := []rune(l.Description)
+		if len(desc) > maxLineLength {
+			desc = append(desc[:maxLineLength-3], '.', '.', '.')
+		}
+		c.text("F1", 10, invoiceMargin, y, string(desc))
+		amountRight(c, "F1", 10, right, y, l.Amount.String())
+	}
+	y -= 8
+	c.line(invoiceMargin, y, right, y)
+
+	for _, t := range []struct {
+		font, title, value string
+	}{
+		{"F1", "Net", inv.Net.String()},
+		{"F1", fmt.Sprintf("%s (%g %%)", inv.TaxName, inv.TaxPercent), inv.Tax.String()},
+		{"F2", "Total", inv.Total.String() + " " + inv.Currency},
+	} {
+		y -= 16
+		c.text(t.font, 10, right-230, y, t.title)
+		amountRight(c, t.font, 10, right, y, t.value)
+	}
+
+	y -= 50
+	c.text("F1", 10, invoiceMargin, y, "Paid by credit card ending in "+inv.Card+".")
+	y -= 14
+	c.text("F1", 10, invoiceMargin, y, "Thank you for shipping with IPPS.")
+
+	return c.document(a4Width, a4Height)
+}
+
+// amountRight draws s, which mostly consists of digits, so that it ends at
+// x.
+func amountRight(c *pdfCanvas, font string, size, x, y float64, s string) {
+	c.text(font, size, x-float64(len(s))*size*digitWidth, y, s)
+}
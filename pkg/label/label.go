@@ -0,0 +1,164 @@
+// Package label renders the shipping labels, which are attached to parcels
+// when they are delivered to one of our shops, and the other documents
+// printed for parcels, i.e. customs declarations and invoices.
+package label
+
+import (
+	"errors"
+	"strings"
+	"unicode"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrAddressMissing = errors.New("label: the parcel's addresses have been deleted")
+	ErrUnknownFormat  = errors.New("label: unknown format, use pdf, zpl or cn23")
+	ErrNoCustoms      = errors.New("label: the parcel has not been declared to customs")
+)
+
+// Label contains everything printed on a parcel's shipping label.
+type Label struct {
+	TrackingID uuid.UUID
+	// TrackingCode is the human-friendly form of the tracking ID, which is
+	// printed below the barcode.
+	TrackingCode string
+	From         *address.Address
+	To           *address.Address
+	ServiceLevel parcel.ServiceLevel
+	Weight       float64
+	Hazards      parcel.Hazards
+	// Customs is the parcel's customs declaration, which may be nil.
+	Customs       *parcel.CustomsDeclaration
+	DeclaredValue parcel.Cents
+	Insured       bool
+	// Routing is the planet routing code, e.g. MAR-EAR for parcels sent
+	// from Mars to Earth.
+	Routing string
+}
+
+// New returns the label of p.
+func New(p *parcel.Parcel) (*Label, error) {
+	if p.ReturnAddress == nil || p.DestinationAddress == nil {
+		return nil, ErrAddressMissing
+	}
+
+	return &Label{
+		TrackingID:    p.ID,
+		TrackingCode:  p.TrackingCode(),
+		From:          p.ReturnAddress,
+		To:            p.DestinationAddress,
+		ServiceLevel:  p.ServiceLevel,
+		Weight:        p.Weight,
+		Hazards:       p.Hazards,
+		Customs:       p.Customs,
+		DeclaredValue: p.DeclaredValue,
+		Insured:       p.Insured,
+		Routing:       planetCode(p.ReturnAddress.Planet) + "-" + planetCode(p.DestinationAddress.Planet),
+	}, nil
+}
+
+// planetCode returns the three letter code of the planet called name. The
+// code of planets unknown to the orbital model is made up of the first
+// letters of their name, padded with Xs.
+func planetCode(name string) string {
+	if p := orbit.Lookup(name); p != nil {
+		name = p.Name
+	}
+	var b strings.Builder
+	for _, r := range strings.ToUpper(name) {
+		if b.Len() == 3 {
+			break
+		}
+		if r < unicode.MaxASCII && unicode.IsLetter(r) {
+			b.WriteRune(r)
+		}
+	}
+	for b.Len() < 3 {
+		b.WriteByte('X')
+	}
+
+	return b.String()
+}
+
+// barcodeData returns the data encoded in the label's barcode, which is the
+// tracking ID without hyphens.
+func (l *Label) barcodeData() string {
+	return strings.Replace(l.TrackingID.String(), "-", "", -1)
+}
+
+// addressLines returns the lines of a's postal address.
+func addressLines(a *address.Address) []string {
+	ll := []string{a.Street, a.Zip + " " + a.City, a.Country}
+	if a.Planet != "" {
+		ll = append(ll, a.Planet)
+	}
+
+	return ll
+}
+
+// Format is a format in which labels are rendered.
+type Format int
+
+const (
+	// PDF renders A6 sized PDF documents.
+	PDF Format = iota
+	// ZPL renders labels for 203 dpi thermal printers understanding the
+	// Zebra Programming Language.
+	ZPL
+	// CN23 renders the customs declaration as an A4 sized PDF document.
+	CN23
+)
+
+// ParseFormat returns the format called name, ignoring case. The empty
+// name is parsed as PDF.
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(name) {
+	case "", "pdf":
+		return PDF, nil
+	case "zpl":
+		return ZPL, nil
+	case "cn23":
+		return CN23, nil
+	default:
+		return 0, ErrUnknownFormat
+	}
+}
+
+// ContentType returns the MIME type of labels rendered in format f.
+func (f Format) ContentType() string {
+	if f == ZPL {
+		return "application/x-zpl"
+	}
+
+	return "application/pdf"
+}
+
+// Extension returns the file name extension of labels rendered in format f.
+func (f Format) Extension() string {
+	switch f {
+	case ZPL:
+		return ".zpl"
+	case CN23:
+		return ".cn23.pdf"
+	default:
+		return ".pdf"
+	}
+}
+
+// Render renders l in format f.
+func (l *Label) Render(f Format) ([]byte, error) {
+	switch f {
+	case PDF:
+		return l.PDF()
+	case ZPL:
+		return l.ZPL()
+	case CN23:
+		return l.CN23()
+	default:
+		return nil, ErrUnknownFormat
+	}
+}
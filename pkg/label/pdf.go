@@ -0,0 +1,163 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// a6Width and a6Height are the dimensions of an A6 page in points.
+	a6Width  = 297.64
+	a6Height = 419.53
+	// pdfMargin is the margin around the label's contents in points.
+	pdfMargin = 14.0
+	// pdfModule is the width of the barcode's narrowest bar in points.
+	pdfModule = 0.66
+)
+
+// PDF renders l as an A6 sized PDF document, which only uses the standard
+// Helvetica fonts.
+func (l *Label) PDF() ([]byte, error) {
+	widths, err := Code128(l.barcodeData())
+	if err != nil {
+		return nil, err
+	}
+
+	c := &pdfCanvas{}
+	y := a6Height - pdfMargin - 22
+	c.text("F2", 22, pdfMargin, y, "IPPS")
+	if l.ServiceLevel == parcel.Express {
+		// Express parcels are marked by inverted text.
+		c.rect(a6Width-pdfMargin-90, y-6, 90, 28)
+		c.fillColor(1)
+		c.text("F2", 16, a6Width-pdfMargin-82, y, "EXPRESS")
+		c.fillColor(0)
+	} else {
+		c.text("F2", 16, a6Width-pdfMargin-90, y, strings.ToUpper(l.ServiceLevel.String()))
+	}
+	y -= 44
+	c.text("F2", 36, pdfMargin, y, l.Routing)
+	y -= 12
+	c.line(pdfMargin, y, a6Width-pdfMargin, y)
+
+	y -= 16
+	c.text("F2", 8, pdfMargin, y, "FROM")
+	for _, s := range addressLines(l.From) {
+		y -= 11
+		c.text("F1", 9, pdfMargin, y, s)
+	}
+	y -= 20
+	c.text("F2", 8, pdfMargin, y, "TO")
+	for _, s := range addressLines(l.To) {
+		y -= 17
+		c.text("F2", 14, pdfMargin, y, s)
+	}
+	y -= 22
+	c.text("F1", 9, pdfMargin, y, fmt.Sprintf("Weight: %.2f kg", l.Weight))
+	if l.Hazards != 0 {
+		y -= 14
+		c.text("F2", 10, pdfMargin, y, "HAZARDOUS GOODS: "+l.Hazards.String())
+	}
+	if l.Insured {
+		y -= 14
+		c.text("F2", 10, pdfMargin, y, "INSURED: "+l.DeclaredValue.String())
+	}
+
+	c.barcode(widths, pdfMargin+10*pdfModule, pdfMargin+16, 70)
+	c.text("F1", 9, pdfMargin, pdfMargin+4, l.TrackingCode)
+
+	return c.document(a6Width, a6Height), nil
+}
+
+// pdfCanvas builds the content stream of a single PDF page.
+type pdfCanvas struct {
+	bytes.Buffer
+}
+
+func (c *pdfCanvas) text(font string, size, x, y float64, s string) {
+	fmt.Fprintf(&c.Buffer, "BT /%s %.2f Tf %.2f %.2f Td (%s) Tj ET\n", font, size, x, y, pdfString(s))
+}
+
+func (c *pdfCanvas) rect(x, y, w, h float64) {
+	fmt.Fprintf(&c.Buffer, "%.2f %.2f %.2f %.2f re f\n", x, y, w, h)
+}
+
+func (c *pdfCanvas) line(x1, y1, x2, y2 float64) {
+	fmt.Fprintf(&c.Buffer, "%.2f %.2f m %.2f %.2f l S\n", x1, y1, x2, y2)
+}
+
+// box strokes the outline of a rectangle.
+func (c *pdfCanvas) box(x, y, w, h float64) {
+	fmt.Fprintf(&c.Buffer, "%.2f %.2f %.2f %.2f re S\n", x, y, w, h)
+}
+
+// barcode draws the Code 128 barcode with the given widths at x, y using
+// bars of height h.
+func (c *pdfCanvas) barcode(widths []int, x, y, h float64) {
+	for i, w := range widths {
+		if i%2 == 0 {
+			c.rect(x, y, float64(w)*pdfModule, h)
+		}
+		x += float64(w) * pdfModule
+	}
+}
+
+// fillColor sets the gray level used for filling, where 0 is black and 1 is
+// white.
+func (c *pdfCanvas) fillColor(gray float64) {
+	fmt.Fprintf(&c.Buffer, "%.2f g\n", gray)
+}
+
+// document returns a PDF document with a single page of the given size in
+// points showing c.
+func (c *pdfCanvas) document(width, height float64) []byte {
+	objects := []string{
+		"<< /Type /Catalog /Pages 2 0 R >>",
+		"<< /Type /Pages /Kids [3 0 R] /Count 1 >>",
+		fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] "+
+			"/Resources << /Font << /F1 4 0 R /F2 5 0 R >> >> /Contents 6 0 R >>", width, height),
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>",
+		"<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>",
+		fmt.Sprintf("<< /Length %d >>\nstream\n%sendstream", c.Len(), c.String()),
+	}
+
+	var b bytes.Buffer
+	b.WriteString("%PDF-1.4\n")
+	offsets := make([]int, len(objects))
+	for i, o := range objects {
+		offsets[i] = b.Len()
+		fmt.Fprintf(&b, "%d 0 obj\n%s\nendobj\n", i+1, o)
+	}
+	xref := b.Len()
+	fmt.Fprintf(&b, "xref\n0 %d\n0000000000 65535 f \n", len(objects)+1)
+	for _, o := range offsets {
+		fmt.Fprintf(&b, "%010d 00000 n \n", o)
+	}
+	fmt.Fprintf(&b, "trailer\n<< /Size %d /Root 1 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(objects)+1, xref)
+
+	return b.Bytes()
+}
+
+// pdfString escapes s for use in a PDF string literal. Characters, which
+// are not part of Latin-1, are replaced by question marks.
+func pdfString(s string) string {
+	var b strings.Builder
+	for _, r := range s {
+		switch {
+		case r == '(' || r == ')' || r == '\\':
+			b.WriteByte('\\')
+			b.WriteRune(r)
+		case r >= ' ' && r <= '~':
+			b.WriteRune(r)
+		case r >= 0xa0 && r <= 0xff:
+			fmt.Fprintf(&b, "\\%03o", r)
+		default:
+			b.WriteByte('?')
+		}
+	}
+
+	return b.String()
+}
@@ -0,0 +1,94 @@
+package label
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	// zplWidth and zplHeight are the dimensions of an A6 label in dots of
+	// a 203 dpi printer.
+	zplWidth  = 839
+	zplHeight = 1183
+	zplMargin = 40
+)
+
+// ZPL renders l as a ZPL II program for 203 dpi thermal printers. The
+// printer draws the Code 128 barcode itself.
+func (l *Label) ZPL() ([]byte, error) {
+	data := l.barcodeData()
+	_, err := Code128(data)
+	if err != nil {
+		return nil, err
+	}
+
+	var b bytes.Buffer
+	fmt.Fprintf(&b, "^XA\n^CI28\n^PW%d\n^LL%d\n", zplWidth, zplHeight)
+	y := zplMargin
+	zplText(&b, zplMargin, y, 60, "IPPS")
+	if l.ServiceLevel == parcel.Express {
+		fmt.Fprintf(&b, "^FO%d,%d^GB280,70,70^FS\n", zplWidth-zplMargin-280, y-5)
+		fmt.Fprintf(&b, "^FO%d,%d^A0N,50,50^FR^FH^FD%s^FS\n", zplWidth-zplMargin-260, y+5, "EXPRESS")
+	} else {
+		zplText(&b, zplWidth-zplMargin-260, y+5, 50, strings.ToUpper(l.ServiceLevel.String()))
+	}
+	y += 100
+	zplText(&b, zplMargin, y, 100, l.Routing)
+	y += 120
+	fmt.Fprintf(&b, "^FO%d,%d^GB%d,3,3^FS\n", zplMargin, y, zplWidth-2*zplMargin)
+
+	y += 30
+	zplText(&b, zplMargin, y, 24, "FROM")
+	for _, s := range addressLines(l.From) {
+		y += 32
+		zplText(&b, zplMargin, y, 28, s)
+	}
+	y += 60
+	zplText(&b, zplMargin, y, 24, "TO")
+	for _, s := range addressLines(l.To) {
+		y += 50
+		zplText(&b, zplMargin, y, 44, s)
+	}
+	y += 70
+	zplText(&b, zplMargin, y, 28, fmt.Sprintf("Weight: %.2f kg", l.Weight))
+	if l.Hazards != 0 {
+		y += 40
+		zplText(&b, zplMargin, y, 30, "HAZARDOUS GOODS: "+l.Hazards.String())
+	}
+	if l.Insured {
+		y += 40
+		zplText(&b, zplMargin, y, 30, "INSURED: "+l.DeclaredValue.String())
+	}
+
+	fmt.Fprintf(&b, "^FO%d,%d^BY2^BCN,160,N,N,N^FD%s^FS\n", zplMargin, zplHeight-zplMargin-210, data)
+	zplText(&b, zplMargin, zplHeight-zplMargin-30, 28, l.TrackingCode)
+	b.WriteString("^XZ\n")
+
+	return b.Bytes(), nil
+}
+
+// zplText writes a field printing s at x, y using the scalable font with
+// height h.
+func zplText(b *bytes.Buffer, x, y, h int, s string) {
+	fmt.Fprintf(b, "^FO%d,%d^A0N,%d,%d^FH^FD%s^FS\n", x, y, h, h, zplString(s))
+}
+
+// zplString escapes s for use in a field with hexadecimal escapes enabled
+// by ^FH. Control characters and all bytes of non-ASCII characters are
+// escaped, as are the command prefixes and the escape character.
+func zplString(s string) string {
+	var b strings.Builder
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		if c < ' ' || c > '~' || c == '^' || c == '~' || c == '_' {
+			fmt.Fprintf(&b, "_%02X", c)
+		} else {
+			b.WriteByte(c)
+		}
+	}
+
+	return b.String()
+}
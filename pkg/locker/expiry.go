@@ -0,0 +1,45 @@
+package locker
+
+import (
+	"log"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// Expirer periodically returns the parcels, which have not been collected
+// from pickup points in time.
+type Expirer struct {
+	Service  *Service
+	Interval time.Duration
+}
+
+// Run returns the expired parcels every e.Interval. It never returns.
+func (e *Expirer) Run() {
+	t := time.NewTicker(e.Interval)
+	defer t.Stop()
+	for range t.C {
+		err := e.Service.ReturnExpired(time.Now())
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+// Releaser is a Publisher, which releases the compartment of a parcel,
+// once its return has been initiated.
+type Releaser struct {
+	Points Storage
+}
+
+func (r *Releaser) Publish(e *parcel.Event) error {
+	if e.Type != parcel.ReturnInitiated || e.Parcel.PickupPointID == nil {
+		return nil
+	}
+	a, err := r.Points.ByParcel(e.Parcel.ID)
+	if err != nil || a == nil {
+		return err
+	}
+
+	return r.Points.SetStatus(a, Returned)
+}
@@ -0,0 +1,207 @@
+// Package locker manages our shops and parcel lockers, which serve as
+// pickup points, at which recipients collect their parcels instead of
+// having them delivered to their addresses.
+package locker
+
+import (
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/schema"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrUnknownKind         = errors.New("locker: unknown kind of pickup point")
+	ErrNameEmpty           = errors.New("locker: the name of the pickup point is required")
+	ErrIncompleteAddress   = errors.New("locker: street, zip, city, country and planet of the pickup point are required")
+	ErrInvalidCompartments = errors.New("locker: a pickup point needs at least one compartment")
+)
+
+// DefaultHoldingPeriod is the time for which parcels are kept at pickup
+// points, unless configured otherwise.
+const DefaultHoldingPeriod = 7 * 24 * time.Hour
+
+// Kind is the kind of a pickup point.
+type Kind string
+
+const (
+	// Shop is one of our shops, where clerks hand over the parcels.
+	Shop Kind = "shop"
+	// Locker is a parcel locker, which recipients open themselves.
+	Locker Kind = "locker"
+)
+
+// ParseKind parses the name of a kind of pickup point, ignoring case.
+func ParseKind(name string) (Kind, error) {
+	switch k := Kind(strings.ToLower(strings.TrimSpace(name))); k {
+	case Shop, Locker:
+		return k, nil
+	default:
+		return "", ErrUnknownKind
+	}
+}
+
+// Point is a shop or parcel locker, at which recipients collect parcels.
+type Point struct {
+	ID      uuid.UUID `json:"id" schema:"-"`
+	Kind    Kind      `json:"kind" schema:"kind"`
+	Name    string    `json:"name" schema:"name"`
+	Street  string    `json:"street" schema:"street"`
+	Zip     string    `json:"zip" schema:"zip"`
+	City    string    `json:"city" schema:"city"`
+	Country string    `json:"country" schema:"country"`
+	Planet  string    `json:"planet" schema:"planet"`
+	// OpeningHours describes when parcels may be collected, e.g.
+	// "Mon-Sat 8:00-20:00".
+	OpeningHours string `json:"openingHours" schema:"opening-hours"`
+	// Compartments is the number of parcels, which can be kept at the
+	// pickup point at the same time.
+	Compartments int `json:"compartments" schema:"compartments"`
+	// Occupied is the number of compartments allocated to parcels.
+	Occupied int `json:"occupied" schema:"-"`
+}
+
+var formDecoder = schema.NewDecoder()
+
+// ParsePointForm parses r's post form into a new pickup point, which is
+// validated.
+func ParsePointForm(r *http.Request) (*Point, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	pt := &Point{}
+	err = formDecoder.Decode(pt, r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+
+	return pt, pt.Validate()
+}
+
+// Validate validates pt, normalizing its kind and trimming its fields.
+func (pt *Point) Validate() error {
+	k, err := ParseKind(string(pt.Kind))
+	if err != nil {
+		return err
+	}
+	pt.Kind = k
+	for _, f := range []*string{&pt.Name, &pt.Street, &pt.Zip, &pt.City, &pt.Country, &pt.Planet,
+		&pt.OpeningHours} {
+		*f = strings.TrimSpace(*f)
+	}
+	if pt.Name == "" {
+		return ErrNameEmpty
+	} else if pt.Street == "" || pt.Zip == "" || pt.City == "" || pt.Country == "" || pt.Planet == "" {
+		return ErrIncompleteAddress
+	} else if pt.Compartments <= 0 {
+		return ErrInvalidCompartments
+	}
+
+	return nil
+}
+
+// Free returns the number of compartments, which are not allocated.
+func (pt *Point) Free() int {
+	if pt.Occupied >= pt.Compartments {
+		return 0
+	}
+
+	return pt.Compartments - pt.Occupied
+}
+
+// Destination returns a new address of u at pt, which is used as the
+// destination of parcels sent to pt.
+func (pt *Point) Destination(u *user.User) (*address.Address, error) {
+	a, err := address.NewForUser(u)
+	if err != nil {
+		return nil, err
+	}
+	a.Street = fmt.Sprintf("%s, %s", pt.Name, pt.Street)
+	a.Zip = pt.Zip
+	a.City = pt.City
+	a.Country = pt.Country
+	a.Planet = pt.Planet
+
+	return a, nil
+}
+
+// Status is the status of a compartment's allocation.
+type Status string
+
+const (
+	// Allocated compartments contain the parcel awaiting its collection.
+	Allocated Status = "allocated"
+	Collected Status = "collected"
+	// Returned parcels have not been collected in time or their return has
+	// been requested otherwise.
+	Returned Status = "returned"
+	// Cancelled allocations have never been used.
+	Cancelled Status = "cancelled"
+)
+
+// codeLength is the number of digits of pickup codes.
+const codeLength = 6
+
+// Allocation is the allocation of a pickup point's compartment to a parcel.
+type Allocation struct {
+	ID       uuid.UUID `json:"id"`
+	Point    *Point    `json:"point"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	// Compartment is the number of the compartment starting at 1.
+	Compartment int `json:"compartment"`
+	// Code is the one-time code, with which the recipient collects the
+	// parcel. It must only be shown to the recipient.
+	Code        string    `json:"code"`
+	Status      Status    `json:"status"`
+	AllocatedAt time.Time `json:"allocatedAt"`
+	// ExpiresAt is the time, after which the parcel is returned to its
+	// sender, unless it has been collected.
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// newAllocation returns a new allocation for the parcel identified by
+// parcelID at pt, which expires after period. The compartment is chosen by
+// the storage.
+func newAllocation(pt *Point, parcelID uuid.UUID, at time.Time, period time.Duration) (*Allocation, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	code, err := newCode()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Allocation{
+		ID:          id,
+		Point:       pt,
+		ParcelID:    parcelID,
+		Code:        code,
+		Status:      Allocated,
+		AllocatedAt: at,
+		ExpiresAt:   at.Add(period),
+	}, nil
+}
+
+// newCode returns a new random numeric pickup code.
+func newCode() (string, error) {
+	max := big.NewInt(1)
+	for i := 0; i < codeLength; i++ {
+		max.Mul(max, big.NewInt(10))
+	}
+	n, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+
+	return fmt.Sprintf("%0*d", codeLength, n), nil
+}
@@ -0,0 +1,242 @@
+package locker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrPointNotFound    = errors.New("locker: the pickup point does not exist")
+	ErrNoPickupPoint    = errors.New("locker: the parcel is not sent to a pickup point")
+	ErrNotOutForPickup  = errors.New("locker: the parcel cannot be deposited at the pickup point in its current state")
+	ErrPointFull        = errors.New("locker: all compartments of the pickup point are occupied")
+	ErrAlreadyAllocated = errors.New("locker: the parcel already occupies a compartment")
+	ErrInvalidCode      = errors.New("locker: the pickup code is invalid or has already been used")
+	ErrNotRecipient     = errors.New("locker: only the recipient may see the pickup code")
+	ErrNotDeposited     = errors.New("locker: the parcel has not been deposited at the pickup point")
+)
+
+// maxCodeAttempts is the number of pickup codes tried, before Deposit
+// gives up, because the codes are already in use.
+const maxCodeAttempts = 5
+
+// Service manages pickup points, deposits parcels in their compartments
+// and hands them over to their recipients.
+type Service struct {
+	Points    Storage
+	Addresses address.Storage
+	Parcels   parcel.Storage
+	// Events must publish the recorded events, so that compartments are
+	// released, when the return of deposited parcels is initiated.
+	Events parcel.EventStorage
+	// HoldingPeriod defaults to DefaultHoldingPeriod.
+	HoldingPeriod time.Duration
+}
+
+// AddPoint validates pt and adds it to the registry of pickup points,
+// assigning it a new ID.
+func (s *Service) AddPoint(pt *Point) error {
+	err := pt.Validate()
+	if err != nil {
+		return err
+	}
+	pt.ID, err = uuid.NewRandom()
+	if err != nil {
+		return err
+	}
+	pt.Occupied = 0
+
+	return s.Points.InsertPoint(pt)
+}
+
+// Resolve sets the destination of req to the address of the pickup point
+// selected by req, if any.
+func (s *Service) Resolve(req *parcel.SendRequest) error {
+	if req.PickupPointID == uuid.Nil {
+		return nil
+	}
+	pt, err := s.Points.PointByID(req.PickupPointID)
+	if err != nil {
+		return err
+	} else if pt == nil {
+		return ErrPointNotFound
+	}
+	req.DestinationAddressID = uuid.Nil
+	req.NewDestination, err = pt.Destination(req.Sender)
+
+	return err
+}
+
+// Redirect changes the destination of p to the pickup point identified by
+// id on behalf of u, who must be p's recipient, e.g. after a failed
+// delivery attempt. The pickup point must be on the planet of p's current
+// destination.
+func (s *Service) Redirect(u *user.User, p *parcel.Parcel, id uuid.UUID) (*parcel.Event, error) {
+	pt, err := s.Points.PointByID(id)
+	if err != nil {
+		return nil, err
+	} else if pt == nil {
+		return nil, ErrPointNotFound
+	}
+	dest, err := pt.Destination(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return parcel.RedirectToPoint(u, p, pt.ID, dest, s.Addresses, s.Parcels, s.Events)
+}
+
+// Deposit allocates a compartment of the pickup point, to which p is sent,
+// and records the DeliveredToPickupPoint event, which occurred at time at,
+// or just now, if at is the zero time.
+func (s *Service) Deposit(p *parcel.Parcel, at time.Time) (*Allocation, *parcel.Event, error) {
+	if p.PickupPointID == nil {
+		return nil, nil, ErrNoPickupPoint
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = parcel.NewState(p, ee).Check(parcel.DeliveredToPickupPoint, at)
+	if err == parcel.ErrInvalidTransition {
+		return nil, nil, ErrNotOutForPickup
+	} else if err != nil {
+		return nil, nil, err
+	}
+	pt, err := s.Points.PointByID(*p.PickupPointID)
+	if err != nil {
+		return nil, nil, err
+	} else if pt == nil {
+		return nil, nil, ErrPointNotFound
+	}
+
+	a, err := s.allocate(pt, p, at.Local())
+	if err != nil {
+		return nil, nil, err
+	}
+	e, err := parcel.Record(s.Events, p, parcel.DeliveredToPickupPoint, at)
+	if err != nil {
+		s.setStatus(a, Cancelled)
+		return nil, nil, err
+	}
+	pt.Occupied++
+
+	return a, e, nil
+}
+
+// allocate allocates a compartment of pt to p, retrying with new pickup
+// codes, if a code is already in use.
+func (s *Service) allocate(pt *Point, p *parcel.Parcel, at time.Time) (*Allocation, error) {
+	period := s.HoldingPeriod
+	if period <= 0 {
+		period = DefaultHoldingPeriod
+	}
+	for i := 0; i < maxCodeAttempts; i++ {
+		a, err := newAllocation(pt, p.ID, at, period)
+		if err != nil {
+			return nil, err
+		}
+		err = s.Points.Allocate(a)
+		if err != ErrCodeInUse {
+			return a, err
+		}
+	}
+
+	return nil, ErrCodeInUse
+}
+
+// Collect hands the parcel, whose pickup code at the pickup point
+// identified by id is code, over to its recipient. The code cannot be used
+// again. The CollectedFromPickupPoint event is recorded at time at, or
+// just now, if at is the zero time.
+func (s *Service) Collect(id uuid.UUID, code string, at time.Time) (*Allocation, *parcel.Event, error) {
+	a, err := s.Points.ByCode(id, code)
+	if err != nil {
+		return nil, nil, err
+	} else if a == nil || a.ExpiresAt.Before(time.Now()) {
+		return nil, nil, ErrInvalidCode
+	}
+	p, err := s.Parcels.ByID(a.ParcelID)
+	if err != nil {
+		return nil, nil, err
+	} else if p == nil {
+		return nil, nil, errors.New("locker: the parcel does not exist")
+	}
+
+	e, err := parcel.Record(s.Events, p, parcel.CollectedFromPickupPoint, at)
+	if err == parcel.ErrInvalidTransition {
+		return nil, nil, ErrInvalidCode
+	} else if err != nil {
+		return nil, nil, err
+	}
+	err = s.setStatus(a, Collected)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return a, e, nil
+}
+
+// Allocation returns the compartment allocated to p, including its pickup
+// code, on behalf of u, who must be p's recipient.
+func (s *Service) Allocation(u *user.User, p *parcel.Parcel) (*Allocation, error) {
+	if !p.SentTo(u) {
+		return nil, ErrNotRecipient
+	}
+	a, err := s.Points.ByParcel(p.ID)
+	if err != nil {
+		return nil, err
+	} else if a == nil {
+		return nil, ErrNotDeposited
+	}
+
+	return a, nil
+}
+
+// ReturnExpired initiates the return of the parcels, which have not been
+// collected before their allocations expired at time at, and releases
+// their compartments.
+func (s *Service) ReturnExpired(at time.Time) error {
+	aa, err := s.Points.Expired(at)
+	if err != nil {
+		return err
+	}
+	for _, a := range aa {
+		p, err := s.Parcels.ByID(a.ParcelID)
+		if err != nil {
+			return err
+		}
+		if p != nil {
+			_, err = parcel.Record(s.Events, p, parcel.ReturnInitiated, time.Time{})
+			if err != nil && err != parcel.ErrInvalidTransition {
+				return err
+			}
+		}
+		// The compartment is released by the Releaser, unless the
+		// parcel could not be returned.
+		err = s.setStatus(a, Returned)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func (s *Service) setStatus(a *Allocation, st Status) error {
+	err := s.Points.SetStatus(a, st)
+	if err != nil {
+		return err
+	}
+	a.Status = st
+
+	return nil
+}
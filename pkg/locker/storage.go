@@ -0,0 +1,47 @@
+package locker
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// ErrCodeInUse is returned by Allocate, if the allocation's pickup code is
+// already used for another parcel at the same pickup point.
+var ErrCodeInUse = errors.New("locker: the pickup code is already in use")
+
+// Storage is the interface for managing pickup points and the allocation
+// of their compartments.
+//
+// PointByID returns nil, if there is no pickup point identified by id.
+//
+// Points returns the pickup points in the given city on planet ordered by
+// their names. Empty names match all cities and planets and are matched
+// ignoring case.
+//
+// Allocate inserts a, choosing the lowest free compartment of a's pickup
+// point. It returns ErrPointFull, if all compartments are allocated,
+// ErrAlreadyAllocated, if the parcel already occupies a compartment, and
+// ErrCodeInUse, if a's code is used by another allocation at the point.
+//
+// ByParcel returns the allocated compartment of the parcel identified by
+// id or nil.
+//
+// ByCode returns the allocated compartment of the pickup point identified
+// by id, whose pickup code is code, or nil.
+//
+// Expired returns the allocated compartments, which have expired at time
+// at.
+//
+// SetStatus updates the status of a.
+type Storage interface {
+	InsertPoint(pt *Point) error
+	PointByID(id uuid.UUID) (*Point, error)
+	Points(city, planet string) ([]*Point, error)
+	Allocate(a *Allocation) error
+	ByParcel(id uuid.UUID) (*Allocation, error)
+	ByCode(id uuid.UUID, code string) (*Allocation, error)
+	Expired(at time.Time) ([]*Allocation, error)
+	SetStatus(a *Allocation, s Status) error
+}
@@ -0,0 +1,120 @@
+// Package orbit implements a simple model of the solar system, which is
+// used to estimate the distances between planets. The planets are assumed
+// to move on circular, coplanar orbits around the sun.
+package orbit
+
+import (
+	"math"
+	"strings"
+	"time"
+)
+
+// Planet describes a planet's orbit.
+type Planet struct {
+	Name string
+	// SemiMajorAxis is the radius of the planet's orbit in astronomical
+	// units.
+	SemiMajorAxis float64
+	// Period is the planet's sidereal orbital period in days.
+	Period float64
+	// Longitude is the planet's mean longitude in degrees at the J2000
+	// epoch.
+	Longitude float64
+}
+
+// j2000 is the epoch of the planets' mean longitudes.
+var j2000 = time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+
+var planets = []*Planet{
+	{Name: "Mercury", SemiMajorAxis: 0.387, Period: 87.969, Longitude: 252.25},
+	{Name: "Venus", SemiMajorAxis: 0.723, Period: 224.701, Longitude: 181.98},
+	{Name: "Earth", SemiMajorAxis: 1.000, Period: 365.256, Longitude: 100.46},
+	{Name: "Mars", SemiMajorAxis: 1.524, Period: 686.980, Longitude: 355.45},
+	{Name: "Jupiter", SemiMajorAxis: 5.203, Period: 4332.59, Longitude: 34.40},
+	{Name: "Saturn", SemiMajorAxis: 9.537, Period: 10759.22, Longitude: 49.94},
+	{Name: "Uranus", SemiMajorAxis: 19.19, Period: 30688.5, Longitude: 313.23},
+	{Name: "Neptune", SemiMajorAxis: 30.07, Period: 60182, Longitude: 304.88},
+}
+
+// Lookup returns the planet called name, ignoring case and surrounding
+// white space, or nil, if there is no such planet.
+func Lookup(name string) *Planet {
+	name = strings.TrimSpace(name)
+	for _, p := range planets {
+		if strings.EqualFold(p.Name, name) {
+			return p
+		}
+	}
+
+	return nil
+}
+
+// Planets returns all known planets ordered by their distance to the sun.
+func Planets() []*Planet {
+	pp := make([]*Planet, len(planets))
+	copy(pp, planets)
+
+	return pp
+}
+
+// Angle returns p's heliocentric longitude in radians at time t.
+func (p *Planet) Angle(t time.Time) float64 {
+	days := t.Sub(j2000).Hours() / 24
+	deg := p.Longitude + 360*days/p.Period
+
+	return math.Mod(deg, 360) * math.Pi / 180
+}
+
+// Position returns p's heliocentric coordinates in astronomical units at
+// time t.
+func (p *Planet) Position(t time.Time) (x, y float64) {
+	a := p.Angle(t)
+
+	return p.SemiMajorAxis * math.Cos(a), p.SemiMajorAxis * math.Sin(a)
+}
+
+// Distance returns the distance between a and b in astronomical units at
+// time t.
+func Distance(a, b *Planet, t time.Time) float64 {
+	ax, ay := a.Position(t)
+	bx, by := b.Position(t)
+
+	return math.Hypot(ax-bx, ay-by)
+}
+
+// TransferTime returns the flight time of a Hohmann transfer from a's orbit
+// to b's orbit.
+func TransferTime(a, b *Planet) time.Duration {
+	// By Kepler's third law, the period in years of an orbit with a
+	// semi-major axis given in astronomical units is the axis to the
+	// power of 1.5. A transfer takes half of the transfer orbit's period.
+	axis := (a.SemiMajorAxis + b.SemiMajorAxis) / 2
+	years := math.Pow(axis, 1.5) / 2
+
+	return time.Duration(years * 365.25 * 24 * float64(time.Hour))
+}
+
+// TransferAngle returns the angle in radians by which b has to lead a at
+// departure, so that a Hohmann transfer from a arrives at b.
+func TransferAngle(a, b *Planet) float64 {
+	days := TransferTime(a, b).Hours() / 24
+
+	return normalize(math.Pi - 2*math.Pi*days/b.Period)
+}
+
+// Phase returns the angle in radians by which b leads a at time t.
+func Phase(a, b *Planet, t time.Time) float64 {
+	return normalize(b.Angle(t) - a.Angle(t))
+}
+
+// normalize returns the angle a in the interval (-π, π].
+func normalize(a float64) float64 {
+	a = math.Mod(a, 2*math.Pi)
+	if a > math.Pi {
+		a -= 2 * math.Pi
+	} else if a <= -math.Pi {
+		a += 2 * math.Pi
+	}
+
+	return a
+}
@@ -0,0 +1,146 @@
+package parcel
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// MaxRescheduleDays is the number of days, by which the recipient may
+// postpone the next delivery attempt.
+const MaxRescheduleDays = 14
+
+var (
+	ErrUnknownFailureReason = errors.New("parcel: unknown reason of the failed delivery attempt")
+	ErrNotReschedulable     = errors.New("parcel: the delivery can only be rescheduled after a failed delivery attempt")
+	ErrInvalidDeliveryDate  = errors.New("parcel: the delivery date must be one of the next 14 days")
+)
+
+// FailureReason is the reason of a failed delivery attempt.
+type FailureReason int
+
+const (
+	// NoReason is used for failed delivery attempts, which have been
+	// recorded without a reason.
+	NoReason FailureReason = iota
+	RecipientAbsent
+	AddressNotFound
+	// Refused parcels are returned to their senders right away.
+	Refused
+)
+
+var failureReasonNames = map[FailureReason]string{
+	RecipientAbsent: "RecipientAbsent",
+	AddressNotFound: "AddressNotFound",
+	Refused:         "Refused",
+}
+
+func (r FailureReason) String() string {
+	switch r {
+	case RecipientAbsent:
+		return "The recipient was absent"
+	case AddressNotFound:
+		return "The address could not be found"
+	case Refused:
+		return "The recipient refused to accept the parcel"
+	default:
+		return "Unknown reason"
+	}
+}
+
+// Name returns the name of r's constant, which is used to identify
+// failure reasons in the APIs.
+func (r FailureReason) Name() string {
+	n, ok := failureReasonNames[r]
+	if !ok {
+		return ""
+	}
+
+	return n
+}
+
+func (r FailureReason) MarshalText() ([]byte, error) {
+	if r == NoReason {
+		return []byte{}, nil
+	}
+	n, ok := failureReasonNames[r]
+	if !ok {
+		return nil, ErrUnknownFailureReason
+	}
+
+	return []byte(n), nil
+}
+
+// UnmarshalText parses a failure reason's name, ignoring case. The empty
+// text is parsed as NoReason.
+func (r *FailureReason) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*r = NoReason
+		return nil
+	}
+	for fr, n := range failureReasonNames {
+		if strings.EqualFold(n, string(text)) {
+			*r = fr
+			return nil
+		}
+	}
+
+	return ErrUnknownFailureReason
+}
+
+// RecordFailure stores a new DeliveryFailed event for p with the reason r
+// in s, which occurred at time at, or just now, if at is the zero time.
+func RecordFailure(s EventStorage, p *Parcel, r FailureReason, at time.Time) (*Event, error) {
+	if _, ok := failureReasonNames[r]; !ok && r != NoReason {
+		return nil, ErrUnknownFailureReason
+	}
+	e, err := NewEvent(p, DeliveryFailed)
+	if err != nil {
+		return nil, err
+	}
+	e.Reason = r
+	err = record(s, e, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Reschedule postpones the next attempt to deliver p to the day of date on
+// behalf of u, who must be p's recipient, and stores the
+// DeliveryRescheduled event in es. The delivery may only be rescheduled
+// after a failed delivery attempt, to one of the next MaxRescheduleDays
+// days.
+func Reschedule(u *user.User, p *Parcel, date time.Time, es EventStorage) (*Event, error) {
+	if !p.SentTo(u) {
+		return nil, ErrNotRecipient
+	}
+	y, m, d := date.Date()
+	day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	y, m, d = time.Now().Date()
+	today := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+	if !day.After(today) || day.After(today.AddDate(0, 0, MaxRescheduleDays)) {
+		return nil, ErrInvalidDeliveryDate
+	}
+
+	e, err := NewEvent(p, DeliveryRescheduled)
+	if err != nil {
+		return nil, err
+	}
+	e.DeliveryDate = &day
+	err = es.Insert(e, func(ee []*Event) error {
+		st, err := redirectableAfter(p, ee)
+		if err == ErrNotRedirectable || err == nil && st.FailedAttempts == 0 {
+			return ErrNotReschedulable
+		}
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,212 @@
+package parcel
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxWeight is the maximum weight of a parcel in kilograms, which is
+	// also the maximum weight of price requests.
+	MaxWeight = 100
+	// MaxLength is the maximum length of each of a parcel's sides in
+	// centimeters.
+	MaxLength = 200
+	// MaxDeclaredValue is the maximum declared value of a parcel in cents.
+	MaxDeclaredValue = 5000000
+	// MaxContentsLength is the maximum number of characters of a parcel's
+	// contents description.
+	MaxContentsLength = 200
+)
+
+var (
+	ErrInvalidWeight        = errors.New("parcel: the weight must be positive")
+	ErrTooHeavy             = errors.New("parcel: the parcel must not weigh more than 100 kg")
+	ErrInvalidDimensions    = errors.New("parcel: the dimensions must be positive")
+	ErrTooLarge             = errors.New("parcel: no side of the parcel may be longer than 200 cm")
+	ErrInvalidDeclaredValue = errors.New("parcel: the declared value must be between 0.00 and 50000.00")
+	ErrContentsEmpty        = errors.New("parcel: a description of the contents is required")
+	ErrContentsTooLong      = errors.New("parcel: the description of the contents is too long")
+	ErrUnknownHazard        = errors.New("parcel: unknown hazard")
+	ErrExplosivesRefused    = errors.New("parcel: explosives are not accepted")
+	ErrHazardousExpress     = errors.New("parcel: hazardous goods cannot be sent express")
+	ErrUninsurable          = errors.New("parcel: only parcels with a declared value may be insured")
+)
+
+// Attributes describes what is shipped in a parcel.
+type Attributes struct {
+	// Weight is the parcel's weight in kilograms.
+	Weight float64 `json:"weight"`
+	// Length, Width and Height are the parcel's dimensions in centimeters.
+	Length float64 `json:"length"`
+	Width  float64 `json:"width"`
+	Height float64 `json:"height"`
+	// DeclaredValue is the value of the parcel's contents.
+	DeclaredValue Cents        `json:"declaredValue"`
+	Contents      string       `json:"contents"`
+	ServiceLevel  ServiceLevel `json:"serviceLevel"`
+	Hazards       Hazards      `json:"hazards"`
+	// Insured is true, if the sender has insured the parcel for its
+	// declared value against damage and loss.
+	Insured bool `json:"insured"`
+}
+
+// Validate returns an error, unless the attributes describe a parcel that
+// may be sent.
+func (a *Attributes) Validate() error {
+	if a.Weight <= 0 || !finite(a.Weight) {
+		return ErrInvalidWeight
+	} else if a.Weight > MaxWeight {
+		return ErrTooHeavy
+	} else if a.Length <= 0 || a.Width <= 0 || a.Height <= 0 || !finite(a.Length, a.Width, a.Height) {
+		return ErrInvalidDimensions
+	} else if a.Length > MaxLength || a.Width > MaxLength || a.Height > MaxLength {
+		return ErrTooLarge
+	} else if a.DeclaredValue < 0 || a.DeclaredValue > MaxDeclaredValue {
+		return ErrInvalidDeclaredValue
+	} else if a.Insured && a.DeclaredValue == 0 {
+		return ErrUninsurable
+	}
+	if strings.TrimSpace(a.Contents) == "" {
+		return ErrContentsEmpty
+	} else if utf8.RuneCountInString(a.Contents) > MaxContentsLength {
+		return ErrContentsTooLong
+	}
+	if _, ok := serviceLevelNames[a.ServiceLevel]; !ok {
+		return ErrUnknownServiceLevel
+	}
+	if a.Hazards&^allHazards != 0 {
+		return ErrUnknownHazard
+	} else if a.Hazards.Has(Explosive) {
+		return ErrExplosivesRefused
+	} else if a.Hazards != 0 && a.ServiceLevel == Express {
+		return ErrHazardousExpress
+	}
+
+	return nil
+}
+
+// finite reports whether none of ff is NaN or infinite, which would pass
+// the comparisons with the limits.
+func finite(ff ...float64) bool {
+	for _, f := range ff {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Cents is an amount of money in cents. It is used for all prices, so
+// pricing.Amount is the same type.
+type Cents int64
+
+func (c Cents) String() string {
+	sign := ""
+	if c < 0 {
+		sign, c = "-", -c
+	}
+
+	return fmt.Sprintf("%s%d.%02d", sign, c/100, c%100)
+}
+
+// Hazards is a set of hazardous goods contained in a parcel.
+type Hazards uint
+
+const (
+	Flammable Hazards = 1 << iota
+	Corrosive
+	Toxic
+	Radioactive
+	LithiumBatteries
+	Explosive
+
+	allHazards = Flammable | Corrosive | Toxic | Radioactive | LithiumBatteries | Explosive
+)
+
+var hazardNames = []struct {
+	hazard Hazards
+	name   string
+}{
+	{Flammable, "Flammable"},
+	{Corrosive, "Corrosive"},
+	{Toxic, "Toxic"},
+	{Radioactive, "Radioactive"},
+	{LithiumBatteries, "LithiumBatteries"},
+	{Explosive, "Explosive"},
+}
+
+// HazardList returns the names of all hazards.
+func HazardList() []string {
+	nn := make([]string, 0, len(hazardNames))
+	for _, h := range hazardNames {
+		nn = append(nn, h.name)
+	}
+
+	return nn
+}
+
+// ParseHazard returns the hazard called name, ignoring case.
+func ParseHazard(name string) (Hazards, error) {
+	for _, h := range hazardNames {
+		if strings.EqualFold(h.name, strings.TrimSpace(name)) {
+			return h.hazard, nil
+		}
+	}
+
+	return 0, ErrUnknownHazard
+}
+
+// Has reports whether hh contains all hazards of h.
+func (hh Hazards) Has(h Hazards) bool {
+	return hh&h == h
+}
+
+// Names returns the names of the hazards in hh.
+func (hh Hazards) Names() []string {
+	nn := []string{}
+	for _, h := range hazardNames {
+		if hh.Has(h.hazard) {
+			nn = append(nn, h.name)
+		}
+	}
+
+	return nn
+}
+
+func (hh Hazards) String() string {
+	if hh == 0 {
+		return "None"
+	}
+
+	return strings.Join(hh.Names(), ", ")
+}
+
+// MarshalJSON encodes hh as an array of hazard names.
+func (hh Hazards) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hh.Names())
+}
+
+// UnmarshalJSON decodes an array of hazard names.
+func (hh *Hazards) UnmarshalJSON(data []byte) error {
+	var nn []string
+	err := json.Unmarshal(data, &nn)
+	if err != nil {
+		return err
+	}
+	*hh = 0
+	for _, n := range nn {
+		h, err := ParseHazard(n)
+		if err != nil {
+			return err
+		}
+		*hh |= h
+	}
+
+	return nil
+}
@@ -0,0 +1,65 @@
+package parcel
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestAttributesValidate(t *testing.T) {
+	valid := Attributes{
+		Weight:        2.5,
+		Length:        30,
+		Width:         20,
+		Height:        10,
+		DeclaredValue: 5000,
+		Contents:      "Books",
+		ServiceLevel:  Standard,
+	}
+	tests := []struct {
+		name   string
+		modify func(a *Attributes)
+		want   error
+	}{
+		{"valid", func(a *Attributes) {}, nil},
+		{"maximum weight", func(a *Attributes) { a.Weight = MaxWeight }, nil},
+		{"maximum length", func(a *Attributes) { a.Length = MaxLength }, nil},
+		{"no declared value", func(a *Attributes) { a.DeclaredValue = 0 }, nil},
+		{"maximum declared value", func(a *Attributes) { a.DeclaredValue = MaxDeclaredValue }, nil},
+		{"insured", func(a *Attributes) { a.Insured = true }, nil},
+		{"express", func(a *Attributes) { a.ServiceLevel = Express }, nil},
+		{"hazardous", func(a *Attributes) { a.Hazards = Flammable | LithiumBatteries }, nil},
+		{"longest contents", func(a *Attributes) { a.Contents = strings.Repeat("ä", MaxContentsLength) }, nil},
+		{"zero weight", func(a *Attributes) { a.Weight = 0 }, ErrInvalidWeight},
+		{"negative weight", func(a *Attributes) { a.Weight = -1 }, ErrInvalidWeight},
+		{"NaN weight", func(a *Attributes) { a.Weight = math.NaN() }, ErrInvalidWeight},
+		{"infinite weight", func(a *Attributes) { a.Weight = math.Inf(1) }, ErrInvalidWeight},
+		{"too heavy", func(a *Attributes) { a.Weight = MaxWeight + 0.1 }, ErrTooHeavy},
+		{"zero width", func(a *Attributes) { a.Width = 0 }, ErrInvalidDimensions},
+		{"negative height", func(a *Attributes) { a.Height = -10 }, ErrInvalidDimensions},
+		{"NaN length", func(a *Attributes) { a.Length = math.NaN() }, ErrInvalidDimensions},
+		{"infinite height", func(a *Attributes) { a.Height = math.Inf(1) }, ErrInvalidDimensions},
+		{"too long", func(a *Attributes) { a.Length = MaxLength + 1 }, ErrTooLarge},
+		{"too wide", func(a *Attributes) { a.Width = MaxLength + 1 }, ErrTooLarge},
+		{"negative declared value", func(a *Attributes) { a.DeclaredValue = -1 }, ErrInvalidDeclaredValue},
+		{"declared value too high", func(a *Attributes) { a.DeclaredValue = MaxDeclaredValue + 1 },
+			ErrInvalidDeclaredValue},
+		{"insured without value", func(a *Attributes) { a.Insured, a.DeclaredValue = true, 0 }, ErrUninsurable},
+		{"no contents", func(a *Attributes) { a.Contents = " \t" }, ErrContentsEmpty},
+		{"contents too long", func(a *Attributes) { a.Contents = strings.Repeat("a", MaxContentsLength+1) },
+			ErrContentsTooLong},
+		{"unknown service level", func(a *Attributes) { a.ServiceLevel = Express + 1 }, ErrUnknownServiceLevel},
+		{"unknown hazard", func(a *Attributes) { a.Hazards = allHazards + 1 }, ErrUnknownHazard},
+		{"explosives", func(a *Attributes) { a.Hazards = Explosive }, ErrExplosivesRefused},
+		{"hazardous express", func(a *Attributes) { a.Hazards, a.ServiceLevel = Toxic, Express },
+			ErrHazardousExpress},
+	}
+	for _, tt := range tests {
+		a := valid
+		tt.modify(&a)
+		err := a.Validate()
+		if err != tt.want {
+			t.Errorf("%s: Validate() = %v, want %v", tt.name, err, tt.want)
+		}
+	}
+}
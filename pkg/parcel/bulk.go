@@ -0,0 +1,588 @@
+package parcel
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// MaxImportRows is the maximum number of parcels imported at once and
+// ImportBatchSize the number of parcels stored in a single transaction.
+const (
+	MaxImportRows   = 1000
+	ImportBatchSize = 100
+)
+
+var (
+	ErrUnknownImportFormat = errors.New("parcel: unknown import format, use csv or jsonl")
+	ErrImportEmpty         = errors.New("parcel: the import does not contain any parcels")
+	ErrTooManyImportRows   = errors.New("parcel: at most 1000 parcels can be imported at once")
+	ErrBatchFailed         = errors.New("parcel: the batch containing the parcel could not be stored")
+)
+
+// ImportFormat is the file format of bulk imports and their results.
+type ImportFormat int
+
+const (
+	// CSV files start with a header naming the columns listed by
+	// ImportColumns. Columns may be omitted and appear in any order.
+	CSV ImportFormat = iota
+	// JSONLines files contain a JSON object per line, whose keys are the
+	// JSON names of the columns listed by ImportColumns. Customs items are
+	// given as the array customsItems instead of the customs* columns.
+	JSONLines
+)
+
+// ParseImportFormat parses the name of an import format, which is either
+// csv or jsonl. If name is empty, the format is derived from the extension
+// of filename, defaulting to CSV.
+func ParseImportFormat(name, filename string) (ImportFormat, error) {
+	if name == "" {
+		name = strings.TrimPrefix(path.Ext(filename), ".")
+	}
+	switch strings.ToLower(name) {
+	case "", "csv":
+		return CSV, nil
+	case "jsonl", "ndjson":
+		return JSONLines, nil
+	default:
+		return 0, ErrUnknownImportFormat
+	}
+}
+
+// ContentType returns the MIME type of files in format f.
+func (f ImportFormat) ContentType() string {
+	if f == JSONLines {
+		return "application/x-ndjson"
+	}
+
+	return "text/csv"
+}
+
+// Extension returns the usual file name extension of files in format f.
+func (f ImportFormat) Extension() string {
+	if f == JSONLines {
+		return "jsonl"
+	}
+
+	return "csv"
+}
+
+// importRecord is a single parcel of a bulk import.
+type importRecord struct {
+	// Reference is the sender's own reference of the parcel, which is
+	// repeated in the results.
+	Reference     string   `json:"reference"`
+	ReturnAddress string   `json:"returnAddress"`
+	Street        string   `json:"street"`
+	Zip           string   `json:"zip"`
+	City          string   `json:"city"`
+	Country       string   `json:"country"`
+	Planet        string   `json:"planet"`
+	Weight        float64  `json:"weight"`
+	Length        float64  `json:"length"`
+	Width         float64  `json:"width"`
+	Height        float64  `json:"height"`
+	DeclaredValue float64  `json:"declaredValue"`
+	Contents      string   `json:"contents"`
+	ServiceLevel  string   `json:"serviceLevel"`
+	Hazards       []string `json:"hazards"`
+	Insured       bool     `json:"insured"`
+
+	CustomsCategory string            `json:"customsCategory"`
+	CustomsItems    []customsItemForm `json:"customsItems"`
+}
+
+// importColumn is a column of CSV imports, which sets a field of the
+// record.
+type importColumn struct {
+	Name string
+	set  func(r *importRecord, v string) error
+}
+
+// customsItem returns the only customs item of CSV records, which is
+// created on first use.
+func (r *importRecord) customsItem() *customsItemForm {
+	if len(r.CustomsItems) == 0 {
+		r.CustomsItems = []customsItemForm{{}}
+	}
+
+	return &r.CustomsItems[0]
+}
+
+func setFloat(f *float64, v string) error {
+	if v == "" {
+		return nil
+	}
+	var err error
+	*f, err = strconv.ParseFloat(v, 64)
+
+	return err
+}
+
+// importColumns lists the columns of CSV imports. CSV records may declare a
+// single customs item.
+var importColumns = []importColumn{
+	{"reference", func(r *importRecord, v string) error { r.Reference = v; return nil }},
+	{"return_address", func(r *importRecord, v string) error { r.ReturnAddress = v; return nil }},
+	{"street", func(r *importRecord, v string) error { r.Street = v; return nil }},
+	{"zip", func(r *importRecord, v string) error { r.Zip = v; return nil }},
+	{"city", func(r *importRecord, v string) error { r.City = v; return nil }},
+	{"country", func(r *importRecord, v string) error { r.Country = v; return nil }},
+	{"planet", func(r *importRecord, v string) error { r.Planet = v; return nil }},
+	{"weight", func(r *importRecord, v string) error { return setFloat(&r.Weight, v) }},
+	{"length", func(r *importRecord, v string) error { return setFloat(&r.Length, v) }},
+	{"width", func(r *importRecord, v string) error { return setFloat(&r.Width, v) }},
+	{"height", func(r *importRecord, v string) error { return setFloat(&r.Height, v) }},
+	{"declared_value", func(r *importRecord, v string) error { return setFloat(&r.DeclaredValue, v) }},
+	{"contents", func(r *importRecord, v string) error { r.Contents = v; return nil }},
+	{"service_level", func(r *importRecord, v string) error { r.ServiceLevel = v; return nil }},
+	// Hazards are separated by semicolons.
+	{"hazards", func(r *importRecord, v string) error {
+		for _, h := range strings.Split(v, ";") {
+			if h = strings.TrimSpace(h); h != "" {
+				r.Hazards = append(r.Hazards, h)
+			}
+		}
+		return nil
+	}},
+	{"insured", func(r *importRecord, v string) error {
+		if v == "" {
+			return nil
+		}
+		var err error
+		r.Insured, err = strconv.ParseBool(v)
+		return err
+	}},
+	{"customs_category", func(r *importRecord, v string) error { r.CustomsCategory = v; return nil }},
+	{"customs_description", func(r *importRecord, v string) error {
+		if v != "" {
+			r.customsItem().Description = v
+		}
+		return nil
+	}},
+	{"customs_hs_code", func(r *importRecord, v string) error {
+		if v != "" {
+			r.customsItem().HSCode = v
+		}
+		return nil
+	}},
+	{"customs_quantity", func(r *importRecord, v string) error {
+		if v == "" {
+			return nil
+		}
+		var err error
+		r.customsItem().Quantity, err = strconv.Atoi(v)
+		return err
+	}},
+	{"customs_weight", func(r *importRecord, v string) error {
+		if v == "" {
+			return nil
+		}
+		return setFloat(&r.customsItem().Weight, v)
+	}},
+	{"customs_value", func(r *importRecord, v string) error {
+		if v == "" {
+			return nil
+		}
+		return setFloat(&r.customsItem().Value, v)
+	}},
+	{"customs_origin", func(r *importRecord, v string) error {
+		if v != "" {
+			r.customsItem().Origin = v
+		}
+		return nil
+	}},
+}
+
+// ImportColumns returns the names of the columns of CSV imports.
+func ImportColumns() []string {
+	nn := make([]string, len(importColumns))
+	for i, c := range importColumns {
+		nn[i] = c.Name
+	}
+
+	return nn
+}
+
+// ImportResult is the result of importing a single parcel.
+type ImportResult struct {
+	// Line is the record's line in the imported file.
+	Line      int    `json:"line"`
+	Reference string `json:"reference,omitempty"`
+	// TrackingID is the ID of the new parcel or nil, if the parcel has not
+	// been imported.
+	TrackingID *uuid.UUID `json:"trackingId,omitempty"`
+	Error      string     `json:"error,omitempty"`
+}
+
+// ImportRequest is a business customer's request to send many parcels at
+// once.
+type ImportRequest struct {
+	Sender *user.User
+	Format ImportFormat
+	// ReturnAddressID identifies one of the sender's addresses, which is
+	// used for all parcels without a return address. It may be uuid.Nil.
+	ReturnAddressID uuid.UUID
+	records         []*importRecord
+	lines           []int
+}
+
+// MaxImportSize is the maximum size of imported files in bytes.
+const MaxImportSize = 4 << 20
+
+var (
+	ErrImportFileMissing = errors.New("parcel: the file to import is missing")
+	ErrImportTooLarge    = errors.New("parcel: the file to import must not be larger than 4 MiB")
+)
+
+// ParseImportForm parses the multipart form of r, which contains the
+// uploaded file, the optional format and the optional default
+// return-address, into an import request of the sender u.
+func ParseImportForm(r *http.Request, u *user.User) (*ImportRequest, error) {
+	err := r.ParseMultipartForm(MaxImportSize)
+	if err != nil {
+		return nil, err
+	}
+	file, fh, err := r.FormFile("file")
+	if err == http.ErrMissingFile {
+		return nil, ErrImportFileMissing
+	} else if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	if fh.Size > MaxImportSize {
+		return nil, ErrImportTooLarge
+	}
+	f, err := ParseImportFormat(r.PostFormValue("format"), fh.Filename)
+	if err != nil {
+		return nil, err
+	}
+	var ret uuid.UUID
+	if id := r.PostFormValue("return-address"); id != "" {
+		ret, err = uuid.Parse(id)
+		if err != nil {
+			return nil, ErrInvalidAddressID
+		}
+	}
+
+	return ParseImport(file, f, u, ret)
+}
+
+// ParseImport reads the parcels to import from r, which is formatted in
+// format f, on behalf of the sender u. It returns an error, if the file is
+// malformed as a whole. Errors of single parcels are reported by Import.
+func ParseImport(r io.Reader, f ImportFormat, u *user.User, ret uuid.UUID) (*ImportRequest, error) {
+	req := &ImportRequest{Sender: u, Format: f, ReturnAddressID: ret}
+	var err error
+	if f == JSONLines {
+		err = req.parseJSONLines(r)
+	} else {
+		err = req.parseCSV(r)
+	}
+	if err != nil {
+		return nil, err
+	} else if len(req.records) == 0 {
+		return nil, ErrImportEmpty
+	}
+
+	return req, nil
+}
+
+func (req *ImportRequest) add(rec *importRecord, line int) error {
+	if len(req.records) == MaxImportRows {
+		return ErrTooManyImportRows
+	}
+	req.records = append(req.records, rec)
+	req.lines = append(req.lines, line)
+
+	return nil
+}
+
+func (req *ImportRequest) parseCSV(r io.Reader) error {
+	cr := csv.NewReader(r)
+	cr.TrimLeadingSpace = true
+	header, err := cr.Read()
+	if err == io.EOF {
+		return ErrImportEmpty
+	} else if err != nil {
+		return fmt.Errorf("parcel: invalid CSV: %v", err)
+	}
+	cols := make([]*importColumn, len(header))
+	for i, h := range header {
+		h = strings.ToLower(strings.TrimSpace(h))
+		for j := range importColumns {
+			if importColumns[j].Name == h {
+				cols[i] = &importColumns[j]
+			}
+		}
+		if cols[i] == nil {
+			return fmt.Errorf("parcel: unknown column %q", h)
+		}
+	}
+
+	for line := 2; ; line++ {
+		row, err := cr.Read()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("parcel: invalid CSV: %v", err)
+		}
+		rec := &importRecord{}
+		for i, v := range row {
+			err = cols[i].set(rec, strings.TrimSpace(v))
+			if err != nil {
+				return fmt.Errorf("parcel: invalid %s in line %d", cols[i].Name, line)
+			}
+		}
+		err = req.add(rec, line)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+func (req *ImportRequest) parseJSONLines(r io.Reader) error {
+	d := json.NewDecoder(r)
+	d.DisallowUnknownFields()
+	for line := 1; ; line++ {
+		rec := &importRecord{}
+		err := d.Decode(rec)
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return fmt.Errorf("parcel: invalid JSON in record %d: %v", line, err)
+		}
+		err = req.add(rec, line)
+		if err != nil {
+			return err
+		}
+	}
+}
+
+// sendRequest converts rec into a request to send a parcel to a new
+// destination address.
+func (req *ImportRequest) sendRequest(rec *importRecord) (*SendRequest, error) {
+	f := &sendForm{
+		Weight:          rec.Weight,
+		Length:          rec.Length,
+		Width:           rec.Width,
+		Height:          rec.Height,
+		DeclaredValue:   rec.DeclaredValue,
+		Contents:        rec.Contents,
+		ServiceLevel:    rec.ServiceLevel,
+		Hazards:         rec.Hazards,
+		Insured:         rec.Insured,
+		CustomsCategory: rec.CustomsCategory,
+		CustomsItems:    rec.CustomsItems,
+	}
+	sr := &SendRequest{Sender: req.Sender, ReturnAddressID: req.ReturnAddressID}
+	if rec.ReturnAddress != "" {
+		id, err := uuid.Parse(rec.ReturnAddress)
+		if err != nil {
+			return nil, ErrInvalidAddressID
+		}
+		sr.ReturnAddressID = id
+	}
+	var err error
+	sr.Attributes, err = f.attributes()
+	if err != nil {
+		return nil, err
+	}
+	sr.Customs, err = f.customs()
+	if err != nil {
+		return nil, err
+	}
+	sr.NewDestination, err = address.NewForUser(req.Sender)
+	if err != nil {
+		return nil, err
+	}
+	sr.NewDestination.Street = strings.TrimSpace(rec.Street)
+	sr.NewDestination.Zip = strings.TrimSpace(rec.Zip)
+	sr.NewDestination.City = strings.TrimSpace(rec.City)
+	sr.NewDestination.Country = strings.TrimSpace(rec.Country)
+	sr.NewDestination.Planet = strings.TrimSpace(rec.Planet)
+
+	return sr, nil
+}
+
+// Importer is the interface wrapping the Import method.
+//
+// Import stores the new addresses aa and the parcels pp together with
+// their initial events ee. Addresses, which equal an address the user has
+// already added, are not inserted again, but are assigned the existing
+// address's ID instead. Either all or none of them are stored.
+type Importer interface {
+	Import(aa []*address.Address, pp []*Parcel, ee []*Event) error
+}
+
+// importBatch collects the parcels stored in a single transaction.
+type importBatch struct {
+	addresses []*address.Address
+	parcels   []*Parcel
+	events    []*Event
+	results   []*ImportResult
+}
+
+// Import creates the parcels requested by req, which are sent without
+// quotes, and stores them in batches of ImportBatchSize parcels in s.
+// Destination addresses are added to the sender's addresses, unless the
+// sender has already added an address with the same street, zip, city,
+// country and planet. A result is returned for every parcel, which contains
+// either its tracking ID or the reason, why it has not been imported.
+func Import(req *ImportRequest, as address.Accesser, s Importer) ([]*ImportResult, error) {
+	known, err := as.ByUser(req.Sender)
+	if err != nil {
+		return nil, err
+	}
+
+	rr := make([]*ImportResult, 0, len(req.records))
+	b := &importBatch{}
+	for i, rec := range req.records {
+		res := &ImportResult{Line: req.lines[i], Reference: rec.Reference}
+		rr = append(rr, res)
+		p, err := req.newParcel(rec, known, b)
+		if err != nil {
+			if !IsInvalid(err) && err != ErrInvalidAddressID && err != ErrReturnAddressEmpty {
+				return nil, err
+			}
+			res.Error = err.Error()
+			continue
+		}
+		e, err := NewEvent(p, DataReceived)
+		if err != nil {
+			return nil, err
+		}
+		b.parcels = append(b.parcels, p)
+		b.events = append(b.events, e)
+		b.results = append(b.results, res)
+		if len(b.parcels) == ImportBatchSize {
+			known = b.store(s, known)
+			b = &importBatch{}
+		}
+	}
+	b.store(s, known)
+
+	return rr, nil
+}
+
+// newParcel returns a new parcel as requested by rec. Its destination
+// address is added to b, unless it equals one of the known addresses or
+// one already added to b.
+func (req *ImportRequest) newParcel(rec *importRecord, known []*address.Address, b *importBatch) (*Parcel, error) {
+	sr, err := req.sendRequest(rec)
+	if err != nil {
+		return nil, err
+	}
+	err = sr.Attributes.Validate()
+	if err != nil {
+		return nil, err
+	}
+	if sr.ReturnAddressID == uuid.Nil {
+		return nil, ErrReturnAddressEmpty
+	}
+	ret := findAddress(known, sr.ReturnAddressID)
+	if ret == nil {
+		return nil, ErrForeignAddress
+	}
+	dest := sr.NewDestination
+	if dest.Street == "" || dest.Zip == "" || dest.City == "" || dest.Country == "" {
+		return nil, ErrIncompleteAddress
+	}
+	isNew := false
+	if a := findEqualAddress(known, dest); a != nil {
+		dest = a
+	} else if a := findEqualAddress(b.addresses, dest); a != nil {
+		dest = a
+	} else {
+		isNew = true
+	}
+	p, err := sr.newParcel(ret, dest)
+	if err != nil {
+		return nil, err
+	}
+	if isNew {
+		b.addresses = append(b.addresses, dest)
+	}
+
+	return p, nil
+}
+
+// store stores b's parcels in s and returns known together with the
+// addresses added by b. If b cannot be stored, its parcels are marked as
+// failed and known is returned unchanged.
+func (b *importBatch) store(s Importer, known []*address.Address) []*address.Address {
+	if len(b.parcels) == 0 {
+		return known
+	}
+	err := s.Import(b.addresses, b.parcels, b.events)
+	if err != nil {
+		log.Println(err)
+		for _, res := range b.results {
+			res.Error = ErrBatchFailed.Error()
+		}
+		return known
+	}
+	for i, res := range b.results {
+		res.TrackingID = &b.parcels[i].ID
+	}
+
+	return append(known, b.addresses...)
+}
+
+// findEqualAddress returns the address of aa, whose street, zip, city,
+// country and planet equal those of a, or nil, if there is none. These
+// fields identify an address of a user.
+func findEqualAddress(aa []*address.Address, a *address.Address) *address.Address {
+	for _, b := range aa {
+		if b.SameAs(a) {
+			return b
+		}
+	}
+
+	return nil
+}
+
+// WriteImportResults writes rr to w in format f.
+func WriteImportResults(w io.Writer, f ImportFormat, rr []*ImportResult) error {
+	if f == JSONLines {
+		e := json.NewEncoder(w)
+		for _, r := range rr {
+			err := e.Encode(r)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	cw := csv.NewWriter(w)
+	err := cw.Write([]string{"line", "reference", "tracking_id", "error"})
+	if err != nil {
+		return err
+	}
+	for _, r := range rr {
+		id := ""
+		if r.TrackingID != nil {
+			id = r.TrackingID.String()
+		}
+		err = cw.Write([]string{strconv.Itoa(r.Line), r.Reference, id, r.Error})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+
+	return cw.Error()
+}
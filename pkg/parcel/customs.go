@@ -0,0 +1,210 @@
+package parcel
+
+import (
+	"errors"
+	"strings"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// maxCustomsItems is the maximum number of items of a customs declaration.
+const maxCustomsItems = 20
+
+var (
+	ErrCustomsRequired        = errors.New("parcel: a customs declaration is required for parcels sent to other planets")
+	ErrCustomsItemsEmpty      = errors.New("parcel: the customs declaration must contain at least one item")
+	ErrTooManyCustomsItems    = errors.New("parcel: the customs declaration must not contain more than 20 items")
+	ErrItemDescriptionEmpty   = errors.New("parcel: every customs item needs a description")
+	ErrInvalidHSCode          = errors.New("parcel: HS codes consist of 6, 8 or 10 digits")
+	ErrInvalidQuantity        = errors.New("parcel: the quantity of customs items must be positive")
+	ErrInvalidItemWeight      = errors.New("parcel: the weight of customs items must be positive")
+	ErrInvalidItemValue       = errors.New("parcel: the value of customs items must not be negative")
+	ErrOriginEmpty            = errors.New("parcel: the country of origin of every customs item is required")
+	ErrCustomsWeight          = errors.New("parcel: the customs items must not weigh more than the parcel")
+	ErrCustomsValue           = errors.New("parcel: the declared value must be the total value of the customs items")
+	ErrUnknownCustomsCategory = errors.New("parcel: unknown customs category")
+)
+
+// CustomsCategory is the category of the items declared to customs.
+type CustomsCategory int
+
+const (
+	SaleOfGoods CustomsCategory = iota
+	Gift
+	Documents
+	CommercialSample
+	ReturnedGoods
+	OtherCategory
+)
+
+var customsCategoryNames = map[CustomsCategory]string{
+	SaleOfGoods:      "SaleOfGoods",
+	Gift:             "Gift",
+	Documents:        "Documents",
+	CommercialSample: "CommercialSample",
+	ReturnedGoods:    "ReturnedGoods",
+	OtherCategory:    "Other",
+}
+
+func (c CustomsCategory) String() string {
+	n, ok := customsCategoryNames[c]
+	if !ok {
+		return "Unknown"
+	}
+
+	return n
+}
+
+func (c CustomsCategory) MarshalText() ([]byte, error) {
+	n, ok := customsCategoryNames[c]
+	if !ok {
+		return nil, ErrUnknownCustomsCategory
+	}
+
+	return []byte(n), nil
+}
+
+// UnmarshalText parses a customs category's name, ignoring case. The empty
+// text is parsed as SaleOfGoods.
+func (c *CustomsCategory) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*c = SaleOfGoods
+		return nil
+	}
+	for cc, n := range customsCategoryNames {
+		if strings.EqualFold(n, string(text)) {
+			*c = cc
+			return nil
+		}
+	}
+
+	return ErrUnknownCustomsCategory
+}
+
+// CustomsCategoryList returns the names of all customs categories.
+func CustomsCategoryList() []string {
+	nn := make([]string, 0, len(customsCategoryNames))
+	for c := SaleOfGoods; c <= OtherCategory; c++ {
+		nn = append(nn, customsCategoryNames[c])
+	}
+
+	return nn
+}
+
+// CustomsItem is a line item of a customs declaration.
+type CustomsItem struct {
+	Description string `json:"description"`
+	// HSCode is the item's code in the Harmonized System.
+	HSCode   string `json:"hsCode"`
+	Quantity int    `json:"quantity"`
+	// Weight is the net weight of all pieces in kilograms.
+	Weight float64 `json:"weight"`
+	// Value is the value of all pieces.
+	Value Cents `json:"value"`
+	// Origin is the country of origin.
+	Origin string `json:"origin"`
+}
+
+// CustomsDeclaration declares the contents of a parcel to customs.
+type CustomsDeclaration struct {
+	Category CustomsCategory `json:"category"`
+	Items    []CustomsItem   `json:"items"`
+}
+
+// Weight returns the net weight of all items in kilograms.
+func (d *CustomsDeclaration) Weight() float64 {
+	var w float64
+	for _, it := range d.Items {
+		w += it.Weight
+	}
+
+	return w
+}
+
+// Value returns the value of all items.
+func (d *CustomsDeclaration) Value() Cents {
+	var v Cents
+	for _, it := range d.Items {
+		v += it.Value
+	}
+
+	return v
+}
+
+// Validate returns an error, unless d is a valid declaration of the
+// contents of a parcel with the attributes a.
+func (d *CustomsDeclaration) Validate(a *Attributes) error {
+	if _, ok := customsCategoryNames[d.Category]; !ok {
+		return ErrUnknownCustomsCategory
+	} else if len(d.Items) == 0 {
+		return ErrCustomsItemsEmpty
+	} else if len(d.Items) > maxCustomsItems {
+		return ErrTooManyCustomsItems
+	}
+	for _, it := range d.Items {
+		err := it.validate()
+		if err != nil {
+			return err
+		}
+	}
+	if d.Weight() > a.Weight {
+		return ErrCustomsWeight
+	} else if d.Value() != a.DeclaredValue {
+		return ErrCustomsValue
+	}
+
+	return nil
+}
+
+func (it *CustomsItem) validate() error {
+	if strings.TrimSpace(it.Description) == "" {
+		return ErrItemDescriptionEmpty
+	} else if !validHSCode(it.HSCode) {
+		return ErrInvalidHSCode
+	} else if it.Quantity <= 0 {
+		return ErrInvalidQuantity
+	} else if it.Weight <= 0 {
+		return ErrInvalidItemWeight
+	} else if it.Value < 0 {
+		return ErrInvalidItemValue
+	} else if strings.TrimSpace(it.Origin) == "" {
+		return ErrOriginEmpty
+	}
+
+	return nil
+}
+
+// NormalizeHSCode removes the dots and spaces, with which HS codes are
+// commonly written, e.g. 8471.30, from c.
+func NormalizeHSCode(c string) string {
+	return strings.NewReplacer(".", "", " ", "").Replace(c)
+}
+
+// validHSCode reports whether c consists of the 6 internationally
+// standardized digits of an HS code, optionally followed by two or four
+// national digits.
+func validHSCode(c string) bool {
+	if len(c) != 6 && len(c) != 8 && len(c) != 10 {
+		return false
+	}
+	for _, r := range c {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// CustomsVisible reports whether u may view the customs declaration of p,
+// which is only shown to the parcel's sender and to operators.
+func CustomsVisible(u *user.User, p *Parcel, as address.Accesser) (bool, error) {
+	if u == nil {
+		return false, nil
+	} else if u.Operator {
+		return true, nil
+	}
+
+	return p.SentBy(u, as)
+}
@@ -0,0 +1,20 @@
+package parcel
+
+import "testing"
+
+func TestNormalizeHSCode(t *testing.T) {
+	for c, want := range map[string]string{
+		"847130":       "847130",
+		"8471.30":      "847130",
+		"8471 30 00":   "84713000",
+		" 0901.21.00 ": "09012100",
+		"":             "",
+	} {
+		got := NormalizeHSCode(c)
+		if got != want {
+			t.Errorf("NormalizeHSCode(%q) = %q, want %q", c, got, want)
+		} else if want != "" && !validHSCode(got) {
+			t.Errorf("NormalizeHSCode(%q) = %q is not a valid HS code", c, got)
+		}
+	}
+}
@@ -0,0 +1,361 @@
+package parcel
+
+import (
+	"errors"
+	"math"
+	"net/http"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/schema"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrForeignAddress     = errors.New("parcel: the address does not belong to the sender")
+	ErrIncompleteAddress  = errors.New("parcel: street, zip, city and country of the destination are required")
+	ErrInvalidAddressID   = errors.New("parcel: the address id is invalid")
+	ErrInvalidQuoteID     = errors.New("parcel: the quote id is invalid")
+	ErrInvalidPointID     = errors.New("parcel: the pickup point id is invalid")
+	ErrInvalidCardID      = errors.New("parcel: the credit card id is invalid")
+	ErrQuoteUsed          = errors.New("parcel: the quote has already been used for another parcel")
+	ErrReturnAddressEmpty = errors.New("parcel: a return address is required")
+	ErrUnknownRecipient   = errors.New("parcel: there is no user with the recipient's username")
+)
+
+var formDecoder = schema.NewDecoder()
+
+type sendForm struct {
+	ReturnAddress      string `schema:"return-address"`
+	DestinationAddress string `schema:"destination-address"`
+	Street             string `schema:"street"`
+	Zip                string `schema:"zip"`
+	City               string `schema:"city"`
+	Country            string `schema:"country"`
+	Planet             string `schema:"planet"`
+	Quote              string `schema:"quote"`
+	PickupPoint        string `schema:"pickup-point"`
+	Card               string `schema:"card"`
+	Recipient          string `schema:"recipient"`
+
+	Weight        float64  `schema:"weight"`
+	Length        float64  `schema:"length"`
+	Width         float64  `schema:"width"`
+	Height        float64  `schema:"height"`
+	DeclaredValue float64  `schema:"declared-value"`
+	Contents      string   `schema:"contents"`
+	ServiceLevel  string   `schema:"service-level"`
+	Hazards       []string `schema:"hazards"`
+	Insured       bool     `schema:"insured"`
+
+	CustomsCategory string            `schema:"customs-category"`
+	CustomsItems    []customsItemForm `schema:"customs-items"`
+}
+
+type customsItemForm struct {
+	Description string  `schema:"description" json:"description"`
+	HSCode      string  `schema:"hs-code" json:"hsCode"`
+	Quantity    int     `schema:"quantity" json:"quantity"`
+	Weight      float64 `schema:"weight" json:"weight"`
+	Value       float64 `schema:"value" json:"value"`
+	Origin      string  `schema:"origin" json:"origin"`
+}
+
+// SendRequest is a customer's request to send a new parcel.
+type SendRequest struct {
+	Sender *user.User
+	// ReturnAddressID identifies one of the sender's addresses.
+	ReturnAddressID uuid.UUID
+	// DestinationAddressID identifies one of the sender's addresses. If it
+	// is uuid.Nil, NewDestination is used as the destination instead.
+	DestinationAddressID uuid.UUID
+	NewDestination       *address.Address
+	// QuoteID identifies the quote accepted by the sender or is uuid.Nil,
+	// if the parcel is sent without a quote.
+	QuoteID uuid.UUID
+	// PickupPointID identifies the pickup point, at which the recipient
+	// collects the parcel, or is uuid.Nil. NewDestination must be set to
+	// the pickup point's address, before the parcel is sent.
+	PickupPointID uuid.UUID
+	// CardID identifies the sender's credit card, which is charged for the
+	// parcel, or is uuid.Nil, if the parcel is paid at the shop.
+	CardID uuid.UUID
+	// Recipient is the username of the user receiving the parcel or empty,
+	// if the recipient is not registered with us. Only this user may
+	// redirect the parcel or have it held.
+	Recipient string
+	Attributes
+	// Customs is the customs declaration of the parcel's contents or nil,
+	// if the parcel is sent without one.
+	Customs *CustomsDeclaration
+}
+
+// QuoteAccepter is the interface wrapping the Accept method.
+//
+// Accept returns an error, unless the quote identified by id may be used
+// by sender to send p.
+type QuoteAccepter interface {
+	Accept(id uuid.UUID, p *Parcel, sender *user.User) error
+}
+
+// ParseSendForm parses r's post form into a SendRequest for the sender u.
+// If the form does not select one of the sender's addresses as the
+// destination, the destination is read from the form's address fields.
+// If the form selects a pickup point, the destination is left empty.
+func ParseSendForm(r *http.Request, u *user.User) (*SendRequest, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	f := &sendForm{}
+	err = formDecoder.Decode(f, r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &SendRequest{Sender: u, Recipient: strings.TrimSpace(f.Recipient)}
+	if f.ReturnAddress == "" {
+		return nil, ErrReturnAddressEmpty
+	}
+	req.ReturnAddressID, err = uuid.Parse(f.ReturnAddress)
+	if err != nil {
+		return nil, ErrInvalidAddressID
+	}
+	req.Attributes, err = f.attributes()
+	if err != nil {
+		return nil, err
+	}
+	req.Customs, err = f.customs()
+	if err != nil {
+		return nil, err
+	}
+	if f.Quote != "" {
+		req.QuoteID, err = uuid.Parse(f.Quote)
+		if err != nil {
+			return nil, ErrInvalidQuoteID
+		}
+	}
+	if f.Card != "" {
+		req.CardID, err = uuid.Parse(f.Card)
+		if err != nil {
+			return nil, ErrInvalidCardID
+		}
+	}
+	if f.PickupPoint != "" {
+		req.PickupPointID, err = uuid.Parse(f.PickupPoint)
+		if err != nil {
+			return nil, ErrInvalidPointID
+		}
+
+		return req, nil
+	}
+	if f.DestinationAddress != "" {
+		req.DestinationAddressID, err = uuid.Parse(f.DestinationAddress)
+		if err != nil {
+			return nil, ErrInvalidAddressID
+		}
+
+		return req, nil
+	}
+
+	req.NewDestination, err = address.NewForUser(u)
+	if err != nil {
+		return nil, err
+	}
+	req.NewDestination.Street = f.Street
+	req.NewDestination.Zip = f.Zip
+	req.NewDestination.City = f.City
+	req.NewDestination.Country = f.Country
+	req.NewDestination.Planet = f.Planet
+
+	return req, nil
+}
+
+func (f *sendForm) attributes() (Attributes, error) {
+	a := Attributes{
+		Weight:        f.Weight,
+		Length:        f.Length,
+		Width:         f.Width,
+		Height:        f.Height,
+		DeclaredValue: Cents(math.Round(f.DeclaredValue * 100)),
+		Contents:      f.Contents,
+		Insured:       f.Insured,
+	}
+	err := a.ServiceLevel.UnmarshalText([]byte(f.ServiceLevel))
+	if err != nil {
+		return a, err
+	}
+	for _, n := range f.Hazards {
+		h, err := ParseHazard(n)
+		if err != nil {
+			return a, err
+		}
+		a.Hazards |= h
+	}
+
+	return a, nil
+}
+
+// customs returns the customs declaration of the form or nil, if the form
+// does not declare any items. Rows of the form without a description and
+// HS code are ignored.
+func (f *sendForm) customs() (*CustomsDeclaration, error) {
+	d := &CustomsDeclaration{}
+	for _, it := range f.CustomsItems {
+		if it.Description == "" && it.HSCode == "" {
+			continue
+		}
+		d.Items = append(d.Items, CustomsItem{
+			Description: it.Description,
+			HSCode:      NormalizeHSCode(it.HSCode),
+			Quantity:    it.Quantity,
+			Weight:      it.Weight,
+			Value:       Cents(math.Round(it.Value * 100)),
+			Origin:      it.Origin,
+		})
+	}
+	if len(d.Items) == 0 {
+		return nil, nil
+	}
+	err := d.Category.UnmarshalText([]byte(f.CustomsCategory))
+	if err != nil {
+		return nil, err
+	}
+
+	return d, nil
+}
+
+// Addresses resolves the return and destination addresses of req using s,
+// making sure that both of them belong to the sender. A new destination
+// address is replaced by the equal address, if the sender has already added
+// it before. Otherwise, it is only added to the sender's addresses by the
+// Creator storing the parcel, so that rejected parcels do not leave
+// addresses behind.
+func (req *SendRequest) Addresses(s address.Accesser) (ret, dest *address.Address, err error) {
+	aa, err := s.ByUser(req.Sender)
+	if err != nil {
+		return nil, nil, err
+	}
+	ret = findAddress(aa, req.ReturnAddressID)
+	if ret == nil {
+		return nil, nil, ErrForeignAddress
+	}
+	if req.DestinationAddressID != uuid.Nil {
+		dest = findAddress(aa, req.DestinationAddressID)
+		if dest == nil {
+			return nil, nil, ErrForeignAddress
+		}
+
+		return ret, dest, nil
+	}
+
+	dest = req.NewDestination
+	if dest == nil || dest.Street == "" || dest.Zip == "" || dest.City == "" || dest.Country == "" {
+		return nil, nil, ErrIncompleteAddress
+	}
+	dest.User = req.Sender
+	if a := findEqualAddress(aa, dest); a != nil {
+		return ret, a, nil
+	}
+
+	return ret, dest, nil
+}
+
+func findAddress(aa []*address.Address, id uuid.UUID) *address.Address {
+	for _, a := range aa {
+		if a.ID == id {
+			return a
+		}
+	}
+
+	return nil
+}
+
+// Send creates a new parcel as requested by req and stores it in s,
+// together with its initial DataReceived event. The parcel's attributes
+// must be valid and parcels sent to other planets must be declared to
+// customs. The recipient named by req, if any, is looked up in us and the
+// quote referenced by req, if any, must be accepted by qa.
+func Send(req *SendRequest, as address.Accesser, us user.Accesser, qa QuoteAccepter, s Creator) (*Parcel,
+	error) {
+	err := req.Attributes.Validate()
+	if err != nil {
+		return nil, err
+	}
+	ret, dest, err := req.Addresses(as)
+	if err != nil {
+		return nil, err
+	}
+	p, err := req.newParcel(ret, dest)
+	if err != nil {
+		return nil, err
+	}
+	if req.Recipient != "" {
+		r, err := us.ByUsername(req.Recipient)
+		if err == user.ErrUserNotExists {
+			return nil, ErrUnknownRecipient
+		} else if err != nil {
+			return nil, err
+		}
+		p.RecipientID = &r.ID
+	}
+	if req.QuoteID != uuid.Nil {
+		err = qa.Accept(req.QuoteID, p, req.Sender)
+		if err != nil {
+			return nil, err
+		}
+		p.QuoteID = &req.QuoteID
+	}
+	e, err := NewEvent(p, DataReceived)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Create(p, e)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
+
+// newParcel returns a new parcel sent from ret to dest with the attributes
+// and the customs declaration of req, which is validated.
+func (req *SendRequest) newParcel(ret, dest *address.Address) (*Parcel, error) {
+	p, err := New(ret, dest)
+	if err != nil {
+		return nil, err
+	}
+	p.Attributes = req.Attributes
+	p.Customs = req.Customs
+	if req.PickupPointID != uuid.Nil {
+		id := req.PickupPointID
+		p.PickupPointID = &id
+	}
+	if p.Customs != nil {
+		err = p.Customs.Validate(&p.Attributes)
+		if err != nil {
+			return nil, err
+		}
+	} else if p.Interplanetary() {
+		return nil, ErrCustomsRequired
+	}
+
+	return p, nil
+}
+
+// IsInvalid reports whether err has been returned by Send, because the
+// request is invalid.
+func IsInvalid(err error) bool {
+	switch err {
+	case ErrForeignAddress, ErrIncompleteAddress, ErrQuoteUsed, ErrUnknownServiceLevel,
+		ErrInvalidWeight, ErrTooHeavy, ErrInvalidDimensions, ErrTooLarge, ErrInvalidDeclaredValue,
+		ErrContentsEmpty, ErrContentsTooLong, ErrUnknownHazard, ErrExplosivesRefused, ErrHazardousExpress,
+		ErrUninsurable, ErrCustomsRequired, ErrCustomsItemsEmpty, ErrTooManyCustomsItems, ErrItemDescriptionEmpty,
+		ErrInvalidHSCode, ErrInvalidQuantity, ErrInvalidItemWeight, ErrInvalidItemValue, ErrOriginEmpty,
+		ErrCustomsWeight, ErrCustomsValue, ErrUnknownCustomsCategory, ErrUnknownRecipient:
+		return true
+	default:
+		return false
+	}
+}
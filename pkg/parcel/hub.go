@@ -0,0 +1,178 @@
+package parcel
+
+import (
+	"log"
+	"sync"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+)
+
+// Publisher is the interface wrapping the Publish method.
+//
+// Publish notifies subscribers about the new event e.
+type Publisher interface {
+	Publish(e *Event) error
+}
+
+// Subscriber is the interface wrapping the Subscribe method.
+//
+// Subscribe returns a channel receiving the events of the parcel identified
+// by id, which are published after the call. The subscription is ended
+// by calling the returned cancel function.
+type Subscriber interface {
+	Subscribe(id uuid.UUID) (events <-chan *Event, cancel func())
+}
+
+// subscriptionBuffer is the number of events buffered per subscription.
+// Events are dropped for subscribers, whose buffer is full.
+const subscriptionBuffer = 16
+
+// Hub is an in-process implementation of the Publisher and Subscriber
+// interfaces.
+type Hub struct {
+	mu   sync.Mutex
+	subs map[uuid.UUID]map[chan *Event]struct{}
+}
+
+// NewHub returns a new Hub without any subscriptions.
+func NewHub() *Hub {
+	return &Hub{subs: make(map[uuid.UUID]map[chan *Event]struct{})}
+}
+
+func (h *Hub) Subscribe(id uuid.UUID) (<-chan *Event, func()) {
+	ch := make(chan *Event, subscriptionBuffer)
+	h.mu.Lock()
+	if h.subs[id] == nil {
+		h.subs[id] = make(map[chan *Event]struct{})
+	}
+	h.subs[id][ch] = struct{}{}
+	h.mu.Unlock()
+
+	var once sync.Once
+	cancel := func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+			delete(h.subs[id], ch)
+			if len(h.subs[id]) == 0 {
+				delete(h.subs, id)
+			}
+		})
+	}
+
+	return ch, cancel
+}
+
+func (h *Hub) Publish(e *Event) error {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	for ch := range h.subs[e.Parcel.ID] {
+		select {
+		case ch <- e:
+		default:
+			log.Printf("parcel: dropping event %s for slow subscriber\n", e.ID)
+		}
+	}
+
+	return nil
+}
+
+// NotifyingEventStorage is an EventStorage, which publishes every
+// event after inserting it into the underlying EventStorage.
+type NotifyingEventStorage struct {
+	EventStorage
+	Publisher Publisher
+}
+
+func (s *NotifyingEventStorage) Insert(e *Event, check func(ee []*Event) error) error {
+	err := s.EventStorage.Insert(e, check)
+	if err != nil {
+		return err
+	}
+	err = s.Publisher.Publish(e)
+	if err != nil {
+		// The event has been stored nevertheless.
+		log.Println(err)
+	}
+
+	return nil
+}
+
+// NotifyingStorage is a Storage, which publishes the initial event of
+// every parcel created or imported by the underlying Storage and the events
+// recording redirections and deliveries.
+type NotifyingStorage struct {
+	Storage
+	Publisher Publisher
+}
+
+func (s *NotifyingStorage) Create(p *Parcel, e *Event) error {
+	err := s.Storage.Create(p, e)
+	if err != nil {
+		return err
+	}
+	err = s.Publisher.Publish(e)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (s *NotifyingStorage) Import(aa []*address.Address, pp []*Parcel, ee []*Event) error {
+	err := s.Storage.Import(aa, pp, ee)
+	if err != nil {
+		return err
+	}
+	for _, e := range ee {
+		err = s.Publisher.Publish(e)
+		if err != nil {
+			log.Println(err)
+		}
+	}
+
+	return nil
+}
+
+func (s *NotifyingStorage) Redirect(p *Parcel, e *Event, check func(ee []*Event) error) error {
+	err := s.Storage.Redirect(p, e, check)
+	if err != nil {
+		return err
+	}
+	err = s.Publisher.Publish(e)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+func (s *NotifyingStorage) Deliver(e *Event, pr *Proof, check func(ee []*Event) error) error {
+	err := s.Storage.Deliver(e, pr, check)
+	if err != nil {
+		return err
+	}
+	err = s.Publisher.Publish(e)
+	if err != nil {
+		log.Println(err)
+	}
+
+	return nil
+}
+
+// Publishers is a Publisher, which publishes every event to all of its
+// elements.
+type Publishers []Publisher
+
+func (pp Publishers) Publish(e *Event) error {
+	var first error
+	for _, p := range pp {
+		err := p.Publish(e)
+		if err != nil && first == nil {
+			first = err
+		}
+	}
+
+	return first
+}
@@ -0,0 +1,71 @@
+package parcel
+
+import (
+	"sort"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// Summary is a parcel together with its latest tracking event.
+type Summary struct {
+	*Parcel
+	// Latest is the parcel's most recent event or nil, if the parcel has
+	// no events.
+	Latest *Event `json:"latest"`
+}
+
+// Incoming returns the summaries of all parcels sent to u, starting with
+// the most recently updated parcel. A parcel is sent to u, if its sender
+// has named u as the recipient.
+func Incoming(u *user.User, s Accesser, es EventAccesser) ([]*Summary, error) {
+	pp, err := s.ByRecipient(u)
+	if err != nil {
+		return nil, err
+	}
+
+	return summarize(pp, es)
+}
+
+// Outgoing returns the summaries of all parcels sent from any of u's
+// addresses, starting with the most recently updated parcel.
+func Outgoing(u *user.User, as address.Accesser, s Accesser, es EventAccesser) ([]*Summary, error) {
+	aa, err := as.ByUser(u)
+	if err != nil {
+		return nil, err
+	}
+	var pp []*Parcel
+	for _, a := range aa {
+		qq, err := s.ByReturnAddress(a)
+		if err != nil {
+			return nil, err
+		}
+		pp = append(pp, qq...)
+	}
+
+	return summarize(pp, es)
+}
+
+// summarize returns the summaries of the parcels pp.
+func summarize(pp []*Parcel, es EventAccesser) ([]*Summary, error) {
+	ss := make([]*Summary, 0, len(pp))
+	for _, p := range pp {
+		ee, err := es.ByParcel(p)
+		if err != nil {
+			return nil, err
+		}
+		s := &Summary{Parcel: p}
+		if len(ee) > 0 {
+			s.Latest = ee[len(ee)-1]
+		}
+		ss = append(ss, s)
+	}
+	sort.SliceStable(ss, func(i, j int) bool {
+		if ss[i].Latest == nil || ss[j].Latest == nil {
+			return ss[j].Latest == nil && ss[i].Latest != nil
+		}
+		return ss[i].Latest.Time.After(ss[j].Latest.Time)
+	})
+
+	return ss, nil
+}
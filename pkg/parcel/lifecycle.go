@@ -0,0 +1,297 @@
+package parcel
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+)
+
+var (
+	ErrInvalidTransition = errors.New("parcel: the event is not possible in the parcel's current state")
+	ErrEventTimeOrder    = errors.New("parcel: the event must not occur before the parcel's latest event")
+	ErrEventInFuture     = errors.New("parcel: the event must not occur in the future")
+	ErrPickupPointEvent  = errors.New("parcel: the event is recorded when the parcel is deposited at or collected from the pickup point")
+)
+
+// maxClockSkew is the time by which the clocks of scanners may be ahead of
+// the server's clock.
+const maxClockSkew = time.Minute
+
+// State is the delivery state of a parcel, which is derived from the
+// parcel's tracking events.
+type State struct {
+	Parcel *Parcel
+	// Last is the most recent event or nil, if there are no events.
+	Last *Event
+	// Planet is the planet on which the parcel is located. It is empty
+	// while the parcel is travelling in a rocket.
+	Planet string
+	// Returning is true, once the parcel's return to its sender has been
+	// initiated.
+	Returning bool
+	// FailedAttempts is the number of failed delivery attempts.
+	FailedAttempts int
+	// LastFailure is the latest DeliveryFailed event or nil, if there has
+	// been no failed delivery attempt.
+	LastFailure *Event
+	// DeliveryDate is the day chosen by the recipient for the next delivery
+	// attempt or the zero time, if the recipient has not chosen one.
+	DeliveryDate time.Time
+	// Held is true, if the recipient has asked us to hold the parcel at a
+	// logistics center for collection.
+	Held bool
+	// position is the type of the latest event, which changed the parcel's
+	// whereabouts.
+	position EventType
+}
+
+// NewState replays the events ee of p and returns p's resulting state.
+// The events must be ordered by their time.
+func NewState(p *Parcel, ee []*Event) *State {
+	s := &State{
+		Parcel: p,
+		Planet: planet(p.ReturnAddress),
+	}
+	for _, e := range ee {
+		s.Apply(e)
+	}
+
+	return s
+}
+
+// Apply updates s as if e was the parcel's latest event.
+func (s *State) Apply(e *Event) {
+	switch e.Type {
+	case LoadedIntoRocket:
+		s.Planet = ""
+	case DeliveredToProcessing:
+		if s.Last != nil && s.position == LoadedIntoRocket {
+			s.Planet = planet(s.Destination())
+		}
+	case DeliveryFailed:
+		s.FailedAttempts++
+		s.LastFailure = e
+	case DeliveryRescheduled:
+		if e.DeliveryDate != nil {
+			s.DeliveryDate = *e.DeliveryDate
+		}
+	case ReturnInitiated:
+		s.Returning = true
+	case HoldRequested:
+		s.Held = true
+	}
+	if e.Type.moves() {
+		s.position = e.Type
+	}
+	s.Last = e
+}
+
+// Position returns the type of the latest event, which changed the
+// parcel's whereabouts.
+func (s *State) Position() EventType {
+	return s.position
+}
+
+// moves reports whether events of type t change the parcel's whereabouts.
+// Requests to return, redirect, hold or reschedule the parcel do not move
+// it.
+func (t EventType) moves() bool {
+	switch t {
+	case ReturnInitiated, Redirected, HoldRequested, DeliveryRescheduled:
+		return false
+	default:
+		return true
+	}
+}
+
+// AtPickupPoint reports whether events of type t occur at a pickup point.
+// They are recorded together with the allocation or release of one of the
+// pickup point's compartments instead of on their own.
+func (t EventType) AtPickupPoint() bool {
+	return t == DeliveredToPickupPoint || t == CollectedFromPickupPoint
+}
+
+// Destination returns the address to which the parcel is currently being
+// delivered, which is its return address once it is being returned.
+func (s *State) Destination() *address.Address {
+	if s.Returning {
+		return s.Parcel.ReturnAddress
+	}
+
+	return s.Parcel.DestinationAddress
+}
+
+// Next returns the event types that may occur next in the parcel's
+// lifecycle. A parcel needs to be loaded into a rocket, unless it already
+// is on its destination's planet. Parcels sent to other planets may be
+// held at customs in logistics centers. Parcels, which the sender has
+// handed over to us, may be returned to the sender as long as they are
+// neither in a rocket nor delivered. The regular course of delivery is
+// listed first.
+func (s *State) Next() []EventType {
+	if s.Last == nil {
+		return []EventType{DataReceived}
+	}
+
+	nn := s.next()
+	if s.Returning {
+		for i, n := range nn {
+			if n == DeliveredToDestination {
+				nn[i] = ReturnedToSender
+			}
+		}
+	} else if s.Returnable() {
+		nn = append(nn, ReturnInitiated)
+	}
+
+	return nn
+}
+
+// Returnable reports whether the parcel's return to its sender may be
+// initiated.
+func (s *State) Returnable() bool {
+	if s.Last == nil || s.Returning {
+		return false
+	}
+	switch s.position {
+	case DataReceived, LoadedIntoRocket, DeliveredToDestination, ReturnedToSender, CollectedByRecipient,
+		CollectedFromPickupPoint:
+		return false
+	default:
+		return true
+	}
+}
+
+// Redirectable reports whether the recipient may still redirect the parcel
+// or have it held for collection, which is possible until the parcel is
+// loaded into a vehicle for its final delivery and again once its delivery
+// has failed.
+func (s *State) Redirectable() bool {
+	if s.Last == nil || s.Returning {
+		return false
+	}
+	switch s.position {
+	case DataReceived, DeliveredToIPPS, PickedUpByCourier, DeliveredToProcessing, LoadedIntoRocket,
+		HeldAtCustoms, ReleasedFromCustoms, DeliveryFailed:
+		return true
+	default:
+		return false
+	}
+}
+
+// next returns the event types, by which the parcel may move on from its
+// current position.
+func (s *State) next() []EventType {
+	switch s.position {
+	case DataReceived:
+		return []EventType{DeliveredToIPPS, PickedUpByCourier}
+	case DeliveredToIPPS, PickedUpByCourier:
+		return []EventType{DeliveredToProcessing}
+	case DeliveredToProcessing:
+		nn := s.dispatch()
+		if s.Parcel.Interplanetary() {
+			nn = append(nn, HeldAtCustoms)
+		}
+		return nn
+	case HeldAtCustoms:
+		return []EventType{ReleasedFromCustoms}
+	case ReleasedFromCustoms:
+		return s.dispatch()
+	case LoadedIntoRocket:
+		return []EventType{DeliveredToProcessing}
+	case LoadedIntoVehicle:
+		if s.Parcel.PickupPointID != nil && !s.Returning {
+			return []EventType{DeliveredToPickupPoint, DeliveryFailed}
+		}
+		return []EventType{DeliveredToDestination, DeliveryFailed}
+	case DeliveredToPickupPoint:
+		if s.Returning {
+			// The parcel is taken back from the pickup point.
+			return []EventType{DeliveredToProcessing}
+		}
+		return []EventType{CollectedFromPickupPoint}
+	case DeliveryFailed:
+		// The parcel is taken back to the logistics center.
+		return []EventType{DeliveredToProcessing}
+	default:
+		return nil
+	}
+}
+
+// dispatch returns the event types by which a parcel leaves a logistics
+// center. Parcels held for collection leave the logistics center on their
+// destination's planet with the recipient.
+func (s *State) dispatch() []EventType {
+	final := LoadedIntoVehicle
+	if s.Held && !s.Returning {
+		final = CollectedByRecipient
+	}
+	dest := planet(s.Destination())
+	if s.Planet == "" || dest == "" {
+		// We cannot tell, where the parcel has to go.
+		return []EventType{LoadedIntoRocket, final}
+	} else if strings.EqualFold(s.Planet, dest) {
+		return []EventType{final}
+	}
+
+	return []EventType{LoadedIntoRocket}
+}
+
+// Check returns an error if an event of type t may not occur next at
+// time at. The event may occur at the same time as the latest event, e.g.
+// when a return is initiated by a failed delivery attempt, since events
+// are stored in the order of their insertion.
+func (s *State) Check(t EventType, at time.Time) error {
+	if s.Last != nil && at.Before(s.Last.Time) {
+		return ErrEventTimeOrder
+	} else if at.After(time.Now().Add(maxClockSkew)) {
+		return ErrEventInFuture
+	}
+
+	for _, n := range s.Next() {
+		if n == t {
+			return nil
+		}
+	}
+
+	return ErrInvalidTransition
+}
+
+// planet returns a's planet or the empty string, if a is nil.
+func planet(a *address.Address) string {
+	if a == nil {
+		return ""
+	}
+
+	return strings.TrimSpace(a.Planet)
+}
+
+// Record stores a new event of type t for p in s, which occurred at time
+// at, or just now, if at is the zero time. It returns ErrInvalidTransition,
+// if the event is not possible in p's current state.
+func Record(s EventStorage, p *Parcel, t EventType, at time.Time) (*Event, error) {
+	e, err := NewEvent(p, t)
+	if err != nil {
+		return nil, err
+	}
+	err = record(s, e, at)
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// record checks whether e may occur next at time at, or just now, if at is
+// the zero time, and stores it in s.
+func record(s EventInserter, e *Event, at time.Time) error {
+	if !at.IsZero() {
+		e.Time = at.Local()
+	}
+
+	return s.Insert(e, func(ee []*Event) error {
+		return NewState(e.Parcel, ee).Check(e.Type, e.Time)
+	})
+}
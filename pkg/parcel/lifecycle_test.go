@@ -0,0 +1,135 @@
+package parcel
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+)
+
+// testParcel returns a parcel sent from Earth to the planet to.
+func testParcel(to string) *Parcel {
+	return &Parcel{
+		ID:                 uuid.New(),
+		ReturnAddress:      &address.Address{City: "Erlangen", Planet: "Earth"},
+		DestinationAddress: &address.Address{City: "Olympus", Planet: to},
+	}
+}
+
+// testEvents returns events of the types tt for p, which occurred one
+// minute after another and ended an hour ago.
+func testEvents(p *Parcel, tt ...EventType) []*Event {
+	at := time.Now().Add(-time.Hour - time.Duration(len(tt))*time.Minute)
+	ee := make([]*Event, 0, len(tt))
+	for _, t := range tt {
+		at = at.Add(time.Minute)
+		ee = append(ee, &Event{ID: uuid.New(), Parcel: p, Type: t, Time: at})
+	}
+
+	return ee
+}
+
+func TestStateNext(t *testing.T) {
+	point := uuid.New()
+	tests := []struct {
+		name   string
+		to     string
+		point  *uuid.UUID
+		events []EventType
+		want   []EventType
+	}{
+		{"new", "Earth", nil, nil, []EventType{DataReceived}},
+		{"data received", "Earth", nil, []EventType{DataReceived},
+			[]EventType{DeliveredToIPPS, PickedUpByCourier}},
+		{"handed over", "Earth", nil, []EventType{DataReceived, DeliveredToIPPS},
+			[]EventType{DeliveredToProcessing, ReturnInitiated}},
+		{"local processing", "Earth", nil, []EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing},
+			[]EventType{LoadedIntoVehicle, ReturnInitiated}},
+		{"interplanetary processing", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing},
+			[]EventType{LoadedIntoRocket, HeldAtCustoms, ReturnInitiated}},
+		{"in rocket", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoRocket},
+			[]EventType{DeliveredToProcessing}},
+		{"arrived on destination planet", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoRocket,
+				DeliveredToProcessing},
+			[]EventType{LoadedIntoVehicle, HeldAtCustoms, ReturnInitiated}},
+		{"held at customs", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, HeldAtCustoms},
+			[]EventType{ReleasedFromCustoms, ReturnInitiated}},
+		{"released from customs", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, HeldAtCustoms,
+				ReleasedFromCustoms},
+			[]EventType{LoadedIntoRocket, ReturnInitiated}},
+		{"in vehicle", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoVehicle},
+			[]EventType{DeliveredToDestination, DeliveryFailed, ReturnInitiated}},
+		{"in vehicle to pickup point", "Earth", &point,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoVehicle},
+			[]EventType{DeliveredToPickupPoint, DeliveryFailed, ReturnInitiated}},
+		{"at pickup point", "Earth", &point,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoVehicle,
+				DeliveredToPickupPoint},
+			[]EventType{CollectedFromPickupPoint, ReturnInitiated}},
+		{"delivery failed", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoVehicle, DeliveryFailed},
+			[]EventType{DeliveredToProcessing, ReturnInitiated}},
+		{"held for collection", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, HoldRequested, DeliveredToProcessing},
+			[]EventType{CollectedByRecipient, ReturnInitiated}},
+		{"returning", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, ReturnInitiated,
+				LoadedIntoVehicle},
+			[]EventType{ReturnedToSender, DeliveryFailed}},
+		{"returning from other planet", "Mars", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoRocket,
+				DeliveredToProcessing, ReturnInitiated},
+			[]EventType{LoadedIntoRocket, HeldAtCustoms}},
+		{"delivered", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, LoadedIntoVehicle,
+				DeliveredToDestination},
+			nil},
+		{"returned", "Earth", nil,
+			[]EventType{DataReceived, DeliveredToIPPS, DeliveredToProcessing, ReturnInitiated,
+				LoadedIntoVehicle, ReturnedToSender},
+			nil},
+	}
+	for _, tt := range tests {
+		p := testParcel(tt.to)
+		p.PickupPointID = tt.point
+		got := NewState(p, testEvents(p, tt.events...)).Next()
+		if len(got) != len(tt.want) || len(got) > 0 && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: Next() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestStateCheck(t *testing.T) {
+	p := testParcel("Earth")
+	ee := testEvents(p, DataReceived, DeliveredToIPPS)
+	last := ee[len(ee)-1].Time
+	tests := []struct {
+		name string
+		typ  EventType
+		at   time.Time
+		want error
+	}{
+		{"next event", DeliveredToProcessing, time.Now(), nil},
+		{"return", ReturnInitiated, time.Now(), nil},
+		{"same time as latest event", DeliveredToProcessing, last, nil},
+		{"within clock skew", DeliveredToProcessing, time.Now().Add(maxClockSkew / 2), nil},
+		{"skipped event", LoadedIntoVehicle, time.Now(), ErrInvalidTransition},
+		{"repeated event", DeliveredToIPPS, time.Now(), ErrInvalidTransition},
+		{"before latest event", DeliveredToProcessing, last.Add(-time.Second), ErrEventTimeOrder},
+		{"in future", DeliveredToProcessing, time.Now().Add(2 * maxClockSkew), ErrEventInFuture},
+	}
+	for _, tt := range tests {
+		err := NewState(p, ee).Check(tt.typ, tt.at)
+		if err != tt.want {
+			t.Errorf("%s: Check(%v) = %v, want %v", tt.name, tt.typ, err, tt.want)
+		}
+	}
+}
@@ -3,20 +3,143 @@
 package parcel
 
 import (
+	"errors"
+	"strings"
 	"time"
 
 	"github.com/google/uuid"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 )
 
 // Parcel is the data type representing a single parcel.
 type Parcel struct {
 	// ID is the parcel's unique identifier. This is also its tracking id.
-	ID                 uuid.UUID
-	ReturnAddress      *address.Address
-	DestinationAddress *address.Address
+	ID                 uuid.UUID        `json:"id"`
+	ReturnAddress      *address.Address `json:"returnAddress"`
+	DestinationAddress *address.Address `json:"destinationAddress"`
+	// QuoteID identifies the quote the parcel has been sent with or is nil,
+	// if the parcel has been sent without a quote.
+	QuoteID *uuid.UUID `json:"quoteId,omitempty"`
+	Attributes
+	// Customs is the customs declaration of the parcel's contents or nil,
+	// if the parcel is not declared to customs.
+	Customs *CustomsDeclaration `json:"customs,omitempty"`
+	// PickupPointID identifies the shop or parcel locker, at which the
+	// recipient collects the parcel, or is nil, if the parcel is delivered
+	// to its destination address.
+	PickupPointID *uuid.UUID `json:"pickupPointId,omitempty"`
+	// RecipientID identifies the user named as the recipient by the sender
+	// or is nil, if the recipient is not registered with us. It is not
+	// published, since the tracking information is public.
+	RecipientID *uuid.UUID `json:"-"`
 }
 
+// New returns a new parcel that is sent from ret to dest, using a
+// randomly generated UUID as its tracking id.
+func New(ret, dest *address.Address) (*Parcel, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Parcel{
+		ID:                 id,
+		ReturnAddress:      ret,
+		DestinationAddress: dest,
+	}, nil
+}
+
+// Interplanetary reports whether p is sent to another planet.
+func (p *Parcel) Interplanetary() bool {
+	return !strings.EqualFold(planet(p.ReturnAddress), planet(p.DestinationAddress))
+}
+
+// SentBy reports whether u has sent p, that is whether p's return address
+// is one of u's addresses.
+func (p *Parcel) SentBy(u *user.User, s address.Accesser) (bool, error) {
+	if p.ReturnAddress == nil {
+		return false, nil
+	}
+	aa, err := s.ByUser(u)
+	if err != nil {
+		return false, err
+	}
+
+	return findAddress(aa, p.ReturnAddress.ID) != nil, nil
+}
+
+// SentTo reports whether p is sent to u, that is whether the sender has
+// named u as p's recipient. The destination address is not compared, since
+// anyone may add an address with the same fields.
+func (p *Parcel) SentTo(u *user.User) bool {
+	return p.RecipientID != nil && *p.RecipientID == u.ID
+}
+
+// Involves reports whether u has sent p or p is sent to u.
+func (p *Parcel) Involves(u *user.User, s address.Accesser) (bool, error) {
+	ok, err := p.SentBy(u, s)
+	if err != nil || ok {
+		return ok, err
+	}
+
+	return p.SentTo(u), nil
+}
+
+var ErrUnknownServiceLevel = errors.New("parcel: unknown service level")
+
+// ServiceLevel determines how fast a parcel is delivered.
+type ServiceLevel int
+
+const (
+	Standard ServiceLevel = iota
+	Express
+)
+
+var serviceLevelNames = map[ServiceLevel]string{
+	Standard: "Standard",
+	Express:  "Express",
+}
+
+func (l ServiceLevel) String() string {
+	n, ok := serviceLevelNames[l]
+	if !ok {
+		return "Unknown"
+	}
+
+	return n
+}
+
+func (l ServiceLevel) MarshalText() ([]byte, error) {
+	n, ok := serviceLevelNames[l]
+	if !ok {
+		return nil, ErrUnknownServiceLevel
+	}
+
+	return []byte(n), nil
+}
+
+// UnmarshalText parses a service level's name, ignoring case. The empty
+// text is parsed as the Standard service level.
+func (l *ServiceLevel) UnmarshalText(text []byte) error {
+	if len(text) == 0 {
+		*l = Standard
+		return nil
+	}
+	for sl, n := range serviceLevelNames {
+		if strings.EqualFold(n, string(text)) {
+			*l = sl
+			return nil
+		}
+	}
+
+	return ErrUnknownServiceLevel
+}
+
+var ErrUnknownEventType = errors.New("parcel: unknown event type")
+
+// EventType is the type of a parcel's tracking event. The event types are
+// declared in the order in which they occur during a parcel's delivery.
 type EventType int
 
 const (
@@ -26,6 +149,18 @@ const (
 	LoadedIntoRocket
 	LoadedIntoVehicle
 	DeliveredToDestination
+	HeldAtCustoms
+	ReleasedFromCustoms
+	DeliveryFailed
+	ReturnInitiated
+	ReturnedToSender
+	Redirected
+	HoldRequested
+	CollectedByRecipient
+	PickedUpByCourier
+	DeliveredToPickupPoint
+	CollectedFromPickupPoint
+	DeliveryRescheduled
 )
 
 func (t EventType) String() string {
@@ -42,15 +177,122 @@ func (t EventType) String() string {
 		return "The parcel has been loaded into a vehicle and is going to be delivered to its final destination"
 	case DeliveredToDestination:
 		return "The package has been delivered to its destination"
+	case HeldAtCustoms:
+		return "The parcel is being held at customs"
+	case ReleasedFromCustoms:
+		return "The parcel has been released from customs"
+	case DeliveryFailed:
+		return "The parcel could not be delivered"
+	case ReturnInitiated:
+		return "The parcel is being returned to the sender"
+	case ReturnedToSender:
+		return "The parcel has been returned to the sender"
+	case Redirected:
+		return "The recipient has redirected the parcel to another address or pickup point"
+	case HoldRequested:
+		return "The recipient has asked us to hold the parcel at a logistics center for collection"
+	case CollectedByRecipient:
+		return "The recipient has collected the parcel at our logistics center"
+	case PickedUpByCourier:
+		return "One of our couriers has picked up the parcel from the sender"
+	case DeliveredToPickupPoint:
+		return "The parcel is ready for collection at the pickup point"
+	case CollectedFromPickupPoint:
+		return "The recipient has collected the parcel at the pickup point"
+	case DeliveryRescheduled:
+		return "The recipient has chosen a new delivery date"
 	default:
 		return "Unknown event"
 	}
 }
 
+var eventTypeNames = map[EventType]string{
+	DataReceived:             "DataReceived",
+	DeliveredToIPPS:          "DeliveredToIPPS",
+	DeliveredToProcessing:    "DeliveredToProcessing",
+	LoadedIntoRocket:         "LoadedIntoRocket",
+	LoadedIntoVehicle:        "LoadedIntoVehicle",
+	DeliveredToDestination:   "DeliveredToDestination",
+	HeldAtCustoms:            "HeldAtCustoms",
+	ReleasedFromCustoms:      "ReleasedFromCustoms",
+	DeliveryFailed:           "DeliveryFailed",
+	ReturnInitiated:          "ReturnInitiated",
+	ReturnedToSender:         "ReturnedToSender",
+	Redirected:               "Redirected",
+	HoldRequested:            "HoldRequested",
+	CollectedByRecipient:     "CollectedByRecipient",
+	PickedUpByCourier:        "PickedUpByCourier",
+	DeliveredToPickupPoint:   "DeliveredToPickupPoint",
+	CollectedFromPickupPoint: "CollectedFromPickupPoint",
+	DeliveryRescheduled:      "DeliveryRescheduled",
+}
+
+// EventTypes returns all event types in the order of their declaration.
+func EventTypes() []EventType {
+	tt := make([]EventType, len(eventTypeNames))
+	for i := range tt {
+		tt[i] = EventType(i)
+	}
+
+	return tt
+}
+
+// Name returns the name of t's constant, which is used to identify
+// event types in the APIs.
+func (t EventType) Name() string {
+	n, ok := eventTypeNames[t]
+	if !ok {
+		return "Unknown"
+	}
+
+	return n
+}
+
+func (t EventType) MarshalText() ([]byte, error) {
+	n, ok := eventTypeNames[t]
+	if !ok {
+		return nil, ErrUnknownEventType
+	}
+
+	return []byte(n), nil
+}
+
+func (t *EventType) UnmarshalText(text []byte) error {
+	for et, n := range eventTypeNames {
+		if n == string(text) {
+			*t = et
+			return nil
+		}
+	}
+
+	return ErrUnknownEventType
+}
+
 // Event is the type representing tracking events for parcels.
 type Event struct {
-	ID     uuid.UUID
-	Parcel *Parcel
-	Type   EventType
-	Time   time.Time
+	ID     uuid.UUID `json:"id"`
+	Parcel *Parcel   `json:"-"`
+	Type   EventType `json:"type"`
+	Time   time.Time `json:"time"`
+	// Reason is the reason of a failed delivery attempt. It is only set for
+	// DeliveryFailed events.
+	Reason FailureReason `json:"reason,omitempty"`
+	// DeliveryDate is the day chosen by the recipient for the next delivery
+	// attempt. It is only set for DeliveryRescheduled events.
+	DeliveryDate *time.Time `json:"deliveryDate,omitempty"`
+}
+
+// NewEvent returns a new event of type t for p, which occurred just now.
+func NewEvent(p *Parcel, t EventType) (*Event, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Event{
+		ID:     id,
+		Parcel: p,
+		Type:   t,
+		Time:   time.Now().Local(),
+	}, nil
 }
@@ -0,0 +1,263 @@
+package parcel
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"math"
+	"mime/multipart"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrRecipientNameEmpty = errors.New("parcel: the name of the person accepting the parcel is required")
+	ErrNoEvidence         = errors.New("parcel: a signature or a photo is required as proof of delivery")
+	ErrInvalidImage       = errors.New("parcel: signatures and photos must be PNG or JPEG images")
+	ErrImageTooLarge      = errors.New("parcel: signatures and photos must not be larger than 2 MiB")
+	ErrInvalidLocation    = errors.New("parcel: the location of the delivery is invalid")
+	ErrNotOutForDelivery  = errors.New("parcel: the parcel cannot be delivered in its current state")
+)
+
+// MaxProofImageSize is the maximum size of signatures and photos in bytes.
+const MaxProofImageSize = 2 << 20
+
+// Location is a geographic location on the parcel's destination planet.
+type Location struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+// Proof is the proof of delivery attached to the event completing a
+// parcel's delivery.
+type Proof struct {
+	Event         *Event `json:"-"`
+	RecipientName string `json:"recipientName"`
+	// Signature and Photo are the keys of the images in the blob store or
+	// empty, if the image has not been captured.
+	Signature string `json:"-"`
+	Photo     string `json:"-"`
+	// Location is where the parcel has been handed over or nil, if it is
+	// unknown.
+	Location *Location `json:"location,omitempty"`
+}
+
+// DeliveryRequest is the evidence captured by the courier when handing a
+// parcel over.
+type DeliveryRequest struct {
+	RecipientName string
+	Signature     []byte
+	Photo         []byte
+	Location      *Location
+	// Time is the time of delivery, which defaults to the time at which the
+	// request is handled, if it is zero.
+	Time time.Time
+}
+
+// Validate returns an error, unless r contains the name of the recipient
+// and at least one PNG or JPEG image.
+func (r *DeliveryRequest) Validate() error {
+	if strings.TrimSpace(r.RecipientName) == "" {
+		return ErrRecipientNameEmpty
+	} else if len(r.Signature) == 0 && len(r.Photo) == 0 {
+		return ErrNoEvidence
+	}
+	for _, img := range [][]byte{r.Signature, r.Photo} {
+		if len(img) == 0 {
+			continue
+		} else if len(img) > MaxProofImageSize {
+			return ErrImageTooLarge
+		}
+		ct := http.DetectContentType(img)
+		if ct != "image/png" && ct != "image/jpeg" {
+			return ErrInvalidImage
+		}
+	}
+	if l := r.Location; l != nil {
+		if math.IsNaN(l.Latitude) || math.IsNaN(l.Longitude) || math.Abs(l.Latitude) > 90 ||
+			math.Abs(l.Longitude) > 180 {
+			return ErrInvalidLocation
+		}
+	}
+
+	return nil
+}
+
+// ParseDeliveryForm parses a delivery request from the multipart form of
+// r, which contains the fields recipient-name, latitude, longitude and
+// time, which is formatted according to RFC 3339, and the files signature
+// and photo. All fields but the recipient's name are optional.
+func ParseDeliveryForm(r *http.Request) (*DeliveryRequest, error) {
+	err := r.ParseMultipartForm(2 * MaxProofImageSize)
+	if err != nil {
+		return nil, err
+	}
+	req := &DeliveryRequest{RecipientName: strings.TrimSpace(r.PostFormValue("recipient-name"))}
+	req.Signature, err = formImage(r.MultipartForm, "signature")
+	if err != nil {
+		return nil, err
+	}
+	req.Photo, err = formImage(r.MultipartForm, "photo")
+	if err != nil {
+		return nil, err
+	}
+	lat, lon := r.PostFormValue("latitude"), r.PostFormValue("longitude")
+	if lat != "" || lon != "" {
+		req.Location = &Location{}
+		req.Location.Latitude, err = strconv.ParseFloat(lat, 64)
+		if err != nil {
+			return nil, ErrInvalidLocation
+		}
+		req.Location.Longitude, err = strconv.ParseFloat(lon, 64)
+		if err != nil {
+			return nil, ErrInvalidLocation
+		}
+	}
+	if ts := r.PostFormValue("time"); ts != "" {
+		req.Time, err = time.Parse(time.RFC3339, ts)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return req, nil
+}
+
+// formImage returns the contents of the file uploaded as the form field
+// name or nil, if there is no such file.
+func formImage(f *multipart.Form, name string) ([]byte, error) {
+	if f == nil || len(f.File[name]) == 0 {
+		return nil, nil
+	}
+	file, err := f.File[name][0].Open()
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	img, err := ioutil.ReadAll(io.LimitReader(file, MaxProofImageSize+1))
+	if err != nil {
+		return nil, err
+	} else if len(img) > MaxProofImageSize {
+		return nil, ErrImageTooLarge
+	}
+
+	return img, nil
+}
+
+// Deliver records the event completing the delivery of p together with the
+// proof of delivery captured in req. Depending on p's state, the event is
+// DeliveredToDestination, ReturnedToSender or CollectedByRecipient. The
+// images are stored in bs, the event and the proof in s, which checks the
+// event again against the events stored by then.
+func Deliver(p *Parcel, req *DeliveryRequest, s Deliverer, es EventAccesser, bs blob.Store) (*Proof, error) {
+	err := req.Validate()
+	if err != nil {
+		return nil, err
+	}
+	ee, err := es.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+	t, ok := NewState(p, ee).completion()
+	if !ok {
+		return nil, ErrNotOutForDelivery
+	}
+	e, err := NewEvent(p, t)
+	if err != nil {
+		return nil, err
+	}
+	if !req.Time.IsZero() {
+		e.Time = req.Time.Local()
+	}
+	check := func(ee []*Event) error {
+		st := NewState(p, ee)
+		if n, ok := st.completion(); !ok || n != e.Type {
+			return ErrNotOutForDelivery
+		}
+		return st.Check(e.Type, e.Time)
+	}
+	err = check(ee)
+	if err != nil {
+		return nil, err
+	}
+
+	pr := &Proof{Event: e, RecipientName: strings.TrimSpace(req.RecipientName), Location: req.Location}
+	pr.Signature, err = putImage(bs, req.Signature)
+	if err != nil {
+		return nil, err
+	}
+	pr.Photo, err = putImage(bs, req.Photo)
+	if err != nil {
+		deleteImages(bs, pr)
+		return nil, err
+	}
+	err = s.Deliver(e, pr, check)
+	if err != nil {
+		deleteImages(bs, pr)
+		return nil, err
+	}
+
+	return pr, nil
+}
+
+// completion returns the event type completing the parcel's delivery, if
+// it may occur next.
+func (s *State) completion() (EventType, bool) {
+	for _, n := range s.Next() {
+		switch n {
+		case DeliveredToDestination, ReturnedToSender, CollectedByRecipient:
+			return n, true
+		}
+	}
+
+	return 0, false
+}
+
+// putImage stores img in bs under a new key, which is returned. Nothing is
+// stored, if img is empty.
+func putImage(bs blob.Store, img []byte) (string, error) {
+	if len(img) == 0 {
+		return "", nil
+	}
+	key, err := blob.NewKey()
+	if err != nil {
+		return "", err
+	}
+	err = bs.Put(key, bytes.NewReader(img))
+	if err != nil {
+		return "", err
+	}
+
+	return key, nil
+}
+
+// deleteImages removes the images of pr, which have already been stored,
+// from bs.
+func deleteImages(bs blob.Store, pr *Proof) {
+	for _, key := range []string{pr.Signature, pr.Photo} {
+		if key != "" {
+			bs.Delete(key)
+		}
+	}
+}
+
+// ProofVisible reports whether u may view the proof of delivery of p,
+// which is only shown to the parcel's sender and to the recipient named by
+// the sender. Users, who have merely added the destination address to
+// their addresses, may not view it.
+func ProofVisible(u *user.User, p *Parcel, as address.Accesser) (bool, error) {
+	if u == nil {
+		return false, nil
+	} else if p.SentTo(u) {
+		return true, nil
+	}
+
+	return p.SentBy(u, as)
+}
@@ -0,0 +1,51 @@
+package parcel
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// testAddresses is an address.Accesser for a single user's addresses.
+type testAddresses []*address.Address
+
+func (aa testAddresses) ByID(id uuid.UUID) (*address.Address, error) {
+	return findAddress(aa, id), nil
+}
+
+func (aa testAddresses) ByUser(u *user.User) ([]*address.Address, error) {
+	return aa, nil
+}
+
+func TestProofVisible(t *testing.T) {
+	u := &user.User{ID: uuid.New(), Username: "alice"}
+	p := testParcel("Earth")
+	p.ReturnAddress.ID = uuid.New()
+	// A copy of the destination, as anyone can add it after tracking p.
+	dest := *p.DestinationAddress
+	dest.ID = uuid.New()
+	tests := []struct {
+		name      string
+		u         *user.User
+		recipient *uuid.UUID
+		aa        testAddresses
+		want      bool
+	}{
+		{"anonymous", nil, nil, nil, false},
+		{"sender", u, nil, testAddresses{p.ReturnAddress}, true},
+		{"recipient", u, &u.ID, nil, true},
+		{"other recipient", u, &testID, nil, false},
+		{"same destination address", u, nil, testAddresses{&dest}, false},
+	}
+	for _, tt := range tests {
+		p.RecipientID = tt.recipient
+		got, err := ProofVisible(tt.u, p, tt.aa)
+		if err != nil {
+			t.Errorf("%s: ProofVisible() returned %v", tt.name, err)
+		} else if got != tt.want {
+			t.Errorf("%s: ProofVisible() = %t, want %t", tt.name, got, tt.want)
+		}
+	}
+}
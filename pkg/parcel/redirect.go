@@ -0,0 +1,162 @@
+package parcel
+
+import (
+	"errors"
+	"strings"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrNotRecipient     = errors.New("parcel: only the recipient may redirect the parcel or have it held")
+	ErrNotRedirectable  = errors.New("parcel: the parcel cannot be redirected or held after it has been loaded into a vehicle")
+	ErrSameDestination  = errors.New("parcel: the parcel is already being sent to this address")
+	ErrRedirectPlanet   = errors.New("parcel: parcels can only be redirected to addresses on the same planet")
+	ErrAlreadyHeld      = errors.New("parcel: the parcel is already being held for collection")
+	ErrInvalidRecipient = errors.New("parcel: the address does not belong to the recipient")
+)
+
+// Redirect changes the destination of p to the address identified by to on
+// behalf of u, who must be p's recipient. The new address must be one of
+// u's addresses on the same planet as the current destination, because the
+// parcel's route and customs declaration must not change. Parcels sent to a
+// pickup point are delivered to the new address instead. The Redirected
+// event is stored together with the new destination in s.
+func Redirect(u *user.User, p *Parcel, to uuid.UUID, as address.Accesser, s Storage,
+	es EventAccesser) (*Event, error) {
+	if !p.SentTo(u) {
+		return nil, ErrNotRecipient
+	}
+	aa, err := as.ByUser(u)
+	if err != nil {
+		return nil, err
+	}
+	a := findAddress(aa, to)
+	if a == nil {
+		return nil, ErrInvalidRecipient
+	} else if a.SameAs(p.DestinationAddress) {
+		return nil, ErrSameDestination
+	} else if !strings.EqualFold(planet(a), planet(p.DestinationAddress)) {
+		return nil, ErrRedirectPlanet
+	}
+	_, err = redirectable(p, es)
+	if err != nil {
+		return nil, err
+	}
+
+	return redirect(p, a, nil, s)
+}
+
+// RedirectToPoint changes the destination of p to the pickup point
+// identified by point on behalf of u, who must be p's recipient. dest is
+// the recipient's new address at the pickup point, which must be on the
+// same planet as the current destination. It is added to u's addresses,
+// unless u has already added it before. The Redirected event is stored
+// together with the new destination in s.
+func RedirectToPoint(u *user.User, p *Parcel, point uuid.UUID, dest *address.Address, as address.Storage,
+	s Storage, es EventAccesser) (*Event, error) {
+	if !p.SentTo(u) {
+		return nil, ErrNotRecipient
+	} else if p.PickupPointID != nil && *p.PickupPointID == point {
+		return nil, ErrSameDestination
+	} else if !strings.EqualFold(planet(dest), planet(p.DestinationAddress)) {
+		return nil, ErrRedirectPlanet
+	}
+	_, err := redirectable(p, es)
+	if err != nil {
+		return nil, err
+	}
+
+	dest.User = u
+	err = as.Insert(dest)
+	if err == address.ErrAddressAlreadyAdded {
+		var aa []*address.Address
+		aa, err = as.ByUser(u)
+		if err != nil {
+			return nil, err
+		}
+		if a := findEqualAddress(aa, dest); a != nil {
+			dest, err = a, nil
+		} else {
+			err = address.ErrAddressAlreadyAdded
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return redirect(p, dest, &point, s)
+}
+
+// redirect changes the destination of p to a and its pickup point to
+// point and stores them together with the Redirected event in s, unless p
+// may no longer be redirected by then.
+func redirect(p *Parcel, a *address.Address, point *uuid.UUID, s Redirector) (*Event, error) {
+	e, err := NewEvent(p, Redirected)
+	if err != nil {
+		return nil, err
+	}
+	old, oldPoint := p.DestinationAddress, p.PickupPointID
+	p.DestinationAddress, p.PickupPointID = a, point
+	err = s.Redirect(p, e, func(ee []*Event) error {
+		_, err := redirectableAfter(p, ee)
+		return err
+	})
+	if err != nil {
+		p.DestinationAddress, p.PickupPointID = old, oldPoint
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// Hold asks us to hold p for collection at a logistics center on the
+// planet of its destination on behalf of u, who must be p's recipient, and
+// stores the HoldRequested event in es.
+func Hold(u *user.User, p *Parcel, es EventStorage) (*Event, error) {
+	if !p.SentTo(u) {
+		return nil, ErrNotRecipient
+	}
+	e, err := NewEvent(p, HoldRequested)
+	if err != nil {
+		return nil, err
+	}
+	err = es.Insert(e, func(ee []*Event) error {
+		st, err := redirectableAfter(p, ee)
+		if err != nil {
+			return err
+		} else if st.Held {
+			return ErrAlreadyHeld
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
+
+// redirectable returns the state of p, unless it may no longer be
+// redirected or held.
+func redirectable(p *Parcel, es EventAccesser) (*State, error) {
+	ee, err := es.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+
+	return redirectableAfter(p, ee)
+}
+
+// redirectableAfter returns the state of p after the events ee, unless it
+// may no longer be redirected or held.
+func redirectableAfter(p *Parcel, ee []*Event) (*State, error) {
+	st := NewState(p, ee)
+	if !st.Redirectable() {
+		return nil, ErrNotRedirectable
+	}
+
+	return st, nil
+}
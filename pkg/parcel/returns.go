@@ -0,0 +1,68 @@
+package parcel
+
+import (
+	"errors"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrNotInvolved   = errors.New("parcel: only the sender or the recipient may request the parcel's return")
+	ErrNotReturnable = errors.New("parcel: the parcel cannot be returned in its current state")
+)
+
+// DefaultMaxDeliveryAttempts is the number of failed delivery attempts,
+// after which parcels are returned to their senders, unless configured
+// otherwise.
+const DefaultMaxDeliveryAttempts = 3
+
+// RequestReturn initiates the return of p to its sender on behalf of u,
+// who must either be p's sender or its recipient, and records the event in
+// s.
+func RequestReturn(u *user.User, p *Parcel, as address.Accesser, s EventStorage) (*Event, error) {
+	ok, err := p.Involves(u, as)
+	if err != nil {
+		return nil, err
+	} else if !ok {
+		return nil, ErrNotInvolved
+	}
+
+	e, err := Record(s, p, ReturnInitiated, time.Time{})
+	if err == ErrInvalidTransition {
+		return nil, ErrNotReturnable
+	}
+
+	return e, err
+}
+
+// AutoReturner is a Publisher, which initiates the return of parcels to
+// their senders, once their delivery has failed MaxAttempts times or their
+// recipients have refused to accept them.
+type AutoReturner struct {
+	Events EventStorage
+	// MaxAttempts defaults to DefaultMaxDeliveryAttempts.
+	MaxAttempts int
+}
+
+func (a *AutoReturner) Publish(e *Event) error {
+	if e.Type != DeliveryFailed {
+		return nil
+	}
+	ee, err := a.Events.ByParcel(e.Parcel)
+	if err != nil {
+		return err
+	}
+	max := a.MaxAttempts
+	if max <= 0 {
+		max = DefaultMaxDeliveryAttempts
+	}
+	st := NewState(e.Parcel, ee)
+	if st.FailedAttempts < max && e.Reason != Refused || !st.Returnable() {
+		return nil
+	}
+	_, err = Record(a.Events, e.Parcel, ReturnInitiated, e.Time)
+
+	return err
+}
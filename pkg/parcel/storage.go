@@ -3,26 +3,89 @@ package parcel
 import (
 	"github.com/google/uuid"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 )
 
 type Inserter interface {
 	Insert(p *Parcel) error
 }
 
+// Creator is the interface wrapping the Create method.
+//
+// Create stores the new parcel p together with its initial event e and
+// adds p's destination address to the sender's addresses, unless the sender
+// has already added an equal address, whose ID is assigned to the
+// destination instead. Either all or none of them are stored.
+type Creator interface {
+	Create(p *Parcel, e *Event) error
+}
+
+// Redirector is the interface wrapping the Redirect method.
+//
+// Redirect stores p's new destination address and pickup point together
+// with the event e recording the change, unless check returns an error for
+// the events of p stored so far. Either both or neither of them are stored
+// and no other event of p is stored in between, like with
+// EventInserter.Insert.
+type Redirector interface {
+	Redirect(p *Parcel, e *Event, check func(ee []*Event) error) error
+}
+
+// Deliverer is the interface wrapping the Deliver method.
+//
+// Deliver stores the event e completing a parcel's delivery together with
+// its proof of delivery pr, unless check returns an error for the events
+// of the parcel stored so far. Either both or neither of them are stored
+// and no other event of the parcel is stored in between.
+type Deliverer interface {
+	Deliver(e *Event, pr *Proof, check func(ee []*Event) error) error
+}
+
+// ProofAccesser is the interface wrapping the ProofByParcel method.
+//
+// ProofByParcel returns the proof of p's delivery or nil, if there is
+// none.
+type ProofAccesser interface {
+	ProofByParcel(p *Parcel) (*Proof, error)
+}
+
+// Accesser is the interface for reading parcels.
+//
+// ByIDs returns the parcels identified by any of ids in no particular
+// order, skipping the IDs of parcels that do not exist.
+//
+// ByRecipient returns the parcels, whose sender has named u as their
+// recipient.
 type Accesser interface {
 	ByID(id uuid.UUID) (*Parcel, error)
-	ByDestination(a *address.Address) ([]*Parcel, error)
+	ByIDs(ids []uuid.UUID) ([]*Parcel, error)
+	ByRecipient(u *user.User) ([]*Parcel, error)
+	ByReturnAddress(a *address.Address) ([]*Parcel, error)
 }
 
 type Storage interface {
 	Inserter
+	Creator
+	Redirector
+	Deliverer
+	Importer
 	Accesser
+	ProofAccesser
 }
 
+// EventInserter is the interface wrapping the Insert method.
+//
+// Insert stores e, unless check returns an error for the events of e's
+// parcel stored so far. The events of a parcel are checked and inserted one
+// at a time, so that no other event is stored in between.
 type EventInserter interface {
-	Insert(e *Event) error
+	Insert(e *Event, check func(ee []*Event) error) error
 }
 
+// EventAccesser is the interface wrapping the ByParcel method.
+//
+// ByParcel returns the events of p ordered by their time. Events, which
+// occurred at the same time, are returned in the order of their insertion.
 type EventAccesser interface {
 	ByParcel(p *Parcel) ([]*Event, error)
 }
@@ -0,0 +1,242 @@
+package parcel
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+// Tracking codes are the human-friendly form of a parcel's tracking id. A
+// code consists of the first two letters of the destination planet, the
+// 128 bits of the parcel's UUID in Crockford's base32 and a check
+// character, which is computed by the Luhn mod 32 algorithm over the
+// encoded UUID, e.g. EA-10DZT0NWZ-58BV9Q2H5-YWKCZNBPX. The planet prefix
+// is merely informative and may be omitted, as may be the hyphens.
+// Lowercase letters are accepted, as are O for 0 and I and L for 1.
+const (
+	// codeAlphabet is Crockford's base32 alphabet, which omits the
+	// letters I, L, O and U to avoid confusion.
+	codeAlphabet = "0123456789ABCDEFGHJKMNPQRSTVWXYZ"
+	// codeBodyLength is the number of characters encoding a UUID. Its
+	// 130 bits leave the two most significant bits unused.
+	codeBodyLength = 26
+	// codeLength is the length of a tracking code without the planet
+	// prefix and the hyphens.
+	codeLength       = codeBodyLength + 1
+	codePrefixLength = 2
+	codeGroupLength  = 9
+	// maxSuggestions limits the number of tracking codes suggested for a
+	// mistyped one.
+	maxSuggestions = 3
+)
+
+var (
+	ErrInvalidTrackingID = errors.New("parcel: the tracking number is invalid")
+	ErrTrackingCodeTypo  = errors.New("parcel: the check character of the tracking number does not match, it probably contains a typo")
+)
+
+// TrackingCode returns the human-friendly tracking code of p.
+func (p *Parcel) TrackingCode() string {
+	return FormatTrackingCode(p.ID, planet(p.DestinationAddress))
+}
+
+// FormatTrackingCode returns the tracking code of id for items sent to the
+// given planet, e.g. consignments.
+func FormatTrackingCode(id uuid.UUID, planet string) string {
+	var b strings.Builder
+	b.WriteString(planetPrefix(planet))
+	code := encodeID(id)
+	for i := 0; i < len(code); i += codeGroupLength {
+		b.WriteByte('-')
+		b.WriteString(code[i : i+codeGroupLength])
+	}
+
+	return b.String()
+}
+
+// ParseTrackingID returns the parcel UUID given by s, which is either a
+// UUID or a tracking code. If the check character of a tracking code does
+// not match, ErrTrackingCodeTypo is returned.
+func ParseTrackingID(s string) (uuid.UUID, error) {
+	s = strings.TrimSpace(s)
+	id, err := uuid.Parse(s)
+	if err == nil {
+		return id, nil
+	}
+	code, ok := normalizeCode(s)
+	if !ok {
+		return uuid.Nil, ErrInvalidTrackingID
+	}
+	if !validCheck(code) {
+		return uuid.Nil, ErrTrackingCodeTypo
+	}
+	id, ok = decodeID(code[:codeBodyLength])
+	if !ok {
+		return uuid.Nil, ErrInvalidTrackingID
+	}
+
+	return id, nil
+}
+
+// SuggestTrackingCodes returns the parcels stored in s, whose tracking
+// codes differ from the mistyped code in a single character or by two
+// swapped adjacent characters. At most maxSuggestions parcels are
+// returned and none, if code is not a tracking code. All candidates are
+// looked up with a single query, since the codes are entered by anyone.
+func SuggestTrackingCodes(code string, s Accesser) ([]*Parcel, error) {
+	c, ok := normalizeCode(strings.TrimSpace(code))
+	if !ok {
+		return nil, nil
+	}
+
+	var ids []uuid.UUID
+	seen := make(map[uuid.UUID]bool)
+	try := func(cand []byte) {
+		if !validCheck(string(cand)) {
+			return
+		}
+		id, ok := decodeID(string(cand[:codeBodyLength]))
+		if ok && !seen[id] {
+			seen[id] = true
+			ids = append(ids, id)
+		}
+	}
+
+	cand := []byte(c)
+	for i := 0; i+1 < len(cand); i++ {
+		cand[i], cand[i+1] = cand[i+1], cand[i]
+		try(cand)
+		cand[i], cand[i+1] = cand[i+1], cand[i]
+	}
+	for i := range cand {
+		orig := cand[i]
+		for j := 0; j < len(codeAlphabet); j++ {
+			if codeAlphabet[j] != orig {
+				cand[i] = codeAlphabet[j]
+				try(cand)
+			}
+		}
+		cand[i] = orig
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	found, err := s.ByIDs(ids)
+	if err != nil {
+		return nil, err
+	}
+	byID := make(map[uuid.UUID]*Parcel, len(found))
+	for _, p := range found {
+		byID[p.ID] = p
+	}
+	// Swapped characters are suggested before mistyped ones.
+	var pp []*Parcel
+	for _, id := range ids {
+		if p := byID[id]; p != nil && len(pp) < maxSuggestions {
+			pp = append(pp, p)
+		}
+	}
+
+	return pp, nil
+}
+
+// planetPrefix returns the first two letters of the planet's name in
+// uppercase, which are padded with X, if the name is shorter.
+func planetPrefix(planet string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(planet) {
+		if b.Len() == codePrefixLength {
+			break
+		} else if r >= 'A' && r <= 'Z' {
+			b.WriteRune(r)
+		}
+	}
+	for b.Len() < codePrefixLength {
+		b.WriteByte('X')
+	}
+
+	return b.String()
+}
+
+// encodeID returns the encoded UUID id followed by its check character.
+func encodeID(id uuid.UUID) string {
+	n := new(big.Int).SetBytes(id[:])
+	b := make([]byte, codeLength)
+	m := new(big.Int)
+	for i := codeBodyLength - 1; i >= 0; i-- {
+		n.DivMod(n, big.NewInt(int64(len(codeAlphabet))), m)
+		b[i] = codeAlphabet[m.Int64()]
+	}
+	b[codeBodyLength] = checkCharacter(b[:codeBodyLength])
+
+	return string(b)
+}
+
+// decodeID returns the UUID encoded by body, which must only consist of
+// characters of the code alphabet. It returns false, if body is too
+// large for a UUID.
+func decodeID(body string) (uuid.UUID, bool) {
+	n := new(big.Int)
+	base := big.NewInt(int64(len(codeAlphabet)))
+	for i := 0; i < len(body); i++ {
+		n.Mul(n, base)
+		n.Add(n, big.NewInt(int64(strings.IndexByte(codeAlphabet, body[i]))))
+	}
+	if n.BitLen() > 8*len(uuid.UUID{}) {
+		return uuid.Nil, false
+	}
+	var id uuid.UUID
+	b := n.Bytes()
+	copy(id[len(id)-len(b):], b)
+
+	return id, true
+}
+
+// normalizeCode strips the planet prefix, the hyphens and spaces from the
+// tracking code s and replaces lowercase and ambiguous letters. It returns
+// false, if s is no tracking code.
+func normalizeCode(s string) (string, bool) {
+	s = strings.ToUpper(strings.NewReplacer("-", "", " ", "").Replace(s))
+	if len(s) == codePrefixLength+codeLength {
+		for i := 0; i < codePrefixLength; i++ {
+			if s[i] < 'A' || s[i] > 'Z' {
+				return "", false
+			}
+		}
+		s = s[codePrefixLength:]
+	} else if len(s) != codeLength {
+		return "", false
+	}
+	s = strings.NewReplacer("O", "0", "I", "1", "L", "1").Replace(s)
+	for i := 0; i < len(s); i++ {
+		if strings.IndexByte(codeAlphabet, s[i]) < 0 {
+			return "", false
+		}
+	}
+
+	return s, true
+}
+
+// checkCharacter returns the Luhn mod 32 check character of body.
+func checkCharacter(body []byte) byte {
+	n := len(codeAlphabet)
+	sum := 0
+	factor := 2
+	for i := len(body) - 1; i >= 0; i-- {
+		addend := factor * strings.IndexByte(codeAlphabet, body[i])
+		sum += addend/n + addend%n
+		factor = 3 - factor
+	}
+
+	return codeAlphabet[(n-sum%n)%n]
+}
+
+// validCheck reports whether the last character of code is the check
+// character of the preceding ones.
+func validCheck(code string) bool {
+	body := []byte(code[:len(code)-1])
+	return checkCharacter(body) == code[len(code)-1]
+}
@@ -0,0 +1,131 @@
+package parcel
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+)
+
+var (
+	testNilID = uuid.Nil
+	testMaxID = uuid.MustParse("ffffffff-ffff-ffff-ffff-ffffffffffff")
+	testID    = uuid.MustParse("01234567-89ab-cdef-0123-456789abcdef")
+)
+
+func TestFormatTrackingCode(t *testing.T) {
+	tests := []struct {
+		id     uuid.UUID
+		planet string
+		want   string
+	}{
+		{testNilID, "Earth", "EA-000000000-000000000-000000000"},
+		{testMaxID, "Mars", "MA-7ZZZZZZZZ-ZZZZZZZZZ-ZZZZZZZZJ"},
+		{testID, "earth", "EA-014D2PF2D-BSQQG28T5-CY4TQKFFR"},
+		{testID, "", "XX-014D2PF2D-BSQQG28T5-CY4TQKFFR"},
+		{testID, "X", "XX-014D2PF2D-BSQQG28T5-CY4TQKFFR"},
+		{testID, "51 Pegasi b", "PE-014D2PF2D-BSQQG28T5-CY4TQKFFR"},
+	}
+	for _, tt := range tests {
+		got := FormatTrackingCode(tt.id, tt.planet)
+		if got != tt.want {
+			t.Errorf("FormatTrackingCode(%v, %q) = %s, want %s", tt.id, tt.planet, got, tt.want)
+		}
+	}
+}
+
+func TestParseTrackingID(t *testing.T) {
+	tests := []struct {
+		name string
+		s    string
+		want uuid.UUID
+		err  error
+	}{
+		{"UUID", testID.String(), testID, nil},
+		{"code", "EA-014D2PF2D-BSQQG28T5-CY4TQKFFR", testID, nil},
+		{"code with spaces", " EA 014D2PF2D BSQQG28T5 CY4TQKFFR ", testID, nil},
+		{"other prefix", "MA-014D2PF2D-BSQQG28T5-CY4TQKFFR", testID, nil},
+		{"without prefix", "014D2PF2D-BSQQG28T5-CY4TQKFFR", testID, nil},
+		{"lowercase without hyphens", "ea014d2pf2dbsqqg28t5cy4tqkffr", testID, nil},
+		{"ambiguous letters", "EA-O14D2PF2D-BSQQG28T5-CY4TQKFFR", testID, nil},
+		{"L for 1", "EA-0L4D2PF2D-BSQQG28T5-CY4TQKFFR", testID, nil},
+		{"nil", "XX-000000000-000000000-000000000", testNilID, nil},
+		{"maximum", "7ZZZZZZZZ-ZZZZZZZZZ-ZZZZZZZZJ", testMaxID, nil},
+		{"substituted character", "EA-014D2PF3D-BSQQG28T5-CY4TQKFFR", uuid.Nil, ErrTrackingCodeTypo},
+		{"swapped characters", "EA-01D42PF2D-BSQQG28T5-CY4TQKFFR", uuid.Nil, ErrTrackingCodeTypo},
+		{"wrong check character", "EA-014D2PF2D-BSQQG28T5-CY4TQKFF0", uuid.Nil, ErrTrackingCodeTypo},
+		{"too short", "EA-014D2PF2D-BSQQG28T5-CY4TQKFF", uuid.Nil, ErrInvalidTrackingID},
+		{"too long", "EA-014D2PF2D-BSQQG28T5-CY4TQKFFR0", uuid.Nil, ErrInvalidTrackingID},
+		{"digits in prefix", "E1-014D2PF2D-BSQQG28T5-CY4TQKFFR", uuid.Nil, ErrInvalidTrackingID},
+		{"U is not in the alphabet", "EA-014D2PF2D-BSQQG28T5-CY4TQKFUR", uuid.Nil, ErrInvalidTrackingID},
+		{"too large for a UUID", "ZZZZZZZZZ-ZZZZZZZZZ-ZZZZZZZZT", uuid.Nil, ErrInvalidTrackingID},
+		{"empty", "", uuid.Nil, ErrInvalidTrackingID},
+	}
+	for _, tt := range tests {
+		got, err := ParseTrackingID(tt.s)
+		if err != tt.err {
+			t.Errorf("%s: ParseTrackingID(%q) returned %v, want %v", tt.name, tt.s, err, tt.err)
+		} else if got != tt.want {
+			t.Errorf("%s: ParseTrackingID(%q) = %v, want %v", tt.name, tt.s, got, tt.want)
+		}
+	}
+}
+
+func TestEncodeID(t *testing.T) {
+	for _, id := range []uuid.UUID{testNilID, testMaxID, testID, uuid.New(), uuid.New()} {
+		code := encodeID(id)
+		if len(code) != codeLength {
+			t.Errorf("encodeID(%v) = %s, want %d characters", id, code, codeLength)
+			continue
+		}
+		got, ok := decodeID(code[:codeBodyLength])
+		if !ok || got != id {
+			t.Errorf("decodeID(%s) = %v, %t, want %v", code[:codeBodyLength], got, ok, id)
+		}
+		if !validCheck(code) {
+			t.Errorf("encodeID(%v) = %s has an invalid check character", id, code)
+		}
+	}
+}
+
+func TestCheckCharacter(t *testing.T) {
+	tests := []struct {
+		body string
+		want byte
+	}{
+		{"00000000000000000000000000", '0'},
+		{"7ZZZZZZZZZZZZZZZZZZZZZZZZZ", 'J'},
+		{"014D2PF2DBSQQG28T5CY4TQKFF", 'R'},
+		{"ZZZZZZZZZZZZZZZZZZZZZZZZZZ", 'T'},
+	}
+	for _, tt := range tests {
+		got := checkCharacter([]byte(tt.body))
+		if got != tt.want {
+			t.Errorf("checkCharacter(%s) = %c, want %c", tt.body, got, tt.want)
+		}
+	}
+}
+
+// TestCheckCharacterTypos checks that every single substituted character
+// and every swap of distinct adjacent characters of a code is detected.
+func TestCheckCharacterTypos(t *testing.T) {
+	code := encodeID(testID)
+	for i := 0; i < len(code); i++ {
+		for j := 0; j < len(codeAlphabet); j++ {
+			b := []byte(code)
+			if b[i] == codeAlphabet[j] {
+				continue
+			}
+			b[i] = codeAlphabet[j]
+			if validCheck(string(b)) {
+				t.Errorf("substituting %c at %d in %s is not detected", codeAlphabet[j], i, code)
+			}
+		}
+		if i+1 < len(code) && code[i] != code[i+1] {
+			b := []byte(code)
+			b[i], b[i+1] = b[i+1], b[i]
+			if validCheck(string(b)) {
+				t.Errorf("swapping the characters at %d in %s is not detected", i, code)
+			}
+		}
+	}
+}
@@ -0,0 +1,89 @@
+package payment
+
+import (
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+)
+
+// DeclinedNumber is the number of the test card, which is always declined
+// by FakeGateway.
+const DeclinedNumber = "4000000000000002"
+
+// Charge is a charge made by FakeGateway.
+type Charge struct {
+	Reference   string
+	CardID      uuid.UUID
+	Amount      pricing.Amount
+	Currency    string
+	Description string
+	Created     time.Time
+	Refunded    bool
+}
+
+// FakeGateway is a Gateway, which keeps the charges in memory instead of
+// contacting a payment provider. It declines cards without a number and
+// the test card DeclinedNumber and accepts all other cards.
+type FakeGateway struct {
+	mu      sync.Mutex
+	charges map[string]*Charge
+}
+
+func NewFakeGateway() *FakeGateway {
+	return &FakeGateway{charges: make(map[string]*Charge)}
+}
+
+func (g *FakeGateway) Charge(c *credit.Card, amount pricing.Amount, currency, description string) (string, error) {
+	n := strings.Replace(strings.TrimSpace(c.Number), " ", "", -1)
+	if n == "" || n == DeclinedNumber || amount <= 0 {
+		return "", ErrDeclined
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return "", err
+	}
+	ch := &Charge{
+		Reference:   "fake_" + strings.Replace(id.String(), "-", "", -1),
+		CardID:      c.ID,
+		Amount:      amount,
+		Currency:    currency,
+		Description: description,
+		Created:     time.Now(),
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.charges[ch.Reference] = ch
+
+	return ch.Reference, nil
+}
+
+func (g *FakeGateway) Refund(reference string) error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	ch, ok := g.charges[reference]
+	if !ok {
+		return ErrUnknownReference
+	} else if ch.Refunded {
+		return ErrAlreadyRefunded
+	}
+	ch.Refunded = true
+
+	return nil
+}
+
+// Charges returns copies of all charges made so far.
+func (g *FakeGateway) Charges() []Charge {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	cc := make([]Charge, 0, len(g.charges))
+	for _, ch := range g.charges {
+		cc = append(cc, *ch)
+	}
+
+	return cc
+}
@@ -0,0 +1,68 @@
+package payment
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+)
+
+func TestFakeGatewayDeclines(t *testing.T) {
+	g := NewFakeGateway()
+	tests := []struct {
+		number string
+		amount pricing.Amount
+	}{
+		{"", 1000},
+		{"  ", 1000},
+		{DeclinedNumber, 1000},
+		{"4000 0000 0000 0002", 1000},
+		{"4242424242424242", 0},
+		{"4242424242424242", -1},
+	}
+	for _, tt := range tests {
+		c := &credit.Card{ID: uuid.New(), Number: tt.number}
+		ref, err := g.Charge(c, tt.amount, "EUR", "test")
+		if err != ErrDeclined || ref != "" {
+			t.Errorf("Charge(%q, %d) = %q, %v, want %v", tt.number, tt.amount, ref, err, ErrDeclined)
+		}
+	}
+	if n := len(g.Charges()); n != 0 {
+		t.Errorf("%d declined charges have been kept", n)
+	}
+}
+
+func TestFakeGatewayRefund(t *testing.T) {
+	g := NewFakeGateway()
+	c := &credit.Card{ID: uuid.New(), Number: "4242 4242 4242 4242"}
+	ref, err := g.Charge(c, 1190, "EUR", "IPPS parcel")
+	if err != nil {
+		t.Fatalf("Charge() returned %v", err)
+	}
+	other, err := g.Charge(c, 500, "EUR", "IPPS parcel")
+	if err != nil {
+		t.Fatalf("Charge() returned %v", err)
+	} else if other == ref {
+		t.Fatalf("two charges have the same reference %s", ref)
+	}
+
+	if err := g.Refund(ref); err != nil {
+		t.Fatalf("Refund() returned %v", err)
+	}
+	if err := g.Refund(ref); err != ErrAlreadyRefunded {
+		t.Errorf("refunding a charge twice returned %v, want %v", err, ErrAlreadyRefunded)
+	}
+	if err := g.Refund("fake_unknown"); err != ErrUnknownReference {
+		t.Errorf("refunding an unknown charge returned %v, want %v", err, ErrUnknownReference)
+	}
+
+	for _, ch := range g.Charges() {
+		if ch.CardID != c.ID || ch.Currency != "EUR" {
+			t.Errorf("charge %s has card %s and currency %s", ch.Reference, ch.CardID, ch.Currency)
+		}
+		if ch.Refunded != (ch.Reference == ref) {
+			t.Errorf("charge %s of %d has refunded %t", ch.Reference, ch.Amount, ch.Refunded)
+		}
+	}
+}
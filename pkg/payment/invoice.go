@@ -0,0 +1,78 @@
+package payment
+
+import (
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// Invoice is the invoice of a parcel paid by credit card.
+type Invoice struct {
+	ID uuid.UUID `json:"id"`
+	// Number is the human-friendly number printed on the invoice, e.g.
+	// INV-20200710-1A2B3C4D.
+	Number string `json:"number"`
+	// UserID is the ID of the user, who has paid the invoice.
+	UserID   uuid.UUID `json:"-"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	// CardID identifies the charged credit card. It is nil, once the card
+	// has been deleted.
+	CardID *uuid.UUID `json:"cardId,omitempty"`
+	// Card is the end of the charged card's number, which is kept after the
+	// card has been deleted.
+	Card  string         `json:"card"`
+	Lines []pricing.Line `json:"lines"`
+	// Net is the sum of the lines, to which the tax is added.
+	Net        pricing.Amount `json:"net"`
+	TaxName    string         `json:"taxName"`
+	TaxPercent float64        `json:"taxPercent"`
+	Tax        pricing.Amount `json:"tax"`
+	Total      pricing.Amount `json:"total"`
+	Currency   string         `json:"currency"`
+	// Reference is the payment gateway's reference of the charge.
+	Reference string    `json:"-"`
+	Created   time.Time `json:"created"`
+}
+
+// newInvoice returns a new invoice for the price q of the parcel
+// identified by parcelID, which u pays with the card c, adding the tax t.
+func newInvoice(u *user.User, c *credit.Card, parcelID uuid.UUID, q *pricing.Quote, t Tax) (*Invoice, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	now := time.Now()
+	inv := &Invoice{
+		ID:         id,
+		Number:     "INV-" + now.Format("20060102") + "-" + strings.ToUpper(id.String()[:8]),
+		UserID:     u.ID,
+		ParcelID:   parcelID,
+		CardID:     &c.ID,
+		Card:       cardEnding(c.Number),
+		Lines:      q.Lines,
+		Net:        q.Total,
+		TaxName:    t.Name,
+		TaxPercent: t.Percent,
+		Currency:   q.Currency,
+		Created:    now,
+	}
+	inv.Tax = pricing.Amount(math.Round(float64(inv.Net) * t.Percent / 100))
+	inv.Total = inv.Net + inv.Tax
+
+	return inv, nil
+}
+
+// cardEnding returns the last four characters of the card number n.
+func cardEnding(n string) string {
+	r := []rune(strings.Replace(strings.TrimSpace(n), " ", "", -1))
+	if len(r) > 4 {
+		r = r[len(r)-4:]
+	}
+
+	return string(r)
+}
@@ -0,0 +1,156 @@
+package payment
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+func TestNewInvoice(t *testing.T) {
+	u := &user.User{ID: uuid.New()}
+	c := &credit.Card{ID: uuid.New(), Number: " 4242 4242 4242 4242 "}
+	q := &pricing.Quote{
+		Lines:    []pricing.Line{{Description: "Base price", Amount: 990}, {Description: "Express", Amount: 15}},
+		Total:    1005,
+		Currency: "EUR",
+	}
+	parcelID := uuid.New()
+	inv, err := newInvoice(u, c, parcelID, q, Tax{Name: "VAT", Percent: 19})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// 19 % of 10.05 are 1.9095, which is rounded to 1.91.
+	if inv.Net != 1005 || inv.Tax != 191 || inv.Total != 1196 || inv.Currency != "EUR" {
+		t.Errorf("the invoice has net %d, tax %d and total %d %s, want 1005, 191 and 1196 EUR", inv.Net,
+			inv.Tax, inv.Total, inv.Currency)
+	}
+	if inv.Card != "4242" || inv.CardID == nil || *inv.CardID != c.ID {
+		t.Errorf("the invoice charges card %s ending in %q", inv.CardID, inv.Card)
+	}
+	if inv.UserID != u.ID || inv.ParcelID != parcelID || len(inv.Lines) != 2 || inv.TaxName != "VAT" {
+		t.Errorf("the invoice %+v does not match its payer, parcel, lines or tax", inv)
+	}
+	prefix := "INV-" + inv.Created.Format("20060102") + "-"
+	if !strings.HasPrefix(inv.Number, prefix) || len(inv.Number) != len(prefix)+8 ||
+		strings.ToUpper(inv.Number) != inv.Number {
+		t.Errorf("the invoice has number %s, want %s followed by 8 upper case characters", inv.Number, prefix)
+	}
+
+	inv, err = newInvoice(u, c, parcelID, q, Tax{})
+	if err != nil {
+		t.Fatal(err)
+	} else if inv.Tax != 0 || inv.Total != inv.Net {
+		t.Errorf("the invoice without tax has tax %d and total %d, want 0 and %d", inv.Tax, inv.Total, inv.Net)
+	}
+}
+
+func TestCardEnding(t *testing.T) {
+	for n, want := range map[string]string{
+		"4242424242424242":     "4242",
+		"4000 0000 0000 0002 ": "0002",
+		"123":                  "123",
+		"":                     "",
+	} {
+		if got := cardEnding(n); got != want {
+			t.Errorf("cardEnding(%q) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+type fixedPrice pricing.Quote
+
+func (fp *fixedPrice) ParcelPrice(p *parcel.Parcel, t time.Time) (*pricing.Quote, error) {
+	q := pricing.Quote(*fp)
+	return &q, nil
+}
+
+// invoices is a Storage, which fails to create parcels, if err is not nil,
+// and does not implement the other methods.
+type invoices struct {
+	Storage
+	err     error
+	created []*Invoice
+}
+
+func (s *invoices) Create(p *parcel.Parcel, e *parcel.Event, inv *Invoice) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.created = append(s.created, inv)
+
+	return nil
+}
+
+type publisher []*parcel.Event
+
+func (pub *publisher) Publish(e *parcel.Event) error {
+	*pub = append(*pub, e)
+	return nil
+}
+
+func TestChargerCreate(t *testing.T) {
+	g := NewFakeGateway()
+	is := &invoices{}
+	pub := &publisher{}
+	s := &Service{
+		Gateway:   g,
+		Invoices:  is,
+		Pricing:   &fixedPrice{Total: 1000, Currency: "EUR"},
+		Tax:       DefaultTax,
+		Publisher: pub,
+	}
+	payer := &user.User{ID: uuid.New()}
+	p := &parcel.Parcel{ID: uuid.New(), DestinationAddress: &address.Address{Planet: "Earth"}}
+	e := &parcel.Event{ID: uuid.New(), Parcel: p, Type: parcel.DataReceived, Time: time.Now()}
+
+	ch := &charger{s: s, payer: payer, card: &credit.Card{ID: uuid.New(), Number: "4242424242424242"}}
+	if err := ch.Create(p, e); err != nil {
+		t.Fatalf("Create() returned %v", err)
+	}
+	cc := g.Charges()
+	if len(cc) != 1 || ch.invoice == nil || len(is.created) != 1 || len(*pub) != 1 {
+		t.Fatalf("Create() made %d charges, stored %d invoices and published %d events, want one each",
+			len(cc), len(is.created), len(*pub))
+	}
+	if cc[0].Amount != 1190 || cc[0].Reference != ch.invoice.Reference || cc[0].Refunded {
+		t.Errorf("Create() charged %+v for the invoice %s of 1190", cc[0], ch.invoice.Reference)
+	}
+	if !strings.Contains(cc[0].Description, p.TrackingCode()) {
+		t.Errorf("the statement reads %q, want the tracking code %s", cc[0].Description, p.TrackingCode())
+	}
+
+	// The charge is refunded, if the parcel cannot be stored.
+	is.err = errors.New("storage failed")
+	ch = &charger{s: s, payer: payer, card: &credit.Card{ID: uuid.New(), Number: "5555555555554444"}}
+	if err := ch.Create(p, e); err != is.err {
+		t.Fatalf("Create() returned %v, want %v", err, is.err)
+	}
+	for _, c := range g.Charges() {
+		if c.CardID == ch.card.ID && !c.Refunded {
+			t.Errorf("the charge %s of the parcel, which has not been stored, has not been refunded", c.Reference)
+		}
+	}
+	if ch.invoice != nil || len(*pub) != 1 {
+		t.Errorf("Create() returned the invoice %v and published %d events after failing", ch.invoice, len(*pub))
+	}
+
+	// Nothing is stored, if the card is declined.
+	is.err = nil
+	ch = &charger{s: s, payer: payer, card: &credit.Card{ID: uuid.New(), Number: DeclinedNumber}}
+	if err := ch.Create(p, e); err != ErrDeclined {
+		t.Errorf("Create() with a declined card returned %v, want %v", err, ErrDeclined)
+	}
+	if len(is.created) != 1 || len(g.Charges()) != 2 {
+		t.Errorf("the declined card led to %d invoices and %d charges, want 1 and 2", len(is.created),
+			len(g.Charges()))
+	}
+}
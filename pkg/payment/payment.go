@@ -0,0 +1,60 @@
+// Package payment charges the prices of parcels to the senders' saved
+// credit cards through a payment gateway and issues the corresponding
+// invoices.
+package payment
+
+import (
+	"errors"
+	"fmt"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+)
+
+var (
+	ErrDeclined         = errors.New("payment: the credit card has been declined")
+	ErrUnknownReference = errors.New("payment: the charge does not exist")
+	ErrAlreadyRefunded  = errors.New("payment: the charge has already been refunded")
+)
+
+// Gateway charges credit cards.
+//
+// Charge charges amount in the given currency to c and returns the
+// gateway's reference of the charge. The description appears on the card
+// holder's statement. Refund refunds the whole charge identified by
+// reference.
+type Gateway interface {
+	Charge(c *credit.Card, amount pricing.Amount, currency, description string) (string, error)
+	Refund(reference string) error
+}
+
+// Tax configures the tax added to the net prices of parcels.
+type Tax struct {
+	// Name is printed on invoices, e.g. "VAT".
+	Name    string
+	Percent float64
+}
+
+// Config selects the payment gateway and configures the tax.
+type Config struct {
+	// Gateway is the name of the gateway. Only "fake", which charges cards
+	// without contacting anyone, is supported, which is also the default.
+	Gateway string
+	Tax     Tax
+}
+
+// DefaultTax is used, if there is no payments configuration.
+var DefaultTax = Tax{Name: "VAT", Percent: 19}
+
+// OpenGateway returns the gateway configured by conf, which may be nil.
+func OpenGateway(conf *Config) (Gateway, error) {
+	if conf == nil {
+		conf = &Config{}
+	}
+	switch conf.Gateway {
+	case "", "fake":
+		return NewFakeGateway(), nil
+	default:
+		return nil, fmt.Errorf("payment: unknown gateway %q", conf.Gateway)
+	}
+}
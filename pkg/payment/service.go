@@ -0,0 +1,152 @@
+package payment
+
+import (
+	"errors"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/credit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrForeignCard     = errors.New("payment: the credit card does not belong to the sender")
+	ErrInvoiceNotFound = errors.New("payment: the invoice does not exist")
+	ErrNotPayer        = errors.New("payment: only the payer may access an invoice")
+)
+
+// Pricer is the interface wrapping the ParcelPrice method.
+//
+// ParcelPrice returns the price of sending p at time t.
+type Pricer interface {
+	ParcelPrice(p *parcel.Parcel, t time.Time) (*pricing.Quote, error)
+}
+
+// Service charges the senders' credit cards for their parcels and issues
+// the invoices.
+type Service struct {
+	Gateway  Gateway
+	Invoices Storage
+	Cards    credit.Accesser
+	Pricing  Pricer
+	Tax      Tax
+	// Publisher publishes the initial events of the parcels paid by credit
+	// card, which are stored together with their invoices.
+	Publisher parcel.Publisher
+}
+
+// Send sends a new parcel like parcel.Send. If req selects one of the
+// sender's credit cards, the parcel's price plus tax is charged to it right
+// before the parcel is stored together with its invoice in s.Invoices
+// instead of pc, and the charge is refunded, if they cannot be stored. The
+// invoice is returned together with the parcel. It is nil, if the parcel
+// is paid at the shop.
+func (s *Service) Send(req *parcel.SendRequest, as address.Accesser, us user.Accesser,
+	qa parcel.QuoteAccepter, pc parcel.Creator) (*parcel.Parcel, *Invoice, error) {
+	if req.CardID == uuid.Nil {
+		p, err := parcel.Send(req, as, us, qa, pc)
+		return p, nil, err
+	}
+	c, err := s.card(req.Sender, req.CardID)
+	if err != nil {
+		return nil, nil, err
+	}
+	ch := &charger{s: s, payer: req.Sender, card: c}
+	p, err := parcel.Send(req, as, us, qa, ch)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return p, ch.invoice, nil
+}
+
+// card returns u's credit card identified by id or ErrForeignCard.
+func (s *Service) card(u *user.User, id uuid.UUID) (*credit.Card, error) {
+	cc, err := s.Cards.ByUser(u)
+	if err == credit.ErrNoCards {
+		return nil, ErrForeignCard
+	} else if err != nil {
+		return nil, err
+	}
+	for _, c := range cc {
+		if c.ID == id {
+			return c, nil
+		}
+	}
+
+	return nil, ErrForeignCard
+}
+
+// charger is a parcel.Creator, which charges the payer's card before
+// creating the parcel together with its invoice.
+type charger struct {
+	s       *Service
+	payer   *user.User
+	card    *credit.Card
+	invoice *Invoice
+}
+
+func (c *charger) Create(p *parcel.Parcel, e *parcel.Event) error {
+	q, err := c.s.Pricing.ParcelPrice(p, time.Now())
+	if err != nil {
+		return err
+	}
+	inv, err := newInvoice(c.payer, c.card, p.ID, q, c.s.Tax)
+	if err != nil {
+		return err
+	}
+	inv.Reference, err = c.s.Gateway.Charge(c.card, inv.Total, inv.Currency, "IPPS parcel "+p.TrackingCode())
+	if err != nil {
+		return err
+	}
+	err = c.s.Invoices.Create(p, e, inv)
+	if err != nil {
+		if rerr := c.s.Gateway.Refund(inv.Reference); rerr != nil {
+			log.Printf("payment: cannot refund charge %s: %v\n", inv.Reference, rerr)
+		}
+		return err
+	}
+	c.invoice = inv
+	err = c.s.Publisher.Publish(e)
+	if err != nil {
+		// The parcel has been paid and stored nevertheless.
+		log.Println(err)
+	}
+
+	return nil
+}
+
+// ByID returns the invoice identified by id, if u has paid it or is an
+// operator.
+func (s *Service) ByID(u *user.User, id uuid.UUID) (*Invoice, error) {
+	inv, err := s.Invoices.ByID(id)
+	if err != nil {
+		return nil, err
+	} else if inv == nil {
+		return nil, ErrInvoiceNotFound
+	} else if inv.UserID != u.ID && !u.Operator {
+		return nil, ErrNotPayer
+	}
+
+	return inv, nil
+}
+
+// Paid returns the invoices paid by u, starting with the most recent one.
+func (s *Service) Paid(u *user.User) ([]*Invoice, error) {
+	return s.Invoices.ByUser(u.ID)
+}
+
+// IsInvalid reports whether err has been returned by Send, because the
+// payment has been refused.
+func IsInvalid(err error) bool {
+	switch err {
+	case ErrDeclined, ErrForeignCard:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,21 @@
+package payment
+
+import (
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// Storage is the interface for storing invoices.
+//
+// Create stores the invoice inv together with the paid parcel p and its
+// initial event e like parcel.Creator. Either all or none of them are
+// stored.
+//
+// ByID returns nil, if there is no invoice identified by id. ByUser
+// returns the invoices paid by the user identified by id, starting with
+// the most recent one.
+type Storage interface {
+	Create(p *parcel.Parcel, e *parcel.Event, inv *Invoice) error
+	ByID(id uuid.UUID) (*Invoice, error)
+	ByUser(id uuid.UUID) ([]*Invoice, error)
+}
@@ -0,0 +1,105 @@
+// Package pickup schedules the collection of parcels at their senders'
+// addresses by couriers, as an alternative to handing them over in one of
+// our shops.
+package pickup
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrCityEmpty          = errors.New("pickup: the city and planet of a time slot are required")
+	ErrInvalidCapacity    = errors.New("pickup: a time slot's capacity must be positive")
+	ErrInvalidSlotTime    = errors.New("pickup: a time slot must end after it starts")
+	ErrSlotInPast         = errors.New("pickup: the time slot has already started")
+	ErrSlotFull           = errors.New("pickup: the time slot is fully booked")
+	ErrWrongCity          = errors.New("pickup: the time slot is not in the city of the parcel's return address")
+	ErrNotSender          = errors.New("pickup: only the sender may book or cancel the parcel's pickup")
+	ErrNotAwaitingPickup  = errors.New("pickup: the parcel has already been handed over to us")
+	ErrAlreadyBooked      = errors.New("pickup: a pickup of the parcel has already been booked")
+	ErrNotBooked          = errors.New("pickup: the pickup is not booked")
+	ErrCancellationClosed = errors.New("pickup: the pickup cannot be cancelled after its time slot has started")
+)
+
+// Slot is a time slot, in which couriers pick up parcels in a city.
+type Slot struct {
+	ID     uuid.UUID `json:"id"`
+	City   string    `json:"city"`
+	Planet string    `json:"planet"`
+	Start  time.Time `json:"start"`
+	End    time.Time `json:"end"`
+	// Capacity is the number of pickups, which can be booked in the slot.
+	Capacity int `json:"capacity"`
+	// Booked is the number of pickups booked in the slot.
+	Booked int `json:"booked"`
+}
+
+// NewSlot returns a new time slot from start to end, in which up to
+// capacity parcels are picked up in the given city on planet.
+func NewSlot(city, planet string, start, end time.Time, capacity int) (*Slot, error) {
+	city, planet = strings.TrimSpace(city), strings.TrimSpace(planet)
+	if city == "" || planet == "" {
+		return nil, ErrCityEmpty
+	} else if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	} else if !end.After(start) {
+		return nil, ErrInvalidSlotTime
+	} else if !start.After(time.Now()) {
+		return nil, ErrSlotInPast
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Slot{ID: id, City: city, Planet: planet, Start: start, End: end, Capacity: capacity}, nil
+}
+
+// Free returns the number of pickups, which can still be booked in s.
+func (s *Slot) Free() int {
+	if s.Booked >= s.Capacity {
+		return 0
+	}
+
+	return s.Capacity - s.Booked
+}
+
+// Serves reports whether couriers pick up parcels at a in s.
+func (s *Slot) Serves(a *address.Address) bool {
+	return a != nil && strings.EqualFold(strings.TrimSpace(a.City), s.City) &&
+		strings.EqualFold(strings.TrimSpace(a.Planet), s.Planet)
+}
+
+// Status is the status of a pickup.
+type Status string
+
+const (
+	Booked    Status = "booked"
+	Cancelled Status = "cancelled"
+	Collected Status = "collected"
+)
+
+// Pickup is the booked collection of a parcel at its return address.
+type Pickup struct {
+	ID       uuid.UUID `json:"id"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	Slot     *Slot     `json:"slot"`
+	Status   Status    `json:"status"`
+	BookedAt time.Time `json:"bookedAt"`
+}
+
+// New returns a new pickup of p in the time slot s.
+func New(p *parcel.Parcel, s *Slot) (*Pickup, error) {
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Pickup{ID: id, ParcelID: p.ID, Slot: s, Status: Booked, BookedAt: time.Now().Local()}, nil
+}
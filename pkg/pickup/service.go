@@ -0,0 +1,215 @@
+package pickup
+
+import (
+	"errors"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// Service books and cancels pickups on behalf of senders and records the
+// collection of parcels confirmed by couriers.
+type Service struct {
+	Pickups   Storage
+	Addresses address.Accesser
+	Parcels   parcel.Accesser
+	// Events must publish the recorded events, so that customers are
+	// notified about the collection of their parcels.
+	Events parcel.EventStorage
+}
+
+// AddSlot adds a new time slot, in which up to capacity parcels are picked
+// up in the given city on planet.
+func (s *Service) AddSlot(city, planet string, start, end time.Time, capacity int) (*Slot, error) {
+	sl, err := NewSlot(city, planet, start, end, capacity)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Pickups.InsertSlot(sl)
+	if err != nil {
+		return nil, err
+	}
+
+	return sl, nil
+}
+
+// Available returns the future time slots in the given city on planet,
+// which can still be booked.
+func (s *Service) Available(city, planet string) ([]*Slot, error) {
+	ss, err := s.Pickups.Slots(city, planet, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	free := make([]*Slot, 0, len(ss))
+	for _, sl := range ss {
+		if sl.Free() > 0 {
+			free = append(free, sl)
+		}
+	}
+
+	return free, nil
+}
+
+// AvailableFor returns the time slots, which can be booked for the pickup
+// of p.
+func (s *Service) AvailableFor(p *parcel.Parcel) ([]*Slot, error) {
+	if p.ReturnAddress == nil {
+		return []*Slot{}, nil
+	}
+
+	return s.Available(p.ReturnAddress.City, p.ReturnAddress.Planet)
+}
+
+// Book books the pickup of p at its return address in the time slot sl on
+// behalf of u, who must be p's sender. The parcel must not have been
+// handed over to us yet.
+func (s *Service) Book(u *user.User, p *parcel.Parcel, sl *Slot) (*Pickup, error) {
+	err := s.checkSender(u, p)
+	if err != nil {
+		return nil, err
+	}
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, err
+	} else if !awaitingPickup(parcel.NewState(p, ee)) {
+		return nil, ErrNotAwaitingPickup
+	}
+	if !sl.Serves(p.ReturnAddress) {
+		return nil, ErrWrongCity
+	} else if !sl.Start.After(time.Now()) {
+		return nil, ErrSlotInPast
+	} else if sl.Free() <= 0 {
+		return nil, ErrSlotFull
+	}
+
+	pk, err := New(p, sl)
+	if err != nil {
+		return nil, err
+	}
+	err = s.Pickups.Book(pk)
+	if err != nil {
+		return nil, err
+	}
+	sl.Booked++
+
+	return pk, nil
+}
+
+// Cancel cancels the booked pickup of p on behalf of u, who must be p's
+// sender, until its time slot starts.
+func (s *Service) Cancel(u *user.User, p *parcel.Parcel) (*Pickup, error) {
+	err := s.checkSender(u, p)
+	if err != nil {
+		return nil, err
+	}
+	pk, err := s.Pickups.ByParcel(p.ID)
+	if err != nil {
+		return nil, err
+	} else if pk == nil {
+		return nil, ErrNotBooked
+	} else if !pk.Slot.Start.After(time.Now()) {
+		return nil, ErrCancellationClosed
+	}
+	err = s.Pickups.SetStatus(pk, Cancelled)
+	if err != nil {
+		return nil, err
+	}
+	pk.Status = Cancelled
+
+	return pk, nil
+}
+
+// Confirm records that a courier has collected the parcel of pk at time
+// at, or just now, if at is the zero time. The PickedUpByCourier event is
+// recorded for the parcel.
+func (s *Service) Confirm(pk *Pickup, at time.Time) (*parcel.Event, error) {
+	if pk.Status != Booked {
+		return nil, ErrNotBooked
+	}
+	p, err := s.Parcels.ByID(pk.ParcelID)
+	if err != nil {
+		return nil, err
+	} else if p == nil {
+		return nil, errors.New("pickup: the parcel does not exist")
+	}
+	e, err := parcel.Record(s.Events, p, parcel.PickedUpByCourier, at)
+	if err == parcel.ErrInvalidTransition {
+		return nil, ErrNotAwaitingPickup
+	} else if err != nil {
+		return nil, err
+	}
+	err = s.Pickups.SetStatus(pk, Collected)
+	if err != nil {
+		return nil, err
+	}
+	pk.Status = Collected
+
+	return e, nil
+}
+
+// Option is a parcel sent by a user, which has not been handed over to us
+// yet.
+type Option struct {
+	Parcel *parcel.Parcel
+	// Pickup is the parcel's booked pickup or nil, if none is booked.
+	Pickup *Pickup
+	// Slots are the time slots, which can be booked for the parcel, if no
+	// pickup is booked.
+	Slots []*Slot
+}
+
+// Options returns the parcels sent by u, which may still be picked up,
+// together with their booked pickups or the time slots available to them.
+func (s *Service) Options(u *user.User) ([]*Option, error) {
+	ss, err := parcel.Outgoing(u, s.Addresses, s.Parcels, s.Events)
+	if err != nil {
+		return nil, err
+	}
+	oo := make([]*Option, 0, len(ss))
+	for _, sum := range ss {
+		ee, err := s.Events.ByParcel(sum.Parcel)
+		if err != nil {
+			return nil, err
+		} else if !awaitingPickup(parcel.NewState(sum.Parcel, ee)) {
+			continue
+		}
+		o := &Option{Parcel: sum.Parcel}
+		o.Pickup, err = s.Pickups.ByParcel(sum.ID)
+		if err != nil {
+			return nil, err
+		}
+		if o.Pickup == nil {
+			o.Slots, err = s.AvailableFor(sum.Parcel)
+			if err != nil {
+				return nil, err
+			}
+		}
+		oo = append(oo, o)
+	}
+
+	return oo, nil
+}
+
+func (s *Service) checkSender(u *user.User, p *parcel.Parcel) error {
+	ok, err := p.SentBy(u, s.Addresses)
+	if err != nil {
+		return err
+	} else if !ok {
+		return ErrNotSender
+	}
+
+	return nil
+}
+
+// awaitingPickup reports whether the parcel in state st may be picked up.
+func awaitingPickup(st *parcel.State) bool {
+	for _, t := range st.Next() {
+		if t == parcel.PickedUpByCourier {
+			return true
+		}
+	}
+
+	return false
+}
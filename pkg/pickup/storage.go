@@ -0,0 +1,34 @@
+package pickup
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Storage is the interface for managing time slots and pickups.
+//
+// SlotByID returns nil, if there is no time slot identified by id.
+//
+// Slots returns the time slots in the given city on planet, which start
+// after time from, ordered by their start. Empty names match all cities
+// and planets and are matched ignoring case.
+//
+// Book inserts p, unless its time slot is fully booked, in which case it
+// returns ErrSlotFull, or the parcel's pickup has already been booked, in
+// which case it returns ErrAlreadyBooked.
+//
+// ByID returns nil, if there is no pickup identified by id.
+//
+// ByParcel returns the booked pickup of the parcel identified by id or nil.
+//
+// SetStatus updates the status of p.
+type Storage interface {
+	InsertSlot(s *Slot) error
+	SlotByID(id uuid.UUID) (*Slot, error)
+	Slots(city, planet string, from time.Time) ([]*Slot, error)
+	Book(p *Pickup) error
+	ByID(id uuid.UUID) (*Pickup, error)
+	ByParcel(id uuid.UUID) (*Pickup, error)
+	SetStatus(p *Pickup, s Status) error
+}
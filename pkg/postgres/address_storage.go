@@ -133,3 +133,36 @@ func (s *AddressStorage) Close() error {
 
 	return s.update.Close()
 }
+
+// nullableAddress is used for scanning addresses selected by outer joins,
+// whose columns are all NULL if there is no such address.
+type nullableAddress struct {
+	id      *uuid.UUID
+	street  sql.NullString
+	zip     sql.NullString
+	city    sql.NullString
+	country sql.NullString
+	planet  sql.NullString
+}
+
+// dest returns the scan destinations for the columns id, street, zip,
+// city, country and planet.
+func (na *nullableAddress) dest() []interface{} {
+	return []interface{}{&na.id, &na.street, &na.zip, &na.city, &na.country, &na.planet}
+}
+
+// address returns the scanned address or nil, if its columns were NULL.
+func (na *nullableAddress) address() *address.Address {
+	if na.id == nil {
+		return nil
+	}
+
+	return &address.Address{
+		ID:      *na.id,
+		Street:  na.street.String,
+		Zip:     na.zip.String,
+		City:    na.city.String,
+		Country: na.country.String,
+		Planet:  na.planet.String,
+	}
+}
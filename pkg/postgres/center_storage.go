@@ -0,0 +1,255 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	installCenterTables = `CREATE TABLE IF NOT EXISTS ipps_center (
+		id     uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+		name   text NOT NULL,
+		city   text NOT NULL DEFAULT '',
+		planet text NOT NULL
+	);
+	CREATE TABLE IF NOT EXISTS ipps_sorting_bin (
+		id     uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+		center uuid NOT NULL CONSTRAINT ipps_sorting_bin_center_fkey
+			REFERENCES ipps_center (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		label  text NOT NULL,
+		planet text NOT NULL,
+		city   text NOT NULL DEFAULT '',
+		CONSTRAINT ipps_sorting_bin_label_key UNIQUE (center, label)
+	);
+	CREATE TABLE IF NOT EXISTS ipps_scan (
+		id        uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		center    uuid        NOT NULL CONSTRAINT ipps_scan_center_fkey
+			REFERENCES ipps_center (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel    uuid        NOT NULL CONSTRAINT ipps_scan_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		bin       uuid        NOT NULL CONSTRAINT ipps_scan_bin_fkey
+			REFERENCES ipps_sorting_bin (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		event     uuid        CONSTRAINT ipps_scan_event_fkey
+			REFERENCES ipps_parcel_event (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		scan_time timestamptz NOT NULL
+	);
+	CREATE INDEX IF NOT EXISTS ipps_scan_parcel_idx ON ipps_scan (parcel, scan_time);`
+	insertCenterStmt = `INSERT INTO ipps_center (id, name, city, planet) VALUES ($1, $2, $3, $4);`
+	centerByIDStmt   = `SELECT id, name, city, planet FROM ipps_center WHERE id = $1;`
+	centersStmt      = `SELECT id, name, city, planet FROM ipps_center ORDER BY planet, name;`
+	insertBinStmt    = `INSERT INTO ipps_sorting_bin (id, center, label, planet, city)
+		VALUES ($1, $2, $3, $4, $5);`
+	binsStmt = `SELECT id, center, label, planet, city FROM ipps_sorting_bin
+		WHERE center = $1
+		ORDER BY label;`
+	insertScanStmt = `INSERT INTO ipps_scan (id, center, parcel, bin, event, scan_time)
+		VALUES ($1, $2, $3, $4, $5, $6);`
+	selectScan = `SELECT s.id, s.center, s.parcel, s.scan_time, b.id, b.center, b.label, b.planet, b.city
+		FROM ipps_scan s
+		JOIN ipps_sorting_bin b ON b.id = s.bin`
+	lastScanStmt = selectScan + `
+		WHERE s.parcel = $1
+		ORDER BY s.scan_time DESC
+		LIMIT 1;`
+	// centerParcelsStmt selects the latest scans at the center, after which
+	// the parcel has neither been scanned again nor moved on. $2 are the
+	// event types, which do not move the parcel out of the center.
+	centerParcelsStmt = selectScan + `
+		WHERE s.center = $1
+			AND NOT EXISTS (SELECT 1 FROM ipps_scan l
+				WHERE l.parcel = s.parcel AND l.scan_time > s.scan_time)
+			AND NOT EXISTS (SELECT 1 FROM ipps_parcel_event e
+				WHERE e.parcel = s.parcel AND e.event_time > s.scan_time
+					AND e.event_type <> ALL ($2::integer[]))
+		ORDER BY s.scan_time;`
+)
+
+// stationaryEvents are the event types, which may occur while a parcel is
+// being processed at a logistics center.
+var stationaryEvents = []parcel.EventType{parcel.HeldAtCustoms, parcel.ReleasedFromCustoms,
+	parcel.ReturnInitiated, parcel.Redirected, parcel.HoldRequested, parcel.DeliveryRescheduled}
+
+// CenterStorage is the type implementing the center.Storage interface.
+type CenterStorage struct {
+	insertCenter *sql.Stmt
+	centerByID   *sql.Stmt
+	centers      *sql.Stmt
+	insertBin    *sql.Stmt
+	bins         *sql.Stmt
+	insertScan   *sql.Stmt
+	lastScan     *sql.Stmt
+	parcels      *sql.Stmt
+}
+
+func NewCenterStorage(db *sql.DB) (*CenterStorage, error) {
+	s := &CenterStorage{}
+	var err error
+
+	s.insertCenter, err = db.Prepare(insertCenterStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.centerByID, err = db.Prepare(centerByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.centers, err = db.Prepare(centersStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertBin, err = db.Prepare(insertBinStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.bins, err = db.Prepare(binsStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertScan, err = db.Prepare(insertScanStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.lastScan, err = db.Prepare(lastScanStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.parcels, err = db.Prepare(centerParcelsStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *CenterStorage) InsertCenter(c *center.Center) error {
+	_, err := s.insertCenter.Exec(c.ID, c.Name, c.City, c.Planet)
+
+	return err
+}
+
+func (s *CenterStorage) CenterByID(id uuid.UUID) (*center.Center, error) {
+	c := &center.Center{}
+	err := s.centerByID.QueryRow(id).Scan(&c.ID, &c.Name, &c.City, &c.Planet)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *CenterStorage) Centers() ([]*center.Center, error) {
+	rows, err := s.centers.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cc := make([]*center.Center, 0)
+	for rows.Next() {
+		c := &center.Center{}
+		err := rows.Scan(&c.ID, &c.Name, &c.City, &c.Planet)
+		if err != nil {
+			return nil, err
+		}
+		cc = append(cc, c)
+	}
+
+	return cc, rows.Err()
+}
+
+func (s *CenterStorage) InsertBin(b *center.Bin) error {
+	_, err := s.insertBin.Exec(b.ID, b.Center, b.Label, b.Planet, b.City)
+	pgErr, ok := err.(*pq.Error)
+	if ok && pgErr.Constraint == "ipps_sorting_bin_label_key" {
+		return center.ErrLabelTaken
+	}
+
+	return err
+}
+
+func (s *CenterStorage) Bins(id uuid.UUID) ([]*center.Bin, error) {
+	rows, err := s.bins.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	bb := make([]*center.Bin, 0)
+	for rows.Next() {
+		b := &center.Bin{}
+		err := rows.Scan(&b.ID, &b.Center, &b.Label, &b.Planet, &b.City)
+		if err != nil {
+			return nil, err
+		}
+		bb = append(bb, b)
+	}
+
+	return bb, rows.Err()
+}
+
+func (s *CenterStorage) InsertScan(sc *center.Scan) error {
+	var event *uuid.UUID
+	if sc.Event != nil {
+		event = &sc.Event.ID
+	}
+	_, err := s.insertScan.Exec(sc.ID, sc.Center, sc.ParcelID, sc.Bin.ID, event, sc.Time)
+
+	return err
+}
+
+func (s *CenterStorage) LastScan(id uuid.UUID) (*center.Scan, error) {
+	sc, err := scanScan(s.lastScan.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return sc, err
+}
+
+func (s *CenterStorage) Parcels(id uuid.UUID) ([]*center.Scan, error) {
+	rows, err := s.parcels.Query(id, pq.Array(eventTypeInts(stationaryEvents)))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ss := make([]*center.Scan, 0)
+	for rows.Next() {
+		sc, err := scanScan(rows)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, sc)
+	}
+
+	return ss, rows.Err()
+}
+
+func (s *CenterStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insertCenter, s.centerByID, s.centers, s.insertBin, s.bins,
+		s.insertScan, s.lastScan, s.parcels} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanScan(row rowScanner) (*center.Scan, error) {
+	sc := &center.Scan{Bin: &center.Bin{}}
+	err := row.Scan(&sc.ID, &sc.Center, &sc.ParcelID, &sc.Time,
+		&sc.Bin.ID, &sc.Bin.Center, &sc.Bin.Label, &sc.Bin.Planet, &sc.Bin.City)
+	if err != nil {
+		return nil, err
+	}
+
+	return sc, nil
+}
@@ -0,0 +1,303 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/claim"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	installClaimTables = `CREATE TABLE IF NOT EXISTS ipps_claim (
+		id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		parcel      uuid        NOT NULL CONSTRAINT ipps_claim_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		claimant    uuid        NOT NULL CONSTRAINT ipps_claim_claimant_fkey
+			REFERENCES ipps_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		kind        text        NOT NULL,
+		description text        NOT NULL,
+		amount      bigint      NOT NULL,
+		card        uuid        CONSTRAINT ipps_claim_card_fkey
+			REFERENCES ipps_card (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		status      text        NOT NULL DEFAULT 'submitted',
+		created     timestamptz NOT NULL DEFAULT now(),
+		reviewer    uuid        CONSTRAINT ipps_claim_reviewer_fkey
+			REFERENCES ipps_user (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		note        text        NOT NULL DEFAULT '',
+		decided     timestamptz
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_claim_parcel_key ON ipps_claim (parcel)
+		WHERE status <> 'rejected';
+	CREATE INDEX IF NOT EXISTS ipps_claim_claimant_idx ON ipps_claim (claimant, created);
+	CREATE INDEX IF NOT EXISTS ipps_claim_status_idx ON ipps_claim (status, created);
+	CREATE TABLE IF NOT EXISTS ipps_claim_evidence (
+		id           uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		claim        uuid        NOT NULL CONSTRAINT ipps_claim_evidence_claim_fkey
+			REFERENCES ipps_claim (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		name         text        NOT NULL,
+		content_type text        NOT NULL,
+		key          text        NOT NULL,
+		uploaded     timestamptz NOT NULL DEFAULT now()
+	);
+	CREATE TABLE IF NOT EXISTS ipps_refund (
+		id      uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		claim   uuid        NOT NULL CONSTRAINT ipps_refund_claim_fkey
+			REFERENCES ipps_claim (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		card    uuid        CONSTRAINT ipps_refund_card_fkey
+			REFERENCES ipps_card (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		amount  bigint      NOT NULL,
+		created timestamptz NOT NULL DEFAULT now(),
+		CONSTRAINT ipps_refund_claim_key UNIQUE (claim)
+	);`
+	insertClaimStmt = `INSERT INTO ipps_claim (id, parcel, claimant, kind, description, amount, card, status, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9);`
+	insertClaimEvidenceStmt = `INSERT INTO ipps_claim_evidence (id, claim, name, content_type, key, uploaded)
+		VALUES ($1, $2, $3, $4, $5, $6);`
+	decideClaimStmt = `UPDATE ipps_claim
+		SET status = $2, reviewer = $3, note = $4, decided = $5
+		WHERE id = $1 AND status = 'submitted';`
+	insertRefundStmt = `INSERT INTO ipps_refund (id, claim, card, amount, created)
+		VALUES ($1, $2, $3, $4, $5);`
+	selectClaim = `SELECT c.id, c.parcel, c.claimant, c.kind, c.description, c.amount, c.card, c.status,
+			c.created, c.reviewer, c.note, c.decided, r.id, r.card, r.amount, r.created
+		FROM ipps_claim c
+		LEFT JOIN ipps_refund r ON r.claim = c.id`
+	claimByIDStmt = selectClaim + `
+		WHERE c.id = $1;`
+	claimsByClaimantStmt = selectClaim + `
+		WHERE c.claimant = $1
+		ORDER BY c.created DESC;`
+	claimsByStatusStmt = selectClaim + `
+		WHERE c.status = $1
+		ORDER BY c.created;`
+	evidenceByClaimStmt = `SELECT id, claim, name, content_type, key, uploaded
+		FROM ipps_claim_evidence
+		WHERE claim = $1
+		ORDER BY uploaded;`
+)
+
+// ClaimStorage is the type implementing the claim.Storage interface.
+type ClaimStorage struct {
+	db             *sql.DB
+	insert         *sql.Stmt
+	insertEvidence *sql.Stmt
+	decide         *sql.Stmt
+	insertRefund   *sql.Stmt
+	byID           *sql.Stmt
+	byClaimant     *sql.Stmt
+	byStatus       *sql.Stmt
+	evidence       *sql.Stmt
+}
+
+func NewClaimStorage(db *sql.DB) (*ClaimStorage, error) {
+	s := &ClaimStorage{db: db}
+	var err error
+
+	s.insert, err = db.Prepare(insertClaimStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertEvidence, err = db.Prepare(insertClaimEvidenceStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.decide, err = db.Prepare(decideClaimStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertRefund, err = db.Prepare(insertRefundStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(claimByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byClaimant, err = db.Prepare(claimsByClaimantStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byStatus, err = db.Prepare(claimsByStatusStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.evidence, err = db.Prepare(evidenceByClaimStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *ClaimStorage) Insert(c *claim.Claim) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(s.insert).Exec(c.ID, c.ParcelID, c.Claimant, c.Kind, c.Description, c.Amount, c.CardID,
+		c.Status, c.Created)
+	if pgErr, ok := err.(*pq.Error); ok && pgErr.Constraint == "ipps_claim_parcel_key" {
+		tx.Rollback()
+		return claim.ErrAlreadyClaimed
+	} else if err != nil {
+		tx.Rollback()
+		return err
+	}
+	insertEvidence := tx.Stmt(s.insertEvidence)
+	for _, e := range c.Evidence {
+		_, err = insertEvidence.Exec(e.ID, e.ClaimID, e.Name, e.ContentType, e.Key, e.Uploaded)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *ClaimStorage) InsertEvidence(e *claim.Evidence) error {
+	_, err := s.insertEvidence.Exec(e.ID, e.ClaimID, e.Name, e.ContentType, e.Key, e.Uploaded)
+	return err
+}
+
+func (s *ClaimStorage) Decide(c *claim.Claim) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	res, err := tx.Stmt(s.decide).Exec(c.ID, c.Status, c.Reviewer, c.Note, c.Decided)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	} else if n == 0 {
+		tx.Rollback()
+		return claim.ErrAlreadyDecided
+	}
+	if r := c.Refund; r != nil {
+		_, err = tx.Stmt(s.insertRefund).Exec(r.ID, r.ClaimID, r.CardID, r.Amount, r.Created)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *ClaimStorage) ByID(id uuid.UUID) (*claim.Claim, error) {
+	c, err := scanClaim(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	c.Evidence, err = s.loadEvidence(c.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return c, nil
+}
+
+func (s *ClaimStorage) ByClaimant(id uuid.UUID) ([]*claim.Claim, error) {
+	return s.query(s.byClaimant, id)
+}
+
+func (s *ClaimStorage) ByStatus(st claim.Status) ([]*claim.Claim, error) {
+	return s.query(s.byStatus, st)
+}
+
+// query returns the claims selected by stmt together with their evidence.
+func (s *ClaimStorage) query(stmt *sql.Stmt, args ...interface{}) ([]*claim.Claim, error) {
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cc := make([]*claim.Claim, 0)
+	for rows.Next() {
+		c, err := scanClaim(rows)
+		if err != nil {
+			return nil, err
+		}
+		cc = append(cc, c)
+	}
+	err = rows.Err()
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cc {
+		c.Evidence, err = s.loadEvidence(c.ID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return cc, nil
+}
+
+func (s *ClaimStorage) loadEvidence(id uuid.UUID) ([]*claim.Evidence, error) {
+	rows, err := s.evidence.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ee := make([]*claim.Evidence, 0)
+	for rows.Next() {
+		e := &claim.Evidence{}
+		err := rows.Scan(&e.ID, &e.ClaimID, &e.Name, &e.ContentType, &e.Key, &e.Uploaded)
+		if err != nil {
+			return nil, err
+		}
+		ee = append(ee, e)
+	}
+
+	return ee, rows.Err()
+}
+
+func (s *ClaimStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insert, s.insertEvidence, s.decide, s.insertRefund, s.byID, s.byClaimant,
+		s.byStatus, s.evidence} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanClaim(row rowScanner) (*claim.Claim, error) {
+	c := &claim.Claim{}
+	var (
+		refundID      *uuid.UUID
+		refundCard    *uuid.UUID
+		refundAmount  sql.NullInt64
+		refundCreated pq.NullTime
+	)
+	err := row.Scan(&c.ID, &c.ParcelID, &c.Claimant, &c.Kind, &c.Description, &c.Amount, &c.CardID, &c.Status,
+		&c.Created, &c.Reviewer, &c.Note, &c.Decided, &refundID, &refundCard, &refundAmount, &refundCreated)
+	if err != nil {
+		return nil, err
+	}
+	if refundID != nil {
+		c.Refund = &claim.Refund{
+			ID:      *refundID,
+			ClaimID: c.ID,
+			CardID:  refundCard,
+			Amount:  parcel.Cents(refundAmount.Int64),
+			Created: refundCreated.Time,
+		}
+	}
+
+	return c, nil
+}
@@ -0,0 +1,175 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/consignment"
+)
+
+const (
+	installConsignmentTables = `CREATE TABLE IF NOT EXISTS ipps_consignment (
+		id        uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		sender    uuid        NOT NULL CONSTRAINT ipps_consignment_sender_fkey
+			REFERENCES ipps_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		reference text        NOT NULL DEFAULT '',
+		created   timestamptz NOT NULL DEFAULT now()
+	);
+	CREATE TABLE IF NOT EXISTS ipps_consignment_parcel (
+		consignment uuid    NOT NULL CONSTRAINT ipps_consignment_parcel_consignment_fkey
+			REFERENCES ipps_consignment (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel      uuid    NOT NULL CONSTRAINT ipps_consignment_parcel_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		position    integer NOT NULL,
+		CONSTRAINT ipps_consignment_parcel_key UNIQUE (parcel)
+	);
+	CREATE INDEX IF NOT EXISTS ipps_consignment_sender_idx ON ipps_consignment (sender, created);`
+	insertConsignmentStmt = `INSERT INTO ipps_consignment (id, sender, reference, created)
+		VALUES ($1, $2, $3, $4);`
+	insertConsignmentParcelStmt = `INSERT INTO ipps_consignment_parcel (consignment, parcel, position)
+		VALUES ($1, $2, $3);`
+	selectConsignment = `SELECT c.id, c.sender, c.reference, c.created,
+			array(SELECT m.parcel FROM ipps_consignment_parcel m
+				WHERE m.consignment = c.id ORDER BY m.position)
+		FROM ipps_consignment c`
+	consignmentByIDStmt = selectConsignment + `
+		WHERE c.id = $1;`
+	consignmentsBySenderStmt = selectConsignment + `
+		WHERE c.sender = $1
+		ORDER BY c.created DESC;`
+	consignmentByParcelStmt = selectConsignment + `
+		JOIN ipps_consignment_parcel p ON p.consignment = c.id
+		WHERE p.parcel = $1;`
+)
+
+// ConsignmentStorage is the type implementing the consignment.Storage
+// interface.
+type ConsignmentStorage struct {
+	db           *sql.DB
+	insert       *sql.Stmt
+	insertParcel *sql.Stmt
+	byID         *sql.Stmt
+	bySender     *sql.Stmt
+	byParcel     *sql.Stmt
+}
+
+func NewConsignmentStorage(db *sql.DB) (*ConsignmentStorage, error) {
+	s := &ConsignmentStorage{db: db}
+	var err error
+
+	s.insert, err = db.Prepare(insertConsignmentStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertParcel, err = db.Prepare(insertConsignmentParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(consignmentByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.bySender, err = db.Prepare(consignmentsBySenderStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byParcel, err = db.Prepare(consignmentByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *ConsignmentStorage) Insert(c *consignment.Consignment) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(s.insert).Exec(c.ID, c.Sender, c.Reference, c.Created)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	insertParcel := tx.Stmt(s.insertParcel)
+	for i, id := range c.ParcelIDs {
+		_, err = insertParcel.Exec(c.ID, id, i)
+		if pgErr, ok := err.(*pq.Error); ok && pgErr.Constraint == "ipps_consignment_parcel_key" {
+			tx.Rollback()
+			return consignment.ErrAlreadyConsigned
+		} else if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *ConsignmentStorage) ByID(id uuid.UUID) (*consignment.Consignment, error) {
+	c, err := scanConsignment(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return c, err
+}
+
+func (s *ConsignmentStorage) BySender(id uuid.UUID) ([]*consignment.Consignment, error) {
+	rows, err := s.bySender.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	cc := make([]*consignment.Consignment, 0)
+	for rows.Next() {
+		c, err := scanConsignment(rows)
+		if err != nil {
+			return nil, err
+		}
+		cc = append(cc, c)
+	}
+
+	return cc, rows.Err()
+}
+
+func (s *ConsignmentStorage) ByParcel(id uuid.UUID) (*consignment.Consignment, error) {
+	c, err := scanConsignment(s.byParcel.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return c, err
+}
+
+func (s *ConsignmentStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insert, s.insertParcel, s.byID, s.bySender, s.byParcel} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanConsignment(row rowScanner) (*consignment.Consignment, error) {
+	c := &consignment.Consignment{}
+	var ids []string
+	err := row.Scan(&c.ID, &c.Sender, &c.Reference, &c.Created, pq.Array(&ids))
+	if err != nil {
+		return nil, err
+	}
+	c.ParcelIDs = make([]uuid.UUID, 0, len(ids))
+	for _, s := range ids {
+		id, err := uuid.Parse(s)
+		if err != nil {
+			return nil, err
+		}
+		c.ParcelIDs = append(c.ParcelIDs, id)
+	}
+
+	return c, nil
+}
@@ -0,0 +1,117 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+const (
+	parcelEventChannel = "ipps_parcel_event"
+	notifyEventStmt    = `SELECT pg_notify('` + parcelEventChannel + `', $1);`
+)
+
+// eventNotification is the payload of the notifications sent on the
+// parcel event channel.
+type eventNotification struct {
+	ID     uuid.UUID        `json:"id"`
+	Parcel uuid.UUID        `json:"parcel"`
+	Type   parcel.EventType `json:"type"`
+	Time   time.Time        `json:"time"`
+	// Reason is needed by the auto returner to return refused parcels.
+	Reason       parcel.FailureReason `json:"reason,omitempty"`
+	DeliveryDate *time.Time           `json:"deliveryDate,omitempty"`
+}
+
+// EventNotifier is an implementation of the parcel.Publisher interface,
+// which distributes events to all ipps instances using the same database,
+// using PostgreSQL's NOTIFY and LISTEN commands.
+type EventNotifier struct {
+	notify   *sql.Stmt
+	listener *pq.Listener
+	local    parcel.Publisher
+}
+
+// NewEventNotifier returns a new EventNotifier, which publishes the events
+// it receives from the database to local.
+func NewEventNotifier(db *sql.DB, conf *Config, local parcel.Publisher) (*EventNotifier, error) {
+	n := &EventNotifier{local: local}
+	var err error
+	n.notify, err = db.Prepare(notifyEventStmt)
+	if err != nil {
+		return nil, err
+	}
+	n.listener = pq.NewListener(connString(conf), time.Second, time.Minute,
+		func(ev pq.ListenerEventType, err error) {
+			if err != nil {
+				log.Printf("postgres: event listener: %v\n", err)
+			}
+		})
+	err = n.listener.Listen(parcelEventChannel)
+	if err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// Publish notifies all listening ipps instances about e.
+func (n *EventNotifier) Publish(e *parcel.Event) error {
+	b, err := json.Marshal(&eventNotification{
+		ID:           e.ID,
+		Parcel:       e.Parcel.ID,
+		Type:         e.Type,
+		Time:         e.Time,
+		Reason:       e.Reason,
+		DeliveryDate: e.DeliveryDate,
+	})
+	if err != nil {
+		return err
+	}
+	_, err = n.notify.Exec(string(b))
+
+	return err
+}
+
+// Listen publishes the events received from the database to the local
+// publisher, until the notifier is closed.
+func (n *EventNotifier) Listen() {
+	for notification := range n.listener.Notify {
+		if notification == nil {
+			// The connection has been re-established, notifications
+			// sent in the meantime are lost.
+			continue
+		}
+		en := &eventNotification{}
+		err := json.Unmarshal([]byte(notification.Extra), en)
+		if err != nil {
+			log.Printf("postgres: invalid event notification: %v\n", err)
+			continue
+		}
+		err = n.local.Publish(&parcel.Event{
+			ID:           en.ID,
+			Parcel:       &parcel.Parcel{ID: en.Parcel},
+			Type:         en.Type,
+			Time:         en.Time.Local(),
+			Reason:       en.Reason,
+			DeliveryDate: en.DeliveryDate,
+		})
+		if err != nil {
+			log.Println(err)
+		}
+	}
+}
+
+func (n *EventNotifier) Close() error {
+	err := n.listener.Close()
+	if err != nil {
+		return err
+	}
+
+	return n.notify.Close()
+}
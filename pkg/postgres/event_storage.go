@@ -2,6 +2,7 @@ package postgres
 
 import (
 	"database/sql"
+	"time"
 
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
 )
@@ -14,26 +15,42 @@ const (
 		parcel     uuid 	   NOT NULL CONSTRAINT ipps_parcel_event_parcel_fkey
                                REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE
 	);`
-	insertParcelEventStmt = `INSERT INTO ipps_parcel_event (id, event_type, event_time, parcel)
-                             VALUES ($1, $2, $3, $4);`
-	parcelEventByParcelStmt = `SELECT (id, event_type, event_time)
+	// seq numbers the events in the order of their insertion, which breaks
+	// ties between events of a parcel, which occurred at the same time.
+	migrateParcelEventTable = `ALTER TABLE ipps_parcel_event
+		ADD COLUMN IF NOT EXISTS failure_reason integer NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS delivery_date  date,
+		ADD COLUMN IF NOT EXISTS seq            bigserial;`
+	insertParcelEventStmt = `INSERT INTO ipps_parcel_event (id, event_type, event_time, parcel, failure_reason,
+                                                         delivery_date)
+                             VALUES ($1, $2, $3, $4, $5, $6);`
+	parcelEventByParcelStmt = `SELECT id, event_type, event_time, failure_reason, delivery_date
                                FROM ipps_parcel_event
                                WHERE parcel = $1
-                               ORDER BY event_time ASC;`
+                               ORDER BY event_time ASC, seq ASC;`
+	// lockParcelStmt locks the row of a parcel until the end of the
+	// transaction, so that its events are inserted one at a time.
+	lockParcelStmt = `SELECT id FROM ipps_parcel WHERE id = $1 FOR UPDATE;`
 )
 
 type EventStorage struct {
-	insert   *sql.Stmt
-	byParcel *sql.Stmt
+	db         *sql.DB
+	insert     *sql.Stmt
+	lockParcel *sql.Stmt
+	byParcel   *sql.Stmt
 }
 
 func NewEventStorage(db *sql.DB) (*EventStorage, error) {
-	s := &EventStorage{}
+	s := &EventStorage{db: db}
 	var err error
 	s.insert, err = db.Prepare(insertParcelEventStmt)
 	if err != nil {
 		return nil, err
 	}
+	s.lockParcel, err = db.Prepare(lockParcelStmt)
+	if err != nil {
+		return nil, err
+	}
 	s.byParcel, err = db.Prepare(parcelEventByParcelStmt)
 	if err != nil {
 		return nil, err
@@ -42,31 +59,78 @@ func NewEventStorage(db *sql.DB) (*EventStorage, error) {
 	return s, nil
 }
 
-func (es *EventStorage) Insert(e *parcel.Event) error {
-	_, err := es.insert.Exec(e.ID, e.Type, e.Time, e.Parcel.ID)
+// Insert locks the row of e's parcel, before it reads the parcel's events
+// for check and inserts e in the same transaction.
+func (es *EventStorage) Insert(e *parcel.Event, check func(ee []*parcel.Event) error) error {
+	tx, err := es.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(es.lockParcel).Exec(e.Parcel.ID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	ee, err := queryEvents(tx.Stmt(es.byParcel), e.Parcel)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	err = check(ee)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(es.insert).Exec(e.ID, e.Type, e.Time, e.Parcel.ID, e.Reason, deliveryDate(e))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
 
-	return err
+	return tx.Commit()
 }
 
 func (es *EventStorage) ByParcel(p *parcel.Parcel) ([]*parcel.Event, error) {
-	rows, err := es.byParcel.Query(p.ID)
+	return queryEvents(es.byParcel, p)
+}
+
+// queryEvents returns the events of p selected by stmt.
+func queryEvents(stmt *sql.Stmt, p *parcel.Parcel) ([]*parcel.Event, error) {
+	rows, err := stmt.Query(p.ID)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	var ee []*parcel.Event
 	for rows.Next() {
 		e := &parcel.Event{Parcel: p}
-		err := rows.Scan(&e.ID, &e.Type, &e.Time)
+		err := rows.Scan(&e.ID, &e.Type, &e.Time, &e.Reason, &e.DeliveryDate)
 		if err != nil {
 			return nil, err
 		}
+		if e.DeliveryDate != nil {
+			y, m, d := e.DeliveryDate.Date()
+			day := time.Date(y, m, d, 0, 0, 0, 0, time.Local)
+			e.DeliveryDate = &day
+		}
 		ee = append(ee, e)
 	}
 
-	return ee, nil
+	return ee, rows.Err()
+}
+
+// deliveryDate returns the value of the delivery_date column of e, which
+// is passed as a string, so that the day does not depend on the time zone
+// of the database session.
+func deliveryDate(e *parcel.Event) interface{} {
+	if e.DeliveryDate == nil {
+		return nil
+	}
+
+	return e.DeliveryDate.Format("2006-01-02")
 }
 
 func (es *EventStorage) Close() error {
@@ -74,6 +138,10 @@ func (es *EventStorage) Close() error {
 	if err != nil {
 		return err
 	}
+	err = es.lockParcel.Close()
+	if err != nil {
+		return err
+	}
 
 	return es.byParcel.Close()
 }
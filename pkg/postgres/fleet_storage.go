@@ -0,0 +1,416 @@
+package postgres
+
+import (
+	"database/sql"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/fleet"
+)
+
+const (
+	installRocketTable = `CREATE TABLE IF NOT EXISTS ipps_rocket (
+		id       uuid    PRIMARY KEY DEFAULT gen_random_uuid(),
+		name     text    NOT NULL UNIQUE,
+		capacity integer NOT NULL CHECK (capacity > 0)
+	);`
+	installLaunchTable = `CREATE TABLE IF NOT EXISTS ipps_launch (
+		id          uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		rocket      uuid        NOT NULL CONSTRAINT ipps_launch_rocket_fkey
+			REFERENCES ipps_rocket (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		origin      text        NOT NULL,
+		destination text        NOT NULL,
+		departure   timestamptz NOT NULL,
+		arrival     timestamptz NOT NULL,
+		status      text        NOT NULL DEFAULT 'scheduled'
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_launch_one_scheduled_per_rocket
+		ON ipps_launch (rocket) WHERE status = 'scheduled';
+	CREATE TABLE IF NOT EXISTS ipps_launch_parcel (
+		launch uuid NOT NULL CONSTRAINT ipps_launch_parcel_launch_fkey
+			REFERENCES ipps_launch (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel uuid NOT NULL CONSTRAINT ipps_launch_parcel_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		PRIMARY KEY (launch, parcel)
+	);
+	CREATE TABLE IF NOT EXISTS ipps_launch_waiting (
+		parcel      uuid        PRIMARY KEY CONSTRAINT ipps_launch_waiting_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		origin      text        NOT NULL,
+		destination text        NOT NULL,
+		since       timestamptz NOT NULL DEFAULT now()
+	);`
+	insertRocketStmt = `INSERT INTO ipps_rocket (id, name, capacity) VALUES ($1, $2, $3);`
+	rocketByIDStmt   = `SELECT id, name, capacity FROM ipps_rocket WHERE id = $1;`
+	allRocketsStmt   = `SELECT id, name, capacity FROM ipps_rocket ORDER BY name;`
+	insertLaunchStmt = `INSERT INTO ipps_launch (id, rocket, origin, destination, departure, arrival, status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7);`
+	selectLaunch = `SELECT l.id, l.origin, l.destination, l.departure, l.arrival, l.status,
+			(SELECT count(*) FROM ipps_launch_parcel lp WHERE lp.launch = l.id),
+			r.id, r.name, r.capacity
+		FROM ipps_launch l
+		JOIN ipps_rocket r ON l.rocket = r.id`
+	launchByIDStmt = selectLaunch + `
+		WHERE l.id = $1;`
+	scheduledLaunchesStmt = selectLaunch + `
+		WHERE l.status = 'scheduled' AND ($1 = '' OR l.origin = $1) AND ($2 = '' OR l.destination = $2)
+		ORDER BY l.departure;`
+	scheduledLaunchByParcelStmt = selectLaunch + `
+		JOIN ipps_launch_parcel p ON p.launch = l.id
+		WHERE l.status = 'scheduled' AND p.parcel = $1;`
+	// lockLaunchStmt locks the launch's row and selects its status, so that
+	// the parcels assigned to it are counted and inserted one at a time and
+	// no parcel is assigned to it while it departs.
+	lockLaunchStmt = `SELECT status FROM ipps_launch WHERE id = $1 FOR UPDATE;`
+	// assignLaunchStmt only assigns the parcel, if the launch is still
+	// scheduled and the rocket has capacity left. The launch must be locked
+	// by lockLaunchStmt first.
+	assignLaunchStmt = `INSERT INTO ipps_launch_parcel (launch, parcel)
+		SELECT l.id, $2
+		FROM ipps_launch l
+		JOIN ipps_rocket r ON l.rocket = r.id
+		WHERE l.id = $1 AND l.status = 'scheduled'
+		AND r.capacity > (SELECT count(*) FROM ipps_launch_parcel WHERE launch = $1);`
+	unwaitStmt         = `DELETE FROM ipps_launch_waiting WHERE parcel = $1;`
+	assignedParcelStmt = `SELECT parcel FROM ipps_launch_parcel WHERE launch = $1;`
+	departLaunchStmt   = `UPDATE ipps_launch SET status = 'departed' WHERE id = $1 AND status = 'scheduled';`
+	waitStmt           = `INSERT INTO ipps_launch_waiting (parcel, origin, destination)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (parcel) DO UPDATE SET (origin, destination) = ($2, $3);`
+	waitingStmt = `SELECT parcel FROM ipps_launch_waiting
+		WHERE origin = $1 AND destination = $2
+		ORDER BY since;`
+)
+
+// RocketStorage is the type implementing the fleet.RocketStorage interface.
+type RocketStorage struct {
+	insert *sql.Stmt
+	byID   *sql.Stmt
+	all    *sql.Stmt
+}
+
+func NewRocketStorage(db *sql.DB) (*RocketStorage, error) {
+	s := &RocketStorage{}
+	var err error
+
+	s.insert, err = db.Prepare(insertRocketStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(rocketByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.all, err = db.Prepare(allRocketsStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *RocketStorage) Insert(r *fleet.Rocket) error {
+	_, err := s.insert.Exec(r.ID, r.Name, r.Capacity)
+
+	return err
+}
+
+func (s *RocketStorage) ByID(id uuid.UUID) (*fleet.Rocket, error) {
+	r := &fleet.Rocket{}
+	err := s.byID.QueryRow(id).Scan(&r.ID, &r.Name, &r.Capacity)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *RocketStorage) All() ([]*fleet.Rocket, error) {
+	rows, err := s.all.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var rr []*fleet.Rocket
+	for rows.Next() {
+		r := &fleet.Rocket{}
+		err := rows.Scan(&r.ID, &r.Name, &r.Capacity)
+		if err != nil {
+			return nil, err
+		}
+		rr = append(rr, r)
+	}
+
+	return rr, rows.Err()
+}
+
+func (s *RocketStorage) Close() error {
+	err := s.insert.Close()
+	if err != nil {
+		return err
+	}
+	err = s.byID.Close()
+	if err != nil {
+		return err
+	}
+
+	return s.all.Close()
+}
+
+// LaunchStorage is the type implementing the fleet.LaunchStorage interface.
+type LaunchStorage struct {
+	db                *sql.DB
+	insert            *sql.Stmt
+	byID              *sql.Stmt
+	scheduled         *sql.Stmt
+	scheduledByParcel *sql.Stmt
+	lock              *sql.Stmt
+	assign            *sql.Stmt
+	unwait            *sql.Stmt
+	assigned          *sql.Stmt
+	depart            *sql.Stmt
+	wait              *sql.Stmt
+	waiting           *sql.Stmt
+}
+
+func NewLaunchStorage(db *sql.DB) (*LaunchStorage, error) {
+	s := &LaunchStorage{db: db}
+	var err error
+
+	s.insert, err = db.Prepare(insertLaunchStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(launchByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.scheduled, err = db.Prepare(scheduledLaunchesStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.scheduledByParcel, err = db.Prepare(scheduledLaunchByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.lock, err = db.Prepare(lockLaunchStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.assign, err = db.Prepare(assignLaunchStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.unwait, err = db.Prepare(unwaitStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.assigned, err = db.Prepare(assignedParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.depart, err = db.Prepare(departLaunchStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.wait, err = db.Prepare(waitStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.waiting, err = db.Prepare(waitingStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *LaunchStorage) Insert(l *fleet.Launch) error {
+	_, err := s.insert.Exec(l.ID, l.Rocket.ID, l.From, l.To, l.Departure, l.Arrival, l.Status)
+	if err != nil {
+		pgErr, ok := err.(*pq.Error)
+		if ok && pgErr.Constraint == "ipps_launch_one_scheduled_per_rocket" {
+			return fleet.ErrRocketBusy
+		}
+	}
+
+	return err
+}
+
+func (s *LaunchStorage) ByID(id uuid.UUID) (*fleet.Launch, error) {
+	l, err := scanLaunch(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return l, err
+}
+
+func (s *LaunchStorage) Scheduled(from, to string) ([]*fleet.Launch, error) {
+	rows, err := s.scheduled.Query(from, to)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ll []*fleet.Launch
+	for rows.Next() {
+		l, err := scanLaunch(rows)
+		if err != nil {
+			return nil, err
+		}
+		ll = append(ll, l)
+	}
+
+	return ll, rows.Err()
+}
+
+func (s *LaunchStorage) ScheduledByParcel(id uuid.UUID) (*fleet.Launch, error) {
+	l, err := scanLaunch(s.scheduledByParcel.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return l, err
+}
+
+func (s *LaunchStorage) Assign(l *fleet.Launch, id uuid.UUID) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = s.lockScheduled(tx, l)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	res, err := tx.Stmt(s.assign).Exec(l.ID, id)
+	if err != nil {
+		tx.Rollback()
+		pgErr, ok := err.(*pq.Error)
+		if ok && pgErr.Constraint == "ipps_launch_parcel_pkey" {
+			return fleet.ErrAlreadyAssigned
+		}
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return err
+	} else if n == 0 {
+		tx.Rollback()
+		return fleet.ErrLaunchFull
+	}
+	_, err = tx.Stmt(s.unwait).Exec(id)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Depart marks l as departed and selects the parcels assigned to it in a
+// single transaction, while l is locked.
+func (s *LaunchStorage) Depart(l *fleet.Launch) ([]uuid.UUID, error) {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return nil, err
+	}
+	err = s.lockScheduled(tx, l)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	res, err := tx.Stmt(s.depart).Exec(l.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	} else if n == 0 {
+		tx.Rollback()
+		return nil, fleet.ErrLaunchDeparted
+	}
+	ids, err := queryIDs(tx.Stmt(s.assigned), l.ID)
+	if err != nil {
+		tx.Rollback()
+		return nil, err
+	}
+
+	return ids, tx.Commit()
+}
+
+// lockScheduled locks l until the end of tx. It returns
+// fleet.ErrLaunchDeparted, if l is no longer scheduled.
+func (s *LaunchStorage) lockScheduled(tx *sql.Tx, l *fleet.Launch) error {
+	var status fleet.LaunchStatus
+	err := tx.Stmt(s.lock).QueryRow(l.ID).Scan(&status)
+	if err != nil {
+		return err
+	} else if status != fleet.Scheduled {
+		return fleet.ErrLaunchDeparted
+	}
+
+	return nil
+}
+
+func (s *LaunchStorage) Wait(id uuid.UUID, from, to string) error {
+	_, err := s.wait.Exec(id, from, to)
+
+	return err
+}
+
+func (s *LaunchStorage) Waiting(from, to string) ([]uuid.UUID, error) {
+	return queryIDs(s.waiting, from, to)
+}
+
+func (s *LaunchStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insert, s.byID, s.scheduled, s.scheduledByParcel, s.lock, s.assign,
+		s.unwait, s.assigned, s.depart, s.wait, s.waiting} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanLaunch(row rowScanner) (*fleet.Launch, error) {
+	l := &fleet.Launch{Rocket: &fleet.Rocket{}}
+	err := row.Scan(&l.ID, &l.From, &l.To, &l.Departure, &l.Arrival, &l.Status, &l.Parcels,
+		&l.Rocket.ID, &l.Rocket.Name, &l.Rocket.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// queryIDs returns the ids selected by stmt.
+func queryIDs(stmt *sql.Stmt, args ...interface{}) ([]uuid.UUID, error) {
+	rows, err := stmt.Query(args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ids []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		err := rows.Scan(&id)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, rows.Err()
+}
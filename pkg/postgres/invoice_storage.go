@@ -0,0 +1,157 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/payment"
+)
+
+const (
+	installInvoiceTable = `CREATE TABLE IF NOT EXISTS ipps_invoice (
+		id          uuid             PRIMARY KEY DEFAULT gen_random_uuid(),
+		number      text             NOT NULL CONSTRAINT ipps_invoice_number_key UNIQUE,
+		user_id     uuid             NOT NULL CONSTRAINT ipps_invoice_user_fkey
+			REFERENCES ipps_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel      uuid             NOT NULL CONSTRAINT ipps_invoice_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		card_id     uuid             CONSTRAINT ipps_invoice_card_fkey
+			REFERENCES ipps_card (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		card        text             NOT NULL,
+		lines       jsonb            NOT NULL,
+		net         bigint           NOT NULL,
+		tax_name    text             NOT NULL,
+		tax_percent double precision NOT NULL,
+		tax         bigint           NOT NULL,
+		total       bigint           NOT NULL,
+		currency    text             NOT NULL,
+		reference   text             NOT NULL,
+		created     timestamptz      NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS ipps_invoice_user_idx ON ipps_invoice (user_id, created);`
+	insertInvoiceStmt = `INSERT INTO ipps_invoice (id, number, user_id, parcel, card_id, card, lines, net, tax_name,
+			tax_percent, tax, total, currency, reference, created)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);`
+	selectInvoice = `SELECT id, number, user_id, parcel, card_id, card, lines, net, tax_name, tax_percent, tax,
+			total, currency, reference, created
+		FROM ipps_invoice`
+	invoiceByIDStmt = selectInvoice + `
+		WHERE id = $1;`
+	invoicesByUserStmt = selectInvoice + `
+		WHERE user_id = $1
+		ORDER BY created DESC;`
+)
+
+// InvoiceStorage is the type implementing the payment.Storage interface.
+// It stores the paid parcels in its ParcelStorage.
+type InvoiceStorage struct {
+	db      *sql.DB
+	parcels *ParcelStorage
+	insert  *sql.Stmt
+	byID    *sql.Stmt
+	byUser  *sql.Stmt
+}
+
+func NewInvoiceStorage(db *sql.DB, ps *ParcelStorage) (*InvoiceStorage, error) {
+	s := &InvoiceStorage{db: db, parcels: ps}
+	var err error
+
+	s.insert, err = db.Prepare(insertInvoiceStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(invoiceByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byUser, err = db.Prepare(invoicesByUserStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+// Create inserts the parcel p, its initial event e and its invoice inv in a
+// single transaction.
+func (s *InvoiceStorage) Create(p *parcel.Parcel, e *parcel.Event, inv *payment.Invoice) error {
+	lines, err := json.Marshal(inv.Lines)
+	if err != nil {
+		return err
+	}
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = s.parcels.createTx(tx, p, e)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(s.insert).Exec(inv.ID, inv.Number, inv.UserID, inv.ParcelID, inv.CardID, inv.Card,
+		string(lines), inv.Net, inv.TaxName, inv.TaxPercent, inv.Tax, inv.Total, inv.Currency, inv.Reference,
+		inv.Created)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *InvoiceStorage) ByID(id uuid.UUID) (*payment.Invoice, error) {
+	inv, err := scanInvoice(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return inv, err
+}
+
+func (s *InvoiceStorage) ByUser(id uuid.UUID) ([]*payment.Invoice, error) {
+	rows, err := s.byUser.Query(id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ii := make([]*payment.Invoice, 0)
+	for rows.Next() {
+		inv, err := scanInvoice(rows)
+		if err != nil {
+			return nil, err
+		}
+		ii = append(ii, inv)
+	}
+
+	return ii, rows.Err()
+}
+
+func (s *InvoiceStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insert, s.byID, s.byUser} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func scanInvoice(row rowScanner) (*payment.Invoice, error) {
+	inv := &payment.Invoice{}
+	var lines []byte
+	err := row.Scan(&inv.ID, &inv.Number, &inv.UserID, &inv.ParcelID, &inv.CardID, &inv.Card, &lines, &inv.Net,
+		&inv.TaxName, &inv.TaxPercent, &inv.Tax, &inv.Total, &inv.Currency, &inv.Reference, &inv.Created)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(lines, &inv.Lines)
+	if err != nil {
+		return nil, err
+	}
+
+	return inv, nil
+}
@@ -0,0 +1,264 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+)
+
+const (
+	installPickupPointTable = `CREATE TABLE IF NOT EXISTS ipps_pickup_point (
+		id            uuid    PRIMARY KEY DEFAULT gen_random_uuid(),
+		kind          text    NOT NULL,
+		name          text    NOT NULL,
+		street        text    NOT NULL,
+		zip           text    NOT NULL,
+		city          text    NOT NULL,
+		country       text    NOT NULL,
+		planet        text    NOT NULL,
+		opening_hours text    NOT NULL DEFAULT '',
+		compartments  integer NOT NULL CHECK (compartments > 0)
+	);`
+	installLockerAllocationTable = `CREATE TABLE IF NOT EXISTS ipps_locker_allocation (
+		id           uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		point        uuid        NOT NULL CONSTRAINT ipps_locker_allocation_point_fkey
+			REFERENCES ipps_pickup_point (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel       uuid        NOT NULL CONSTRAINT ipps_locker_allocation_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		compartment  integer     NOT NULL,
+		code         text        NOT NULL,
+		status       text        NOT NULL DEFAULT 'allocated',
+		allocated_at timestamptz NOT NULL,
+		expires_at   timestamptz NOT NULL
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_locker_allocation_compartment_key
+		ON ipps_locker_allocation (point, compartment) WHERE status = 'allocated';
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_locker_allocation_parcel_key
+		ON ipps_locker_allocation (parcel) WHERE status = 'allocated';
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_locker_allocation_code_key
+		ON ipps_locker_allocation (point, code) WHERE status = 'allocated';`
+	insertPickupPointStmt = `INSERT INTO ipps_pickup_point (id, kind, name, street, zip, city, country, planet,
+			opening_hours, compartments)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10);`
+	selectPickupPoint = `SELECT p.id, p.kind, p.name, p.street, p.zip, p.city, p.country, p.planet,
+			p.opening_hours, p.compartments,
+			(SELECT count(*) FROM ipps_locker_allocation a WHERE a.point = p.id AND a.status = 'allocated')
+		FROM ipps_pickup_point p`
+	pickupPointByIDStmt = selectPickupPoint + `
+		WHERE p.id = $1;`
+	pickupPointsStmt = selectPickupPoint + `
+		WHERE ($1 = '' OR lower(p.city) = lower($1)) AND ($2 = '' OR lower(p.planet) = lower($2))
+		ORDER BY p.name;`
+	// allocateStmt allocates the lowest free compartment of the pickup
+	// point. It does not insert anything, if all compartments are occupied.
+	allocateStmt = `INSERT INTO ipps_locker_allocation (id, point, parcel, compartment, code, status,
+			allocated_at, expires_at)
+		SELECT $1, p.id, $3, c, $4, 'allocated', $5, $6
+		FROM ipps_pickup_point p, generate_series(1, p.compartments) c
+		WHERE p.id = $2 AND c NOT IN
+			(SELECT compartment FROM ipps_locker_allocation WHERE point = $2 AND status = 'allocated')
+		ORDER BY c
+		LIMIT 1
+		RETURNING compartment;`
+	selectAllocation = `SELECT a.id, a.parcel, a.compartment, a.code, a.status, a.allocated_at, a.expires_at,
+			p.id, p.kind, p.name, p.street, p.zip, p.city, p.country, p.planet, p.opening_hours,
+			p.compartments,
+			(SELECT count(*) FROM ipps_locker_allocation o WHERE o.point = p.id AND o.status = 'allocated')
+		FROM ipps_locker_allocation a
+		JOIN ipps_pickup_point p ON p.id = a.point`
+	allocationByParcelStmt = selectAllocation + `
+		WHERE a.parcel = $1 AND a.status = 'allocated';`
+	allocationByCodeStmt = selectAllocation + `
+		WHERE a.point = $1 AND a.code = $2 AND a.status = 'allocated';`
+	expiredAllocationsStmt = selectAllocation + `
+		WHERE a.status = 'allocated' AND a.expires_at <= $1
+		ORDER BY a.expires_at;`
+	setAllocationStatusStmt = `UPDATE ipps_locker_allocation SET status = $2 WHERE id = $1;`
+)
+
+// LockerStorage is the type implementing the locker.Storage interface.
+type LockerStorage struct {
+	insertPoint *sql.Stmt
+	pointByID   *sql.Stmt
+	points      *sql.Stmt
+	allocate    *sql.Stmt
+	byParcel    *sql.Stmt
+	byCode      *sql.Stmt
+	expired     *sql.Stmt
+	setStatus   *sql.Stmt
+}
+
+func NewLockerStorage(db *sql.DB) (*LockerStorage, error) {
+	s := &LockerStorage{}
+	var err error
+
+	s.insertPoint, err = db.Prepare(insertPickupPointStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.pointByID, err = db.Prepare(pickupPointByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.points, err = db.Prepare(pickupPointsStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.allocate, err = db.Prepare(allocateStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byParcel, err = db.Prepare(allocationByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byCode, err = db.Prepare(allocationByCodeStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.expired, err = db.Prepare(expiredAllocationsStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.setStatus, err = db.Prepare(setAllocationStatusStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *LockerStorage) InsertPoint(pt *locker.Point) error {
+	_, err := s.insertPoint.Exec(pt.ID, pt.Kind, pt.Name, pt.Street, pt.Zip, pt.City, pt.Country, pt.Planet,
+		pt.OpeningHours, pt.Compartments)
+
+	return err
+}
+
+func (s *LockerStorage) PointByID(id uuid.UUID) (*locker.Point, error) {
+	pt := &locker.Point{}
+	err := s.pointByID.QueryRow(id).Scan(pointDest(pt)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return pt, nil
+}
+
+func (s *LockerStorage) Points(city, planet string) ([]*locker.Point, error) {
+	rows, err := s.points.Query(city, planet)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	pp := make([]*locker.Point, 0)
+	for rows.Next() {
+		pt := &locker.Point{}
+		err := rows.Scan(pointDest(pt)...)
+		if err != nil {
+			return nil, err
+		}
+		pp = append(pp, pt)
+	}
+
+	return pp, rows.Err()
+}
+
+func (s *LockerStorage) Allocate(a *locker.Allocation) error {
+	err := s.allocate.QueryRow(a.ID, a.Point.ID, a.ParcelID, a.Code, a.AllocatedAt, a.ExpiresAt).
+		Scan(&a.Compartment)
+	if err == sql.ErrNoRows {
+		return locker.ErrPointFull
+	} else if pgErr, ok := err.(*pq.Error); ok {
+		switch pgErr.Constraint {
+		case "ipps_locker_allocation_parcel_key":
+			return locker.ErrAlreadyAllocated
+		case "ipps_locker_allocation_code_key", "ipps_locker_allocation_compartment_key":
+			// Another parcel has been allocated the same compartment
+			// concurrently, which is retried like a code in use.
+			return locker.ErrCodeInUse
+		}
+	}
+
+	return err
+}
+
+func (s *LockerStorage) ByParcel(id uuid.UUID) (*locker.Allocation, error) {
+	a, err := scanAllocation(s.byParcel.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return a, err
+}
+
+func (s *LockerStorage) ByCode(id uuid.UUID, code string) (*locker.Allocation, error) {
+	a, err := scanAllocation(s.byCode.QueryRow(id, code))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return a, err
+}
+
+func (s *LockerStorage) Expired(at time.Time) ([]*locker.Allocation, error) {
+	rows, err := s.expired.Query(at)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	aa := make([]*locker.Allocation, 0)
+	for rows.Next() {
+		a, err := scanAllocation(rows)
+		if err != nil {
+			return nil, err
+		}
+		aa = append(aa, a)
+	}
+
+	return aa, rows.Err()
+}
+
+func (s *LockerStorage) SetStatus(a *locker.Allocation, st locker.Status) error {
+	_, err := s.setStatus.Exec(a.ID, st)
+
+	return err
+}
+
+func (s *LockerStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insertPoint, s.pointByID, s.points, s.allocate, s.byParcel,
+		s.byCode, s.expired, s.setStatus} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// pointDest returns the scan destinations of the columns selected by
+// selectPickupPoint.
+func pointDest(pt *locker.Point) []interface{} {
+	return []interface{}{&pt.ID, &pt.Kind, &pt.Name, &pt.Street, &pt.Zip, &pt.City, &pt.Country, &pt.Planet,
+		&pt.OpeningHours, &pt.Compartments, &pt.Occupied}
+}
+
+func scanAllocation(row rowScanner) (*locker.Allocation, error) {
+	a := &locker.Allocation{Point: &locker.Point{}}
+	dd := append([]interface{}{&a.ID, &a.ParcelID, &a.Compartment, &a.Code, &a.Status, &a.AllocatedAt,
+		&a.ExpiresAt}, pointDest(a.Point)...)
+	err := row.Scan(dd...)
+	if err != nil {
+		return nil, err
+	}
+
+	return a, nil
+}
@@ -2,10 +2,13 @@ package postgres
 
 import (
 	"database/sql"
+	"encoding/json"
 
 	"github.com/google/uuid"
+	"github.com/lib/pq"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
 	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
 )
 
 const (
@@ -14,38 +17,176 @@ const (
 		destination_address uuid CONSTRAINT ipps_parcel_dest_addr_fkey
 								 REFERENCES ipps_address (id) ON DELETE SET NULL ON UPDATE CASCADE,
 		return_address      uuid CONSTRAINT ipps_parcel_return_addr_fkey
-								 REFERENCES ipps_address (id) ON DELETE SET NULL ON UPDATE CASCADE
+								 REFERENCES ipps_address (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		quote               uuid CONSTRAINT ipps_parcel_quote_key UNIQUE
+								 CONSTRAINT ipps_parcel_quote_fkey
+								 REFERENCES ipps_quote (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		weight              double precision NOT NULL DEFAULT 0,
+		length              double precision NOT NULL DEFAULT 0,
+		width               double precision NOT NULL DEFAULT 0,
+		height              double precision NOT NULL DEFAULT 0,
+		declared_value      bigint           NOT NULL DEFAULT 0,
+		contents            text             NOT NULL DEFAULT '',
+		service_level       integer          NOT NULL DEFAULT 0,
+		hazards             integer          NOT NULL DEFAULT 0,
+		insured             boolean          NOT NULL DEFAULT false,
+		pickup_point        uuid CONSTRAINT ipps_parcel_pickup_point_fkey
+								 REFERENCES ipps_pickup_point (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		recipient           uuid CONSTRAINT ipps_parcel_recipient_fkey
+								 REFERENCES ipps_user (id) ON DELETE SET NULL ON UPDATE CASCADE
+	);`
+	migrateParcelTable = `ALTER TABLE ipps_parcel
+		ADD COLUMN IF NOT EXISTS quote uuid CONSTRAINT ipps_parcel_quote_key UNIQUE
+			CONSTRAINT ipps_parcel_quote_fkey
+			REFERENCES ipps_quote (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		ADD COLUMN IF NOT EXISTS weight         double precision NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS length         double precision NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS width          double precision NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS height         double precision NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS declared_value bigint           NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS contents       text             NOT NULL DEFAULT '',
+		ADD COLUMN IF NOT EXISTS service_level  integer          NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS hazards        integer          NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS insured        boolean          NOT NULL DEFAULT false,
+		ADD COLUMN IF NOT EXISTS pickup_point   uuid CONSTRAINT ipps_parcel_pickup_point_fkey
+			REFERENCES ipps_pickup_point (id) ON DELETE SET NULL ON UPDATE CASCADE,
+		ADD COLUMN IF NOT EXISTS recipient      uuid CONSTRAINT ipps_parcel_recipient_fkey
+			REFERENCES ipps_user (id) ON DELETE SET NULL ON UPDATE CASCADE;
+	CREATE INDEX IF NOT EXISTS ipps_parcel_recipient_idx ON ipps_parcel (recipient);`
+	installCustomsTable = `CREATE TABLE IF NOT EXISTS ipps_customs_declaration (
+		parcel   uuid    PRIMARY KEY CONSTRAINT ipps_customs_declaration_parcel_fkey
+							 REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		category integer NOT NULL,
+		items    jsonb   NOT NULL
 	);`
-	insertParcelStmt = `INSERT INTO ipps_parcel(id, destination_address, return_address)
+	insertCustomsStmt = `INSERT INTO ipps_customs_declaration (parcel, category, items)
 						VALUES ($1, $2, $3);`
-	parcelByIDStmt = `SELECT (id, destination_address, return_address)
-					  FROM ipps_parcel
-					  WHERE id = $1;`
-	parcelByDestinationStmt = `SELECT (id, destination_address, return_address)
-					  FROM ipps_parcel
-					  WHERE destination_address = $1;`
+	insertParcelStmt = `INSERT INTO ipps_parcel(id, destination_address, return_address, quote,
+							weight, length, width, height, declared_value, contents, service_level, hazards,
+							insured, pickup_point, recipient)
+						VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15);`
+	// upsertAddressStmt inserts an address of a user and returns its ID or,
+	// if the user has already added the address, the existing address's ID.
+	upsertAddressStmt = `INSERT INTO ipps_address (id, street, zip, city, country, planet, user_id)
+						 VALUES ($1, $2, $3, $4, $5, $6, $7)
+						 ON CONFLICT ON CONSTRAINT ipps_address_unique_per_user
+						 DO UPDATE SET street = EXCLUDED.street
+						 RETURNING id;`
+	updateDestinationStmt = `UPDATE ipps_parcel SET destination_address = $2, pickup_point = $3
+							 WHERE id = $1;`
+	// selectParcel selects parcels together with their addresses, which
+	// are NULL if they have been deleted in the meantime.
+	selectParcel = `SELECT p.id, p.quote, p.pickup_point, p.recipient, p.weight, p.length, p.width, p.height,
+					  p.declared_value, p.contents, p.service_level, p.hazards, p.insured, c.category, c.items,
+					  d.id, d.street, d.zip, d.city, d.country, d.planet,
+					  r.id, r.street, r.zip, r.city, r.country, r.planet
+					  FROM ipps_parcel p
+					  LEFT JOIN ipps_address d ON d.id = p.destination_address
+					  LEFT JOIN ipps_address r ON r.id = p.return_address
+					  LEFT JOIN ipps_customs_declaration c ON c.parcel = p.id`
+	parcelByIDStmt = selectParcel + `
+					  WHERE p.id = $1;`
+	parcelsByIDsStmt = selectParcel + `
+					  WHERE p.id = ANY($1::uuid[]);`
+	parcelByRecipientStmt = selectParcel + `
+					  WHERE p.recipient = $1;`
+	parcelByReturnAddressStmt = selectParcel + `
+					  WHERE p.return_address = $1;`
+	installProofTable = `CREATE TABLE IF NOT EXISTS ipps_delivery_proof (
+		event          uuid             PRIMARY KEY CONSTRAINT ipps_delivery_proof_event_fkey
+										REFERENCES ipps_parcel_event (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		parcel         uuid             NOT NULL CONSTRAINT ipps_delivery_proof_parcel_key UNIQUE
+										CONSTRAINT ipps_delivery_proof_parcel_fkey
+										REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		recipient_name text             NOT NULL,
+		signature      text             NOT NULL DEFAULT '',
+		photo          text             NOT NULL DEFAULT '',
+		latitude       double precision,
+		longitude      double precision
+	);`
+	insertProofStmt = `INSERT INTO ipps_delivery_proof (event, parcel, recipient_name, signature, photo,
+						   latitude, longitude)
+					   VALUES ($1, $2, $3, $4, $5, $6, $7);`
+	// proofByParcelStmt selects the proof of a parcel's delivery together
+	// with the event it is attached to.
+	proofByParcelStmt = `SELECT e.id, e.event_type, e.event_time,
+						 d.recipient_name, d.signature, d.photo, d.latitude, d.longitude
+						 FROM ipps_delivery_proof d
+						 JOIN ipps_parcel_event e ON e.id = d.event
+						 WHERE d.parcel = $1;`
 )
 
 // ParcelStorage is the PostgreSQL based implementation of
 // the parcel.Storage and parcel.EventStorage interfaces.
 type ParcelStorage struct {
+	db            *sql.DB
 	insert        *sql.Stmt
+	insertCustoms *sql.Stmt
+	insertEvent   *sql.Stmt
+	insertProof   *sql.Stmt
+	upsertAddress *sql.Stmt
+	updateDest    *sql.Stmt
+	lockParcel    *sql.Stmt
+	events        *sql.Stmt
 	byID          *sql.Stmt
-	byDestination *sql.Stmt
+	byIDs         *sql.Stmt
+	byRecipient   *sql.Stmt
+	byReturnAddr  *sql.Stmt
+	proofByParcel *sql.Stmt
 }
 
 func NewParcelStorage(db *sql.DB) (*ParcelStorage, error) {
-	ps := &ParcelStorage{}
+	ps := &ParcelStorage{db: db}
 	var err error
 	ps.insert, err = db.Prepare(insertParcelStmt)
 	if err != nil {
 		return nil, err
 	}
+	ps.insertCustoms, err = db.Prepare(insertCustomsStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.insertEvent, err = db.Prepare(insertParcelEventStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.insertProof, err = db.Prepare(insertProofStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.upsertAddress, err = db.Prepare(upsertAddressStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.updateDest, err = db.Prepare(updateDestinationStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.lockParcel, err = db.Prepare(lockParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.events, err = db.Prepare(parcelEventByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
 	ps.byID, err = db.Prepare(parcelByIDStmt)
 	if err != nil {
 		return nil, err
 	}
-	ps.byDestination, err = db.Prepare(parcelByDestinationStmt)
+	ps.byIDs, err = db.Prepare(parcelsByIDsStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.byRecipient, err = db.Prepare(parcelByRecipientStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.byReturnAddr, err = db.Prepare(parcelByReturnAddressStmt)
+	if err != nil {
+		return nil, err
+	}
+	ps.proofByParcel, err = db.Prepare(proofByParcelStmt)
 	if err != nil {
 		return nil, err
 	}
@@ -53,14 +194,183 @@ func NewParcelStorage(db *sql.DB) (*ParcelStorage, error) {
 	return ps, nil
 }
 
+// Insert inserts p together with its customs declaration in a single
+// transaction.
 func (ps *ParcelStorage) Insert(p *parcel.Parcel) error {
-	_, err := ps.insert.Exec(p.ID, p.DestinationAddress.ID, p.DestinationAddress.ID)
+	tx, err := ps.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = ps.insertTx(tx, p)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Create inserts p, its customs declaration and its initial event e in a
+// single transaction.
+func (ps *ParcelStorage) Create(p *parcel.Parcel, e *parcel.Event) error {
+	tx, err := ps.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = ps.createTx(tx, p, e)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// createTx stores the new parcel p, its destination address and its initial
+// event e in the transaction tx.
+func (ps *ParcelStorage) createTx(tx *sql.Tx, p *parcel.Parcel, e *parcel.Event) error {
+	a := p.DestinationAddress
+	err := tx.Stmt(ps.upsertAddress).QueryRow(a.ID, a.Street, a.Zip, a.City, a.Country, a.Planet, a.User.ID).
+		Scan(&a.ID)
+	if err != nil {
+		return err
+	}
+	err = ps.insertTx(tx, p)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(ps.insertEvent).Exec(e.ID, e.Type, e.Time, p.ID, e.Reason, deliveryDate(e))
+
+	return err
+}
+
+// Import inserts the addresses aa and the parcels pp together with their
+// initial events ee in a single transaction. Addresses the user has already
+// added are assigned the IDs of the existing addresses.
+func (ps *ParcelStorage) Import(aa []*address.Address, pp []*parcel.Parcel, ee []*parcel.Event) error {
+	tx, err := ps.db.Begin()
+	if err != nil {
+		return err
+	}
+	upsert := tx.Stmt(ps.upsertAddress)
+	for _, a := range aa {
+		err = upsert.QueryRow(a.ID, a.Street, a.Zip, a.City, a.Country, a.Planet, a.User.ID).Scan(&a.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	for i, p := range pp {
+		err = ps.insertTx(tx, p)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		e := ee[i]
+		_, err = tx.Stmt(ps.insertEvent).Exec(e.ID, e.Type, e.Time, p.ID, e.Reason, deliveryDate(e))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+// Redirect updates the destination address and pickup point of p and
+// inserts the event e in a single transaction, after check has accepted
+// the events of p. p is locked like by EventStorage.Insert.
+func (ps *ParcelStorage) Redirect(p *parcel.Parcel, e *parcel.Event,
+	check func(ee []*parcel.Event) error) error {
+	tx, err := ps.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = ps.checkTx(tx, p, check)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(ps.updateDest).Exec(p.ID, p.DestinationAddress.ID, p.PickupPointID)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(ps.insertEvent).Exec(e.ID, e.Type, e.Time, p.ID, e.Reason, deliveryDate(e))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// Deliver inserts the event e completing a parcel's delivery and its proof
+// of delivery pr in a single transaction, after check has accepted the
+// events of the parcel, which is locked like by EventStorage.Insert.
+func (ps *ParcelStorage) Deliver(e *parcel.Event, pr *parcel.Proof,
+	check func(ee []*parcel.Event) error) error {
+	tx, err := ps.db.Begin()
+	if err != nil {
+		return err
+	}
+	err = ps.checkTx(tx, e.Parcel, check)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(ps.insertEvent).Exec(e.ID, e.Type, e.Time, e.Parcel.ID, e.Reason, deliveryDate(e))
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	var lat, lon *float64
+	if pr.Location != nil {
+		lat, lon = &pr.Location.Latitude, &pr.Location.Longitude
+	}
+	_, err = tx.Stmt(ps.insertProof).Exec(e.ID, e.Parcel.ID, pr.RecipientName, pr.Signature, pr.Photo,
+		lat, lon)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+// checkTx locks p until the end of tx and calls check with p's events.
+func (ps *ParcelStorage) checkTx(tx *sql.Tx, p *parcel.Parcel, check func(ee []*parcel.Event) error) error {
+	_, err := tx.Stmt(ps.lockParcel).Exec(p.ID)
+	if err != nil {
+		return err
+	}
+	ee, err := queryEvents(tx.Stmt(ps.events), p)
+	if err != nil {
+		return err
+	}
+
+	return check(ee)
+}
+
+func (ps *ParcelStorage) insertTx(tx *sql.Tx, p *parcel.Parcel) error {
+	_, err := tx.Stmt(ps.insert).Exec(insertParcelArgs(p)...)
+	if err != nil {
+		return quoteError(err)
+	}
+	if p.Customs == nil {
+		return nil
+	}
+	items, err := json.Marshal(p.Customs.Items)
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(ps.insertCustoms).Exec(p.ID, p.Customs.Category, string(items))
+
 	return err
 }
 
 func (ps *ParcelStorage) ByID(id uuid.UUID) (*parcel.Parcel, error) {
-	p := &parcel.Parcel{}
-	err := ps.byID.QueryRow(id).Scan(&p.ID, &p.DestinationAddress, &p.ReturnAddress)
+	p, err := scanParcel(ps.byID.QueryRow(id))
 	if err == sql.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
@@ -70,25 +380,62 @@ func (ps *ParcelStorage) ByID(id uuid.UUID) (*parcel.Parcel, error) {
 	return p, nil
 }
 
-func (ps *ParcelStorage) ByDestination(a *address.Address) ([]*parcel.Parcel, error) {
-	rows, err := ps.byDestination.Query(a.ID)
+func (ps *ParcelStorage) ByIDs(ids []uuid.UUID) ([]*parcel.Parcel, error) {
+	ss := make([]string, len(ids))
+	for i, id := range ids {
+		ss[i] = id.String()
+	}
+
+	return queryParcels(ps.byIDs, pq.StringArray(ss))
+}
+
+func (ps *ParcelStorage) ByRecipient(u *user.User) ([]*parcel.Parcel, error) {
+	return queryParcels(ps.byRecipient, u.ID)
+}
+
+func (ps *ParcelStorage) ByReturnAddress(a *address.Address) ([]*parcel.Parcel, error) {
+	return queryParcels(ps.byReturnAddr, a.ID)
+}
+
+func (ps *ParcelStorage) ProofByParcel(p *parcel.Parcel) (*parcel.Proof, error) {
+	e := &parcel.Event{Parcel: p}
+	pr := &parcel.Proof{Event: e}
+	var lat, lon *float64
+	err := ps.proofByParcel.QueryRow(p.ID).Scan(&e.ID, &e.Type, &e.Time,
+		&pr.RecipientName, &pr.Signature, &pr.Photo, &lat, &lon)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	if lat != nil && lon != nil {
+		pr.Location = &parcel.Location{Latitude: *lat, Longitude: *lon}
+	}
+
+	return pr, nil
+}
+
+// queryParcels returns the parcels selected by stmt with the given
+// arguments.
+func queryParcels(stmt *sql.Stmt, args ...interface{}) ([]*parcel.Parcel, error) {
+	rows, err := stmt.Query(args...)
 	if err == sql.ErrNoRows {
 		return nil, nil
 	} else if err != nil {
 		return nil, err
 	}
+	defer rows.Close()
 
 	pp := make([]*parcel.Parcel, 0)
 	for rows.Next() {
-		p := &parcel.Parcel{}
-		err := rows.Scan(&p.ID, &p.DestinationAddress, &p.ReturnAddress)
+		p, err := scanParcel(rows)
 		if err != nil {
 			return nil, err
 		}
 		pp = append(pp, p)
 	}
 
-	return pp, nil
+	return pp, rows.Err()
 }
 
 func (ps *ParcelStorage) Close() error {
@@ -96,10 +443,103 @@ func (ps *ParcelStorage) Close() error {
 	if err != nil {
 		return err
 	}
-	err = ps.byDestination.Close()
+	err = ps.insertCustoms.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.insertEvent.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.insertProof.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.upsertAddress.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.updateDest.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.lockParcel.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.events.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.byIDs.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.byRecipient.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.byReturnAddr.Close()
+	if err != nil {
+		return err
+	}
+	err = ps.proofByParcel.Close()
 	if err != nil {
 		return err
 	}
 
 	return ps.byID.Close()
 }
+
+// rowScanner is the interface shared by sql.Row and sql.Rows.
+type rowScanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// insertParcelArgs returns the arguments of insertParcelStmt for p.
+func insertParcelArgs(p *parcel.Parcel) []interface{} {
+	a := &p.Attributes
+	return []interface{}{p.ID, p.DestinationAddress.ID, p.ReturnAddress.ID, p.QuoteID,
+		a.Weight, a.Length, a.Width, a.Height, a.DeclaredValue, a.Contents, a.ServiceLevel, a.Hazards,
+		a.Insured, p.PickupPointID, p.RecipientID}
+}
+
+// scanParcel scans a row selected by selectParcel into a new parcel.
+func scanParcel(row rowScanner) (*parcel.Parcel, error) {
+	p := &parcel.Parcel{}
+	var dest, ret nullableAddress
+	a := &p.Attributes
+	var category *parcel.CustomsCategory
+	var items []byte
+	dd := []interface{}{&p.ID, &p.QuoteID, &p.PickupPointID, &p.RecipientID, &a.Weight, &a.Length,
+		&a.Width, &a.Height, &a.DeclaredValue, &a.Contents, &a.ServiceLevel, &a.Hazards, &a.Insured, &category,
+		&items}
+	dd = append(dd, dest.dest()...)
+	dd = append(dd, ret.dest()...)
+	err := row.Scan(dd...)
+	if err != nil {
+		return nil, err
+	}
+	if category != nil {
+		p.Customs = &parcel.CustomsDeclaration{Category: *category}
+		err = json.Unmarshal(items, &p.Customs.Items)
+		if err != nil {
+			return nil, err
+		}
+	}
+	p.DestinationAddress = dest.address()
+	p.ReturnAddress = ret.address()
+
+	return p, nil
+}
+
+// quoteError returns parcel.ErrQuoteUsed, if err is caused by inserting a
+// parcel with a quote, which has already been used, and err otherwise.
+func quoteError(err error) error {
+	pgErr, ok := err.(*pq.Error)
+	if ok && pgErr.Constraint == "ipps_parcel_quote_key" {
+		return parcel.ErrQuoteUsed
+	}
+
+	return err
+}
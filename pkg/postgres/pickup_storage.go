@@ -0,0 +1,216 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pickup"
+)
+
+const (
+	installPickupTables = `CREATE TABLE IF NOT EXISTS ipps_pickup_slot (
+		id       uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		city     text        NOT NULL,
+		planet   text        NOT NULL,
+		start    timestamptz NOT NULL,
+		finish   timestamptz NOT NULL CHECK (finish > start),
+		capacity integer     NOT NULL CHECK (capacity > 0)
+	);
+	CREATE TABLE IF NOT EXISTS ipps_pickup (
+		id        uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		parcel    uuid        NOT NULL CONSTRAINT ipps_pickup_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		slot      uuid        NOT NULL CONSTRAINT ipps_pickup_slot_fkey
+			REFERENCES ipps_pickup_slot (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		status    text        NOT NULL DEFAULT 'booked',
+		booked_at timestamptz NOT NULL DEFAULT now()
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_pickup_one_booked_per_parcel
+		ON ipps_pickup (parcel) WHERE status = 'booked';`
+	insertPickupSlotStmt = `INSERT INTO ipps_pickup_slot (id, city, planet, start, finish, capacity)
+		VALUES ($1, $2, $3, $4, $5, $6);`
+	selectPickupSlot = `SELECT s.id, s.city, s.planet, s.start, s.finish, s.capacity,
+			(SELECT count(*) FROM ipps_pickup p WHERE p.slot = s.id AND p.status <> 'cancelled')
+		FROM ipps_pickup_slot s`
+	pickupSlotByIDStmt = selectPickupSlot + `
+		WHERE s.id = $1;`
+	pickupSlotsStmt = selectPickupSlot + `
+		WHERE ($1 = '' OR lower(s.city) = lower($1)) AND ($2 = '' OR lower(s.planet) = lower($2))
+			AND s.start > $3
+		ORDER BY s.start;`
+	// bookPickupStmt only books the pickup, if the slot has capacity left.
+	bookPickupStmt = `INSERT INTO ipps_pickup (id, parcel, slot, status, booked_at)
+		SELECT $1, $2, s.id, 'booked', $4
+		FROM ipps_pickup_slot s
+		WHERE s.id = $3 AND s.capacity >
+			(SELECT count(*) FROM ipps_pickup WHERE slot = $3 AND status <> 'cancelled');`
+	selectPickup = `SELECT p.id, p.parcel, p.status, p.booked_at,
+			s.id, s.city, s.planet, s.start, s.finish, s.capacity,
+			(SELECT count(*) FROM ipps_pickup b WHERE b.slot = s.id AND b.status <> 'cancelled')
+		FROM ipps_pickup p
+		JOIN ipps_pickup_slot s ON s.id = p.slot`
+	pickupByIDStmt = selectPickup + `
+		WHERE p.id = $1;`
+	pickupByParcelStmt = selectPickup + `
+		WHERE p.parcel = $1 AND p.status = 'booked';`
+	setPickupStatusStmt = `UPDATE ipps_pickup SET status = $2 WHERE id = $1;`
+)
+
+// PickupStorage is the type implementing the pickup.Storage interface.
+type PickupStorage struct {
+	insertSlot *sql.Stmt
+	slotByID   *sql.Stmt
+	slots      *sql.Stmt
+	book       *sql.Stmt
+	byID       *sql.Stmt
+	byParcel   *sql.Stmt
+	setStatus  *sql.Stmt
+}
+
+func NewPickupStorage(db *sql.DB) (*PickupStorage, error) {
+	s := &PickupStorage{}
+	var err error
+
+	s.insertSlot, err = db.Prepare(insertPickupSlotStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.slotByID, err = db.Prepare(pickupSlotByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.slots, err = db.Prepare(pickupSlotsStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.book, err = db.Prepare(bookPickupStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(pickupByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byParcel, err = db.Prepare(pickupByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.setStatus, err = db.Prepare(setPickupStatusStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *PickupStorage) InsertSlot(sl *pickup.Slot) error {
+	_, err := s.insertSlot.Exec(sl.ID, sl.City, sl.Planet, sl.Start, sl.End, sl.Capacity)
+
+	return err
+}
+
+func (s *PickupStorage) SlotByID(id uuid.UUID) (*pickup.Slot, error) {
+	sl := &pickup.Slot{}
+	err := s.slotByID.QueryRow(id).Scan(slotDest(sl)...)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return sl, nil
+}
+
+func (s *PickupStorage) Slots(city, planet string, from time.Time) ([]*pickup.Slot, error) {
+	rows, err := s.slots.Query(city, planet, from)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ss := make([]*pickup.Slot, 0)
+	for rows.Next() {
+		sl := &pickup.Slot{}
+		err := rows.Scan(slotDest(sl)...)
+		if err != nil {
+			return nil, err
+		}
+		ss = append(ss, sl)
+	}
+
+	return ss, rows.Err()
+}
+
+func (s *PickupStorage) Book(p *pickup.Pickup) error {
+	res, err := s.book.Exec(p.ID, p.ParcelID, p.Slot.ID, p.BookedAt)
+	if err != nil {
+		pgErr, ok := err.(*pq.Error)
+		if ok && pgErr.Constraint == "ipps_pickup_one_booked_per_parcel" {
+			return pickup.ErrAlreadyBooked
+		}
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	} else if n == 0 {
+		return pickup.ErrSlotFull
+	}
+
+	return nil
+}
+
+func (s *PickupStorage) ByID(id uuid.UUID) (*pickup.Pickup, error) {
+	p, err := scanPickup(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return p, err
+}
+
+func (s *PickupStorage) ByParcel(id uuid.UUID) (*pickup.Pickup, error) {
+	p, err := scanPickup(s.byParcel.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+
+	return p, err
+}
+
+func (s *PickupStorage) SetStatus(p *pickup.Pickup, st pickup.Status) error {
+	_, err := s.setStatus.Exec(p.ID, st)
+
+	return err
+}
+
+func (s *PickupStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insertSlot, s.slotByID, s.slots, s.book, s.byID, s.byParcel,
+		s.setStatus} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// slotDest returns the scan destinations of the columns selected by
+// selectPickupSlot.
+func slotDest(sl *pickup.Slot) []interface{} {
+	return []interface{}{&sl.ID, &sl.City, &sl.Planet, &sl.Start, &sl.End, &sl.Capacity, &sl.Booked}
+}
+
+func scanPickup(row rowScanner) (*pickup.Pickup, error) {
+	p := &pickup.Pickup{Slot: &pickup.Slot{}}
+	dd := append([]interface{}{&p.ID, &p.ParcelID, &p.Status, &p.BookedAt}, slotDest(p.Slot)...)
+	err := row.Scan(dd...)
+	if err != nil {
+		return nil, err
+	}
+
+	return p, nil
+}
@@ -25,8 +25,11 @@ const connFmt = "host=%s port=%d user=%s password=%s dbname=%s connect_timeout=5
 // Connect connects to the Postgres database using the connection settings specified
 // in conf.
 func Connect(conf *Config) (*sql.DB, error) {
-	connStr := fmt.Sprintf(connFmt, conf.Host, conf.Port, conf.User, conf.Password, conf.Name)
-	return sql.Open("postgres", connStr)
+	return sql.Open("postgres", connString(conf))
+}
+
+func connString(conf *Config) string {
+	return fmt.Sprintf(connFmt, conf.Host, conf.Port, conf.User, conf.Password, conf.Name)
 }
 
 // InstallTables installs all tables necessary to run the website, using
@@ -36,6 +39,10 @@ func InstallTables(db *sql.DB) error {
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec(migrateUserTable)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec(installCardTable)
 	if err != nil {
 		return err
@@ -48,11 +55,87 @@ func InstallTables(db *sql.DB) error {
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec(installQuoteTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(migrateQuoteTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installPickupPointTable)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec(installParcelTable)
 	if err != nil {
 		return err
 	}
+	_, err = db.Exec(migrateParcelTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installCustomsTable)
+	if err != nil {
+		return err
+	}
 	_, err = db.Exec(installParcelEventTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(migrateParcelEventTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installProofTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installWebhookTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installWebhookDeliveryTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installWebhookDeadLetterTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installRocketTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installLaunchTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installPickupTables)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installLockerAllocationTable)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installCenterTables)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installRouteTables)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installConsignmentTables)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installClaimTables)
+	if err != nil {
+		return err
+	}
+	_, err = db.Exec(installInvoiceTable)
 
 	return err
 }
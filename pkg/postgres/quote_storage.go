@@ -0,0 +1,112 @@
+package postgres
+
+import (
+	"database/sql"
+	"encoding/json"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/pricing"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+const (
+	installQuoteTable = `CREATE TABLE IF NOT EXISTS ipps_quote (
+		id             uuid             PRIMARY KEY DEFAULT gen_random_uuid(),
+		user_id        uuid             CONSTRAINT ipps_quote_user_fkey
+			REFERENCES ipps_user ON DELETE CASCADE ON UPDATE CASCADE,
+		weight         double precision NOT NULL,
+		length         double precision NOT NULL,
+		width          double precision NOT NULL,
+		height         double precision NOT NULL,
+		origin         text             NOT NULL,
+		destination    text             NOT NULL,
+		service_level  integer          NOT NULL,
+		declared_value bigint           NOT NULL DEFAULT 0,
+		insured        boolean          NOT NULL DEFAULT false,
+		lines          jsonb            NOT NULL,
+		total          bigint           NOT NULL,
+		currency       text             NOT NULL,
+		created        timestamptz      NOT NULL,
+		expires        timestamptz      NOT NULL
+	);`
+	migrateQuoteTable = `ALTER TABLE ipps_quote
+		ADD COLUMN IF NOT EXISTS declared_value bigint  NOT NULL DEFAULT 0,
+		ADD COLUMN IF NOT EXISTS insured        boolean NOT NULL DEFAULT false;`
+	insertQuoteStmt = `INSERT INTO ipps_quote (id, user_id, weight, length, width, height, origin, destination,
+			service_level, declared_value, insured, lines, total, currency, created, expires)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16);`
+	quoteByIDStmt = `SELECT id, user_id, weight, length, width, height, origin, destination,
+			service_level, declared_value, insured, lines, total, currency, created, expires
+		FROM ipps_quote
+		WHERE id = $1;`
+)
+
+// QuoteStorage is the type implementing the pricing.Storage interface.
+type QuoteStorage struct {
+	insert *sql.Stmt
+	byID   *sql.Stmt
+}
+
+func NewQuoteStorage(db *sql.DB) (*QuoteStorage, error) {
+	s := &QuoteStorage{}
+	var err error
+
+	s.insert, err = db.Prepare(insertQuoteStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(quoteByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *QuoteStorage) Insert(q *pricing.Quote) error {
+	lines, err := json.Marshal(q.Lines)
+	if err != nil {
+		return err
+	}
+	var userID *uuid.UUID
+	if q.User != nil {
+		userID = &q.User.ID
+	}
+	r := &q.Request
+	_, err = s.insert.Exec(q.ID, userID, r.Weight, r.Length, r.Width, r.Height, r.From, r.To,
+		r.ServiceLevel, r.DeclaredValue, r.Insured, string(lines), q.Total, q.Currency, q.Created, q.Expires)
+
+	return err
+}
+
+func (s *QuoteStorage) ByID(id uuid.UUID) (*pricing.Quote, error) {
+	q := &pricing.Quote{}
+	r := &q.Request
+	var userID *uuid.UUID
+	var lines []byte
+	err := s.byID.QueryRow(id).Scan(&q.ID, &userID, &r.Weight, &r.Length, &r.Width, &r.Height,
+		&r.From, &r.To, &r.ServiceLevel, &r.DeclaredValue, &r.Insured, &lines, &q.Total, &q.Currency, &q.Created, &q.Expires)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(lines, &q.Lines)
+	if err != nil {
+		return nil, err
+	}
+	if userID != nil {
+		q.User = &user.User{ID: *userID}
+	}
+
+	return q, nil
+}
+
+func (s *QuoteStorage) Close() error {
+	err := s.insert.Close()
+	if err != nil {
+		return err
+	}
+
+	return s.byID.Close()
+}
@@ -0,0 +1,383 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/route"
+)
+
+const (
+	installRouteTables = `CREATE TABLE IF NOT EXISTS ipps_vehicle (
+		id       uuid    PRIMARY KEY DEFAULT gen_random_uuid(),
+		name     text    NOT NULL,
+		city     text    NOT NULL,
+		planet   text    NOT NULL,
+		capacity integer NOT NULL CHECK (capacity > 0)
+	);
+	CREATE TABLE IF NOT EXISTS ipps_place (
+		planet    text             NOT NULL,
+		city      text             NOT NULL,
+		zip       text             NOT NULL,
+		latitude  double precision NOT NULL,
+		longitude double precision NOT NULL
+	);
+	CREATE UNIQUE INDEX IF NOT EXISTS ipps_place_key ON ipps_place (lower(planet), lower(city), zip);
+	CREATE TABLE IF NOT EXISTS ipps_route (
+		id      uuid PRIMARY KEY DEFAULT gen_random_uuid(),
+		vehicle uuid NOT NULL CONSTRAINT ipps_route_vehicle_fkey
+			REFERENCES ipps_vehicle (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		driver  uuid NOT NULL CONSTRAINT ipps_route_driver_fkey
+			REFERENCES ipps_user (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		center  uuid NOT NULL CONSTRAINT ipps_route_center_fkey
+			REFERENCES ipps_center (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		day     date NOT NULL,
+		CONSTRAINT ipps_route_vehicle_day_key UNIQUE (vehicle, day)
+	);
+	CREATE TABLE IF NOT EXISTS ipps_route_stop (
+		id        uuid             PRIMARY KEY DEFAULT gen_random_uuid(),
+		route     uuid             NOT NULL CONSTRAINT ipps_route_stop_route_fkey
+			REFERENCES ipps_route (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		sequence  integer          NOT NULL,
+		parcel    uuid             NOT NULL CONSTRAINT ipps_route_stop_parcel_fkey
+			REFERENCES ipps_parcel (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		address   uuid             NOT NULL CONSTRAINT ipps_route_stop_address_fkey
+			REFERENCES ipps_address (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		latitude  double precision,
+		longitude double precision,
+		status    text             NOT NULL DEFAULT 'pending'
+	);`
+	insertVehicleStmt = `INSERT INTO ipps_vehicle (id, name, city, planet, capacity)
+		VALUES ($1, $2, $3, $4, $5);`
+	selectVehicle   = `SELECT id, name, city, planet, capacity FROM ipps_vehicle`
+	vehicleByIDStmt = selectVehicle + `
+		WHERE id = $1;`
+	vehiclesStmt = selectVehicle + `
+		ORDER BY planet, city, name;`
+	insertPlaceStmt = `INSERT INTO ipps_place (planet, city, zip, latitude, longitude)
+		VALUES ($1, $2, $3, $4, $5)
+		ON CONFLICT (lower(planet), lower(city), zip)
+		DO UPDATE SET latitude = EXCLUDED.latitude, longitude = EXCLUDED.longitude;`
+	locateStmt = `SELECT latitude, longitude FROM ipps_place
+		WHERE lower(planet) = lower($1) AND lower(city) = lower($2) AND zip = $3;`
+	insertRouteStmt = `INSERT INTO ipps_route (id, vehicle, driver, center, day)
+		VALUES ($1, $2, $3, $4, $5);`
+	insertStopStmt = `INSERT INTO ipps_route_stop (id, route, sequence, parcel, address, latitude, longitude,
+			status)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8);`
+	selectRoute = `SELECT r.id, r.driver, r.center, r.day,
+			v.id, v.name, v.city, v.planet, v.capacity
+		FROM ipps_route r
+		JOIN ipps_vehicle v ON v.id = r.vehicle`
+	routeByIDStmt = selectRoute + `
+		WHERE r.id = $1;`
+	routesByDriverStmt = selectRoute + `
+		WHERE r.driver = $1 AND r.day >= $2::date
+		ORDER BY r.day, v.name;`
+	stopsByRouteStmt = `SELECT s.id, s.sequence, s.parcel, s.latitude, s.longitude, s.status,
+			a.id, a.street, a.zip, a.city, a.country, a.planet
+		FROM ipps_route_stop s
+		JOIN ipps_address a ON a.id = s.address
+		WHERE s.route = $1
+		ORDER BY s.sequence;`
+	setStopStatusStmt = `UPDATE ipps_route_stop SET status = $2 WHERE id = $1;`
+)
+
+// RouteStorage is the type implementing the route.Storage interface.
+type RouteStorage struct {
+	db            *sql.DB
+	insertVehicle *sql.Stmt
+	vehicleByID   *sql.Stmt
+	vehicles      *sql.Stmt
+	insertPlace   *sql.Stmt
+	locate        *sql.Stmt
+	insertRoute   *sql.Stmt
+	insertStop    *sql.Stmt
+	insertEvent   *sql.Stmt
+	lockParcel    *sql.Stmt
+	events        *sql.Stmt
+	byID          *sql.Stmt
+	byDriver      *sql.Stmt
+	stops         *sql.Stmt
+	setStatus     *sql.Stmt
+}
+
+func NewRouteStorage(db *sql.DB) (*RouteStorage, error) {
+	s := &RouteStorage{db: db}
+	var err error
+
+	s.insertVehicle, err = db.Prepare(insertVehicleStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.vehicleByID, err = db.Prepare(vehicleByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.vehicles, err = db.Prepare(vehiclesStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertPlace, err = db.Prepare(insertPlaceStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.locate, err = db.Prepare(locateStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertRoute, err = db.Prepare(insertRouteStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertStop, err = db.Prepare(insertStopStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertEvent, err = db.Prepare(insertParcelEventStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.lockParcel, err = db.Prepare(lockParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.events, err = db.Prepare(parcelEventByParcelStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byID, err = db.Prepare(routeByIDStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byDriver, err = db.Prepare(routesByDriverStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.stops, err = db.Prepare(stopsByRouteStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.setStatus, err = db.Prepare(setStopStatusStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *RouteStorage) InsertVehicle(v *route.Vehicle) error {
+	_, err := s.insertVehicle.Exec(v.ID, v.Name, v.City, v.Planet, v.Capacity)
+
+	return err
+}
+
+func (s *RouteStorage) VehicleByID(id uuid.UUID) (*route.Vehicle, error) {
+	v := &route.Vehicle{}
+	err := s.vehicleByID.QueryRow(id).Scan(&v.ID, &v.Name, &v.City, &v.Planet, &v.Capacity)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func (s *RouteStorage) Vehicles() ([]*route.Vehicle, error) {
+	rows, err := s.vehicles.Query()
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	vv := make([]*route.Vehicle, 0)
+	for rows.Next() {
+		v := &route.Vehicle{}
+		err := rows.Scan(&v.ID, &v.Name, &v.City, &v.Planet, &v.Capacity)
+		if err != nil {
+			return nil, err
+		}
+		vv = append(vv, v)
+	}
+
+	return vv, rows.Err()
+}
+
+func (s *RouteStorage) InsertPlace(p *route.Place) error {
+	_, err := s.insertPlace.Exec(p.Planet, p.City, p.Zip, p.Location.Latitude, p.Location.Longitude)
+
+	return err
+}
+
+func (s *RouteStorage) Locate(planet, city, zip string) (*parcel.Location, error) {
+	l := &parcel.Location{}
+	err := s.locate.QueryRow(planet, city, zip).Scan(&l.Latitude, &l.Longitude)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+
+	return l, nil
+}
+
+// Insert locks the rows of the events' parcels one after another, before it
+// reads their events for check and inserts the events.
+func (s *RouteStorage) Insert(r *route.Route, ee []*parcel.Event,
+	check func(e *parcel.Event, ee []*parcel.Event) error) error {
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(s.insertRoute).Exec(r.ID, r.Vehicle.ID, r.Driver, r.Center,
+		r.Day.Format("2006-01-02"))
+	if pgErr, ok := err.(*pq.Error); ok && pgErr.Constraint == "ipps_route_vehicle_day_key" {
+		tx.Rollback()
+		return route.ErrVehicleBusy
+	} else if err != nil {
+		tx.Rollback()
+		return err
+	}
+	insertStop := tx.Stmt(s.insertStop)
+	for _, st := range r.Stops {
+		var lat, lon *float64
+		if st.Location != nil {
+			lat, lon = &st.Location.Latitude, &st.Location.Longitude
+		}
+		_, err = insertStop.Exec(st.ID, r.ID, st.Sequence, st.ParcelID, st.Address.ID, lat, lon, st.Status)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+	lockParcel, events, insertEvent := tx.Stmt(s.lockParcel), tx.Stmt(s.events), tx.Stmt(s.insertEvent)
+	for _, e := range ee {
+		_, err = lockParcel.Exec(e.Parcel.ID)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		pe, err := queryEvents(events, e.Parcel)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		err = check(e, pe)
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+		_, err = insertEvent.Exec(e.ID, e.Type, e.Time, e.Parcel.ID, e.Reason, deliveryDate(e))
+		if err != nil {
+			tx.Rollback()
+			return err
+		}
+	}
+
+	return tx.Commit()
+}
+
+func (s *RouteStorage) ByID(id uuid.UUID) (*route.Route, error) {
+	r, err := scanRoute(s.byID.QueryRow(id))
+	if err == sql.ErrNoRows {
+		return nil, nil
+	} else if err != nil {
+		return nil, err
+	}
+	r.Stops, err = s.routeStops(r)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
+
+func (s *RouteStorage) ByDriver(id uuid.UUID, from time.Time) ([]*route.Route, error) {
+	rows, err := s.byDriver.Query(id, from.Format("2006-01-02"))
+	if err != nil {
+		return nil, err
+	}
+	rr := make([]*route.Route, 0)
+	for rows.Next() {
+		r, err := scanRoute(rows)
+		if err != nil {
+			rows.Close()
+			return nil, err
+		}
+		rr = append(rr, r)
+	}
+	rows.Close()
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	for _, r := range rr {
+		r.Stops, err = s.routeStops(r)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return rr, nil
+}
+
+func (s *RouteStorage) SetStopStatus(st *route.Stop, status route.StopStatus) error {
+	_, err := s.setStatus.Exec(st.ID, status)
+
+	return err
+}
+
+func (s *RouteStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.insertVehicle, s.vehicleByID, s.vehicles, s.insertPlace, s.locate,
+		s.insertRoute, s.insertStop, s.insertEvent, s.lockParcel, s.events, s.byID, s.byDriver, s.stops, s.setStatus} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// routeStops returns the stops of r ordered by their sequence.
+func (s *RouteStorage) routeStops(r *route.Route) ([]*route.Stop, error) {
+	rows, err := s.stops.Query(r.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	ss := make([]*route.Stop, 0)
+	for rows.Next() {
+		st := &route.Stop{Address: &address.Address{}}
+		var lat, lon sql.NullFloat64
+		err := rows.Scan(&st.ID, &st.Sequence, &st.ParcelID, &lat, &lon, &st.Status,
+			&st.Address.ID, &st.Address.Street, &st.Address.Zip, &st.Address.City, &st.Address.Country,
+			&st.Address.Planet)
+		if err != nil {
+			return nil, err
+		}
+		if lat.Valid && lon.Valid {
+			st.Location = &parcel.Location{Latitude: lat.Float64, Longitude: lon.Float64}
+		}
+		ss = append(ss, st)
+	}
+
+	return ss, rows.Err()
+}
+
+func scanRoute(row rowScanner) (*route.Route, error) {
+	r := &route.Route{Vehicle: &route.Vehicle{}}
+	err := row.Scan(&r.ID, &r.Driver, &r.Center, &r.Day,
+		&r.Vehicle.ID, &r.Vehicle.Name, &r.Vehicle.City, &r.Vehicle.Planet, &r.Vehicle.Capacity)
+	if err != nil {
+		return nil, err
+	}
+
+	return r, nil
+}
@@ -15,21 +15,24 @@ const (
 							username           varchar(128) NOT NULL CONSTRAINT ipps_user_username_key UNIQUE,
 							email              varchar(128) NOT NULL CONSTRAINT ipps_user_email_key UNIQUE,
 							full_name          varchar(128) NOT NULL,
-                            password           varchar(64)  NOT NULL
+                            password           varchar(64)  NOT NULL,
+							operator           boolean      NOT NULL DEFAULT false
 						);`
+	migrateUserTable = `ALTER TABLE ipps_user
+						ADD COLUMN IF NOT EXISTS operator boolean NOT NULL DEFAULT false;`
 	insertUserStmt = `INSERT INTO ipps_user (id, username, email, password, full_name)
                       VALUES ($1, $2, $3, $4, $5);`
 	updateUserStmt = `UPDATE ipps_user
-                      SET (email, password, full_name) = ($2, $3, $4)
+                      SET (email, password, full_name, operator) = ($2, $3, $4, $5)
                       WHERE id = $1;`
 	deleteUserStmt = `DELETE FROM ipps_user WHERE id = $1;`
-	userByIDStmt   = `SELECT id, username, email, password, full_name
+	userByIDStmt   = `SELECT id, username, email, password, full_name, operator
                       FROM ipps_user
 					  WHERE id = $1;`
-	userByNameStmt = `SELECT id, username, email, password, full_name
+	userByNameStmt = `SELECT id, username, email, password, full_name, operator
                       FROM ipps_user
                       WHERE username = $1;`
-	userByEmailStmt = `SELECT id, username, email, password, full_name
+	userByEmailStmt = `SELECT id, username, email, password, full_name, operator
                       FROM ipps_user
                       WHERE email = $1;`
 )
@@ -111,7 +114,7 @@ func (us *UserStorage) ByUsername(username string) (*user.User, error) {
 }
 
 func (us *UserStorage) Update(user *user.User) error {
-	_, err := us.update.Exec(user.ID, user.Email.Address, user.Password, user.Name)
+	_, err := us.update.Exec(user.ID, user.Email.Address, user.Password, user.Name, user.Operator)
 	return err
 }
 
@@ -149,7 +152,7 @@ func (us *UserStorage) Close() error {
 func userFromRow(row *sql.Row) (*user.User, error) {
 	u := &user.User{}
 	var email string
-	err := row.Scan(&u.ID, &u.Username, &email, &u.Password, &u.Name)
+	err := row.Scan(&u.ID, &u.Username, &email, &u.Password, &u.Name, &u.Operator)
 	if err == sql.ErrNoRows {
 		return nil, user.ErrUserNotExists
 	} else if err != nil {
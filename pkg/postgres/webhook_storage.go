@@ -0,0 +1,318 @@
+package postgres
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/webhook"
+)
+
+const (
+	installWebhookTable = `CREATE TABLE IF NOT EXISTS ipps_webhook (
+		id          uuid      PRIMARY KEY DEFAULT gen_random_uuid(),
+		url         text      NOT NULL,
+		secret      text      NOT NULL,
+		event_types integer[] NOT NULL DEFAULT '{}',
+		user_id     uuid      NOT NULL CONSTRAINT ipps_webhook_user_fkey
+			REFERENCES ipps_user ON DELETE CASCADE ON UPDATE CASCADE
+	);`
+	installWebhookDeliveryTable = `CREATE TABLE IF NOT EXISTS ipps_webhook_delivery (
+		id              uuid        PRIMARY KEY DEFAULT gen_random_uuid(),
+		webhook         uuid        NOT NULL CONSTRAINT ipps_webhook_delivery_webhook_fkey
+			REFERENCES ipps_webhook (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		event           uuid        NOT NULL CONSTRAINT ipps_webhook_delivery_event_fkey
+			REFERENCES ipps_parcel_event (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		event_type      integer     NOT NULL,
+		parcel          uuid        NOT NULL,
+		payload         text        NOT NULL,
+		status          text        NOT NULL DEFAULT 'pending',
+		attempts        integer     NOT NULL DEFAULT 0,
+		next_attempt    timestamptz NOT NULL DEFAULT now(),
+		last_attempt    timestamptz,
+		response_status integer     NOT NULL DEFAULT 0,
+		last_error      text        NOT NULL DEFAULT '',
+		created         timestamptz NOT NULL DEFAULT now()
+	);
+	CREATE INDEX IF NOT EXISTS ipps_webhook_delivery_due
+		ON ipps_webhook_delivery (next_attempt) WHERE status = 'pending';`
+	installWebhookDeadLetterTable = `CREATE TABLE IF NOT EXISTS ipps_webhook_dead_letter (
+		delivery   uuid        PRIMARY KEY CONSTRAINT ipps_webhook_dead_letter_delivery_fkey
+			REFERENCES ipps_webhook_delivery (id) ON DELETE CASCADE ON UPDATE CASCADE,
+		payload    text        NOT NULL,
+		last_error text        NOT NULL,
+		failed_at  timestamptz NOT NULL DEFAULT now()
+	);`
+	insertWebhookStmt = `INSERT INTO ipps_webhook (id, url, secret, event_types, user_id)
+	                     VALUES ($1, $2, $3, $4, $5);`
+	webhookByUserStmt = `SELECT id, url, secret, event_types
+	                     FROM ipps_webhook
+	                     WHERE user_id = $1;`
+	deleteWebhookStmt = `DELETE FROM ipps_webhook
+	                     WHERE id = $1 AND user_id = $2;`
+	// enqueueWebhookDeliveryStmt creates deliveries for the subscriptions of
+	// the user owning the parcel's return address.
+	enqueueWebhookDeliveryStmt = `INSERT INTO ipps_webhook_delivery (webhook, event, event_type, parcel, payload)
+		SELECT w.id, $1, $2, p.id, $4
+		FROM ipps_webhook w
+		JOIN ipps_address a ON a.user_id = w.user_id
+		JOIN ipps_parcel p ON p.return_address = a.id
+		WHERE p.id = $3 AND (cardinality(w.event_types) = 0 OR $2 = ANY (w.event_types));`
+	claimWebhookDeliveryStmt = `UPDATE ipps_webhook_delivery d
+		SET next_attempt = now() + $2 * interval '1 second'
+		FROM ipps_webhook w
+		WHERE d.webhook = w.id AND d.id IN (
+			SELECT id FROM ipps_webhook_delivery
+			WHERE status = 'pending' AND next_attempt <= now()
+			ORDER BY next_attempt
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED)
+		RETURNING ` + webhookDeliveryColumns + `, w.url, w.secret;`
+	updateWebhookDeliveryStmt = `UPDATE ipps_webhook_delivery
+		SET (status, attempts, next_attempt, last_attempt, response_status, last_error) = ($2, $3, $4, $5, $6, $7)
+		WHERE id = $1;`
+	insertWebhookDeadLetterStmt = `INSERT INTO ipps_webhook_dead_letter (delivery, payload, last_error)
+		VALUES ($1, $2, $3);`
+	webhookDeliveryByUserStmt = `SELECT ` + webhookDeliveryColumns + `, w.url, w.secret
+		FROM ipps_webhook_delivery d
+		JOIN ipps_webhook w ON d.webhook = w.id
+		WHERE w.user_id = $1
+		ORDER BY d.created DESC
+		LIMIT $2;`
+	webhookDeliveryColumns = `d.id, d.webhook, d.event, d.event_type, d.parcel, d.payload, d.status,
+		d.attempts, d.next_attempt, d.last_attempt, d.response_status, d.last_error, d.created`
+)
+
+// WebhookStorage is the type implementing the webhook.Storage interface.
+type WebhookStorage struct {
+	insert *sql.Stmt
+	byUser *sql.Stmt
+	delete *sql.Stmt
+}
+
+func NewWebhookStorage(db *sql.DB) (*WebhookStorage, error) {
+	s := &WebhookStorage{}
+	var err error
+
+	s.insert, err = db.Prepare(insertWebhookStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byUser, err = db.Prepare(webhookByUserStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.delete, err = db.Prepare(deleteWebhookStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *WebhookStorage) Insert(w *webhook.Subscription) error {
+	_, err := s.insert.Exec(w.ID, w.URL, w.Secret, pq.Array(eventTypeInts(w.EventTypes)), w.User.ID)
+
+	return err
+}
+
+func (s *WebhookStorage) ByUser(u *user.User) ([]*webhook.Subscription, error) {
+	rows, err := s.byUser.Query(u.ID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var ww []*webhook.Subscription
+	for rows.Next() {
+		w := &webhook.Subscription{User: u}
+		var tt []int64
+		err := rows.Scan(&w.ID, &w.URL, &w.Secret, pq.Array(&tt))
+		if err != nil {
+			return nil, err
+		}
+		w.EventTypes = eventTypes(tt)
+		ww = append(ww, w)
+	}
+
+	return ww, rows.Err()
+}
+
+func (s *WebhookStorage) Delete(w *webhook.Subscription) error {
+	_, err := s.delete.Exec(w.ID, w.User.ID)
+
+	return err
+}
+
+func (s *WebhookStorage) Close() error {
+	err := s.insert.Close()
+	if err != nil {
+		return err
+	}
+	err = s.byUser.Close()
+	if err != nil {
+		return err
+	}
+
+	return s.delete.Close()
+}
+
+func eventTypeInts(tt []parcel.EventType) []int64 {
+	ii := make([]int64, len(tt))
+	for i, t := range tt {
+		ii[i] = int64(t)
+	}
+
+	return ii
+}
+
+func eventTypes(ii []int64) []parcel.EventType {
+	tt := make([]parcel.EventType, len(ii))
+	for i, n := range ii {
+		tt[i] = parcel.EventType(n)
+	}
+
+	return tt
+}
+
+// WebhookDeliveryStorage is the type implementing the
+// webhook.DeliveryStorage interface.
+type WebhookDeliveryStorage struct {
+	db               *sql.DB
+	enqueue          *sql.Stmt
+	claim            *sql.Stmt
+	update           *sql.Stmt
+	insertDeadLetter *sql.Stmt
+	byUser           *sql.Stmt
+}
+
+func NewWebhookDeliveryStorage(db *sql.DB) (*WebhookDeliveryStorage, error) {
+	s := &WebhookDeliveryStorage{db: db}
+	var err error
+
+	s.enqueue, err = db.Prepare(enqueueWebhookDeliveryStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.claim, err = db.Prepare(claimWebhookDeliveryStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.update, err = db.Prepare(updateWebhookDeliveryStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.insertDeadLetter, err = db.Prepare(insertWebhookDeadLetterStmt)
+	if err != nil {
+		return nil, err
+	}
+	s.byUser, err = db.Prepare(webhookDeliveryByUserStmt)
+	if err != nil {
+		return nil, err
+	}
+
+	return s, nil
+}
+
+func (s *WebhookDeliveryStorage) Enqueue(e *parcel.Event, payload []byte) error {
+	_, err := s.enqueue.Exec(e.ID, e.Type, e.Parcel.ID, string(payload))
+
+	return err
+}
+
+func (s *WebhookDeliveryStorage) Claim(n int, lease time.Duration) ([]*webhook.Delivery, error) {
+	rows, err := s.claim.Query(n, lease.Seconds())
+	if err != nil {
+		return nil, err
+	}
+
+	return scanDeliveries(rows)
+}
+
+func (s *WebhookDeliveryStorage) Update(d *webhook.Delivery) error {
+	_, err := s.update.Exec(d.ID, d.Status, d.Attempts, d.NextAttempt, nullTime(d.LastAttempt),
+		d.ResponseStatus, d.LastError)
+
+	return err
+}
+
+func (s *WebhookDeliveryStorage) DeadLetter(d *webhook.Delivery) error {
+	d.Status = webhook.Failed
+	tx, err := s.db.Begin()
+	if err != nil {
+		return err
+	}
+	_, err = tx.Stmt(s.update).Exec(d.ID, d.Status, d.Attempts, d.NextAttempt, nullTime(d.LastAttempt),
+		d.ResponseStatus, d.LastError)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+	_, err = tx.Stmt(s.insertDeadLetter).Exec(d.ID, string(d.Payload), d.LastError)
+	if err != nil {
+		tx.Rollback()
+		return err
+	}
+
+	return tx.Commit()
+}
+
+func (s *WebhookDeliveryStorage) ByUser(u *user.User, n int) ([]*webhook.Delivery, error) {
+	rows, err := s.byUser.Query(u.ID, n)
+	if err != nil {
+		return nil, err
+	}
+
+	dd, err := scanDeliveries(rows)
+	for _, d := range dd {
+		d.Subscription.User = u
+	}
+
+	return dd, err
+}
+
+func (s *WebhookDeliveryStorage) Close() error {
+	for _, stmt := range []*sql.Stmt{s.enqueue, s.claim, s.update, s.insertDeadLetter, s.byUser} {
+		err := stmt.Close()
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// scanDeliveries scans and closes rows selecting webhookDeliveryColumns
+// followed by the subscription's URL and secret.
+func scanDeliveries(rows *sql.Rows) ([]*webhook.Delivery, error) {
+	defer rows.Close()
+
+	var dd []*webhook.Delivery
+	for rows.Next() {
+		d := &webhook.Delivery{Subscription: &webhook.Subscription{}}
+		var payload string
+		var lastAttempt pq.NullTime
+		err := rows.Scan(&d.ID, &d.Subscription.ID, &d.EventID, &d.EventType, &d.ParcelID, &payload,
+			&d.Status, &d.Attempts, &d.NextAttempt, &lastAttempt, &d.ResponseStatus, &d.LastError,
+			&d.Created, &d.Subscription.URL, &d.Subscription.Secret)
+		if err != nil {
+			return nil, err
+		}
+		d.Payload = []byte(payload)
+		d.LastAttempt = lastAttempt.Time
+		dd = append(dd, d)
+	}
+
+	return dd, rows.Err()
+}
+
+// nullTime returns t or nil, if t is the zero time.
+func nullTime(t time.Time) interface{} {
+	if t.IsZero() {
+		return nil
+	}
+
+	return t
+}
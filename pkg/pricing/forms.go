@@ -0,0 +1,53 @@
+package pricing
+
+import (
+	"math"
+	"net/http"
+
+	"github.com/gorilla/schema"
+)
+
+var formDecoder = schema.NewDecoder()
+
+type quoteForm struct {
+	Weight       float64 `schema:"weight,required"`
+	Length       float64 `schema:"length,required"`
+	Width        float64 `schema:"width,required"`
+	Height       float64 `schema:"height,required"`
+	From         string  `schema:"from,required"`
+	To           string  `schema:"to,required"`
+	ServiceLevel string  `schema:"service-level"`
+	// DeclaredValue is given in the currency's main unit, e.g. euros.
+	DeclaredValue float64 `schema:"declared-value"`
+	Insured       bool    `schema:"insured"`
+}
+
+// ParseQuoteForm parses r's post form into a price request.
+func ParseQuoteForm(r *http.Request) (*Request, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	f := &quoteForm{}
+	err = formDecoder.Decode(f, r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+
+	req := &Request{
+		Weight:        f.Weight,
+		Length:        f.Length,
+		Width:         f.Width,
+		Height:        f.Height,
+		From:          f.From,
+		To:            f.To,
+		DeclaredValue: Amount(math.Round(f.DeclaredValue * 100)),
+		Insured:       f.Insured,
+	}
+	err = req.ServiceLevel.UnmarshalText([]byte(f.ServiceLevel))
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
@@ -0,0 +1,406 @@
+// Package pricing computes the prices of sending parcels and manages the
+// quotes given to customers.
+package pricing
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/orbit"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrInvalidWeight     = errors.New("pricing: the weight must be positive")
+	ErrTooHeavy          = errors.New("pricing: the parcel is too heavy")
+	ErrInvalidDimensions = errors.New("pricing: the dimensions must be positive")
+	ErrPlanetMissing     = errors.New("pricing: the origin and destination planets are required")
+	ErrUninsurable       = errors.New("pricing: only parcels with a declared value may be insured")
+	ErrQuoteNotFound     = errors.New("pricing: the quote does not exist")
+	ErrQuoteExpired      = errors.New("pricing: the quote has expired")
+	ErrQuoteForeign      = errors.New("pricing: the quote belongs to another customer")
+	ErrQuoteRoute        = errors.New("pricing: the quote is for different planets")
+	ErrQuoteMismatch     = errors.New("pricing: the quote is for a smaller, lighter, slower or less insured parcel")
+)
+
+// Amount is an amount of money in cents like the declared values of
+// parcels.
+type Amount = parcel.Cents
+
+// Surcharges configures the surcharges added to the price of parcels.
+type Surcharges struct {
+	// Interplanetary is added, if the parcel is sent to another planet.
+	Interplanetary Amount
+	// Oversize is added, if the parcel's longest side in centimeters
+	// exceeds OversizeLength.
+	Oversize       Amount
+	OversizeLength float64 `toml:"oversize_length"`
+	// Heavy is added, if the parcel's weight in kilograms exceeds
+	// HeavyWeight.
+	Heavy       Amount
+	HeavyWeight float64 `toml:"heavy_weight"`
+	// FuelPercent is the fuel surcharge in percent of the net price.
+	FuelPercent float64 `toml:"fuel_percent"`
+}
+
+// Insurance configures the premium of insuring a parcel for its declared
+// value.
+type Insurance struct {
+	// Percent is the premium in percent of the declared value.
+	Percent float64
+	// Minimum is the minimum premium.
+	Minimum Amount
+}
+
+// Config configures the prices. All amounts are given in cents.
+type Config struct {
+	Currency string
+	// Base is the price of every parcel.
+	Base Amount
+	// PerKg is the price per started kilogram of chargeable weight, which
+	// is the greater of the actual and the volumetric weight.
+	PerKg Amount `toml:"per_kg"`
+	// VolumetricDivisor is the volume in cubic centimeters, which is
+	// charged like a kilogram.
+	VolumetricDivisor float64 `toml:"volumetric_divisor"`
+	// PerAU is the price per astronomical unit between the planets.
+	PerAU Amount `toml:"per_au"`
+	// UnknownDistance is the distance in astronomical units assumed for
+	// planets unknown to the orbital model.
+	UnknownDistance float64 `toml:"unknown_distance"`
+	// ExpressFactor multiplies the net price of express parcels.
+	ExpressFactor float64 `toml:"express_factor"`
+	// Validity is the time for which quotes may be accepted, e.g. "72h".
+	Validity   string
+	Surcharges Surcharges
+	Insurance  Insurance
+}
+
+// DefaultConfig is used, if there is no pricing configuration.
+var DefaultConfig = Config{
+	Currency:          "EUR",
+	Base:              499,
+	PerKg:             150,
+	VolumetricDivisor: 5000,
+	PerAU:             2000,
+	UnknownDistance:   5,
+	ExpressFactor:     1.8,
+	Validity:          "72h",
+	Surcharges: Surcharges{
+		Interplanetary: 1000,
+		Oversize:       1500,
+		OversizeLength: 120,
+		Heavy:          2000,
+		HeavyWeight:    30,
+		FuelPercent:    5,
+	},
+	Insurance: Insurance{
+		Percent: 1.5,
+		Minimum: 250,
+	},
+}
+
+// Request describes the parcel, for which a price is requested.
+type Request struct {
+	// Weight is the parcel's weight in kilograms.
+	Weight float64 `json:"weight"`
+	// Length, Width and Height are the parcel's dimensions in centimeters.
+	Length       float64             `json:"length"`
+	Width        float64             `json:"width"`
+	Height       float64             `json:"height"`
+	From         string              `json:"from"`
+	To           string              `json:"to"`
+	ServiceLevel parcel.ServiceLevel `json:"serviceLevel"`
+	// DeclaredValue is the value of the parcel's contents, for which it is
+	// insured, if Insured is true.
+	DeclaredValue Amount `json:"declaredValue"`
+	Insured       bool   `json:"insured"`
+}
+
+func (r *Request) validate() error {
+	if r.Weight <= 0 || !finite(r.Weight) {
+		return ErrInvalidWeight
+	} else if r.Weight > parcel.MaxWeight {
+		return ErrTooHeavy
+	} else if r.Length <= 0 || r.Width <= 0 || r.Height <= 0 || !finite(r.Length, r.Width, r.Height) {
+		return ErrInvalidDimensions
+	} else if strings.TrimSpace(r.From) == "" || strings.TrimSpace(r.To) == "" {
+		return ErrPlanetMissing
+	} else if r.Insured && r.DeclaredValue <= 0 {
+		return ErrUninsurable
+	}
+
+	return nil
+}
+
+// finite reports whether all of ff are neither NaN nor infinite, since
+// prices cannot be computed for such a parcel.
+func finite(ff ...float64) bool {
+	for _, f := range ff {
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// covers reports whether r's price applies to a parcel with the attributes
+// a. The dimensions are compared after sorting them, so that a parcel may
+// be turned on its side. Insured parcels must not be declared with a higher
+// value than the insured one.
+func (r *Request) covers(a *parcel.Attributes) bool {
+	rd := []float64{r.Length, r.Width, r.Height}
+	ad := []float64{a.Length, a.Width, a.Height}
+	sort.Float64s(rd)
+	sort.Float64s(ad)
+	for i := range rd {
+		if ad[i] > rd[i] {
+			return false
+		}
+	}
+
+	if a.Insured && (!r.Insured || a.DeclaredValue > r.DeclaredValue) {
+		return false
+	}
+
+	return a.Weight <= r.Weight && a.ServiceLevel == r.ServiceLevel
+}
+
+// Line is a single item of a price.
+type Line struct {
+	Description string `json:"description"`
+	Amount      Amount `json:"amount"`
+}
+
+// Quote is a price offered to a customer, which may be accepted when
+// sending a parcel until it expires.
+type Quote struct {
+	ID uuid.UUID `json:"id"`
+	// User is the customer who requested the quote or nil, if the quote
+	// has been requested anonymously and may be accepted by anyone.
+	User     *user.User `json:"-"`
+	Request  Request    `json:"request"`
+	Lines    []Line     `json:"lines"`
+	Total    Amount     `json:"total"`
+	Currency string     `json:"currency"`
+	Created  time.Time  `json:"created"`
+	Expires  time.Time  `json:"expires"`
+}
+
+// Service computes prices and stores the resulting quotes.
+type Service struct {
+	conf     Config
+	validity time.Duration
+	quotes   Storage
+}
+
+// New returns a service pricing parcels as configured by conf, which uses
+// DefaultConfig if it is nil, and storing the quotes in s.
+func New(conf *Config, s Storage) (*Service, error) {
+	if conf == nil {
+		conf = &DefaultConfig
+	}
+	if conf.VolumetricDivisor <= 0 || conf.ExpressFactor <= 0 {
+		return nil, errors.New("pricing: the volumetric divisor and express factor must be positive")
+	}
+	validity, err := time.ParseDuration(conf.Validity)
+	if err != nil {
+		return nil, fmt.Errorf("pricing: invalid validity: %v", err)
+	}
+
+	return &Service{conf: *conf, validity: validity, quotes: s}, nil
+}
+
+// Price computes the price of sending the parcel described by r at time t.
+func (s *Service) Price(r *Request, t time.Time) (*Quote, error) {
+	err := r.validate()
+	if err != nil {
+		return nil, err
+	}
+
+	return s.price(r, t), nil
+}
+
+// ParcelPrice returns the price of sending p, which is the quote accepted
+// for p or, if p has been sent without one, the price of p's attributes
+// and planets at time t.
+func (s *Service) ParcelPrice(p *parcel.Parcel, t time.Time) (*Quote, error) {
+	if p.QuoteID != nil {
+		q, err := s.quotes.ByID(*p.QuoteID)
+		if err != nil {
+			return nil, err
+		} else if q != nil {
+			return q, nil
+		}
+	}
+	r := &Request{
+		Weight:        p.Weight,
+		Length:        p.Length,
+		Width:         p.Width,
+		Height:        p.Height,
+		ServiceLevel:  p.ServiceLevel,
+		DeclaredValue: p.DeclaredValue,
+		Insured:       p.Insured,
+	}
+	if p.ReturnAddress != nil {
+		r.From = p.ReturnAddress.Planet
+	}
+	if p.DestinationAddress != nil {
+		r.To = p.DestinationAddress.Planet
+	}
+
+	// The attributes have been validated when sending the parcel, whose
+	// addresses may lack a planet.
+	return s.price(r, t), nil
+}
+
+// price computes the price of sending the parcel described by the valid
+// request r at time t.
+func (s *Service) price(r *Request, t time.Time) *Quote {
+	c := &s.conf
+	q := &Quote{
+		Request:  *r,
+		Currency: c.Currency,
+		Created:  t,
+		Expires:  t.Add(s.validity),
+	}
+	q.add("Base rate", c.Base)
+
+	volumetric := r.Length * r.Width * r.Height / c.VolumetricDivisor
+	kg := math.Ceil(math.Max(r.Weight, volumetric))
+	q.add(fmt.Sprintf("Weight (%.0f kg)", kg), Amount(kg)*c.PerKg)
+
+	if !samePlanet(r.From, r.To) {
+		d := s.distance(r.From, r.To, t)
+		q.add(fmt.Sprintf("Distance (%.2f AU)", d), Amount(math.Ceil(d*float64(c.PerAU))))
+		q.add("Interplanetary handling", c.Surcharges.Interplanetary)
+	}
+	if math.Max(r.Length, math.Max(r.Width, r.Height)) > c.Surcharges.OversizeLength {
+		q.add("Oversize surcharge", c.Surcharges.Oversize)
+	}
+	if r.Weight > c.Surcharges.HeavyWeight {
+		q.add("Heavy parcel surcharge", c.Surcharges.Heavy)
+	}
+
+	net := q.Total
+	if r.ServiceLevel == parcel.Express {
+		q.add("Express service", Amount(math.Ceil(float64(net)*(c.ExpressFactor-1))))
+	}
+	if c.Surcharges.FuelPercent > 0 {
+		q.add("Fuel surcharge", Amount(math.Ceil(float64(net)*c.Surcharges.FuelPercent/100)))
+	}
+	if r.Insured {
+		q.add(fmt.Sprintf("Insurance (%s %s)", r.DeclaredValue, c.Currency), s.Premium(r.DeclaredValue))
+	}
+
+	return q
+}
+
+// Premium returns the premium of insuring a parcel for the declared value
+// v.
+func (s *Service) Premium(v Amount) Amount {
+	p := Amount(math.Ceil(float64(v) * s.conf.Insurance.Percent / 100))
+	if p < s.conf.Insurance.Minimum {
+		return s.conf.Insurance.Minimum
+	}
+
+	return p
+}
+
+func (q *Quote) add(description string, a Amount) {
+	if a == 0 {
+		return
+	}
+	q.Lines = append(q.Lines, Line{Description: description, Amount: a})
+	q.Total += a
+}
+
+func samePlanet(a, b string) bool {
+	return strings.EqualFold(strings.TrimSpace(a), strings.TrimSpace(b))
+}
+
+// distance returns the distance in astronomical units between the planets
+// called from and to at time t.
+func (s *Service) distance(from, to string, t time.Time) float64 {
+	a, b := orbit.Lookup(from), orbit.Lookup(to)
+	if a == nil || b == nil {
+		return s.conf.UnknownDistance
+	}
+
+	return orbit.Distance(a, b, t)
+}
+
+// Quote computes the price of sending the parcel described by r for u,
+// who may be nil, and stores the resulting quote.
+func (s *Service) Quote(r *Request, u *user.User) (*Quote, error) {
+	q, err := s.Price(r, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	q.ID, err = uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	q.User = u
+	err = s.quotes.Insert(q)
+	if err != nil {
+		return nil, err
+	}
+
+	return q, nil
+}
+
+// Accept implements the parcel.QuoteAccepter interface. The quote must
+// not have expired, it must be the sender's or an anonymous quote and it
+// must be for the planets of p's addresses. p must neither be heavier nor
+// larger than the quoted parcel and must have the quoted service level.
+func (s *Service) Accept(id uuid.UUID, p *parcel.Parcel, sender *user.User) error {
+	q, err := s.quotes.ByID(id)
+	if err != nil {
+		return err
+	} else if q == nil {
+		return ErrQuoteNotFound
+	}
+	if time.Now().After(q.Expires) {
+		return ErrQuoteExpired
+	} else if q.User != nil && (sender == nil || q.User.ID != sender.ID) {
+		return ErrQuoteForeign
+	} else if p.ReturnAddress == nil || p.DestinationAddress == nil ||
+		!samePlanet(q.Request.From, p.ReturnAddress.Planet) ||
+		!samePlanet(q.Request.To, p.DestinationAddress.Planet) {
+		return ErrQuoteRoute
+	} else if !q.Request.covers(&p.Attributes) {
+		return ErrQuoteMismatch
+	}
+
+	return nil
+}
+
+// IsInvalid reports whether err has been returned by Price or Quote,
+// because the request is invalid.
+func IsInvalid(err error) bool {
+	switch err {
+	case ErrInvalidWeight, ErrTooHeavy, ErrInvalidDimensions, ErrPlanetMissing, ErrUninsurable:
+		return true
+	default:
+		return false
+	}
+}
+
+// IsRejection reports whether err has been returned by Accept, because the
+// quote may not be used for the parcel.
+func IsRejection(err error) bool {
+	switch err {
+	case ErrQuoteNotFound, ErrQuoteExpired, ErrQuoteForeign, ErrQuoteRoute, ErrQuoteMismatch:
+		return true
+	default:
+		return false
+	}
+}
@@ -0,0 +1,98 @@
+package pricing
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+func TestPrice(t *testing.T) {
+	s, err := New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		req  Request
+		want Amount
+	}{
+		// 499 base + 2 * 150 per kg + 40 fuel
+		{"standard", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "earth"}, 839},
+		// 499 base + 12 * 150 volumetric kg + 115 fuel
+		{"volumetric weight", Request{Weight: 1, Length: 50, Width: 40, Height: 30, From: "Earth", To: "Earth"},
+			2414},
+		// 499 base + 3 * 150 volumetric kg + 1500 oversize + 123 fuel
+		{"oversize", Request{Weight: 1, Length: 130, Width: 10, Height: 10, From: "Earth", To: "Earth"}, 2572},
+		// 499 base + 31 * 150 per kg + 2000 heavy + 358 fuel
+		{"heavy", Request{Weight: 31, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Earth"}, 7507},
+		// 839 standard + 640 express
+		{"express", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Earth",
+			ServiceLevel: parcel.Express}, 1479},
+		// 839 standard + 250 minimum premium
+		{"minimum premium", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Earth",
+			DeclaredValue: 10000, Insured: true}, 1089},
+		// 839 standard + 1500 premium
+		{"premium", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Earth",
+			DeclaredValue: 100000, Insured: true}, 2339},
+		// 499 base + 2 * 150 per kg + 5 AU * 2000 + 1000 interplanetary + 590 fuel
+		{"unknown planets", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Vulcan", To: "Kronos"},
+			12389},
+		// Uninsured parcels do not pay a premium for their declared value.
+		{"uninsured", Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Earth",
+			DeclaredValue: 100000}, 839},
+	}
+	for _, tt := range tests {
+		q, err := s.Price(&tt.req, time.Now())
+		if err != nil {
+			t.Errorf("%s: Price() returned %v", tt.name, err)
+			continue
+		}
+		if q.Total != tt.want {
+			t.Errorf("%s: Price() = %v, want %v", tt.name, q.Total, tt.want)
+		}
+		var sum Amount
+		for _, l := range q.Lines {
+			sum += l.Amount
+		}
+		if sum != q.Total {
+			t.Errorf("%s: the lines add up to %v instead of %v", tt.name, sum, q.Total)
+		}
+	}
+}
+
+func TestPriceInvalid(t *testing.T) {
+	s, err := New(nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	valid := Request{Weight: 2, Length: 10, Width: 10, Height: 10, From: "Earth", To: "Mars"}
+	tests := []struct {
+		name   string
+		modify func(r *Request)
+		want   error
+	}{
+		{"zero weight", func(r *Request) { r.Weight = 0 }, ErrInvalidWeight},
+		{"negative weight", func(r *Request) { r.Weight = -1 }, ErrInvalidWeight},
+		{"NaN weight", func(r *Request) { r.Weight = math.NaN() }, ErrInvalidWeight},
+		{"infinite weight", func(r *Request) { r.Weight = math.Inf(1) }, ErrInvalidWeight},
+		{"too heavy", func(r *Request) { r.Weight = parcel.MaxWeight + 1 }, ErrTooHeavy},
+		{"zero length", func(r *Request) { r.Length = 0 }, ErrInvalidDimensions},
+		{"NaN width", func(r *Request) { r.Width = math.NaN() }, ErrInvalidDimensions},
+		{"infinite height", func(r *Request) { r.Height = math.Inf(1) }, ErrInvalidDimensions},
+		{"origin missing", func(r *Request) { r.From = " " }, ErrPlanetMissing},
+		{"destination missing", func(r *Request) { r.To = "" }, ErrPlanetMissing},
+		{"insured without value", func(r *Request) { r.Insured = true }, ErrUninsurable},
+	}
+	for _, tt := range tests {
+		r := valid
+		tt.modify(&r)
+		_, err := s.Price(&r, time.Now())
+		if err != tt.want {
+			t.Errorf("%s: Price() returned %v, want %v", tt.name, err, tt.want)
+		} else if !IsInvalid(err) {
+			t.Errorf("%s: IsInvalid(%v) = false", tt.name, err)
+		}
+	}
+}
@@ -0,0 +1,14 @@
+package pricing
+
+import (
+	"github.com/google/uuid"
+)
+
+// Storage is the interface for storing quotes.
+//
+// ByID returns nil, if there is no quote identified by id. The returned
+// quote's user only has its ID set.
+type Storage interface {
+	Insert(q *Quote) error
+	ByID(id uuid.UUID) (*Quote, error)
+}
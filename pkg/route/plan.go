@@ -0,0 +1,98 @@
+package route
+
+import (
+	"math"
+	"sort"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// distance returns the central angle between a and b in radians, which is
+// proportional to their distance on any planet.
+func distance(a, b *parcel.Location) float64 {
+	rad := math.Pi / 180
+	lat1, lat2 := a.Latitude*rad, b.Latitude*rad
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * rad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+
+	return 2 * math.Asin(math.Min(1, math.Sqrt(h)))
+}
+
+// order orders the stops ss and numbers them. The located stops are visited
+// first, starting with the one with the lowest zip code, in the order found
+// by the nearest neighbour heuristic, which is then improved by 2-opt. The
+// stops missing in the geodata table are appended ordered by their zip
+// codes, so that those in the same area are still visited together.
+func order(ss []*Stop) {
+	sort.SliceStable(ss, func(i, j int) bool {
+		return ss[i].Address.Zip < ss[j].Address.Zip
+	})
+	var located, unknown []*Stop
+	for _, st := range ss {
+		if st.Location != nil {
+			located = append(located, st)
+		} else {
+			unknown = append(unknown, st)
+		}
+	}
+
+	nearestNeighbour(located)
+	twoOpt(located)
+	for i, st := range append(located, unknown...) {
+		ss[i] = st
+		st.Sequence = i + 1
+	}
+}
+
+// nearestNeighbour orders ss in place, such that every stop is followed by
+// the nearest stop not visited yet.
+func nearestNeighbour(ss []*Stop) {
+	for i := 1; i < len(ss); i++ {
+		best := i
+		for j := i + 1; j < len(ss); j++ {
+			if distance(ss[i-1].Location, ss[j].Location) < distance(ss[i-1].Location, ss[best].Location) {
+				best = j
+			}
+		}
+		ss[i], ss[best] = ss[best], ss[i]
+	}
+}
+
+// maxTwoOptRounds limits the number of improvement rounds of twoOpt.
+const maxTwoOptRounds = 100
+
+// twoOpt shortens the path through ss, which starts at its first stop, by
+// reversing segments of it, as long as that makes the path shorter.
+func twoOpt(ss []*Stop) {
+	d := func(i, j int) float64 {
+		return distance(ss[i].Location, ss[j].Location)
+	}
+	for round := 0; round < maxTwoOptRounds; round++ {
+		improved := false
+		for i := 1; i < len(ss)-1; i++ {
+			for k := i + 1; k < len(ss); k++ {
+				before := d(i-1, i)
+				after := d(i-1, k)
+				if k+1 < len(ss) {
+					before += d(k, k+1)
+					after += d(i, k+1)
+				}
+				if after < before-1e-12 {
+					reverse(ss[i : k+1])
+					improved = true
+				}
+			}
+		}
+		if !improved {
+			return
+		}
+	}
+}
+
+func reverse(ss []*Stop) {
+	for i, j := 0, len(ss)-1; i < j; i, j = i+1, j-1 {
+		ss[i], ss[j] = ss[j], ss[i]
+	}
+}
@@ -0,0 +1,104 @@
+package route
+
+import (
+	"math"
+	"testing"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// testStop returns a stop at zip, which is located at the latitude lat and
+// longitude lon, unless located is false.
+func testStop(zip string, located bool, lat, lon float64) *Stop {
+	st := &Stop{Address: &address.Address{Zip: zip}}
+	if located {
+		st.Location = &parcel.Location{Latitude: lat, Longitude: lon}
+	}
+
+	return st
+}
+
+// length returns the length of the path through ss in radians.
+func length(ss []*Stop) float64 {
+	var l float64
+	for i := 1; i < len(ss); i++ {
+		l += distance(ss[i-1].Location, ss[i].Location)
+	}
+
+	return l
+}
+
+func TestDistance(t *testing.T) {
+	tests := []struct {
+		a, b parcel.Location
+		want float64
+	}{
+		{parcel.Location{Latitude: 49.6, Longitude: 11}, parcel.Location{Latitude: 49.6, Longitude: 11}, 0},
+		{parcel.Location{}, parcel.Location{Longitude: 90}, math.Pi / 2},
+		{parcel.Location{}, parcel.Location{Latitude: -90}, math.Pi / 2},
+		{parcel.Location{Latitude: 90}, parcel.Location{Latitude: -90}, math.Pi},
+		{parcel.Location{Longitude: -179}, parcel.Location{Longitude: 179}, 2 * math.Pi / 180},
+	}
+	for _, tt := range tests {
+		got := distance(&tt.a, &tt.b)
+		if math.Abs(got-tt.want) > 1e-9 {
+			t.Errorf("distance(%v, %v) = %v, want %v", tt.a, tt.b, got, tt.want)
+		}
+	}
+}
+
+func TestOrder(t *testing.T) {
+	// The located stops lie on the equator. Starting at the lowest zip
+	// code, the nearest stop is always the next one to the east.
+	ss := []*Stop{
+		testStop("99", false, 0, 0),
+		testStop("20", true, 0, 3),
+		testStop("40", true, 0, 1),
+		testStop("10", true, 0, 0),
+		testStop("05", false, 0, 0),
+		testStop("30", true, 0, 2),
+	}
+	order(ss)
+
+	want := []string{"10", "40", "30", "20", "05", "99"}
+	for i, st := range ss {
+		if st.Address.Zip != want[i] {
+			t.Errorf("stop %d has zip code %s, want %s", i, st.Address.Zip, want[i])
+		}
+		if st.Sequence != i+1 {
+			t.Errorf("stop %d with zip code %s has sequence %d, want %d", i, st.Address.Zip, st.Sequence, i+1)
+		}
+	}
+}
+
+func TestTwoOpt(t *testing.T) {
+	// The path crosses itself from the first corner of the square to the
+	// opposite corner and back to the second one.
+	ss := []*Stop{
+		testStop("1", true, 0, 0),
+		testStop("2", true, 1, 1),
+		testStop("3", true, 1, 0),
+		testStop("4", true, 0, 1),
+	}
+	before := length(ss)
+	twoOpt(ss)
+
+	if ss[0].Address.Zip != "1" {
+		t.Errorf("twoOpt moved the first stop to %s", ss[0].Address.Zip)
+	}
+	if got := length(ss); got >= before || got > 3.01*math.Pi/180 {
+		t.Errorf("twoOpt shortened the path from %v to %v, want about %v", before, got, 3*math.Pi/180)
+	}
+}
+
+func TestOrderEmpty(t *testing.T) {
+	// Must not panic.
+	order(nil)
+	order([]*Stop{testStop("1", false, 0, 0)})
+	ss := []*Stop{testStop("1", true, 0, 0)}
+	order(ss)
+	if ss[0].Sequence != 1 {
+		t.Errorf("the only stop has sequence %d, want 1", ss[0].Sequence)
+	}
+}
@@ -0,0 +1,142 @@
+// Package route plans the daily routes of the vehicles delivering parcels
+// from logistics centers to their destinations.
+package route
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/address"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+var (
+	ErrVehicleNameEmpty = errors.New("route: a vehicle's name must not be empty")
+	ErrCityEmpty        = errors.New("route: the city and planet must not be empty")
+	ErrInvalidCapacity  = errors.New("route: a vehicle's capacity must be positive")
+	ErrInvalidLocation  = errors.New("route: the latitude and longitude are out of range")
+)
+
+// Vehicle is a delivery vehicle, which serves the destinations in a city.
+type Vehicle struct {
+	ID     uuid.UUID `json:"id"`
+	Name   string    `json:"name"`
+	City   string    `json:"city"`
+	Planet string    `json:"planet"`
+	// Capacity is the number of parcels the vehicle can carry.
+	Capacity int `json:"capacity"`
+}
+
+// NewVehicle returns a new vehicle called name, which carries up to
+// capacity parcels to destinations in the given city on planet.
+func NewVehicle(name, city, planet string, capacity int) (*Vehicle, error) {
+	name = strings.TrimSpace(name)
+	city, planet = strings.TrimSpace(city), strings.TrimSpace(planet)
+	if name == "" {
+		return nil, ErrVehicleNameEmpty
+	} else if city == "" || planet == "" {
+		return nil, ErrCityEmpty
+	} else if capacity <= 0 {
+		return nil, ErrInvalidCapacity
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+
+	return &Vehicle{ID: id, Name: name, City: city, Planet: planet, Capacity: capacity}, nil
+}
+
+// Place is an entry of the geodata table, which locates a zip code of a
+// city.
+type Place struct {
+	Planet   string          `json:"planet"`
+	City     string          `json:"city"`
+	Zip      string          `json:"zip"`
+	Location parcel.Location `json:"location"`
+}
+
+// NewPlace returns the place locating zip in the given city on planet at
+// the latitude lat and longitude lon in degrees.
+func NewPlace(planet, city, zip string, lat, lon float64) (*Place, error) {
+	city, planet = strings.TrimSpace(city), strings.TrimSpace(planet)
+	if city == "" || planet == "" {
+		return nil, ErrCityEmpty
+	} else if math.IsNaN(lat) || math.IsNaN(lon) || math.Abs(lat) > 90 || math.Abs(lon) > 180 {
+		return nil, ErrInvalidLocation
+	}
+
+	return &Place{
+		Planet:   planet,
+		City:     city,
+		Zip:      strings.TrimSpace(zip),
+		Location: parcel.Location{Latitude: lat, Longitude: lon},
+	}, nil
+}
+
+// StopStatus is the status of a route's stop.
+type StopStatus string
+
+const (
+	Pending   StopStatus = "pending"
+	Delivered StopStatus = "delivered"
+	Failed    StopStatus = "failed"
+)
+
+// Stop is the delivery of a parcel on a route.
+type Stop struct {
+	ID uuid.UUID `json:"id"`
+	// Sequence is the position of the stop on its route starting at 1.
+	Sequence int       `json:"sequence"`
+	ParcelID uuid.UUID `json:"parcelId"`
+	// Address is the address, to which the parcel is delivered.
+	Address *address.Address `json:"address"`
+	// Location is nil, if the geodata table does not locate the address.
+	Location *parcel.Location `json:"location,omitempty"`
+	Status   StopStatus       `json:"status"`
+}
+
+// Route is the daily route of a vehicle and its driver.
+type Route struct {
+	ID      uuid.UUID `json:"id"`
+	Vehicle *Vehicle  `json:"vehicle"`
+	// Driver is the ID of the user driving the vehicle.
+	Driver uuid.UUID `json:"driver"`
+	// Center is the ID of the logistics center, at which the vehicle has
+	// been loaded.
+	Center uuid.UUID `json:"center"`
+	Day    time.Time `json:"day"`
+	Stops  []*Stop   `json:"stops"`
+}
+
+// Stop returns the stop of r identified by id or nil.
+func (r *Route) Stop(id uuid.UUID) *Stop {
+	for _, st := range r.Stops {
+		if st.ID == id {
+			return st
+		}
+	}
+
+	return nil
+}
+
+// Done reports whether all stops of r have been served.
+func (r *Route) Done() bool {
+	for _, st := range r.Stops {
+		if st.Status == Pending {
+			return false
+		}
+	}
+
+	return true
+}
+
+// day returns midnight of t's day in t's location.
+func day(t time.Time) time.Time {
+	y, m, d := t.Date()
+
+	return time.Date(y, m, d, 0, 0, 0, 0, t.Location())
+}
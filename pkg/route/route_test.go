@@ -0,0 +1,76 @@
+package route
+
+import (
+	"math"
+	"testing"
+)
+
+func TestNewVehicle(t *testing.T) {
+	v, err := NewVehicle(" Van 1 ", " Erlangen", "Earth ", 40)
+	if err != nil {
+		t.Fatalf("NewVehicle() returned %v", err)
+	}
+	if v.Name != "Van 1" || v.City != "Erlangen" || v.Planet != "Earth" || v.Capacity != 40 {
+		t.Errorf("NewVehicle() = %+v, want the trimmed name, city and planet", v)
+	}
+
+	tests := []struct {
+		name, city, planet string
+		capacity           int
+		want               error
+	}{
+		{" ", "Erlangen", "Earth", 40, ErrVehicleNameEmpty},
+		{"Van 1", "", "Earth", 40, ErrCityEmpty},
+		{"Van 1", "Erlangen", "\t", 40, ErrCityEmpty},
+		{"Van 1", "Erlangen", "Earth", 0, ErrInvalidCapacity},
+		{"Van 1", "Erlangen", "Earth", -1, ErrInvalidCapacity},
+	}
+	for _, tt := range tests {
+		_, err := NewVehicle(tt.name, tt.city, tt.planet, tt.capacity)
+		if err != tt.want {
+			t.Errorf("NewVehicle(%q, %q, %q, %d) returned %v, want %v", tt.name, tt.city, tt.planet,
+				tt.capacity, err, tt.want)
+		}
+	}
+}
+
+func TestNewPlace(t *testing.T) {
+	tests := []struct {
+		lat, lon float64
+		want     error
+	}{
+		{49.59, 11.0, nil},
+		{90, 180, nil},
+		{-90, -180, nil},
+		{90.1, 0, ErrInvalidLocation},
+		{0, -180.1, ErrInvalidLocation},
+		{math.NaN(), 0, ErrInvalidLocation},
+		{0, math.NaN(), ErrInvalidLocation},
+		{math.Inf(1), 0, ErrInvalidLocation},
+	}
+	for _, tt := range tests {
+		p, err := NewPlace("Earth", "Erlangen", " 91052 ", tt.lat, tt.lon)
+		if err != tt.want {
+			t.Errorf("NewPlace() at %v, %v returned %v, want %v", tt.lat, tt.lon, err, tt.want)
+		} else if err == nil && p.Zip != "91052" {
+			t.Errorf("NewPlace() has zip code %q, want it trimmed", p.Zip)
+		}
+	}
+	if _, err := NewPlace("", "Erlangen", "91052", 0, 0); err != ErrCityEmpty {
+		t.Errorf("NewPlace() without a planet returned %v, want %v", err, ErrCityEmpty)
+	}
+}
+
+func TestRouteDone(t *testing.T) {
+	r := &Route{Stops: []*Stop{{Status: Delivered}, {Status: Pending}, {Status: Failed}}}
+	if r.Done() {
+		t.Error("a route with a pending stop is done")
+	}
+	r.Stops[1].Status = Delivered
+	if !r.Done() {
+		t.Error("a route, whose stops have all been served, is not done")
+	}
+	if !(&Route{}).Done() {
+		t.Error("a route without stops is not done")
+	}
+}
@@ -0,0 +1,266 @@
+package route
+
+import (
+	"errors"
+	"log"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/blob"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/center"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/locker"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrVehicleNotFound = errors.New("route: the vehicle does not exist")
+	ErrWrongPlanet     = errors.New("route: the vehicle is not on the logistics center's planet")
+	ErrNoParcels       = errors.New("route: there are no parcels to be delivered in the vehicle's city")
+	ErrRouteNotFound   = errors.New("route: the route does not exist")
+	ErrNotDriver       = errors.New("route: only the route's driver may serve its stops")
+	ErrStopNotFound    = errors.New("route: the stop is not on the route")
+	ErrStopServed      = errors.New("route: the stop has already been served")
+	ErrReasonRequired  = errors.New("route: the reason of the failed delivery attempt is required")
+)
+
+// Service plans the routes of delivery vehicles and records the delivery
+// of their stops.
+type Service struct {
+	Routes  Storage
+	Centers center.Storage
+	Parcels parcel.Accesser
+	// Events must publish the recorded events, so that customers are
+	// notified about their parcels' delivery.
+	Events parcel.EventStorage
+	// Deliveries stores the events completing the delivery together with
+	// their proofs of delivery. It must publish the events like Events.
+	Deliveries parcel.Deliverer
+	// Blobs stores the images of the proofs of delivery.
+	Blobs blob.Store
+	// Lockers deposits the parcels sent to pickup points.
+	Lockers *locker.Service
+	// Publisher publishes the LoadedIntoVehicle events, which are stored
+	// together with the routes.
+	Publisher parcel.Publisher
+}
+
+// Plan plans the route of the vehicle identified by vehicleID, which is
+// driven by driver on the day of at, or today, if at is the zero time. The
+// vehicle is loaded with the parcels being processed at the logistics
+// center identified by centerID, which are to be delivered in the vehicle's
+// city, up to its capacity and longest waiting first. Parcels, whose
+// recipients have chosen a later delivery date, are left at the center.
+// Their LoadedIntoVehicle events are recorded at time at together with the
+// route, so that either the whole route or nothing is stored.
+func (s *Service) Plan(centerID, vehicleID uuid.UUID, driver *user.User, at time.Time) (*Route, error) {
+	c, err := s.Centers.CenterByID(centerID)
+	if err != nil {
+		return nil, err
+	} else if c == nil {
+		return nil, center.ErrCenterNotFound
+	}
+	v, err := s.Routes.VehicleByID(vehicleID)
+	if err != nil {
+		return nil, err
+	} else if v == nil {
+		return nil, ErrVehicleNotFound
+	} else if !strings.EqualFold(v.Planet, c.Planet) {
+		return nil, ErrWrongPlanet
+	}
+	if at.IsZero() {
+		at = time.Now()
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	r := &Route{ID: id, Vehicle: v, Driver: driver.ID, Center: c.ID, Day: day(at)}
+
+	var ee []*parcel.Event
+	r.Stops, ee, err = s.stops(c, v, at)
+	if err != nil {
+		return nil, err
+	} else if len(r.Stops) == 0 {
+		return nil, ErrNoParcels
+	}
+	order(r.Stops)
+	err = s.Routes.Insert(r, ee, func(e *parcel.Event, ee []*parcel.Event) error {
+		return parcel.NewState(e.Parcel, ee).Check(e.Type, e.Time)
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, e := range ee {
+		err = s.Publisher.Publish(e)
+		if err != nil {
+			// The route has been stored nevertheless.
+			log.Println(err)
+		}
+	}
+
+	return r, nil
+}
+
+// stops returns the stops for the parcels at c, which may be loaded into v
+// at time at, together with the parcels' LoadedIntoVehicle events.
+func (s *Service) stops(c *center.Center, v *Vehicle, at time.Time) ([]*Stop, []*parcel.Event, error) {
+	ss, err := s.Centers.Parcels(c.ID)
+	if err != nil {
+		return nil, nil, err
+	}
+	sort.SliceStable(ss, func(i, j int) bool {
+		return ss[i].Time.Before(ss[j].Time)
+	})
+
+	var stops []*Stop
+	var events []*parcel.Event
+	for _, sc := range ss {
+		if len(stops) == v.Capacity {
+			break
+		}
+		p, err := s.Parcels.ByID(sc.ParcelID)
+		if err != nil {
+			return nil, nil, err
+		} else if p == nil {
+			continue
+		}
+		ee, err := s.Events.ByParcel(p)
+		if err != nil {
+			return nil, nil, err
+		}
+		state := parcel.NewState(p, ee)
+		dest := state.Destination()
+		if dest == nil || state.Check(parcel.LoadedIntoVehicle, at) != nil || state.DeliveryDate.After(day(at)) ||
+			!strings.EqualFold(strings.TrimSpace(dest.City), v.City) ||
+			!strings.EqualFold(strings.TrimSpace(dest.Planet), v.Planet) {
+			continue
+		}
+
+		st := &Stop{ParcelID: p.ID, Address: dest, Status: Pending}
+		st.ID, err = uuid.NewRandom()
+		if err != nil {
+			return nil, nil, err
+		}
+		st.Location, err = s.Routes.Locate(dest.Planet, dest.City, dest.Zip)
+		if err != nil {
+			return nil, nil, err
+		}
+		e, err := parcel.NewEvent(p, parcel.LoadedIntoVehicle)
+		if err != nil {
+			return nil, nil, err
+		}
+		e.Time = at.Local()
+		stops = append(stops, st)
+		events = append(events, e)
+	}
+
+	return stops, events, nil
+}
+
+// Route returns the route identified by id on behalf of u, who must be its
+// driver or an operator.
+func (s *Service) Route(u *user.User, id uuid.UUID) (*Route, error) {
+	r, err := s.Routes.ByID(id)
+	if err != nil {
+		return nil, err
+	} else if r == nil {
+		return nil, ErrRouteNotFound
+	} else if r.Driver != u.ID && !u.Operator {
+		return nil, ErrNotDriver
+	}
+
+	return r, nil
+}
+
+// Deliver records the delivery of the parcel of the stop identified by
+// stopID on the route identified by routeID together with the proof of
+// delivery captured in req like parcel.Deliver. Parcels being returned are
+// handed over to their senders. Parcels sent to pickup points are deposited
+// there at req.Time, which need not contain any proof. It may only be called
+// by the route's driver.
+func (s *Service) Deliver(u *user.User, routeID, stopID uuid.UUID, req *parcel.DeliveryRequest) (*Stop,
+	*parcel.Event, error) {
+	return s.serve(u, routeID, stopID, Delivered, func(p *parcel.Parcel) (*parcel.Event, error) {
+		return s.deliver(p, req)
+	})
+}
+
+// Fail records a failed attempt to deliver the parcel of the stop identified
+// by stopID on the route identified by routeID for the given reason, which
+// occurred at time at, or just now, if at is the zero time. It may only be
+// called by the route's driver.
+func (s *Service) Fail(u *user.User, routeID, stopID uuid.UUID, reason parcel.FailureReason,
+	at time.Time) (*Stop, *parcel.Event, error) {
+	if reason == parcel.NoReason {
+		return nil, nil, ErrReasonRequired
+	}
+
+	return s.serve(u, routeID, stopID, Failed, func(p *parcel.Parcel) (*parcel.Event, error) {
+		return parcel.RecordFailure(s.Events, p, reason, at)
+	})
+}
+
+// serve records the event returned by record for the parcel of the stop
+// identified by stopID on the route identified by routeID and sets the
+// stop's status to status.
+func (s *Service) serve(u *user.User, routeID, stopID uuid.UUID, status StopStatus,
+	record func(p *parcel.Parcel) (*parcel.Event, error)) (*Stop, *parcel.Event, error) {
+	r, err := s.Routes.ByID(routeID)
+	if err != nil {
+		return nil, nil, err
+	} else if r == nil {
+		return nil, nil, ErrRouteNotFound
+	} else if r.Driver != u.ID {
+		return nil, nil, ErrNotDriver
+	}
+	st := r.Stop(stopID)
+	if st == nil {
+		return nil, nil, ErrStopNotFound
+	} else if st.Status != Pending {
+		return nil, nil, ErrStopServed
+	}
+	p, err := s.Parcels.ByID(st.ParcelID)
+	if err != nil {
+		return nil, nil, err
+	} else if p == nil {
+		return nil, nil, ErrStopNotFound
+	}
+
+	e, err := record(p)
+	if err != nil {
+		return nil, nil, err
+	}
+	err = s.Routes.SetStopStatus(st, status)
+	if err != nil {
+		return nil, nil, err
+	}
+	st.Status = status
+
+	return st, e, nil
+}
+
+// deliver records the regular delivery of p from the vehicle.
+func (s *Service) deliver(p *parcel.Parcel, req *parcel.DeliveryRequest) (*parcel.Event, error) {
+	ee, err := s.Events.ByParcel(p)
+	if err != nil {
+		return nil, err
+	}
+	nn := parcel.NewState(p, ee).Next()
+	if len(nn) == 0 {
+		return nil, parcel.ErrInvalidTransition
+	}
+	// The regular course of delivery is listed first.
+	if nn[0] == parcel.DeliveredToPickupPoint {
+		_, e, err := s.Lockers.Deposit(p, req.Time)
+		return e, err
+	}
+	pr, err := parcel.Deliver(p, req, s.Deliveries, s.Events, s.Blobs)
+	if err != nil {
+		return nil, err
+	}
+
+	return pr.Event, nil
+}
@@ -0,0 +1,48 @@
+package route
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// ErrVehicleBusy is returned by Insert, if the route's vehicle already has
+// a route on the same day.
+var ErrVehicleBusy = errors.New("route: the vehicle already has a route on that day")
+
+// Storage is the interface for managing vehicles, routes and the geodata
+// locating their stops.
+//
+// VehicleByID returns nil, if there is no vehicle identified by id.
+//
+// Vehicles returns all vehicles ordered by their planets, cities and names.
+//
+// InsertPlace replaces the place with the same planet, city and zip code,
+// if there is one. Locate returns nil, if there is no such place.
+// Planets and cities are matched ignoring case.
+//
+// Insert inserts r together with its stops and the events ee in a single
+// transaction. Like parcel.EventInserter, it passes each event and the
+// events of its parcel stored so far to check and stores neither r nor any
+// event, if check returns an error. It returns ErrVehicleBusy, if r's
+// vehicle already has a route on r's day.
+//
+// ByID returns nil, if there is no route identified by id.
+//
+// ByDriver returns the routes of the driver identified by id, which are
+// driven on or after the day of from, ordered by their days.
+//
+// SetStopStatus updates the status of st.
+type Storage interface {
+	InsertVehicle(v *Vehicle) error
+	VehicleByID(id uuid.UUID) (*Vehicle, error)
+	Vehicles() ([]*Vehicle, error)
+	InsertPlace(p *Place) error
+	Locate(planet, city, zip string) (*parcel.Location, error)
+	Insert(r *Route, ee []*parcel.Event, check func(e *parcel.Event, ee []*parcel.Event) error) error
+	ByID(id uuid.UUID) (*Route, error)
+	ByDriver(id uuid.UUID, from time.Time) ([]*Route, error)
+	SetStopStatus(st *Stop, s StopStatus) error
+}
@@ -16,6 +16,9 @@ type User struct {
 	Password []byte        `json:"-"`
 	Email    *mail.Address `json:"email"`
 	Name     string        `json:"name"`
+	// Operator denotes whether the user is a member of IPPS's logistics
+	// staff, who may record tracking events for parcels.
+	Operator bool `json:"-"`
 }
 
 // New initializes and returns a new User object.
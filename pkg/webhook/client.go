@@ -0,0 +1,101 @@
+package webhook
+
+import (
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"syscall"
+	"time"
+)
+
+// ErrForbiddenDestination is returned, if a webhook's URL refers to a
+// loopback, private or otherwise internal address.
+var ErrForbiddenDestination = errors.New("webhook: the URL must not refer to a private or loopback address")
+
+// internalNets are the networks, which webhooks must not be delivered to,
+// because they are only reachable from within our own network.
+var internalNets = parseCIDRs(
+	"0.0.0.0/8",
+	"10.0.0.0/8",
+	"100.64.0.0/10",
+	"127.0.0.0/8",
+	"169.254.0.0/16",
+	"172.16.0.0/12",
+	"192.0.0.0/24",
+	"192.168.0.0/16",
+	"198.18.0.0/15",
+	"224.0.0.0/4",
+	"240.0.0.0/4",
+	"::/128",
+	"::1/128",
+	"fc00::/7",
+	"fe80::/10",
+	"ff00::/8",
+)
+
+func parseCIDRs(cidrs ...string) []*net.IPNet {
+	nn := make([]*net.IPNet, 0, len(cidrs))
+	for _, c := range cidrs {
+		_, n, err := net.ParseCIDR(c)
+		if err != nil {
+			panic(err)
+		}
+		nn = append(nn, n)
+	}
+
+	return nn
+}
+
+// internalIP reports whether ip belongs to one of the internalNets.
+func internalIP(ip net.IP) bool {
+	if v4 := ip.To4(); v4 != nil {
+		ip = v4
+	}
+	for _, n := range internalNets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// internalHost reports whether the host name h is obviously internal, i.e.
+// whether it is localhost or an internal IP address. Names resolving to
+// internal addresses are refused by the client returned by NewClient.
+func internalHost(h string) bool {
+	h = strings.ToLower(strings.TrimSuffix(h, "."))
+	if h == "localhost" || strings.HasSuffix(h, ".localhost") {
+		return true
+	}
+	ip := net.ParseIP(h)
+
+	return ip != nil && internalIP(ip)
+}
+
+// NewClient returns an HTTP client for delivering webhooks with the given
+// timeout, which refuses to connect to internal addresses. The address is
+// checked after the host name has been resolved, so that neither DNS names
+// nor redirects lead to our own network.
+func NewClient(timeout time.Duration) *http.Client {
+	d := &net.Dialer{
+		Timeout: timeout,
+		Control: func(network, address string, c syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			ip := net.ParseIP(host)
+			if ip == nil || internalIP(ip) {
+				return ErrForbiddenDestination
+			}
+			return nil
+		},
+	}
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: &http.Transport{DialContext: d.DialContext},
+	}
+}
@@ -0,0 +1,60 @@
+package webhook
+
+import (
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+// Inserter is the interface wrapping the Insert method.
+//
+// Insert inserts s into the Inserter's underlying storage.
+type Inserter interface {
+	Insert(s *Subscription) error
+}
+
+// Accesser is the interface wrapping the ByUser method.
+//
+// ByUser returns all of u's subscriptions.
+type Accesser interface {
+	ByUser(u *user.User) ([]*Subscription, error)
+}
+
+// Deleter is the interface wrapping the Delete method.
+//
+// Delete removes s from the Deleter's underlying storage, if s belongs
+// to s.User.
+type Deleter interface {
+	Delete(s *Subscription) error
+}
+
+// Storage is the interface wrapping all interfaces for managing
+// subscriptions.
+type Storage interface {
+	Inserter
+	Accesser
+	Deleter
+}
+
+// DeliveryStorage is the interface for managing the queue of deliveries.
+//
+// Enqueue creates a pending delivery of payload for every subscription
+// that is interested in e and belongs to the owner of the return address
+// of e's parcel.
+//
+// Claim returns up to n pending deliveries, which are due. The deliveries
+// are not returned by other calls of Claim, until lease has passed.
+//
+// Update stores the result of an attempt to deliver d.
+//
+// DeadLetter marks d as failed and moves it to the dead letter queue.
+//
+// ByUser returns the n most recent deliveries to u's subscriptions.
+type DeliveryStorage interface {
+	Enqueue(e *parcel.Event, payload []byte) error
+	Claim(n int, lease time.Duration) ([]*Delivery, error)
+	Update(d *Delivery) error
+	DeadLetter(d *Delivery) error
+	ByUser(u *user.User, n int) ([]*Delivery, error)
+}
@@ -0,0 +1,180 @@
+// Package webhook implements outbound webhooks, which notify customers
+// about the tracking events of the parcels they have sent.
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/gorilla/schema"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/user"
+)
+
+var (
+	ErrInvalidURL = errors.New("webhook: the URL must be an absolute http or https URL")
+)
+
+// Subscription is a customer's subscription to the tracking events of the
+// parcels sent by the customer, i.e. whose return address belongs to the
+// customer.
+type Subscription struct {
+	ID  uuid.UUID `json:"id"`
+	URL string    `json:"url"`
+	// Secret is the key used to sign the payloads sent to URL.
+	Secret string `json:"-"`
+	// EventTypes are the event types the subscription is interested in.
+	// The subscription receives all events, if it is empty.
+	EventTypes []parcel.EventType `json:"eventTypes"`
+	User       *user.User         `json:"-"`
+}
+
+// New returns a new subscription of u, which sends the events of type
+// tt to rawURL. A random secret is generated, if secret is empty.
+// ErrForbiddenDestination is returned, if rawURL refers to localhost or an
+// internal IP address.
+func New(u *user.User, rawURL, secret string, tt []parcel.EventType) (*Subscription, error) {
+	l, err := url.Parse(rawURL)
+	if err != nil || !l.IsAbs() || (l.Scheme != "http" && l.Scheme != "https") || l.Hostname() == "" {
+		return nil, ErrInvalidURL
+	} else if internalHost(l.Hostname()) {
+		return nil, ErrForbiddenDestination
+	}
+	id, err := uuid.NewRandom()
+	if err != nil {
+		return nil, err
+	}
+	if secret == "" {
+		secret, err = newSecret()
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &Subscription{
+		ID:         id,
+		URL:        l.String(),
+		Secret:     secret,
+		EventTypes: tt,
+		User:       u,
+	}, nil
+}
+
+func newSecret() (string, error) {
+	b := make([]byte, 24)
+	_, err := rand.Read(b)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
+
+type subscriptionForm struct {
+	URL        string   `schema:"url,required"`
+	Secret     string   `schema:"secret"`
+	EventTypes []string `schema:"event-types"`
+}
+
+var formDecoder = schema.NewDecoder()
+
+// NewFromForm parses r's post form into a new subscription of u.
+func NewFromForm(r *http.Request, u *user.User) (*Subscription, error) {
+	err := r.ParseForm()
+	if err != nil {
+		return nil, err
+	}
+	f := &subscriptionForm{}
+	err = formDecoder.Decode(f, r.PostForm)
+	if err != nil {
+		return nil, err
+	}
+	tt := make([]parcel.EventType, 0, len(f.EventTypes))
+	for _, n := range f.EventTypes {
+		var t parcel.EventType
+		err = t.UnmarshalText([]byte(n))
+		if err != nil {
+			return nil, err
+		}
+		tt = append(tt, t)
+	}
+
+	return New(u, f.URL, f.Secret, tt)
+}
+
+// Sign returns the hex encoded HMAC-SHA256 of payload using secret as the
+// key. Receivers verify the signature sent in the X-IPPS-Signature header
+// to make sure that the payload has been sent by IPPS.
+func Sign(secret string, payload []byte) string {
+	m := hmac.New(sha256.New, []byte(secret))
+	m.Write(payload)
+
+	return hex.EncodeToString(m.Sum(nil))
+}
+
+// Payload is the JSON document sent to subscribers for every event.
+type Payload struct {
+	Event struct {
+		ID          uuid.UUID        `json:"id"`
+		Type        parcel.EventType `json:"type"`
+		Description string           `json:"description"`
+		Time        time.Time        `json:"time"`
+	} `json:"event"`
+	Parcel struct {
+		ID uuid.UUID `json:"id"`
+	} `json:"parcel"`
+}
+
+// NewPayload returns the JSON encoded payload for the event e.
+func NewPayload(e *parcel.Event) ([]byte, error) {
+	p := &Payload{}
+	p.Event.ID = e.ID
+	p.Event.Type = e.Type
+	p.Event.Description = e.Type.String()
+	p.Event.Time = e.Time
+	p.Parcel.ID = e.Parcel.ID
+
+	return json.Marshal(p)
+}
+
+// DeliveryStatus is the status of a delivery.
+type DeliveryStatus string
+
+const (
+	// Pending deliveries are (re-)tried when their next attempt is due.
+	Pending DeliveryStatus = "pending"
+	// Delivered deliveries have been accepted by the subscriber.
+	Delivered DeliveryStatus = "delivered"
+	// Failed deliveries have exceeded the maximum number of attempts and
+	// have been moved to the dead letter queue.
+	Failed DeliveryStatus = "failed"
+)
+
+// Delivery is the delivery of a single event's payload to a subscription.
+type Delivery struct {
+	ID           uuid.UUID
+	Subscription *Subscription
+	EventID      uuid.UUID
+	EventType    parcel.EventType
+	ParcelID     uuid.UUID
+	Payload      []byte
+	Status       DeliveryStatus
+	Attempts     int
+	NextAttempt  time.Time
+	// LastAttempt is the time of the last attempt or the zero time, if
+	// the delivery has not been attempted, yet.
+	LastAttempt time.Time
+	// ResponseStatus is the HTTP status code of the last attempt's response
+	// or zero, if no response has been received.
+	ResponseStatus int
+	LastError      string
+	Created        time.Time
+}
@@ -0,0 +1,139 @@
+package webhook
+
+import (
+	"bytes"
+	"fmt"
+	"log"
+	"net/http"
+	"time"
+
+	"gitlab.cs.fau.de/faust/faustctf-2020/ipps/pkg/parcel"
+)
+
+// Enqueuer is a parcel.Publisher, which enqueues a delivery of every
+// published event for the interested subscriptions. It must only be used
+// by the instance inserting the event, so that every event is enqueued
+// once.
+type Enqueuer struct {
+	Deliveries DeliveryStorage
+}
+
+func (q *Enqueuer) Publish(e *parcel.Event) error {
+	payload, err := NewPayload(e)
+	if err != nil {
+		return err
+	}
+
+	return q.Deliveries.Enqueue(e, payload)
+}
+
+// DefaultInterval is the time between polling the queue for due
+// deliveries, if a worker's interval is not positive.
+const DefaultInterval = 5 * time.Second
+
+// Worker delivers the queued payloads to the subscribers. Several workers
+// may share the same DeliveryStorage. The Client should be created by
+// NewClient, so that payloads are not sent to internal addresses.
+type Worker struct {
+	Deliveries DeliveryStorage
+	Client     *http.Client
+	// Interval is the time between polling the queue for due deliveries.
+	// It defaults to DefaultInterval.
+	Interval time.Duration
+	// BatchSize is the maximum number of deliveries claimed at once.
+	BatchSize int
+	// MaxAttempts is the number of attempts after which a delivery is moved
+	// to the dead letter queue.
+	MaxAttempts int
+	// Backoff is the delay before the first retry. It is doubled for every
+	// further retry, but never exceeds MaxBackoff.
+	Backoff    time.Duration
+	MaxBackoff time.Duration
+}
+
+// Run delivers the due payloads every w.Interval. It never returns.
+func (w *Worker) Run() {
+	if w.Interval <= 0 {
+		w.Interval = DefaultInterval
+	}
+	t := time.NewTicker(w.Interval)
+	defer t.Stop()
+	for range t.C {
+		w.deliverDue()
+	}
+}
+
+func (w *Worker) deliverDue() {
+	// The deliveries must not be claimed by other workers, until they have
+	// been attempted.
+	lease := time.Duration(w.BatchSize)*w.Client.Timeout + w.Interval
+	dd, err := w.Deliveries.Claim(w.BatchSize, lease)
+	if err != nil {
+		log.Println(err)
+		return
+	}
+	for _, d := range dd {
+		w.deliver(d)
+	}
+}
+
+func (w *Worker) deliver(d *Delivery) {
+	d.Attempts++
+	d.LastAttempt = time.Now()
+	d.ResponseStatus, d.LastError = 0, ""
+	err := w.post(d)
+	if err == nil {
+		d.Status = Delivered
+		err = w.Deliveries.Update(d)
+	} else if d.Attempts >= w.MaxAttempts {
+		d.LastError = err.Error()
+		err = w.Deliveries.DeadLetter(d)
+	} else {
+		d.LastError = err.Error()
+		d.NextAttempt = d.LastAttempt.Add(w.backoff(d.Attempts))
+		err = w.Deliveries.Update(d)
+	}
+	if err != nil {
+		log.Println(err)
+	}
+}
+
+// post sends d's payload to its subscription and records the response's
+// status code in d.
+func (w *Worker) post(d *Delivery) error {
+	req, err := http.NewRequest("POST", d.Subscription.URL, bytes.NewReader(d.Payload))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "IPPS-Webhook/1.0")
+	req.Header.Set("X-IPPS-Delivery", d.ID.String())
+	req.Header.Set("X-IPPS-Event", d.EventType.Name())
+	req.Header.Set("X-IPPS-Signature", "sha256="+Sign(d.Subscription.Secret, d.Payload))
+
+	resp, err := w.Client.Do(req)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	d.ResponseStatus = resp.StatusCode
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("webhook: unexpected response status %s", resp.Status)
+	}
+
+	return nil
+}
+
+// backoff returns the delay before the next attempt after the given number
+// of attempts.
+func (w *Worker) backoff(attempts int) time.Duration {
+	b := w.Backoff
+	for i := 1; i < attempts && b < w.MaxBackoff; i++ {
+		b *= 2
+	}
+	if b > w.MaxBackoff {
+		return w.MaxBackoff
+	}
+
+	return b
+}
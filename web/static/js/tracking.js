@@ -0,0 +1,24 @@
+window.addEventListener("load", function() {
+  let events = document.getElementById("events");
+  let parcelID = events.dataset.parcelId;
+  let source = new EventSource("/api/parcels/" + parcelID + "/events/stream");
+
+  source.addEventListener("parcel-event", function(message) {
+    let event = JSON.parse(message.data);
+    if (events.querySelector(`[data-event-id="${event.id}"]`) != null) {
+      return;
+    }
+
+    let template = document.getElementById("event-template").content;
+    let card = document.importNode(template, true).querySelector(".card");
+    card.dataset.eventId = event.id;
+    card.querySelector("h5").innerText = new Date(event.time).toLocaleString();
+    card.querySelector("p").innerText = event.description + ".";
+
+    let placeholder = document.getElementById("no-events");
+    if (placeholder != null) {
+      placeholder.remove();
+    }
+    events.appendChild(card);
+  });
+});